@@ -8,7 +8,9 @@ CREATE TABLE IF NOT EXISTS users (
     last_name TEXT NOT NULL,   
     description TEXT DEFAULT '',  
     user_pic TEXT DEFAULT 'default_user.jpg', 
-    password_hash BYTEA NOT NULL                   
+    password_hash BYTEA NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT '{customer}'
+        CHECK (roles <@ ARRAY['customer', 'restaurant_staff', 'courier', 'admin']::TEXT[])
 );
 
 CREATE TABLE IF NOT EXISTS restaurant_tags (
@@ -27,7 +29,9 @@ CREATE TABLE IF NOT EXISTS restaurants (
     working_mode_from INT DEFAULT 8,  
     working_mode_to INT DEFAULT 23,   
     delivery_time_from INT DEFAULT 50,  
-    delivery_time_to INT DEFAULT 60                                       
+    delivery_time_to INT DEFAULT 60,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION
 );
 
 CREATE TABLE IF NOT EXISTS reviews (
@@ -69,7 +73,7 @@ CREATE TABLE IF NOT EXISTS orders (
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     status TEXT NOT NULL,
     address_id TEXT NOT NULL,
-    order_products TEXT NOT NULL,
+    restaurant_id UUID REFERENCES restaurants(id) ON DELETE SET NULL,
 
     apartment_or_office TEXT,
     intercom TEXT,
@@ -77,16 +81,195 @@ CREATE TABLE IF NOT EXISTS orders (
     floor TEXT,
     courier_comment TEXT,
     leave_at_door BOOLEAN DEFAULT FALSE,
+    subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    delivery_fee NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    service_fee NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    discount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    tip NUMERIC(10, 2) NOT NULL DEFAULT 0,
     final_price NUMERIC(10, 2) NOT NULL,
+    payment_id TEXT UNIQUE,
+    promo_code TEXT,
+    deliver_at TIMESTAMPTZ,
+    eta TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL DEFAULT now()
 );
 
-CREATE OR REPLACE FUNCTION set_order_in_delivery(order_id UUID) RETURNS VOID AS $$
+CREATE TABLE IF NOT EXISTS order_items (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    price NUMERIC(10, 2) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    weight INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items (order_id, id);
+CREATE INDEX IF NOT EXISTS idx_order_items_product ON order_items (product_id);
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant ON orders (restaurant_id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_created ON orders (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_status ON orders (restaurant_id, status);
+
+CREATE TABLE IF NOT EXISTS restaurant_staff (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS couriers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    available BOOLEAN NOT NULL DEFAULT FALSE,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    max_orders INT NOT NULL DEFAULT 2 CHECK (max_orders > 0),
+    simulated BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS courier_assignments (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    courier_id UUID NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('offered', 'accepted', 'picked_up', 'delivered')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_assignments_courier ON courier_assignments (courier_id, status);
+
+CREATE TABLE IF NOT EXISTS order_status_events (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    actor TEXT NOT NULL CHECK (actor IN ('user', 'system', 'restaurant', 'courier')),
+    reason TEXT,
+    eta TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_events_order ON order_status_events (order_id, id);
+
+CREATE TABLE IF NOT EXISTS order_status_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_jobs_run_at ON order_status_jobs (run_at);
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code TEXT NOT NULL UNIQUE,
+    type TEXT NOT NULL CHECK (type IN ('percent', 'fixed', 'free_delivery')),
+    value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    first_order_only BOOLEAN NOT NULL DEFAULT FALSE,
+    min_subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    max_uses INT,
+    max_uses_per_user INT,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    restaurant_id UUID REFERENCES restaurants(id) ON DELETE CASCADE,
+    tag_id UUID REFERENCES restaurant_tags(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_uses (
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_code_uses_user ON promo_code_uses (promo_code_id, user_id);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_active ON sessions (user_id) WHERE revoked_at IS NULL;
+
+-- Роли restaurant_staff и courier выдаются и снимаются вместе с привязками пользователя
+-- в restaurant_staff и couriers; при снятии роли сессии пользователя отзываются.
+CREATE OR REPLACE FUNCTION sync_link_role() RETURNS trigger AS $$
+DECLARE
+    link_role TEXT := TG_ARGV[0];
 BEGIN
-    UPDATE orders SET status = 'in_delivery' WHERE id = order_id;
+    IF TG_OP <> 'INSERT' THEN
+        IF OLD.user_id IS NOT NULL AND (TG_OP = 'DELETE' OR OLD.user_id IS DISTINCT FROM NEW.user_id) THEN
+            UPDATE users SET roles = array_remove(roles, link_role)
+            WHERE id = OLD.user_id AND link_role = ANY(roles);
+            IF FOUND THEN
+                UPDATE sessions SET revoked_at = now() WHERE user_id = OLD.user_id AND revoked_at IS NULL;
+            END IF;
+        END IF;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        IF NEW.user_id IS NOT NULL THEN
+            UPDATE users SET roles = array_append(roles, link_role)
+            WHERE id = NEW.user_id AND NOT link_role = ANY(roles);
+        END IF;
+    END IF;
+    RETURN NULL;
 END;
 $$ LANGUAGE plpgsql;
 
+DROP TRIGGER IF EXISTS trg_restaurant_staff_role ON restaurant_staff;
+CREATE TRIGGER trg_restaurant_staff_role
+AFTER INSERT OR UPDATE OF user_id OR DELETE ON restaurant_staff
+FOR EACH ROW EXECUTE FUNCTION sync_link_role('restaurant_staff');
+
+DROP TRIGGER IF EXISTS trg_couriers_role ON couriers;
+CREATE TRIGGER trg_couriers_role
+AFTER INSERT OR UPDATE OF user_id OR DELETE ON couriers
+FOR EACH ROW EXECUTE FUNCTION sync_link_role('courier');
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    token_hash BYTEA PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at TIMESTAMPTZ,
+    successor_sealed BYTEA
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens (session_id);
+
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id TEXT PRIMARY KEY,
+    order_id UUID NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    confirmation_url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS order_tip_payments (
+    payment_id TEXT PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_tip_payments_order ON order_tip_payments (order_id, created_at);
+
+-- Переносы данных из build/sql/migrations, которые выполняются один раз. Свежей базе они
+-- не нужны: данные в ней сразу записываются в новом виде.
+CREATE TABLE IF NOT EXISTS data_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO data_migrations (name)
+VALUES ('011_unescape_status_reasons'), ('015_unescape_order_fields')
+ON CONFLICT DO NOTHING;
+
 INSERT INTO restaurant_tags (id, name)
 VALUES 
   (gen_random_uuid(), 'Итальянский'),
@@ -284,6 +467,14 @@ VALUES
 ((SELECT id FROM restaurants WHERE name = 'Том Ям'), (SELECT id FROM restaurant_tags WHERE name = 'Турецкий')),
 ((SELECT id FROM restaurants WHERE name = 'Джонджоли'), (SELECT id FROM restaurant_tags WHERE name = 'Индийский'));
 
+INSERT INTO promo_codes (code, type, value, first_order_only, min_subtotal, max_uses, max_uses_per_user, starts_at, ends_at, restaurant_id, tag_id)
+VALUES
+('WELCOME', 'percent', 20, TRUE, 0, NULL, 1, NULL, NULL, NULL, NULL),
+('MINUS300', 'fixed', 300, FALSE, 1500, 1000, 1, NULL, NULL, NULL, NULL),
+('FREEDELIVERY', 'free_delivery', 0, FALSE, 700, NULL, 3, NULL, NULL, NULL, NULL),
+('VEGAN15', 'percent', 15, FALSE, 0, NULL, NULL, NULL, NULL, NULL, (SELECT id FROM restaurant_tags WHERE name = 'Веганский')),
+('TOMYAM10', 'percent', 10, FALSE, 0, 500, 2, NULL, NULL, (SELECT id FROM restaurants WHERE name = 'Том Ям'), NULL);
+
 INSERT INTO products (restaurant_id, name, price, image_url, weight, category)
 VALUES 
     ((SELECT id FROM restaurants WHERE name = 'Красное море' ),'Рамен с курицей', 740, 'default_product.jpg', 350,'Закуски'),
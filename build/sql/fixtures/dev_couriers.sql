@@ -0,0 +1,9 @@
+-- Курьеры-симуляторы для локального стенда (make seed-dev). Работают, только если у сервиса
+-- cart включён COURIER_SIMULATION=true; в прод эти данные не попадают.
+BEGIN;
+
+INSERT INTO couriers (name, available, simulated)
+SELECT name, TRUE, TRUE FROM (VALUES ('Симулятор 1'), ('Симулятор 2'), ('Симулятор 3')) AS s(name)
+WHERE NOT EXISTS (SELECT 1 FROM couriers WHERE simulated);
+
+COMMIT;
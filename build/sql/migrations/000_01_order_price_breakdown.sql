@@ -0,0 +1,12 @@
+-- Изменения схемы, сделанные до появления каталога migrations, лежат в файлах 000_NN: make migrate
+-- применяет их раньше 001, потому что следующие миграции на них опираются.
+-- Стоимость заказа по строкам: сумма товаров, доставка, сервисный сбор и скидка. У старых заказов
+-- строки остаются нулевыми, итог по-прежнему хранится в final_price.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee NUMERIC(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_fee NUMERIC(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+COMMIT;
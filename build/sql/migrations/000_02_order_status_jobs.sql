@@ -0,0 +1,18 @@
+-- Отложенные переходы статусов заказа хранятся в order_status_jobs и выполняются сервисом cart,
+-- поэтому функция для pg_cron больше не нужна.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_status_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_jobs_run_at ON order_status_jobs (run_at);
+
+DROP FUNCTION IF EXISTS set_order_in_delivery(UUID);
+
+COMMIT;
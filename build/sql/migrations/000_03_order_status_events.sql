@@ -0,0 +1,15 @@
+-- История статусов заказа. У старых заказов история начинается с первой смены статуса после миграции.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_status_events (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    actor TEXT NOT NULL CHECK (actor IN ('user', 'system', 'restaurant')),
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_events_order ON order_status_events (order_id, id);
+
+COMMIT;
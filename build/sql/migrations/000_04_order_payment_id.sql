@@ -0,0 +1,6 @@
+-- Идентификатор платежа, по которому уведомление провайдера находит заказ.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_id TEXT UNIQUE;
+
+COMMIT;
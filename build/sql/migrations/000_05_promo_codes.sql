@@ -0,0 +1,31 @@
+-- Промокоды и их погашения; в заказе запоминается применённый код. Сами коды заводятся вручную,
+-- демо-набор из create_tables.sql сюда не переносится.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code TEXT;
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code TEXT NOT NULL UNIQUE,
+    type TEXT NOT NULL CHECK (type IN ('percent', 'fixed', 'free_delivery')),
+    value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    first_order_only BOOLEAN NOT NULL DEFAULT FALSE,
+    min_subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    max_uses INT,
+    max_uses_per_user INT,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    restaurant_id UUID REFERENCES restaurants(id) ON DELETE CASCADE,
+    tag_id UUID REFERENCES restaurant_tags(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_uses (
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_code_uses_user ON promo_code_uses (promo_code_id, user_id);
+
+COMMIT;
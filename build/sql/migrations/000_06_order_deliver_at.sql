@@ -0,0 +1,6 @@
+-- Время доставки, выбранное клиентом для запланированного заказа; NULL — доставить как можно скорее.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMPTZ;
+
+COMMIT;
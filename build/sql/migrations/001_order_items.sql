@@ -0,0 +1,55 @@
+-- Переносит состав заказов из JSON-колонки orders.order_products в таблицу order_items.
+-- Свежие базы создаются сразу с новой схемой (create_tables.sql), скрипт нужен только для
+-- уже развёрнутых: make migrate. Повторный запуск ничего не меняет.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS restaurant_id UUID REFERENCES restaurants(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    price NUMERIC(10, 2) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    weight INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items (order_id, id);
+CREATE INDEX IF NOT EXISTS idx_order_items_product ON order_items (product_id);
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant ON orders (restaurant_id);
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'orders' AND column_name = 'order_products'
+    ) THEN
+        UPDATE orders o SET restaurant_id = r.id
+        FROM restaurants r
+        WHERE o.restaurant_id IS NULL
+          AND r.id::text = o.order_products::jsonb ->> 'restaurant_id';
+
+        INSERT INTO order_items (order_id, product_id, name, price, quantity, weight)
+        SELECT o.id,
+               (item ->> 'id')::uuid,
+               COALESCE(item ->> 'name', ''),
+               COALESCE((item ->> 'price')::numeric, 0),
+               (item ->> 'amount')::int,
+               COALESCE((item ->> 'weight')::int, 0)
+        FROM orders o
+        CROSS JOIN LATERAL jsonb_array_elements(
+            CASE WHEN jsonb_typeof(o.order_products::jsonb -> 'products') = 'array'
+                 THEN o.order_products::jsonb -> 'products'
+                 ELSE '[]'::jsonb
+            END
+        ) WITH ORDINALITY AS e(item, position)
+        WHERE (item ->> 'amount')::int > 0
+          AND NOT EXISTS (SELECT 1 FROM order_items oi WHERE oi.order_id = o.id)
+        ORDER BY o.created_at, o.id, e.position;
+
+        ALTER TABLE orders DROP COLUMN order_products;
+    END IF;
+END $$;
+
+COMMIT;
@@ -0,0 +1,2 @@
+-- Индекс под постраничную выдачу истории заказов: фильтр по пользователю и курсор (created_at, id).
+CREATE INDEX IF NOT EXISTS idx_orders_user_created ON orders (user_id, created_at DESC, id DESC);
@@ -0,0 +1,7 @@
+-- Сотрудники ресторанов: видят и обрабатывают заказы только своего ресторана.
+CREATE TABLE IF NOT EXISTS restaurant_staff (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_restaurant_status ON orders (restaurant_id, status);
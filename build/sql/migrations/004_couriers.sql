@@ -0,0 +1,35 @@
+-- Курьеры и назначения заказов. Заказы в "ready_for_pickup" больше не уходят в доставку по таймеру:
+-- их раздаёт диспетчер сервиса cart, а статусы дальше меняют курьеры. Уже запланированные
+-- таймерные переходы в order_status_jobs выполнятся как раньше, чтобы заказы в пути не зависли.
+BEGIN;
+
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+CREATE TABLE IF NOT EXISTS couriers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    available BOOLEAN NOT NULL DEFAULT FALSE,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    max_orders INT NOT NULL DEFAULT 2 CHECK (max_orders > 0),
+    simulated BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS courier_assignments (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    courier_id UUID NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('offered', 'accepted', 'picked_up', 'delivered')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_assignments_courier ON courier_assignments (courier_id, status);
+
+ALTER TABLE order_status_events DROP CONSTRAINT IF EXISTS order_status_events_actor_check;
+ALTER TABLE order_status_events ADD CONSTRAINT order_status_events_actor_check
+    CHECK (actor IN ('user', 'system', 'restaurant', 'courier'));
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Ожидаемое время доставки (ETA): текущее значение хранится в заказе, а каждое событие
+-- истории запоминает ETA, посчитанное при смене статуса. У старых заказов ETA остаётся пустым.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS eta TIMESTAMPTZ;
+ALTER TABLE order_status_events ADD COLUMN IF NOT EXISTS eta TIMESTAMPTZ;
+
+COMMIT;
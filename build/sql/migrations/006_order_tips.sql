@@ -0,0 +1,6 @@
+-- Чаевые курьеру — отдельная строка в стоимости заказа, уже включённая в final_price.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tip NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+COMMIT;
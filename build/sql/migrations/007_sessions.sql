@@ -0,0 +1,15 @@
+-- Реестр сессий: каждый выданный токен несёт jti = sessions.id и действует, только пока сессия
+-- не отозвана. Токены, выданные до миграции, jti не содержат и перестают приниматься.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_active ON sessions (user_id) WHERE revoked_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Refresh-токены: хранятся только sha256-хэши, каждый токен одноразовый и при обмене заменяется
+-- новым в той же сессии. Повторное предъявление уже использованного токена отзывает всю сессию.
+-- Access-токен живёт 15 минут, сессия (семейство refresh-токенов) — 30 дней.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    token_hash BYTEA PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens (session_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Роли пользователей. Они попадают в access-токен, поэтому выданная роль начинает действовать
+-- со следующего обмена refresh-токена, а при снятии роли сессии пользователя отзываются.
+-- Сотрудникам ресторанов и курьерам роли выдаются по уже существующим привязкам.
+-- В токенах, выданных до миграции, ролей нет: доступ к ручкам сотрудников и курьеров
+-- появится после ближайшего обмена refresh-токена.
+-- Первого администратора назначают вручную:
+--   UPDATE users SET roles = array_append(roles, 'admin') WHERE login = '...';
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT '{customer}'
+    CHECK (roles <@ ARRAY['customer', 'restaurant_staff', 'courier', 'admin']::TEXT[]);
+
+UPDATE users SET roles = array_append(roles, 'restaurant_staff')
+WHERE id IN (SELECT user_id FROM restaurant_staff) AND NOT 'restaurant_staff' = ANY(roles);
+
+UPDATE users SET roles = array_append(roles, 'courier')
+WHERE id IN (SELECT user_id FROM couriers WHERE user_id IS NOT NULL) AND NOT 'courier' = ANY(roles);
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Платежи фейкового провайдера (PAYMENT_PROVIDER=fake). Раньше они жили в памяти cart,
+-- и после перезапуска сервиса заказ нельзя было ни оплатить, ни вернуть. Заказ создаёт
+-- платёж до своей записи в orders, поэтому внешнего ключа на orders нет.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id TEXT PRIMARY KEY,
+    order_id UUID NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    confirmation_url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Причины смены статуса раньше экранировались при записи, а теперь хранятся как есть и
+-- экранируются при выдаче. Возвращаем сохранённым причинам исходный вид, иначе клиент
+-- увидел бы их экранированными дважды. Обратная замена повторяет html.EscapeString.
+-- make migrate запускает все файлы заново, поэтому перенос данных отмечается в data_migrations
+-- и выполняется один раз: иначе повторный запуск испортил бы причины, уже записанные как есть.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS data_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+DO $$
+BEGIN
+    INSERT INTO data_migrations (name) VALUES ('011_unescape_status_reasons') ON CONFLICT DO NOTHING;
+    IF NOT FOUND THEN
+        RETURN;
+    END IF;
+
+    UPDATE order_status_events
+    SET reason = replace(replace(replace(replace(replace(reason,
+        '&lt;', '<'), '&gt;', '>'), '&#39;', ''''), '&#34;', '"'), '&amp;', '&')
+    WHERE reason LIKE '%&%';
+END;
+$$;
+
+COMMIT;
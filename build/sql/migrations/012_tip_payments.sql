@@ -0,0 +1,15 @@
+-- Доплаты чаевых после доставки — отдельные платежи, а не часть оплаты заказа. Здесь
+-- записано, какие платежи относятся к чаевым: уведомления о них не меняют статус заказа,
+-- а уменьшение чаевых возвращается сначала из этих платежей.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_tip_payments (
+    payment_id TEXT PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_tip_payments_order ON order_tip_payments (order_id, created_at);
+
+COMMIT;
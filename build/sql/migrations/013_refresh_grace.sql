@@ -0,0 +1,8 @@
+-- Два параллельных обмена одного refresh-токена (например, из двух вкладок) не должны
+-- отзывать сессию. Преемник токена сохраняется зашифрованным ключом, выведенным из самого
+-- токена, и в течение короткого окна повторное предъявление возвращает того же преемника.
+BEGIN;
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS successor_sealed BYTEA;
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- Роли restaurant_staff и courier следуют за привязками в restaurant_staff и couriers:
+-- триггер выдаёт роль, когда у пользователя появляется привязка, и снимает её, когда
+-- привязка удалена или переназначена. При снятии роли сессии пользователя отзываются,
+-- как и в RevokeRole, чтобы роль не жила в уже выданных access-токенах.
+-- Привязки, созданные после 009_user_roles.sql, догоняются здесь же.
+BEGIN;
+
+CREATE OR REPLACE FUNCTION sync_link_role() RETURNS trigger AS $$
+DECLARE
+    link_role TEXT := TG_ARGV[0];
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        IF OLD.user_id IS NOT NULL AND (TG_OP = 'DELETE' OR OLD.user_id IS DISTINCT FROM NEW.user_id) THEN
+            UPDATE users SET roles = array_remove(roles, link_role)
+            WHERE id = OLD.user_id AND link_role = ANY(roles);
+            IF FOUND THEN
+                UPDATE sessions SET revoked_at = now() WHERE user_id = OLD.user_id AND revoked_at IS NULL;
+            END IF;
+        END IF;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        IF NEW.user_id IS NOT NULL THEN
+            UPDATE users SET roles = array_append(roles, link_role)
+            WHERE id = NEW.user_id AND NOT link_role = ANY(roles);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_restaurant_staff_role ON restaurant_staff;
+CREATE TRIGGER trg_restaurant_staff_role
+AFTER INSERT OR UPDATE OF user_id OR DELETE ON restaurant_staff
+FOR EACH ROW EXECUTE FUNCTION sync_link_role('restaurant_staff');
+
+DROP TRIGGER IF EXISTS trg_couriers_role ON couriers;
+CREATE TRIGGER trg_couriers_role
+AFTER INSERT OR UPDATE OF user_id OR DELETE ON couriers
+FOR EACH ROW EXECUTE FUNCTION sync_link_role('courier');
+
+UPDATE users SET roles = array_append(roles, 'restaurant_staff')
+WHERE id IN (SELECT user_id FROM restaurant_staff) AND NOT 'restaurant_staff' = ANY(roles);
+
+UPDATE users SET roles = array_append(roles, 'courier')
+WHERE id IN (SELECT user_id FROM couriers WHERE user_id IS NOT NULL) AND NOT 'courier' = ANY(roles);
+
+COMMIT;
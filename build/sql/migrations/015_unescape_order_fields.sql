@@ -0,0 +1,43 @@
+-- Адрес, комментарии и названия позиций заказа раньше экранировались перед записью, причём
+-- по нескольку раз, а теперь хранятся как есть и экранируются при выдаче. Снимаем все слои
+-- экранирования, пока значение не перестанет меняться. Обратная замена повторяет
+-- html.EscapeString. Перенос выполняется один раз, см. 011_unescape_status_reasons.sql.
+BEGIN;
+
+CREATE FUNCTION pg_temp.html_unescape(value TEXT) RETURNS TEXT AS $$
+DECLARE
+    previous TEXT;
+BEGIN
+    LOOP
+        previous := value;
+        value := replace(replace(replace(replace(replace(value,
+            '&lt;', '<'), '&gt;', '>'), '&#39;', ''''), '&#34;', '"'), '&amp;', '&');
+        EXIT WHEN value IS NOT DISTINCT FROM previous;
+    END LOOP;
+    RETURN value;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+    INSERT INTO data_migrations (name) VALUES ('015_unescape_order_fields') ON CONFLICT DO NOTHING;
+    IF NOT FOUND THEN
+        RETURN;
+    END IF;
+
+    UPDATE order_items SET name = pg_temp.html_unescape(name) WHERE name LIKE '%&%';
+
+    UPDATE orders SET
+        address_id = pg_temp.html_unescape(address_id),
+        apartment_or_office = pg_temp.html_unescape(apartment_or_office),
+        intercom = pg_temp.html_unescape(intercom),
+        entrance = pg_temp.html_unescape(entrance),
+        floor = pg_temp.html_unescape(floor),
+        courier_comment = pg_temp.html_unescape(courier_comment),
+        promo_code = pg_temp.html_unescape(promo_code)
+    WHERE concat_ws(' ', address_id, apartment_or_office, intercom, entrance, floor, courier_comment, promo_code)
+        LIKE '%&%';
+END;
+$$;
+
+COMMIT;
@@ -10,9 +10,11 @@ import (
 
 	grpcAuth "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc"
 	generatedAuth "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/keys"
 	authRepo "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/repo"
 	authUsecase "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/usecase"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/metrics"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
 	mw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/metrics"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -31,13 +33,20 @@ func run() (err error) {
 	if err != nil {
 		return
 	}
-	AuthUsecase := authUsecase.CreateAuthUsecase(AuthRepo)
+	keyRing, err := keys.LoadKeyRing(os.Getenv("JWT_KEYS_DIR"), os.Getenv("JWT_ACTIVE_KID"))
+	if err != nil {
+		return
+	}
+	AuthUsecase := authUsecase.CreateAuthUsecase(AuthRepo, keyRing)
 	AuthDelivery := grpcAuth.CreateAuthHandler(AuthUsecase)
 
 	grpcMetrics, _ := metrics.NewGrpcMetrics("auth")
 	grpcMiddleware := mw.NewGrpcMw(grpcMetrics)
 
-	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcMiddleware.UnaryServerInterceptor()))
+	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcMiddleware.UnaryServerInterceptor(),
+		grpcauth.UnaryServerInterceptor(keyRing.PublicKeys(), grpcAuth.AnonymousMethods, nil),
+		grpcauth.UnaryRoleInterceptor(grpcAuth.RolePolicy)))
 	generatedAuth.RegisterAuthServiceServer(gRPCServer, AuthDelivery)
 
 	go func() {
@@ -52,6 +61,7 @@ func run() (err error) {
 
 	r := mux.NewRouter().PathPrefix("/api").Subrouter()
 	r.PathPrefix("/metrics").Handler(promhttp.Handler())
+	r.Handle("/.well-known/jwks.json", keyRing).Methods(http.MethodGet)
 	http.Handle("/", r)
 	httpSrv := http.Server{Handler: r, Addr: fmt.Sprintf(":%s", "5462")}
 	go func() {
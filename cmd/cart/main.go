@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -14,7 +15,11 @@ import (
 	cartRedisRepo "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/repo/redis"
 	cartUsecase "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/usecase"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/metrics"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
 	mw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/metrics"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	fakePayment "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment/fake"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -26,6 +31,21 @@ func main() {
 	}
 }
 
+// newPaymentProvider выбирает платёжного провайдера по PAYMENT_PROVIDER. Провайдер по
+// умолчанию не подставляется, чтобы стенд не принимал заказы без явно настроенной оплаты.
+func newPaymentProvider() (payment.PaymentProvider, error) {
+	switch provider := os.Getenv("PAYMENT_PROVIDER"); provider {
+	case "fake":
+		store, err := fakePayment.NewPgStore()
+		if err != nil {
+			return nil, err
+		}
+		return fakePayment.NewProvider(fakePayment.ConfigFromEnv(), store), nil
+	default:
+		return nil, fmt.Errorf("неизвестный платёжный провайдер %q", provider)
+	}
+}
+
 func run() (err error) {
 
 	CartRepoPg, err := cartPgRepo.NewRestaurantRepository()
@@ -36,16 +56,33 @@ func run() (err error) {
 	if err != nil {
 		return
 	}
-	CartUsecase := cartUsecase.NewCartUsecase(cartRepoRedis, CartRepoPg)
+	payments, err := newPaymentProvider()
+	if err != nil {
+		return
+	}
+	CartUsecase := cartUsecase.NewCartUsecase(cartRepoRedis, CartRepoPg, CartRepoPg, payments)
 	CartDelivery := grpcCart.CreateCartHandler(CartUsecase)
 
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+	go CartUsecase.RunStatusWorker(workerCtx)
+	go CartUsecase.RunDispatcher(workerCtx)
+
 	grpcMetrics, err := metrics.NewGrpcMetrics("cart")
 	if err != nil {
 		return
 	}
 	grpcMiddleware := mw.NewGrpcMw(grpcMetrics)
 
-	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcMiddleware.UnaryServerInterceptor()))
+	jwks := jwtUtils.NewRemoteKeySet(os.Getenv("JWKS_URL"))
+
+	gRPCServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcMiddleware.UnaryServerInterceptor(),
+			grpcauth.UnaryServerInterceptor(jwks, grpcCart.AnonymousMethods,
+				grpcCart.SignedMethods(os.Getenv("PAYMENT_WEBHOOK_SECRET"))),
+			grpcauth.UnaryRoleInterceptor(grpcCart.RolePolicy)),
+		grpc.ChainStreamInterceptor(grpcauth.StreamServerInterceptor(jwks, grpcCart.AnonymousMethods)))
 	generatedCart.RegisterCartServiceServer(gRPCServer, CartDelivery)
 
 	go func() {
@@ -71,6 +108,7 @@ func run() (err error) {
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
 	<-stop
+	stopWorker()
 	gRPCServer.GracefulStop()
 	return nil
 }
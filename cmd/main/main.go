@@ -11,12 +11,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	authGen "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
 	authHandler "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/http"
 	cartGen "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
 	cartHandler "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/http"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/metrics"
+	authmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/auth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/cors"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/log"
 	metricsmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/metrics"
 	restaurantDelivery "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/restaurants/delivery/http"
@@ -25,6 +28,7 @@ import (
 	searchDelivery "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/search/delivery/http"
 	searchRepo "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/search/repo"
 	searchUsecase "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/search/usecase"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -45,7 +49,11 @@ func main() {
 
 	logger := slog.New(slog.NewJSONHandler(io.MultiWriter(logFile, os.Stdout), &slog.HandlerOptions{Level: slog.LevelInfo}))
 
-	cartConn, err := grpc.Dial("cart:5460", grpc.WithInsecure())
+	jwks := jwtUtils.NewRemoteKeySet(os.Getenv("JWKS_URL"))
+
+	cartConn, err := grpc.Dial("cart:5460", grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(grpcauth.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcauth.StreamClientInterceptor()))
 	if err != nil {
 		logger.Error("Ошибка подключения к gRPC Cart-сервису: " + err.Error())
 		return
@@ -62,7 +70,8 @@ func main() {
 	MetricsMiddleware := metricsmw.CreateHttpMetricsMiddleware(Metrics, logger)
 	logMW := log.CreateLoggerMiddleware(logger)
 
-	conn, err := grpc.Dial("auth:5459", grpc.WithInsecure())
+	conn, err := grpc.Dial("auth:5459", grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(grpcauth.UnaryClientInterceptor()))
 	if err != nil {
 		logger.Error("Ошибка подключения к gRPC Auth-сервису: " + err.Error())
 		return
@@ -71,7 +80,8 @@ func main() {
 
 	authGRPCClient := authGen.NewAuthServiceClient(conn)
 
-	authHandler := authHandler.CreateAuthHandler(authGRPCClient)
+	authHandler := authHandler.CreateAuthHandler(authGRPCClient, cartHandler)
+	authMW := authmw.CreateAuthMiddleware(authGRPCClient, jwks)
 
 	restaurantRepo, err := restaurantRepo.NewRestaurantRepository()
 	if err != nil {
@@ -95,20 +105,23 @@ func main() {
 	r.Use(
 		logMW,
 		MetricsMiddleware,
-		cors.CorsMiddleware)
+		cors.CorsMiddleware,
+		authMW)
 
 	auth := r.PathPrefix("/auth").Subrouter()
 	{
 
 		auth.HandleFunc("/signin", authHandler.SignIn).Methods(http.MethodPost, http.MethodOptions)
 		auth.HandleFunc("/signup", authHandler.SignUp).Methods(http.MethodPost, http.MethodOptions)
-		auth.HandleFunc("/check", authHandler.Check).Methods(http.MethodGet, http.MethodOptions)
+		auth.HandleFunc("/check", authmw.Protect(authHandler.Check, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodGet, http.MethodOptions)
 		auth.HandleFunc("/logout", authHandler.LogOut).Methods(http.MethodGet, http.MethodOptions)
-		auth.HandleFunc("/update_user", authHandler.UpdateUser).Methods(http.MethodPost, http.MethodOptions)
-		auth.HandleFunc("/update_userpic", authHandler.UpdateUserPic).Methods(http.MethodPost, http.MethodOptions)
-		auth.HandleFunc("/address", authHandler.GetUserAddresses).Methods(http.MethodGet, http.MethodOptions)
-		auth.HandleFunc("/address", authHandler.DeleteAddress).Methods(http.MethodDelete, http.MethodOptions)
-		auth.HandleFunc("/address", authHandler.AddAddress).Methods(http.MethodPost, http.MethodOptions)
+		auth.HandleFunc("/logout_all", authmw.Protect(authHandler.LogOutAll, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		auth.HandleFunc("/refresh", authHandler.Refresh).Methods(http.MethodPost, http.MethodOptions)
+		auth.HandleFunc("/update_user", authmw.Protect(authHandler.UpdateUser, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		auth.HandleFunc("/update_userpic", authmw.Protect(authHandler.UpdateUserPic, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		auth.HandleFunc("/address", authmw.Protect(authHandler.GetUserAddresses, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodGet, http.MethodOptions)
+		auth.HandleFunc("/address", authmw.Protect(authHandler.DeleteAddress, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodDelete, http.MethodOptions)
+		auth.HandleFunc("/address", authmw.Protect(authHandler.AddAddress, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
 
 	}
 	restaurants := r.PathPrefix("/restaurants").Subrouter()
@@ -116,23 +129,47 @@ func main() {
 		restaurants.HandleFunc("/list", restaurantDelivery.RestaurantList).Methods(http.MethodGet, http.MethodOptions)
 		restaurants.HandleFunc("/{id}", restaurantDelivery.GetProductsByRestaurant).Methods(http.MethodGet, http.MethodOptions)
 		restaurants.HandleFunc("/{id}/reviews", restaurantDelivery.ReviewsList).Methods(http.MethodGet, http.MethodOptions)
-		restaurants.HandleFunc("/{id}/reviews", restaurantDelivery.CreateReview).Methods(http.MethodPost, http.MethodOptions)
-		restaurants.HandleFunc("/{id}/check", restaurantDelivery.CheckReviews).Methods(http.MethodGet, http.MethodOptions)
+		restaurants.HandleFunc("/{id}/reviews", authmw.Protect(restaurantDelivery.CreateReview, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		restaurants.HandleFunc("/{id}/check", authmw.Protect(restaurantDelivery.CheckReviews, authmw.RequireAuth)).Methods(http.MethodGet, http.MethodOptions)
 		restaurants.HandleFunc("/{id}/search", searchDelivery.SearchProductsInRestaurant).Methods(http.MethodGet)
 	}
 	cart := r.PathPrefix("/cart").Subrouter()
 	{
-		cart.HandleFunc("", cartHandler.GetCart).Methods(http.MethodGet, http.MethodOptions)
+		cart.HandleFunc("", authmw.Protect(cartHandler.GetCart, authmw.RequireCSRF)).Methods(http.MethodGet, http.MethodOptions)
 		cart.HandleFunc("/update/{productID}", cartHandler.UpdateQuantityInCart).Methods(http.MethodPost, http.MethodOptions)
-		cart.HandleFunc("/clear", cartHandler.ClearCart).Methods(http.MethodPost, http.MethodOptions)
+		cart.HandleFunc("/clear", authmw.Protect(cartHandler.ClearCart, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		cart.HandleFunc("/merge", authmw.Protect(cartHandler.MergeGuestCart, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+		cart.HandleFunc("/promo", authmw.Protect(cartHandler.PreviewPromo, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
 	}
 
 	order := r.PathPrefix("/order").Subrouter()
 	{
-		order.HandleFunc("", cartHandler.GetOrders).Methods(http.MethodGet)
-		order.HandleFunc("/{orderID}", cartHandler.GetOrderById).Methods(http.MethodGet)
-		order.HandleFunc("/{orderID}/update", cartHandler.UpdateOrderStatus).Methods(http.MethodPost)
-		order.HandleFunc("/create", cartHandler.CreateOrder).Methods(http.MethodPost, http.MethodOptions)
+		order.HandleFunc("", authmw.Protect(cartHandler.GetOrders, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodGet)
+		order.HandleFunc("/{orderID}", authmw.Protect(cartHandler.GetOrderById, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodGet)
+		order.HandleFunc("/{orderID}/events", authmw.Protect(cartHandler.OrderEvents, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodGet)
+		order.HandleFunc("/{orderID}/cancel", authmw.Protect(cartHandler.CancelOrder, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost)
+		order.HandleFunc("/{orderID}/reorder", authmw.Protect(cartHandler.Reorder, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost)
+		order.HandleFunc("/{orderID}/tip", authmw.Protect(cartHandler.UpdateTip, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost)
+		order.HandleFunc("/create", authmw.Protect(cartHandler.CreateOrder, authmw.RequireAuth, authmw.RequireCSRF)).Methods(http.MethodPost, http.MethodOptions)
+	}
+
+	staff := r.PathPrefix("/staff").Subrouter()
+	{
+		staff.HandleFunc("/orders", authmw.Protect(cartHandler.GetRestaurantOrders, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleRestaurantStaff))).Methods(http.MethodGet)
+		staff.HandleFunc("/orders/{orderID}/{action:accept|reject|cooking|ready}", authmw.Protect(cartHandler.UpdateRestaurantOrder, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleRestaurantStaff))).Methods(http.MethodPost)
+	}
+
+	courier := r.PathPrefix("/courier").Subrouter()
+	{
+		courier.HandleFunc("/availability", authmw.Protect(cartHandler.SetCourierAvailability, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleCourier))).Methods(http.MethodPost)
+		courier.HandleFunc("/orders", authmw.Protect(cartHandler.GetCourierOrders, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleCourier))).Methods(http.MethodGet)
+		courier.HandleFunc("/orders/{orderID}/{action:accept|decline|pickup|deliver}", authmw.Protect(cartHandler.UpdateCourierOrder, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleCourier))).Methods(http.MethodPost)
+	}
+
+	admin := r.PathPrefix("/admin").Subrouter()
+	{
+		admin.HandleFunc("/users/{userID}/roles", authmw.Protect(authHandler.GrantRole, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleAdmin))).Methods(http.MethodPost, http.MethodOptions)
+		admin.HandleFunc("/users/{userID}/roles/{role}", authmw.Protect(authHandler.RevokeRole, authmw.RequireAuth, authmw.RequireCSRF, authmw.RequireRole(models.RoleAdmin))).Methods(http.MethodDelete, http.MethodOptions)
 	}
 
 	search := r.PathPrefix("/search").Subrouter()
@@ -140,7 +177,7 @@ func main() {
 		search.HandleFunc("", searchDelivery.SearchRestaurantWithProducts).Methods(http.MethodGet)
 	}
 
-	r.HandleFunc("/payment", cartHandler.UpdateOrderStatus).Methods(http.MethodPost)
+	r.HandleFunc("/payment", cartHandler.PaymentWebhook).Methods(http.MethodPost)
 	r.PathPrefix("/metrics").Handler(promhttp.Handler())
 	http.Handle("/", r)
 	srv := http.Server{
@@ -30,6 +30,23 @@ type CartInReq struct {
 	RestaurantId string `json:"restaurant_id"`
 }
 
+// easyjson:json
+type CartConflict struct {
+	Error          string `json:"error"`
+	RestaurantID   string `json:"restaurant_id"`
+	RestaurantName string `json:"restaurant_name"`
+}
+
+// easyjson:json
+type PriceBreakdown struct {
+	Subtotal    float64 `json:"subtotal"`
+	DeliveryFee float64 `json:"delivery_fee"`
+	ServiceFee  float64 `json:"service_fee"`
+	Discount    float64 `json:"discount"`
+	Tip         float64 `json:"tip"`
+	Total       float64 `json:"total"`
+}
+
 // easyjson:json
 type Order struct {
 	ID            uuid.UUID `json:"id"`
@@ -38,28 +55,87 @@ type Order struct {
 	Address       string    `json:"address"`
 	OrderProducts Cart      `json:"order_products"`
 
-	ApartmentOrOffice string    `json:"apartment_or_office"`
-	Intercom          string    `json:"intercom"`
-	Entrance          string    `json:"entrance"`
-	Floor             string    `json:"floor"`
-	CourierComment    string    `json:"courier_comment"`
-	LeaveAtDoor       bool      `json:"leave_at_door"`
-	CreatedAt         time.Time `json:"created_at"`
-	FinalPrice        float64   `json:"final_price"`
+	ApartmentOrOffice string     `json:"apartment_or_office"`
+	Intercom          string     `json:"intercom"`
+	Entrance          string     `json:"entrance"`
+	Floor             string     `json:"floor"`
+	CourierComment    string     `json:"courier_comment"`
+	LeaveAtDoor       bool       `json:"leave_at_door"`
+	CreatedAt         time.Time  `json:"created_at"`
+	FinalPrice        float64    `json:"final_price"`
+	PaymentID         string     `json:"payment_id,omitempty"`
+	PaymentURL        string     `json:"payment_url,omitempty"`
+	PromoCode         string     `json:"promo_code,omitempty"`
+	PromoCodeID       uuid.UUID  `json:"-"`
+	DeliverAt         *time.Time `json:"deliver_at,omitempty"`
+	ETA               *time.Time `json:"eta,omitempty"`
+
+	PriceBreakdown PriceBreakdown     `json:"price_breakdown"`
+	Timeline       []OrderStatusEvent `json:"timeline"`
+}
+
+// easyjson:json
+type OrderStatusEvent struct {
+	Status    string     `json:"status"`
+	Actor     string     `json:"actor"`
+	Reason    string     `json:"reason,omitempty"`
+	ETA       *time.Time `json:"eta,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
 }
 
 // easyjson:json
 type OrderInReq struct {
-	Status  string `json:"status"`
 	Address string `json:"address"`
 
-	ApartmentOrOffice string  `json:"apartment_or_office"`
-	Intercom          string  `json:"intercom"`
-	Entrance          string  `json:"entrance"`
-	Floor             string  `json:"floor"`
-	CourierComment    string  `json:"courier_comment"`
-	LeaveAtDoor       bool    `json:"leave_at_door"`
-	FinalPrice        float64 `json:"final_price"`
+	ApartmentOrOffice string     `json:"apartment_or_office"`
+	Intercom          string     `json:"intercom"`
+	Entrance          string     `json:"entrance"`
+	Floor             string     `json:"floor"`
+	CourierComment    string     `json:"courier_comment"`
+	LeaveAtDoor       bool       `json:"leave_at_door"`
+	FinalPrice        float64    `json:"final_price"`
+	PromoCode         string     `json:"promo_code,omitempty"`
+	DeliverAt         *time.Time `json:"deliver_at,omitempty"`
+	TipAmount         float64    `json:"tip_amount,omitempty"`
+	TipPercent        float64    `json:"tip_percent,omitempty"`
+}
+
+// easyjson:json
+type TipReq struct {
+	Amount  float64 `json:"tip_amount,omitempty"`
+	Percent float64 `json:"tip_percent,omitempty"`
+}
+
+// easyjson:json
+type CancelOrderReq struct {
+	Reason string `json:"reason"`
+}
+
+// easyjson:json
+type StatusTransition struct {
+	ID         uuid.UUID `json:"id"`
+	OrderID    uuid.UUID `json:"order_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	RunAt      time.Time `json:"run_at"`
+}
+
+// KitchenOrder — заказ ресторана-симулятора: статус, выбранное время доставки, окно доставки
+// ресторана и момент последней смены статуса, от которого отсчитывается время готовки.
+type KitchenOrder struct {
+	OrderID      uuid.UUID
+	Status       string
+	DeliverAt    *time.Time
+	DeliveryTime DeliveryTime
+	UpdatedAt    time.Time
+}
+
+// DeliveryEstimate — данные, из которых считается ожидаемое время доставки (ETA) заказа:
+// окно доставки ресторана в минутах, число заказов впереди на кухне и выбранное время доставки.
+type DeliveryEstimate struct {
+	DeliveryTime DeliveryTime
+	QueueLength  int
+	DeliverAt    *time.Time
 }
 
 func (c *CartItem) Sanitize() {
@@ -86,15 +162,22 @@ func (o *Order) Sanitize() {
 	o.Entrance = html.EscapeString(o.Entrance)
 	o.Floor = html.EscapeString(o.Floor)
 	o.CourierComment = html.EscapeString(o.CourierComment)
+	o.PromoCode = html.EscapeString(o.PromoCode)
 	o.OrderProducts.Sanitize()
 }
 
+// Sanitize экранирует причину смены статуса. Причина хранится в том виде, в каком её ввели,
+// и экранируется только при выдаче клиенту.
+func (e *OrderStatusEvent) Sanitize() {
+	e.Reason = html.EscapeString(e.Reason)
+}
+
 func (o *OrderInReq) Sanitize() {
-	o.Status = html.EscapeString(o.Status)
 	o.Address = html.EscapeString(o.Address)
 	o.ApartmentOrOffice = html.EscapeString(o.ApartmentOrOffice)
 	o.Intercom = html.EscapeString(o.Intercom)
 	o.Entrance = html.EscapeString(o.Entrance)
 	o.Floor = html.EscapeString(o.Floor)
 	o.CourierComment = html.EscapeString(o.CourierComment)
-}
\ No newline at end of file
+	o.PromoCode = html.EscapeString(o.PromoCode)
+}
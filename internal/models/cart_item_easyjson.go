@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -17,7 +18,287 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in *jlexer.Lexer, out *OrderInReq) {
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in *jlexer.Lexer, out *TipReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tip_amount":
+			out.Amount = float64(in.Float64())
+		case "tip_percent":
+			out.Percent = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(out *jwriter.Writer, in TipReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Amount != 0 {
+		const prefix string = ",\"tip_amount\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Amount))
+	}
+	if in.Percent != 0 {
+		const prefix string = ",\"tip_percent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.Percent))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TipReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TipReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TipReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TipReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(in *jlexer.Lexer, out *StatusTransition) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "order_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.OrderID).UnmarshalText(data))
+			}
+		case "from_status":
+			out.FromStatus = string(in.String())
+		case "to_status":
+			out.ToStatus = string(in.String())
+		case "run_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.RunAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(out *jwriter.Writer, in StatusTransition) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.RawText((in.OrderID).MarshalText())
+	}
+	{
+		const prefix string = ",\"from_status\":"
+		out.RawString(prefix)
+		out.String(string(in.FromStatus))
+	}
+	{
+		const prefix string = ",\"to_status\":"
+		out.RawString(prefix)
+		out.String(string(in.ToStatus))
+	}
+	{
+		const prefix string = ",\"run_at\":"
+		out.RawString(prefix)
+		out.Raw((in.RunAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StatusTransition) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StatusTransition) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StatusTransition) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StatusTransition) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(in *jlexer.Lexer, out *PriceBreakdown) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "subtotal":
+			out.Subtotal = float64(in.Float64())
+		case "delivery_fee":
+			out.DeliveryFee = float64(in.Float64())
+		case "service_fee":
+			out.ServiceFee = float64(in.Float64())
+		case "discount":
+			out.Discount = float64(in.Float64())
+		case "tip":
+			out.Tip = float64(in.Float64())
+		case "total":
+			out.Total = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(out *jwriter.Writer, in PriceBreakdown) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"subtotal\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Subtotal))
+	}
+	{
+		const prefix string = ",\"delivery_fee\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.DeliveryFee))
+	}
+	{
+		const prefix string = ",\"service_fee\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ServiceFee))
+	}
+	{
+		const prefix string = ",\"discount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Discount))
+	}
+	{
+		const prefix string = ",\"tip\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Tip))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Total))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PriceBreakdown) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PriceBreakdown) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PriceBreakdown) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PriceBreakdown) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(in *jlexer.Lexer, out *OrderStatusEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -38,6 +319,110 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in
 		switch key {
 		case "status":
 			out.Status = string(in.String())
+		case "actor":
+			out.Actor = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "eta":
+			if in.IsNull() {
+				in.Skip()
+				out.ETA = nil
+			} else {
+				if out.ETA == nil {
+					out.ETA = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ETA).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(out *jwriter.Writer, in OrderStatusEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"actor\":"
+		out.RawString(prefix)
+		out.String(string(in.Actor))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.ETA != nil {
+		const prefix string = ",\"eta\":"
+		out.RawString(prefix)
+		out.Raw((*in.ETA).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(in *jlexer.Lexer, out *OrderInReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
 		case "address":
 			out.Address = string(in.String())
 		case "apartment_or_office":
@@ -54,6 +439,24 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in
 			out.LeaveAtDoor = bool(in.Bool())
 		case "final_price":
 			out.FinalPrice = float64(in.Float64())
+		case "promo_code":
+			out.PromoCode = string(in.String())
+		case "deliver_at":
+			if in.IsNull() {
+				in.Skip()
+				out.DeliverAt = nil
+			} else {
+				if out.DeliverAt == nil {
+					out.DeliverAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.DeliverAt).UnmarshalJSON(data))
+				}
+			}
+		case "tip_amount":
+			out.TipAmount = float64(in.Float64())
+		case "tip_percent":
+			out.TipPercent = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -64,18 +467,13 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in
 		in.Consumed()
 	}
 }
-func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(out *jwriter.Writer, in OrderInReq) {
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(out *jwriter.Writer, in OrderInReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"status\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Status))
-	}
 	{
 		const prefix string = ",\"address\":"
-		out.RawString(prefix)
+		out.RawString(prefix[1:])
 		out.String(string(in.Address))
 	}
 	{
@@ -113,33 +511,53 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(ou
 		out.RawString(prefix)
 		out.Float64(float64(in.FinalPrice))
 	}
+	if in.PromoCode != "" {
+		const prefix string = ",\"promo_code\":"
+		out.RawString(prefix)
+		out.String(string(in.PromoCode))
+	}
+	if in.DeliverAt != nil {
+		const prefix string = ",\"deliver_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.DeliverAt).MarshalJSON())
+	}
+	if in.TipAmount != 0 {
+		const prefix string = ",\"tip_amount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.TipAmount))
+	}
+	if in.TipPercent != 0 {
+		const prefix string = ",\"tip_percent\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.TipPercent))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v OrderInReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(&w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OrderInReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels(w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OrderInReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(&r, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OrderInReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels(l, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(l, v)
 }
-func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(in *jlexer.Lexer, out *Order) {
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels5(in *jlexer.Lexer, out *Order) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -188,6 +606,61 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(i
 			}
 		case "final_price":
 			out.FinalPrice = float64(in.Float64())
+		case "payment_id":
+			out.PaymentID = string(in.String())
+		case "payment_url":
+			out.PaymentURL = string(in.String())
+		case "promo_code":
+			out.PromoCode = string(in.String())
+		case "deliver_at":
+			if in.IsNull() {
+				in.Skip()
+				out.DeliverAt = nil
+			} else {
+				if out.DeliverAt == nil {
+					out.DeliverAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.DeliverAt).UnmarshalJSON(data))
+				}
+			}
+		case "eta":
+			if in.IsNull() {
+				in.Skip()
+				out.ETA = nil
+			} else {
+				if out.ETA == nil {
+					out.ETA = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ETA).UnmarshalJSON(data))
+				}
+			}
+		case "price_breakdown":
+			(out.PriceBreakdown).UnmarshalEasyJSON(in)
+		case "timeline":
+			if in.IsNull() {
+				in.Skip()
+				out.Timeline = nil
+			} else {
+				in.Delim('[')
+				if out.Timeline == nil {
+					if !in.IsDelim(']') {
+						out.Timeline = make([]OrderStatusEvent, 0, 0)
+					} else {
+						out.Timeline = []OrderStatusEvent{}
+					}
+				} else {
+					out.Timeline = (out.Timeline)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 OrderStatusEvent
+					(v1).UnmarshalEasyJSON(in)
+					out.Timeline = append(out.Timeline, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -198,7 +671,7 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(i
 		in.Consumed()
 	}
 }
-func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(out *jwriter.Writer, in Order) {
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels5(out *jwriter.Writer, in Order) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -267,33 +740,79 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(o
 		out.RawString(prefix)
 		out.Float64(float64(in.FinalPrice))
 	}
+	if in.PaymentID != "" {
+		const prefix string = ",\"payment_id\":"
+		out.RawString(prefix)
+		out.String(string(in.PaymentID))
+	}
+	if in.PaymentURL != "" {
+		const prefix string = ",\"payment_url\":"
+		out.RawString(prefix)
+		out.String(string(in.PaymentURL))
+	}
+	if in.PromoCode != "" {
+		const prefix string = ",\"promo_code\":"
+		out.RawString(prefix)
+		out.String(string(in.PromoCode))
+	}
+	if in.DeliverAt != nil {
+		const prefix string = ",\"deliver_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.DeliverAt).MarshalJSON())
+	}
+	if in.ETA != nil {
+		const prefix string = ",\"eta\":"
+		out.RawString(prefix)
+		out.Raw((*in.ETA).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"price_breakdown\":"
+		out.RawString(prefix)
+		(in.PriceBreakdown).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"timeline\":"
+		out.RawString(prefix)
+		if in.Timeline == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Timeline {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Order) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(&w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Order) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Order) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(&r, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Order) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(l, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels5(l, v)
 }
-func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(in *jlexer.Lexer, out *CartItem) {
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels6(in *jlexer.Lexer, out *CartItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -336,7 +855,7 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(i
 		in.Consumed()
 	}
 }
-func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(out *jwriter.Writer, in CartItem) {
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels6(out *jwriter.Writer, in CartItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -376,27 +895,27 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(o
 // MarshalJSON supports json.Marshaler interface
 func (v CartItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(&w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CartItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels2(w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CartItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(&r, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CartItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels2(l, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels6(l, v)
 }
-func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(in *jlexer.Lexer, out *CartInReq) {
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels7(in *jlexer.Lexer, out *CartInReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -429,7 +948,7 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(i
 		in.Consumed()
 	}
 }
-func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(out *jwriter.Writer, in CartInReq) {
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels7(out *jwriter.Writer, in CartInReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -449,27 +968,107 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(o
 // MarshalJSON supports json.Marshaler interface
 func (v CartInReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(&w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CartInReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels3(w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CartInReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(&r, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CartInReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels3(l, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels7(l, v)
 }
-func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(in *jlexer.Lexer, out *Cart) {
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels8(in *jlexer.Lexer, out *CartConflict) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "error":
+			out.Error = string(in.String())
+		case "restaurant_id":
+			out.RestaurantID = string(in.String())
+		case "restaurant_name":
+			out.RestaurantName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels8(out *jwriter.Writer, in CartConflict) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"restaurant_id\":"
+		out.RawString(prefix)
+		out.String(string(in.RestaurantID))
+	}
+	{
+		const prefix string = ",\"restaurant_name\":"
+		out.RawString(prefix)
+		out.String(string(in.RestaurantName))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CartConflict) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CartConflict) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CartConflict) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CartConflict) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels8(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels9(in *jlexer.Lexer, out *Cart) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -510,9 +1109,9 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(i
 					out.CartItems = (out.CartItems)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 CartItem
-					(v1).UnmarshalEasyJSON(in)
-					out.CartItems = append(out.CartItems, v1)
+					var v4 CartItem
+					(v4).UnmarshalEasyJSON(in)
+					out.CartItems = append(out.CartItems, v4)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -527,7 +1126,7 @@ func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(i
 		in.Consumed()
 	}
 }
-func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(out *jwriter.Writer, in Cart) {
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels9(out *jwriter.Writer, in Cart) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -548,11 +1147,11 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(o
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.CartItems {
-				if v2 > 0 {
+			for v5, v6 := range in.CartItems {
+				if v5 > 0 {
 					out.RawByte(',')
 				}
-				(v3).MarshalEasyJSON(out)
+				(v6).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -563,23 +1162,89 @@ func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(o
 // MarshalJSON supports json.Marshaler interface
 func (v Cart) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(&w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Cart) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(w, v)
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Cart) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(&r, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Cart) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(l, v)
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels9(l, v)
+}
+func easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels10(in *jlexer.Lexer, out *CancelOrderReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels10(out *jwriter.Writer, in CancelOrderReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CancelOrderReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CancelOrderReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE590a42aEncodeGithubComGoParkMailRu20251AdminadminInternalModels10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CancelOrderReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CancelOrderReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE590a42aDecodeGithubComGoParkMailRu20251AdminadminInternalModels10(l, v)
 }
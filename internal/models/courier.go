@@ -0,0 +1,47 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// easyjson:json
+type Courier struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Available    bool      `json:"available"`
+	Latitude     *float64  `json:"latitude,omitempty"`
+	Longitude    *float64  `json:"longitude,omitempty"`
+	ActiveOrders int       `json:"active_orders"`
+	MaxOrders    int       `json:"max_orders"`
+}
+
+// easyjson:json
+type CourierAvailabilityReq struct {
+	Available bool     `json:"available"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// DispatchOrder — готовый к выдаче заказ, которому диспетчер ищет курьера.
+type DispatchOrder struct {
+	OrderID   uuid.UUID
+	Latitude  *float64
+	Longitude *float64
+}
+
+// CourierAssignment — назначение заказа курьеру вместе с текущим статусом заказа.
+type CourierAssignment struct {
+	OrderID     uuid.UUID
+	CourierID   uuid.UUID
+	Status      string
+	OrderStatus string
+	UserID      string
+	UpdatedAt   time.Time
+}
+
+func (c *Courier) Sanitize() {
+	c.Name = html.EscapeString(c.Name)
+}
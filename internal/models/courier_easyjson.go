@@ -0,0 +1,249 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels(in *jlexer.Lexer, out *CourierAvailabilityReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "available":
+			out.Available = bool(in.Bool())
+		case "latitude":
+			if in.IsNull() {
+				in.Skip()
+				out.Latitude = nil
+			} else {
+				if out.Latitude == nil {
+					out.Latitude = new(float64)
+				}
+				*out.Latitude = float64(in.Float64())
+			}
+		case "longitude":
+			if in.IsNull() {
+				in.Skip()
+				out.Longitude = nil
+			} else {
+				if out.Longitude == nil {
+					out.Longitude = new(float64)
+				}
+				*out.Longitude = float64(in.Float64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels(out *jwriter.Writer, in CourierAvailabilityReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"available\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Available))
+	}
+	{
+		const prefix string = ",\"latitude\":"
+		out.RawString(prefix)
+		if in.Latitude == nil {
+			out.RawString("null")
+		} else {
+			out.Float64(float64(*in.Latitude))
+		}
+	}
+	{
+		const prefix string = ",\"longitude\":"
+		out.RawString(prefix)
+		if in.Longitude == nil {
+			out.RawString("null")
+		} else {
+			out.Float64(float64(*in.Longitude))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CourierAvailabilityReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CourierAvailabilityReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CourierAvailabilityReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CourierAvailabilityReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels(l, v)
+}
+func easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels1(in *jlexer.Lexer, out *Courier) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "name":
+			out.Name = string(in.String())
+		case "available":
+			out.Available = bool(in.Bool())
+		case "latitude":
+			if in.IsNull() {
+				in.Skip()
+				out.Latitude = nil
+			} else {
+				if out.Latitude == nil {
+					out.Latitude = new(float64)
+				}
+				*out.Latitude = float64(in.Float64())
+			}
+		case "longitude":
+			if in.IsNull() {
+				in.Skip()
+				out.Longitude = nil
+			} else {
+				if out.Longitude == nil {
+					out.Longitude = new(float64)
+				}
+				*out.Longitude = float64(in.Float64())
+			}
+		case "active_orders":
+			out.ActiveOrders = int(in.Int())
+		case "max_orders":
+			out.MaxOrders = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels1(out *jwriter.Writer, in Courier) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"available\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Available))
+	}
+	if in.Latitude != nil {
+		const prefix string = ",\"latitude\":"
+		out.RawString(prefix)
+		out.Float64(float64(*in.Latitude))
+	}
+	if in.Longitude != nil {
+		const prefix string = ",\"longitude\":"
+		out.RawString(prefix)
+		out.Float64(float64(*in.Longitude))
+	}
+	{
+		const prefix string = ",\"active_orders\":"
+		out.RawString(prefix)
+		out.Int(int(in.ActiveOrders))
+	}
+	{
+		const prefix string = ",\"max_orders\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxOrders))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Courier) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Courier) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson7814433EncodeGithubComGoParkMailRu20251AdminadminInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Courier) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Courier) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson7814433DecodeGithubComGoParkMailRu20251AdminadminInternalModels1(l, v)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// OrderFilter — условия выборки истории заказов. Нулевые значения полей означают «без ограничения».
+type OrderFilter struct {
+	Statuses     []string
+	RestaurantID uuid.UUID
+	From         *time.Time
+	To           *time.Time
+	MinTotal     float64
+	MaxTotal     float64
+	OldestFirst  bool
+	After        *OrderCursor
+}
+
+// OrderCursor — позиция последнего заказа на странице, с которой продолжается выборка.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// easyjson:json
+type OrderPage struct {
+	Orders     []Order `json:"orders"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+func (p *OrderPage) Sanitize() {
+	for i := range p.Orders {
+		p.Orders[i].Sanitize()
+	}
+}
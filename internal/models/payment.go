@@ -0,0 +1,35 @@
+package models
+
+import (
+	"html"
+
+	"github.com/satori/uuid"
+)
+
+// easyjson:json
+type PaymentNotification struct {
+	OrderID   string  `json:"order_id"`
+	PaymentID string  `json:"payment_id"`
+	Amount    float64 `json:"amount"`
+}
+
+func (p *PaymentNotification) Sanitize() {
+	p.OrderID = html.EscapeString(p.OrderID)
+	p.PaymentID = html.EscapeString(p.PaymentID)
+}
+
+// TipPayment — отдельный платёж, которым доплачены чаевые уже доставленного заказа.
+type TipPayment struct {
+	PaymentID string
+	OrderID   uuid.UUID
+	Amount    float64
+}
+
+type PaymentIntent struct {
+	ID              string
+	OrderID         uuid.UUID
+	Amount          float64
+	RefundedAmount  float64
+	Status          string
+	ConfirmationURL string
+}
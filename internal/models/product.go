@@ -44,6 +44,7 @@ type Review struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// easyjson:json
 type ReviewUser struct {
 	Id uuid.UUID `json:"id"`
 }
@@ -65,6 +66,8 @@ type RestaurantFull struct {
 	RatingCount  int          `json:"rating_count"`
 	WorkingMode  WorkingMode  `json:"working_mode"`
 	DeliveryTime DeliveryTime `json:"delivery_time"`
+	IsOpen       bool         `json:"is_open"`
+	OpensAt      *time.Time   `json:"opens_at,omitempty"`
 	Tags         []string     `json:"tags"`
 	Categories   []Category   `json:"categories"`
 	Reviews      []Review     `json:"reviews"`
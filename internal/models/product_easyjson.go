@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -381,6 +382,20 @@ func easyjsonCf3f67efDecodeGithubComGoParkMailRu20251AdminadminInternalModels4(i
 			(out.WorkingMode).UnmarshalEasyJSON(in)
 		case "delivery_time":
 			(out.DeliveryTime).UnmarshalEasyJSON(in)
+		case "is_open":
+			out.IsOpen = bool(in.Bool())
+		case "opens_at":
+			if in.IsNull() {
+				in.Skip()
+				out.OpensAt = nil
+			} else {
+				if out.OpensAt == nil {
+					out.OpensAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.OpensAt).UnmarshalJSON(data))
+				}
+			}
 		case "tags":
 			if in.IsNull() {
 				in.Skip()
@@ -509,6 +524,16 @@ func easyjsonCf3f67efEncodeGithubComGoParkMailRu20251AdminadminInternalModels4(o
 		out.RawString(prefix)
 		(in.DeliveryTime).MarshalEasyJSON(out)
 	}
+	{
+		const prefix string = ",\"is_open\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsOpen))
+	}
+	if in.OpensAt != nil {
+		const prefix string = ",\"opens_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.OpensAt).MarshalJSON())
+	}
 	{
 		const prefix string = ",\"tags\":"
 		out.RawString(prefix)
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// PromoCode — условия промокода. Нулевые MinSubtotal, MaxUses, MaxUsesPerUser и пустые
+// RestaurantID, TagID, StartsAt, EndsAt означают, что соответствующего ограничения нет.
+type PromoCode struct {
+	ID             uuid.UUID
+	Code           string
+	Type           string
+	Value          float64
+	FirstOrderOnly bool
+	MinSubtotal    float64
+	MaxUses        int
+	MaxUsesPerUser int
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	RestaurantID   uuid.UUID
+	TagID          uuid.UUID
+	// RestaurantHasTag — есть ли TagID среди тегов ресторана, для которого запрошен промокод.
+	RestaurantHasTag bool
+}
+
+// PromoUsage — сколько раз промокод уже применён и сколько активных заказов у пользователя.
+type PromoUsage struct {
+	Total      int
+	ByUser     int
+	UserOrders int
+}
+
+// easyjson:json
+type PromoReq struct {
+	PromoCode string `json:"promo_code"`
+}
+
+// easyjson:json
+type PromoPreview struct {
+	PromoCode      string         `json:"promo_code"`
+	Cart           Cart           `json:"cart"`
+	PriceBreakdown PriceBreakdown `json:"price_breakdown"`
+}
+
+func (p *PromoReq) Sanitize() {
+	p.PromoCode = html.EscapeString(p.PromoCode)
+}
+
+func (p *PromoPreview) Sanitize() {
+	p.PromoCode = html.EscapeString(p.PromoCode)
+	p.Cart.Sanitize()
+}
@@ -0,0 +1,165 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in *jlexer.Lexer, out *PromoReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "promo_code":
+			out.PromoCode = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels(out *jwriter.Writer, in PromoReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"promo_code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PromoCode))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels(l, v)
+}
+func easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(in *jlexer.Lexer, out *PromoPreview) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "promo_code":
+			out.PromoCode = string(in.String())
+		case "cart":
+			(out.Cart).UnmarshalEasyJSON(in)
+		case "price_breakdown":
+			(out.PriceBreakdown).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(out *jwriter.Writer, in PromoPreview) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"promo_code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PromoCode))
+	}
+	{
+		const prefix string = ",\"cart\":"
+		out.RawString(prefix)
+		(in.Cart).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"price_breakdown\":"
+		out.RawString(prefix)
+		(in.PriceBreakdown).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PromoPreview) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PromoPreview) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson47107b8bEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PromoPreview) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PromoPreview) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson47107b8bDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(l, v)
+}
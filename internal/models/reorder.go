@@ -0,0 +1,38 @@
+package models
+
+import (
+	"html"
+
+	"github.com/satori/uuid"
+)
+
+// easyjson:json
+type ReorderItem struct {
+	Id       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Amount   int       `json:"amount"`
+	OldPrice float64   `json:"old_price"`
+	NewPrice float64   `json:"new_price,omitempty"`
+}
+
+// ReorderResult — корзина после повтора заказа и товары, которые пришлось убрать или чья цена изменилась.
+// easyjson:json
+type ReorderResult struct {
+	Cart     Cart          `json:"cart"`
+	Dropped  []ReorderItem `json:"dropped"`
+	Repriced []ReorderItem `json:"repriced"`
+}
+
+func (i *ReorderItem) Sanitize() {
+	i.Name = html.EscapeString(i.Name)
+}
+
+func (r *ReorderResult) Sanitize() {
+	r.Cart.Sanitize()
+	for i := range r.Dropped {
+		r.Dropped[i].Sanitize()
+	}
+	for i := range r.Repriced {
+		r.Repriced[i].Sanitize()
+	}
+}
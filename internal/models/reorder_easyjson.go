@@ -0,0 +1,259 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels(in *jlexer.Lexer, out *ReorderResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "cart":
+			(out.Cart).UnmarshalEasyJSON(in)
+		case "dropped":
+			if in.IsNull() {
+				in.Skip()
+				out.Dropped = nil
+			} else {
+				in.Delim('[')
+				if out.Dropped == nil {
+					if !in.IsDelim(']') {
+						out.Dropped = make([]ReorderItem, 0, 1)
+					} else {
+						out.Dropped = []ReorderItem{}
+					}
+				} else {
+					out.Dropped = (out.Dropped)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ReorderItem
+					(v1).UnmarshalEasyJSON(in)
+					out.Dropped = append(out.Dropped, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repriced":
+			if in.IsNull() {
+				in.Skip()
+				out.Repriced = nil
+			} else {
+				in.Delim('[')
+				if out.Repriced == nil {
+					if !in.IsDelim(']') {
+						out.Repriced = make([]ReorderItem, 0, 1)
+					} else {
+						out.Repriced = []ReorderItem{}
+					}
+				} else {
+					out.Repriced = (out.Repriced)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 ReorderItem
+					(v2).UnmarshalEasyJSON(in)
+					out.Repriced = append(out.Repriced, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels(out *jwriter.Writer, in ReorderResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"cart\":"
+		out.RawString(prefix[1:])
+		(in.Cart).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"dropped\":"
+		out.RawString(prefix)
+		if in.Dropped == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Dropped {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				(v4).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repriced\":"
+		out.RawString(prefix)
+		if in.Repriced == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Repriced {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReorderResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReorderResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReorderResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReorderResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels(l, v)
+}
+func easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(in *jlexer.Lexer, out *ReorderItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.Id).UnmarshalText(data))
+			}
+		case "name":
+			out.Name = string(in.String())
+		case "amount":
+			out.Amount = int(in.Int())
+		case "old_price":
+			out.OldPrice = float64(in.Float64())
+		case "new_price":
+			out.NewPrice = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(out *jwriter.Writer, in ReorderItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.Id).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.Amount))
+	}
+	{
+		const prefix string = ",\"old_price\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.OldPrice))
+	}
+	if in.NewPrice != 0 {
+		const prefix string = ",\"new_price\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.NewPrice))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReorderItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReorderItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4cbb82fEncodeGithubComGoParkMailRu20251AdminadminInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReorderItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReorderItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4cbb82fDecodeGithubComGoParkMailRu20251AdminadminInternalModels1(l, v)
+}
@@ -2,17 +2,21 @@ package models
 
 import (
 	"html"
+	"time"
 
 	uuid "github.com/satori/uuid"
 )
 
 // easyjson:json
 type Restaurant struct {
-	Id          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Rating      float64   `json:"rating"`
-	ImageURL    string    `json:"image_url"`
+	Id          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Rating      float64     `json:"rating"`
+	ImageURL    string      `json:"image_url"`
+	IsOpen      bool        `json:"is_open"`
+	OpensAt     *time.Time  `json:"opens_at,omitempty"`
+	WorkingMode WorkingMode `json:"-"`
 }
 
 // easyjson:json
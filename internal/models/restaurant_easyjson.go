@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -197,6 +198,20 @@ func easyjson16134a91DecodeGithubComGoParkMailRu20251AdminadminInternalModels1(i
 			out.Rating = float64(in.Float64())
 		case "image_url":
 			out.ImageURL = string(in.String())
+		case "is_open":
+			out.IsOpen = bool(in.Bool())
+		case "opens_at":
+			if in.IsNull() {
+				in.Skip()
+				out.OpensAt = nil
+			} else {
+				if out.OpensAt == nil {
+					out.OpensAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.OpensAt).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -236,6 +251,16 @@ func easyjson16134a91EncodeGithubComGoParkMailRu20251AdminadminInternalModels1(o
 		out.RawString(prefix)
 		out.String(string(in.ImageURL))
 	}
+	{
+		const prefix string = ",\"is_open\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsOpen))
+	}
+	if in.OpensAt != nil {
+		const prefix string = ",\"opens_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.OpensAt).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
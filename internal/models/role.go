@@ -0,0 +1,38 @@
+package models
+
+import (
+	"html"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	RoleCustomer        = "customer"
+	RoleRestaurantStaff = "restaurant_staff"
+	RoleCourier         = "courier"
+	RoleAdmin           = "admin"
+)
+
+// ValidRole сообщает, существует ли такая роль.
+func ValidRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleRestaurantStaff, RoleCourier, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// easyjson:json
+type RoleReq struct {
+	Role string `json:"role"`
+}
+
+func (r *RoleReq) Sanitize() {
+	r.Role = html.EscapeString(r.Role)
+}
+
+// easyjson:json
+type UserRoles struct {
+	UserID uuid.UUID `json:"user_id"`
+	Roles  []string  `json:"roles"`
+}
@@ -15,6 +15,7 @@ type User struct {
 	LastName     string    `json:"last_name"`
 	Description  string    `json:"description"`
 	UserPic      string    `json:"path"`
+	Roles        []string  `json:"roles"`
 	PasswordHash []byte    `json:"-"`
 }
 
@@ -24,7 +24,6 @@ const (
 
 type CheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -59,16 +58,8 @@ func (*CheckRequest) Descriptor() ([]byte, []int) {
 	return file_proto_auth_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *CheckRequest) GetLogin() string {
-	if x != nil {
-		return x.Login
-	}
-	return ""
-}
-
 type AddressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -103,13 +94,6 @@ func (*AddressRequest) Descriptor() ([]byte, []int) {
 	return file_proto_auth_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *AddressRequest) GetLogin() string {
-	if x != nil {
-		return x.Login
-	}
-	return ""
-}
-
 type SignInRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
@@ -240,7 +224,6 @@ func (x *SignUpRequest) GetPassword() string {
 
 type UpdateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=Description,proto3" json:"Description,omitempty"`
 	FirstName     string                 `protobuf:"bytes,3,opt,name=FirstName,proto3" json:"FirstName,omitempty"`
 	LastName      string                 `protobuf:"bytes,4,opt,name=LastName,proto3" json:"LastName,omitempty"`
@@ -280,13 +263,6 @@ func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
 	return file_proto_auth_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UpdateUserRequest) GetLogin() string {
-	if x != nil {
-		return x.Login
-	}
-	return ""
-}
-
 func (x *UpdateUserRequest) GetDescription() string {
 	if x != nil {
 		return x.Description
@@ -324,7 +300,6 @@ func (x *UpdateUserRequest) GetPassword() string {
 
 type UpdateUserPicRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
 	UserPic       []byte                 `protobuf:"bytes,2,opt,name=user_pic,json=userPic,proto3" json:"user_pic,omitempty"`
 	FileExtension string                 `protobuf:"bytes,3,opt,name=file_extension,json=fileExtension,proto3" json:"file_extension,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -361,13 +336,6 @@ func (*UpdateUserPicRequest) Descriptor() ([]byte, []int) {
 	return file_proto_auth_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UpdateUserPicRequest) GetLogin() string {
-	if x != nil {
-		return x.Login
-	}
-	return ""
-}
-
 func (x *UpdateUserPicRequest) GetUserPic() []byte {
 	if x != nil {
 		return x.UserPic
@@ -497,6 +465,8 @@ type UserResponse struct {
 	UserPic       string                 `protobuf:"bytes,7,opt,name=UserPic,proto3" json:"UserPic,omitempty"`
 	Token         string                 `protobuf:"bytes,8,opt,name=Token,proto3" json:"Token,omitempty"`
 	CsrfToken     string                 `protobuf:"bytes,9,opt,name=CsrfToken,proto3" json:"CsrfToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,10,opt,name=RefreshToken,proto3" json:"RefreshToken,omitempty"`
+	Roles         []string               `protobuf:"bytes,11,rep,name=Roles,proto3" json:"Roles,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -594,6 +564,20 @@ func (x *UserResponse) GetCsrfToken() string {
 	return ""
 }
 
+func (x *UserResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *UserResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
 type AddressListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Addresses     []*Address             `protobuf:"bytes,1,rep,name=Addresses,proto3" json:"Addresses,omitempty"`
@@ -638,15 +622,249 @@ func (x *AddressListResponse) GetAddresses() []*Address {
 	return nil
 }
 
+type SessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=SessionId,proto3" json:"SessionId,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=RefreshToken,proto3" json:"RefreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_proto_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=RefreshToken,proto3" json:"RefreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_proto_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefreshRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogOutAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogOutAllRequest) Reset() {
+	*x = LogOutAllRequest{}
+	mi := &file_proto_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogOutAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogOutAllRequest) ProtoMessage() {}
+
+func (x *LogOutAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogOutAllRequest.ProtoReflect.Descriptor instead.
+func (*LogOutAllRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{12}
+}
+
+type RoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=Role,proto3" json:"Role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleRequest) Reset() {
+	*x = RoleRequest{}
+	mi := &file_proto_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleRequest) ProtoMessage() {}
+
+func (x *RoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleRequest.ProtoReflect.Descriptor instead.
+func (*RoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=Roles,proto3" json:"Roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RolesResponse) Reset() {
+	*x = RolesResponse{}
+	mi := &file_proto_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolesResponse) ProtoMessage() {}
+
+func (x *RolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolesResponse.ProtoReflect.Descriptor instead.
+func (*RolesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RolesResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RolesResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
 var File_proto_auth_proto protoreflect.FileDescriptor
 
 const file_proto_auth_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/auth.proto\x12\x04auth\x1a\x1bgoogle/protobuf/empty.proto\"$\n" +
-	"\fCheckRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\"&\n" +
-	"\x0eAddressRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\"A\n" +
+	"\x10proto/auth.proto\x12\x04auth\x1a\x1bgoogle/protobuf/empty.proto\"\x14\n" +
+	"\fCheckRequestJ\x04\b\x01\x10\x02\"\x16\n" +
+	"\x0eAddressRequestJ\x04\b\x01\x10\x02\"A\n" +
 	"\rSignInRequest\x12\x14\n" +
 	"\x05Login\x18\x01 \x01(\tR\x05Login\x12\x1a\n" +
 	"\bPassword\x18\x02 \x01(\tR\bPassword\"\x9d\x01\n" +
@@ -655,24 +873,22 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\tFirstName\x18\x02 \x01(\tR\tFirstName\x12\x1a\n" +
 	"\bLastName\x18\x03 \x01(\tR\bLastName\x12 \n" +
 	"\vPhoneNumber\x18\x04 \x01(\tR\vPhoneNumber\x12\x1a\n" +
-	"\bPassword\x18\x05 \x01(\tR\bPassword\"\xc3\x01\n" +
-	"\x11UpdateUserRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\x12 \n" +
+	"\bPassword\x18\x05 \x01(\tR\bPassword\"\xb3\x01\n" +
+	"\x11UpdateUserRequest\x12 \n" +
 	"\vDescription\x18\x02 \x01(\tR\vDescription\x12\x1c\n" +
 	"\tFirstName\x18\x03 \x01(\tR\tFirstName\x12\x1a\n" +
 	"\bLastName\x18\x04 \x01(\tR\bLastName\x12 \n" +
 	"\vPhoneNumber\x18\x05 \x01(\tR\vPhoneNumber\x12\x1a\n" +
-	"\bPassword\x18\x06 \x01(\tR\bPassword\"n\n" +
-	"\x14UpdateUserPicRequest\x12\x14\n" +
-	"\x05login\x18\x01 \x01(\tR\x05login\x12\x19\n" +
+	"\bPassword\x18\x06 \x01(\tR\bPasswordJ\x04\b\x01\x10\x02\"^\n" +
+	"\x14UpdateUserPicRequest\x12\x19\n" +
 	"\buser_pic\x18\x02 \x01(\fR\auserPic\x12%\n" +
-	"\x0efile_extension\x18\x03 \x01(\tR\rfileExtension\"&\n" +
+	"\x0efile_extension\x18\x03 \x01(\tR\rfileExtensionJ\x04\b\x01\x10\x02\"&\n" +
 	"\x14DeleteAddressRequest\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\tR\x02Id\"K\n" +
 	"\aAddress\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x18\n" +
 	"\aAddress\x18\x02 \x01(\tR\aAddress\x12\x16\n" +
-	"\x06UserId\x18\x03 \x01(\tR\x06UserId\"\x80\x02\n" +
+	"\x06UserId\x18\x03 \x01(\tR\x06UserId\"\xba\x02\n" +
 	"\fUserResponse\x12\x14\n" +
 	"\x05Login\x18\x01 \x01(\tR\x05Login\x12 \n" +
 	"\vPhoneNumber\x18\x02 \x01(\tR\vPhoneNumber\x12\x0e\n" +
@@ -682,9 +898,24 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\vDescription\x18\x06 \x01(\tR\vDescription\x12\x18\n" +
 	"\aUserPic\x18\a \x01(\tR\aUserPic\x12\x14\n" +
 	"\x05Token\x18\b \x01(\tR\x05Token\x12\x1c\n" +
-	"\tCsrfToken\x18\t \x01(\tR\tCsrfToken\"B\n" +
+	"\tCsrfToken\x18\t \x01(\tR\tCsrfToken\x12\"\n" +
+	"\fRefreshToken\x18\n" +
+	" \x01(\tR\fRefreshToken\x12\x14\n" +
+	"\x05Roles\x18\v \x03(\tR\x05Roles\"B\n" +
 	"\x13AddressListResponse\x12+\n" +
-	"\tAddresses\x18\x01 \x03(\v2\r.auth.AddressR\tAddresses2\xef\x03\n" +
+	"\tAddresses\x18\x01 \x03(\v2\r.auth.AddressR\tAddresses\"R\n" +
+	"\x0eSessionRequest\x12\x1c\n" +
+	"\tSessionId\x18\x01 \x01(\tR\tSessionId\x12\"\n" +
+	"\fRefreshToken\x18\x02 \x01(\tR\fRefreshToken\"4\n" +
+	"\x0eRefreshRequest\x12\"\n" +
+	"\fRefreshToken\x18\x01 \x01(\tR\fRefreshToken\"\x18\n" +
+	"\x10LogOutAllRequestJ\x04\b\x01\x10\x02\"9\n" +
+	"\vRoleRequest\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x12\n" +
+	"\x04Role\x18\x02 \x01(\tR\x04Role\"=\n" +
+	"\rRolesResponse\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x14\n" +
+	"\x05Roles\x18\x02 \x03(\tR\x05Roles2\xce\x06\n" +
 	"\vAuthService\x123\n" +
 	"\x06SignIn\x12\x13.auth.SignInRequest\x1a\x12.auth.UserResponse\"\x00\x123\n" +
 	"\x06SignUp\x12\x13.auth.SignUpRequest\x1a\x12.auth.UserResponse\"\x00\x121\n" +
@@ -695,7 +926,14 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\x10GetUserAddresses\x12\x14.auth.AddressRequest\x1a\x19.auth.AddressListResponse\"\x00\x12E\n" +
 	"\rDeleteAddress\x12\x1a.auth.DeleteAddressRequest\x1a\x16.google.protobuf.Empty\"\x00\x125\n" +
 	"\n" +
-	"AddAddress\x12\r.auth.Address\x1a\x16.google.protobuf.Empty\"\x00B'Z%./internal/pkg/auth/delivery/grpc/genb\x06proto3"
+	"AddAddress\x12\r.auth.Address\x1a\x16.google.protobuf.Empty\"\x00\x12>\n" +
+	"\fCheckSession\x12\x14.auth.SessionRequest\x1a\x16.google.protobuf.Empty\"\x00\x128\n" +
+	"\x06LogOut\x12\x14.auth.SessionRequest\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
+	"\tLogOutAll\x12\x16.auth.LogOutAllRequest\x1a\x16.google.protobuf.Empty\"\x00\x125\n" +
+	"\aRefresh\x12\x14.auth.RefreshRequest\x1a\x12.auth.UserResponse\"\x00\x125\n" +
+	"\tGrantRole\x12\x11.auth.RoleRequest\x1a\x13.auth.RolesResponse\"\x00\x126\n" +
+	"\n" +
+	"RevokeRole\x12\x11.auth.RoleRequest\x1a\x13.auth.RolesResponse\"\x00B'Z%./internal/pkg/auth/delivery/grpc/genb\x06proto3"
 
 var (
 	file_proto_auth_proto_rawDescOnce sync.Once
@@ -709,7 +947,7 @@ func file_proto_auth_proto_rawDescGZIP() []byte {
 	return file_proto_auth_proto_rawDescData
 }
 
-var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_auth_proto_goTypes = []any{
 	(*CheckRequest)(nil),         // 0: auth.CheckRequest
 	(*AddressRequest)(nil),       // 1: auth.AddressRequest
@@ -721,7 +959,12 @@ var file_proto_auth_proto_goTypes = []any{
 	(*Address)(nil),              // 7: auth.Address
 	(*UserResponse)(nil),         // 8: auth.UserResponse
 	(*AddressListResponse)(nil),  // 9: auth.AddressListResponse
-	(*emptypb.Empty)(nil),        // 10: google.protobuf.Empty
+	(*SessionRequest)(nil),       // 10: auth.SessionRequest
+	(*RefreshRequest)(nil),       // 11: auth.RefreshRequest
+	(*LogOutAllRequest)(nil),     // 12: auth.LogOutAllRequest
+	(*RoleRequest)(nil),          // 13: auth.RoleRequest
+	(*RolesResponse)(nil),        // 14: auth.RolesResponse
+	(*emptypb.Empty)(nil),        // 15: google.protobuf.Empty
 }
 var file_proto_auth_proto_depIdxs = []int32{
 	7,  // 0: auth.AddressListResponse.Addresses:type_name -> auth.Address
@@ -733,16 +976,28 @@ var file_proto_auth_proto_depIdxs = []int32{
 	1,  // 6: auth.AuthService.GetUserAddresses:input_type -> auth.AddressRequest
 	6,  // 7: auth.AuthService.DeleteAddress:input_type -> auth.DeleteAddressRequest
 	7,  // 8: auth.AuthService.AddAddress:input_type -> auth.Address
-	8,  // 9: auth.AuthService.SignIn:output_type -> auth.UserResponse
-	8,  // 10: auth.AuthService.SignUp:output_type -> auth.UserResponse
-	8,  // 11: auth.AuthService.Check:output_type -> auth.UserResponse
-	8,  // 12: auth.AuthService.UpdateUser:output_type -> auth.UserResponse
-	8,  // 13: auth.AuthService.UpdateUserPic:output_type -> auth.UserResponse
-	9,  // 14: auth.AuthService.GetUserAddresses:output_type -> auth.AddressListResponse
-	10, // 15: auth.AuthService.DeleteAddress:output_type -> google.protobuf.Empty
-	10, // 16: auth.AuthService.AddAddress:output_type -> google.protobuf.Empty
-	9,  // [9:17] is the sub-list for method output_type
-	1,  // [1:9] is the sub-list for method input_type
+	10, // 9: auth.AuthService.CheckSession:input_type -> auth.SessionRequest
+	10, // 10: auth.AuthService.LogOut:input_type -> auth.SessionRequest
+	12, // 11: auth.AuthService.LogOutAll:input_type -> auth.LogOutAllRequest
+	11, // 12: auth.AuthService.Refresh:input_type -> auth.RefreshRequest
+	13, // 13: auth.AuthService.GrantRole:input_type -> auth.RoleRequest
+	13, // 14: auth.AuthService.RevokeRole:input_type -> auth.RoleRequest
+	8,  // 15: auth.AuthService.SignIn:output_type -> auth.UserResponse
+	8,  // 16: auth.AuthService.SignUp:output_type -> auth.UserResponse
+	8,  // 17: auth.AuthService.Check:output_type -> auth.UserResponse
+	8,  // 18: auth.AuthService.UpdateUser:output_type -> auth.UserResponse
+	8,  // 19: auth.AuthService.UpdateUserPic:output_type -> auth.UserResponse
+	9,  // 20: auth.AuthService.GetUserAddresses:output_type -> auth.AddressListResponse
+	15, // 21: auth.AuthService.DeleteAddress:output_type -> google.protobuf.Empty
+	15, // 22: auth.AuthService.AddAddress:output_type -> google.protobuf.Empty
+	15, // 23: auth.AuthService.CheckSession:output_type -> google.protobuf.Empty
+	15, // 24: auth.AuthService.LogOut:output_type -> google.protobuf.Empty
+	15, // 25: auth.AuthService.LogOutAll:output_type -> google.protobuf.Empty
+	8,  // 26: auth.AuthService.Refresh:output_type -> auth.UserResponse
+	14, // 27: auth.AuthService.GrantRole:output_type -> auth.RolesResponse
+	14, // 28: auth.AuthService.RevokeRole:output_type -> auth.RolesResponse
+	15, // [15:29] is the sub-list for method output_type
+	1,  // [1:15] is the sub-list for method input_type
 	1,  // [1:1] is the sub-list for extension type_name
 	1,  // [1:1] is the sub-list for extension extendee
 	0,  // [0:1] is the sub-list for field type_name
@@ -759,7 +1014,7 @@ func file_proto_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_auth_proto_rawDesc), len(file_proto_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -4,11 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"os"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
 	"github.com/satori/uuid"
 	"google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -16,13 +16,21 @@ import (
 )
 
 type AuthHandler struct {
-	uc     auth.AuthUsecase
-	secret string
+	uc auth.AuthUsecase
 	gen.AuthServiceServer
 }
 
 func CreateAuthHandler(uc auth.AuthUsecase) *AuthHandler {
-	return &AuthHandler{uc: uc, secret: os.Getenv("JWT_SECRET")}
+	return &AuthHandler{uc: uc}
+}
+
+// requireCaller возвращает пользователя, от имени которого пришёл вызов, из его access-токена.
+func requireCaller(ctx context.Context) (grpcauth.Caller, error) {
+	user, ok := grpcauth.CallerFromContext(ctx)
+	if !ok {
+		return grpcauth.Caller{}, status.Error(codes.Unauthenticated, "требуется авторизация")
+	}
+	return user, nil
 }
 
 func (h *AuthHandler) SignIn(ctx context.Context, in *gen.SignInRequest) (*gen.UserResponse, error) {
@@ -32,7 +40,7 @@ func (h *AuthHandler) SignIn(ctx context.Context, in *gen.SignInRequest) (*gen.U
 	}
 	req.Sanitize()
 
-	user, token, csrfToken, err := h.uc.SignIn(ctx, req)
+	user, token, csrfToken, refreshToken, err := h.uc.SignIn(ctx, req)
 
 	if err != nil {
 		switch err {
@@ -46,15 +54,17 @@ func (h *AuthHandler) SignIn(ctx context.Context, in *gen.SignInRequest) (*gen.U
 	}
 
 	return &gen.UserResponse{
-		Login:       user.Login,
-		PhoneNumber: user.PhoneNumber,
-		Id:          user.Id.String(),
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Description: user.Description,
-		UserPic:     user.UserPic,
-		Token:       token,
-		CsrfToken:   csrfToken,
+		Login:        user.Login,
+		PhoneNumber:  user.PhoneNumber,
+		Id:           user.Id.String(),
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Description:  user.Description,
+		UserPic:      user.UserPic,
+		Roles:        user.Roles,
+		Token:        token,
+		CsrfToken:    csrfToken,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
@@ -68,7 +78,7 @@ func (h *AuthHandler) SignUp(ctx context.Context, in *gen.SignUpRequest) (*gen.U
 	}
 	req.Sanitize()
 
-	user, token, csrfToken, err := h.uc.SignUp(ctx, req)
+	user, token, csrfToken, refreshToken, err := h.uc.SignUp(ctx, req)
 
 	if err != nil {
 		switch err {
@@ -81,21 +91,27 @@ func (h *AuthHandler) SignUp(ctx context.Context, in *gen.SignUpRequest) (*gen.U
 		}
 	}
 	return &gen.UserResponse{
-		Login:       user.Login,
-		PhoneNumber: user.PhoneNumber,
-		Id:          user.Id.String(),
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Description: user.Description,
-		UserPic:     user.UserPic,
-		Token:       token,
-		CsrfToken:   csrfToken,
+		Login:        user.Login,
+		PhoneNumber:  user.PhoneNumber,
+		Id:           user.Id.String(),
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Description:  user.Description,
+		UserPic:      user.UserPic,
+		Roles:        user.Roles,
+		Token:        token,
+		CsrfToken:    csrfToken,
+		RefreshToken: refreshToken,
 	}, nil
 
 }
 
 func (h *AuthHandler) Check(ctx context.Context, in *gen.CheckRequest) (*gen.UserResponse, error) {
-	user, err := h.uc.Check(ctx, in.Login)
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.uc.Check(ctx, caller.Login)
 
 	if err != nil {
 		if errors.Is(err, auth.ErrUserNotFound) {
@@ -111,10 +127,16 @@ func (h *AuthHandler) Check(ctx context.Context, in *gen.CheckRequest) (*gen.Use
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}, nil
 }
 
 func (h *AuthHandler) UpdateUser(ctx context.Context, in *gen.UpdateUserRequest) (*gen.UserResponse, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := models.UpdateUserReq{
 		Description: in.Description,
 		FirstName:   in.FirstName,
@@ -124,7 +146,7 @@ func (h *AuthHandler) UpdateUser(ctx context.Context, in *gen.UpdateUserRequest)
 	}
 	req.Sanitize()
 
-	user, err := h.uc.UpdateUser(ctx, in.Login, req)
+	user, err := h.uc.UpdateUser(ctx, caller.Login, req)
 	if err != nil {
 		switch err {
 		case auth.ErrInvalidPassword, auth.ErrInvalidName, auth.ErrInvalidPhone, auth.ErrSamePassword:
@@ -141,13 +163,19 @@ func (h *AuthHandler) UpdateUser(ctx context.Context, in *gen.UpdateUserRequest)
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}, nil
 
 }
 
 func (h *AuthHandler) UpdateUserPic(ctx context.Context, in *gen.UpdateUserPicRequest) (*gen.UserResponse, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	reader := bytes.NewReader(in.UserPic)
-	user, err := h.uc.UpdateUserPic(ctx, in.Login, reader, in.FileExtension)
+	user, err := h.uc.UpdateUserPic(ctx, caller.Login, reader, in.FileExtension)
 	if err != nil {
 		switch err {
 		case auth.ErrUserNotFound:
@@ -168,12 +196,18 @@ func (h *AuthHandler) UpdateUserPic(ctx context.Context, in *gen.UpdateUserPicRe
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}, nil
 
 }
 
 func (h *AuthHandler) GetUserAddresses(ctx context.Context, in *gen.AddressRequest) (*gen.AddressListResponse, error) {
-	addresses, err := h.uc.GetUserAddresses(ctx, in.Login)
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := h.uc.GetUserAddresses(ctx, caller.Login)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
@@ -209,18 +243,18 @@ func (h *AuthHandler) DeleteAddress(ctx context.Context, in *gen.DeleteAddressRe
 }
 
 func (h *AuthHandler) AddAddress(ctx context.Context, in *gen.Address) (*emptypb.Empty, error) {
-	parsedUUIDa, err := uuid.FromString(in.Id)
+	caller, err := requireCaller(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		return nil, err
 	}
-	parsedUUIDu, err := uuid.FromString(in.UserId)
+	parsedUUIDa, err := uuid.FromString(in.Id)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	address := models.Address{
 		Id:      parsedUUIDa,
 		Address: in.Address,
-		UserId:  parsedUUIDu,
+		UserId:  caller.ID,
 	}
 
 	err = h.uc.AddAddress(ctx, address)
@@ -229,3 +263,103 @@ func (h *AuthHandler) AddAddress(ctx context.Context, in *gen.Address) (*emptypb
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (h *AuthHandler) CheckSession(ctx context.Context, in *gen.SessionRequest) (*emptypb.Empty, error) {
+	sessionID, err := uuid.FromString(in.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", auth.ErrSessionRevoked)
+	}
+
+	err = h.uc.CheckSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, auth.ErrSessionRevoked) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// LogOut завершает сессию токена вызова. Без access-токена (он мог уже истечь) сессию
+// указывает только refresh-токен: id сессии из запроса не принимается.
+func (h *AuthHandler) LogOut(ctx context.Context, in *gen.SessionRequest) (*emptypb.Empty, error) {
+	sessionID := uuid.Nil
+	if caller, ok := grpcauth.CallerFromContext(ctx); ok {
+		sessionID = caller.SessionID
+	}
+
+	err := h.uc.LogOut(ctx, sessionID, in.RefreshToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *AuthHandler) LogOutAll(ctx context.Context, in *gen.LogOutAllRequest) (*emptypb.Empty, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.uc.LogOutAll(ctx, caller.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *AuthHandler) Refresh(ctx context.Context, in *gen.RefreshRequest) (*gen.UserResponse, error) {
+	user, token, csrfToken, refreshToken, err := h.uc.Refresh(ctx, in.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidRefreshToken), errors.Is(err, auth.ErrRefreshTokenReused),
+			errors.Is(err, auth.ErrUserNotFound):
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
+	return &gen.UserResponse{
+		Login:        user.Login,
+		PhoneNumber:  user.PhoneNumber,
+		Id:           user.Id.String(),
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Description:  user.Description,
+		UserPic:      user.UserPic,
+		Roles:        user.Roles,
+		Token:        token,
+		CsrfToken:    csrfToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (h *AuthHandler) GrantRole(ctx context.Context, in *gen.RoleRequest) (*gen.RolesResponse, error) {
+	return h.changeRole(ctx, in, h.uc.GrantRole)
+}
+
+func (h *AuthHandler) RevokeRole(ctx context.Context, in *gen.RoleRequest) (*gen.RolesResponse, error) {
+	return h.changeRole(ctx, in, h.uc.RevokeRole)
+}
+
+func (h *AuthHandler) changeRole(ctx context.Context, in *gen.RoleRequest,
+	change func(ctx context.Context, userID uuid.UUID, role string) ([]string, error)) (*gen.RolesResponse, error) {
+	userID, err := uuid.FromString(in.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	roles, err := change(ctx, userID, in.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUnknownRole):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, auth.ErrUserNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+	return &gen.RolesResponse{UserId: in.UserId, Roles: roles}, nil
+}
@@ -10,12 +10,22 @@ import (
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/mocks"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	"github.com/golang-jwt/jwt"
 	"github.com/satori/uuid"
 	"github.com/golang/mock/gomock"
 	"google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 )
 
+func userContext(id uuid.UUID, login string) context.Context {
+	return grpcauth.ContextWithClaims(context.Background(), jwt.MapClaims{
+		"id":    id.String(),
+		"login": login,
+		"jti":   uuid.NewV4().String(),
+	})
+}
+
 func TestAuthHandler_SignIn(t *testing.T) {
     ctrl := gomock.NewController(t)
     defer ctrl.Finish()
@@ -56,6 +66,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                     },
                     "access_token",
                     "csrf_token",
+                    "refresh_token",
                     nil,
                 )
             },
@@ -76,6 +87,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                 UserPic:     "avatar.jpg",
                 Token:       "access_token",
                 CsrfToken:   "csrf_token",
+                RefreshToken: "refresh_token",
             },
             wantErr: false,
         },
@@ -86,7 +98,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                 f.uc.EXPECT().SignIn(gomock.Any(), models.SignInReq{
                     Login:    "invalid",
                     Password: "password123",
-                }).Return(models.User{}, "", "", auth.ErrInvalidLogin) // Возвращаем пустую структуру вместо nil
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidLogin) // Возвращаем пустую структуру вместо nil
             },
             args: args{
                 ctx: context.Background(),
@@ -105,7 +117,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                 f.uc.EXPECT().SignIn(gomock.Any(), models.SignInReq{
                     Login:    "notfound@example.com",
                     Password: "password123",
-                }).Return(models.User{}, "", "", auth.ErrUserNotFound)
+                }).Return(models.User{}, "", "", "", auth.ErrUserNotFound)
             },
             args: args{
                 ctx: context.Background(),
@@ -124,7 +136,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                 f.uc.EXPECT().SignIn(gomock.Any(), models.SignInReq{
                     Login:    "test@example.com",
                     Password: "wrongpassword",
-                }).Return(models.User{}, "", "", auth.ErrInvalidCredentials)
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidCredentials)
             },
             args: args{
                 ctx: context.Background(),
@@ -143,7 +155,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
                 f.uc.EXPECT().SignIn(gomock.Any(), models.SignInReq{
                     Login:    "test@example.com",
                     Password: "password123",
-                }).Return(models.User{}, "", "", errors.New("database error"))
+                }).Return(models.User{}, "", "", "", errors.New("database error"))
             },
             args: args{
                 ctx: context.Background(),
@@ -238,6 +250,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     },
                     "access_token",
                     "csrf_token",
+                    "refresh_token",
                     nil,
                 )
             },
@@ -261,6 +274,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                 UserPic:     "avatar.jpg",
                 Token:       "access_token",
                 CsrfToken:   "csrf_token",
+                RefreshToken: "refresh_token",
             },
             wantErr: false,
         },
@@ -273,7 +287,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "+1234567890",
                     Password:    "password123",
-                }).Return(models.User{}, "", "", auth.ErrInvalidLogin)
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidLogin)
             },
             args: args{
                 ctx: context.Background(),
@@ -298,7 +312,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "+1234567890",
                     Password:    "short",
-                }).Return(models.User{}, "", "", auth.ErrInvalidPassword)
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidPassword)
             },
             args: args{
                 ctx: context.Background(),
@@ -323,7 +337,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "+1234567890",
                     Password:    "password123",
-                }).Return(models.User{}, "", "", auth.ErrInvalidName)
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidName)
             },
             args: args{
                 ctx: context.Background(),
@@ -348,7 +362,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "invalid",
                     Password:    "password123",
-                }).Return(models.User{}, "", "", auth.ErrInvalidPhone)
+                }).Return(models.User{}, "", "", "", auth.ErrInvalidPhone)
             },
             args: args{
                 ctx: context.Background(),
@@ -373,7 +387,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "+1234567890",
                     Password:    "password123",
-                }).Return(models.User{}, "", "", auth.ErrCreatingUser)
+                }).Return(models.User{}, "", "", "", auth.ErrCreatingUser)
             },
             args: args{
                 ctx: context.Background(),
@@ -398,7 +412,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
                     LastName:    "Doe",
                     PhoneNumber: "+1234567890",
                     Password:    "password123",
-                }).Return(models.User{}, "", "", errors.New("database error"))
+                }).Return(models.User{}, "", "", "", errors.New("database error"))
             },
             args: args{
                 ctx: context.Background(),
@@ -492,8 +506,8 @@ func TestAuthHandler_Check(t *testing.T) {
 					Return(user, nil)
 			},
 			args: args{
-				ctx: context.Background(),
-				in:  &gen.CheckRequest{Login: validLogin},
+				ctx: userContext(uuid.NewV4(), validLogin),
+				in:  &gen.CheckRequest{},
 			},
 			want: &gen.UserResponse{
 				Login:       user.Login,
@@ -506,6 +520,16 @@ func TestAuthHandler_Check(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "Anonymous call",
+			setup: func(f *fields) {},
+			args: args{
+				ctx: context.Background(),
+				in:  &gen.CheckRequest{},
+			},
+			wantErr:     true,
+			wantErrCode: codes.Unauthenticated,
+		},
 		{
 			name: "User not found",
 			setup: func(f *fields) {
@@ -514,8 +538,8 @@ func TestAuthHandler_Check(t *testing.T) {
 					Return(models.User{}, auth.ErrUserNotFound)
 			},
 			args: args{
-				ctx: context.Background(),
-				in:  &gen.CheckRequest{Login: validLogin},
+				ctx: userContext(uuid.NewV4(), validLogin),
+				in:  &gen.CheckRequest{},
 			},
 			wantErr:     true,
 			wantErrCode: codes.InvalidArgument,
@@ -594,9 +618,8 @@ func TestAuthHandler_UpdateUser(t *testing.T) {
 				}, nil)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "test@example.com"),
 				in: &gen.UpdateUserRequest{
-					Login:       "test@example.com",
 					Description: "Updated description",
 					FirstName:   "John",
 					LastName:    "Doe",
@@ -627,9 +650,8 @@ func TestAuthHandler_UpdateUser(t *testing.T) {
 				}).Return(models.User{}, auth.ErrInvalidPassword)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "test@example.com"),
 				in: &gen.UpdateUserRequest{
-					Login:       "test@example.com",
 					Description: "",
 					FirstName:   "John",
 					LastName:    "Doe",
@@ -653,9 +675,8 @@ func TestAuthHandler_UpdateUser(t *testing.T) {
 				}).Return(models.User{}, auth.ErrInvalidPhone)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "test@example.com"),
 				in: &gen.UpdateUserRequest{
-					Login:       "test@example.com",
 					Description: "",
 					FirstName:   "John",
 					LastName:    "Doe",
@@ -679,9 +700,8 @@ func TestAuthHandler_UpdateUser(t *testing.T) {
 				}).Return(models.User{}, auth.ErrSamePassword)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "test@example.com"),
 				in: &gen.UpdateUserRequest{
-					Login:       "test@example.com",
 					Description: "",
 					FirstName:   "John",
 					LastName:    "Doe",
@@ -705,9 +725,8 @@ func TestAuthHandler_UpdateUser(t *testing.T) {
 				}).Return(models.User{}, errors.New("db connection failed"))
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "test@example.com"),
 				in: &gen.UpdateUserRequest{
-					Login:       "test@example.com",
 					Description: "",
 					FirstName:   "John",
 					LastName:    "Doe",
@@ -796,9 +815,8 @@ func TestAuthHandler_UpdateUserPic(t *testing.T) {
 					}, nil)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "testuser"),
 				in: &gen.UpdateUserPicRequest{
-					Login:         "testuser",
 					UserPic:       picData,
 					FileExtension: ".jpg",
 				},
@@ -821,9 +839,8 @@ func TestAuthHandler_UpdateUserPic(t *testing.T) {
 					Return(models.User{}, auth.ErrUserNotFound)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "ghost"),
 				in: &gen.UpdateUserPicRequest{
-					Login:         "ghost",
 					UserPic:       picData,
 					FileExtension: ".jpg",
 				},
@@ -838,9 +855,8 @@ func TestAuthHandler_UpdateUserPic(t *testing.T) {
 					Return(models.User{}, auth.ErrBasePath)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "testuser"),
 				in: &gen.UpdateUserPicRequest{
-					Login:         "testuser",
 					UserPic:       picData,
 					FileExtension: ".jpg",
 				},
@@ -855,9 +871,8 @@ func TestAuthHandler_UpdateUserPic(t *testing.T) {
 					Return(models.User{}, auth.ErrFileSaving)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "testuser"),
 				in: &gen.UpdateUserPicRequest{
-					Login:         "testuser",
 					UserPic:       picData,
 					FileExtension: ".jpg",
 				},
@@ -872,9 +887,8 @@ func TestAuthHandler_UpdateUserPic(t *testing.T) {
 					Return(models.User{}, errors.New("unknown error"))
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(uuid.NewV4(), "testuser"),
 				in: &gen.UpdateUserPicRequest{
-					Login:         "testuser",
 					UserPic:       picData,
 					FileExtension: ".jpg",
 				},
@@ -959,8 +973,8 @@ func TestAuthHandler_GetUserAddresses(t *testing.T) {
 					Return([]models.Address{addr1, addr2}, nil)
 			},
 			args: args{
-				ctx: context.Background(),
-				in:  &gen.AddressRequest{Login: "testuser"},
+				ctx: userContext(uuid.NewV4(), "testuser"),
+				in:  &gen.AddressRequest{},
 			},
 			want: &gen.AddressListResponse{
 				Addresses: []*gen.Address{
@@ -985,8 +999,8 @@ func TestAuthHandler_GetUserAddresses(t *testing.T) {
 					Return(nil, errors.New("db error"))
 			},
 			args: args{
-				ctx: context.Background(),
-				in:  &gen.AddressRequest{Login: "broken"},
+				ctx: userContext(uuid.NewV4(), "broken"),
+				in:  &gen.AddressRequest{},
 			},
 			wantErr:     true,
 			wantErrCode: codes.Internal,
@@ -1155,11 +1169,10 @@ func TestAuthHandler_AddAddress(t *testing.T) {
 					Return(nil)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(validUserID, "testuser"),
 				in: &gen.Address{
 					Id:      validAddressID.String(),
 					Address: "123 Main St",
-					UserId:  validUserID.String(),
 				},
 			},
 			wantErr: false,
@@ -1168,29 +1181,48 @@ func TestAuthHandler_AddAddress(t *testing.T) {
 			name: "Invalid Address ID",
 			setup: func(f *fields) {},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(validUserID, "testuser"),
 				in: &gen.Address{
 					Id:      invalidUUID,
 					Address: "Somewhere",
-					UserId:  validUserID.String(),
 				},
 			},
 			wantErr:     true,
 			wantErrCode: codes.InvalidArgument,
 		},
 		{
-			name: "Invalid User ID",
-			setup: func(f *fields) {}, 
+			name: "User ID from request is ignored",
+			setup: func(f *fields) {
+				f.uc.EXPECT().
+					AddAddress(gomock.Any(), models.Address{
+						Id:      validAddressID,
+						Address: "Somewhere",
+						UserId:  validUserID,
+					}).
+					Return(nil)
+			},
+			args: args{
+				ctx: userContext(validUserID, "testuser"),
+				in: &gen.Address{
+					Id:      validAddressID.String(),
+					Address: "Somewhere",
+					UserId:  uuid.NewV4().String(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Anonymous call",
+			setup: func(f *fields) {},
 			args: args{
 				ctx: context.Background(),
 				in: &gen.Address{
 					Id:      validAddressID.String(),
 					Address: "Somewhere",
-					UserId:  invalidUUID,
 				},
 			},
 			wantErr:     true,
-			wantErrCode: codes.InvalidArgument,
+			wantErrCode: codes.Unauthenticated,
 		},
 		{
 			name: "Usecase error",
@@ -1204,11 +1236,10 @@ func TestAuthHandler_AddAddress(t *testing.T) {
 					Return(errors.New("internal error"))
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: userContext(validUserID, "testuser"),
 				in: &gen.Address{
 					Id:      validAddressID.String(),
 					Address: "Fail St",
-					UserId:  validUserID.String(),
 				},
 			},
 			wantErr:     true,
@@ -1244,3 +1275,121 @@ func TestAuthHandler_AddAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_CheckSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionID := uuid.NewV4()
+
+	tests := []struct {
+		name        string
+		in          *gen.SessionRequest
+		setup       func(uc *mocks.MockAuthUsecase)
+		wantErrCode codes.Code
+	}{
+		{
+			name: "Active",
+			in:   &gen.SessionRequest{SessionId: sessionID.String()},
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().CheckSession(gomock.Any(), sessionID).Return(nil)
+			},
+			wantErrCode: codes.OK,
+		},
+		{
+			name:        "Token without session",
+			in:          &gen.SessionRequest{SessionId: "not-a-uuid"},
+			setup:       func(uc *mocks.MockAuthUsecase) {},
+			wantErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Revoked",
+			in:   &gen.SessionRequest{SessionId: sessionID.String()},
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().CheckSession(gomock.Any(), sessionID).Return(auth.ErrSessionRevoked)
+			},
+			wantErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Usecase error",
+			in:   &gen.SessionRequest{SessionId: sessionID.String()},
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().CheckSession(gomock.Any(), sessionID).Return(auth.ErrDBError)
+			},
+			wantErrCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewMockAuthUsecase(ctrl)
+			tt.setup(uc)
+
+			h := &AuthHandler{uc: uc}
+			_, err := h.CheckSession(context.Background(), tt.in)
+
+			if status.Code(err) != tt.wantErrCode {
+				t.Errorf("expected gRPC error code %v, got %v", tt.wantErrCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{Id: uuid.NewV4(), Login: "testuser"}
+
+	tests := []struct {
+		name        string
+		setup       func(uc *mocks.MockAuthUsecase)
+		wantErrCode codes.Code
+	}{
+		{
+			name: "Success",
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().Refresh(gomock.Any(), "refresh").Return(user, "access", "csrf", "next", nil)
+			},
+			wantErrCode: codes.OK,
+		},
+		{
+			name: "Invalid token",
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().Refresh(gomock.Any(), "refresh").Return(models.User{}, "", "", "", auth.ErrInvalidRefreshToken)
+			},
+			wantErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Reused token",
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().Refresh(gomock.Any(), "refresh").Return(models.User{}, "", "", "", auth.ErrRefreshTokenReused)
+			},
+			wantErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Usecase error",
+			setup: func(uc *mocks.MockAuthUsecase) {
+				uc.EXPECT().Refresh(gomock.Any(), "refresh").Return(models.User{}, "", "", "", auth.ErrDBError)
+			},
+			wantErrCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewMockAuthUsecase(ctrl)
+			tt.setup(uc)
+
+			h := &AuthHandler{uc: uc}
+			got, err := h.Refresh(context.Background(), &gen.RefreshRequest{RefreshToken: "refresh"})
+
+			if status.Code(err) != tt.wantErrCode {
+				t.Fatalf("expected gRPC error code %v, got %v", tt.wantErrCode, status.Code(err))
+			}
+			if err == nil && (got.Token != "access" || got.RefreshToken != "next" || got.CsrfToken != "csrf") {
+				t.Errorf("unexpected tokens in response: %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+)
+
+// RolePolicy — методы AuthService, доступные только пользователям с определёнными ролями.
+var RolePolicy = grpcauth.Policy{
+	gen.AuthService_GrantRole_FullMethodName:  {models.RoleAdmin},
+	gen.AuthService_RevokeRole_FullMethodName: {models.RoleAdmin},
+}
+
+// AnonymousMethods — методы AuthService, которые можно вызывать без токена: вход, регистрация,
+// обмен refresh-токена, выход по refresh-токену и проверка сессии из middleware main.
+var AnonymousMethods = grpcauth.Anonymous{
+	gen.AuthService_SignIn_FullMethodName:       true,
+	gen.AuthService_SignUp_FullMethodName:       true,
+	gen.AuthService_Refresh_FullMethodName:      true,
+	gen.AuthService_LogOut_FullMethodName:       true,
+	gen.AuthService_CheckSession_FullMethodName: true,
+}
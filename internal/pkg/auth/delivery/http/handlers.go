@@ -7,16 +7,15 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
-	"os"
 	"strings"
-	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
+	authmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/auth"
 	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/send_error"
-	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/mux"
 	"github.com/mailru/easyjson"
 	"github.com/satori/uuid"
 	"google.golang.org/grpc/codes"
@@ -31,13 +30,19 @@ var allowedMimeTypes = map[string]string{
 	"image/webp": ".webp",
 }
 
+// GuestCartMerger переносит корзину, собранную до входа, в корзину пользователя. Пользователя
+// сервис корзин берёт из только что выданного access-токена.
+type GuestCartMerger interface {
+	MergeGuestCartOnLogin(w http.ResponseWriter, r *http.Request, token string)
+}
+
 type AuthHandler struct {
 	client gen.AuthServiceClient
-	secret string
+	carts  GuestCartMerger
 }
 
-func CreateAuthHandler(client gen.AuthServiceClient) *AuthHandler {
-	return &AuthHandler{client: client, secret: os.Getenv("JWT_SECRET")}
+func CreateAuthHandler(client gen.AuthServiceClient, carts GuestCartMerger) *AuthHandler {
+	return &AuthHandler{client: client, carts: carts}
 }
 
 func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
@@ -77,27 +82,14 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "AdminJWT",
-		Value:    user.Token,
-		HttpOnly: true,
-		Secure:   false,
-		Expires:  time.Now().Add(24 * time.Hour),
-		Path:     "/",
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "CSRF-Token",
-		Value:    user.CsrfToken,
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: false,
-		Secure:   false,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
-	})
+	jwtUtils.SetAuthCookies(w, user.Token, user.RefreshToken, user.CsrfToken)
 
 	w.Header().Set("X-CSRF-Token", user.CsrfToken)
 
+	if h.carts != nil {
+		h.carts.MergeGuestCartOnLogin(w, r, user.Token)
+	}
+
 	parsedUUID, err := uuid.FromString(user.Id)
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("некорректный id: %w", err), http.StatusUnauthorized)
@@ -112,6 +104,7 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}
 
 	data, err := json.Marshal(newModel)
@@ -173,27 +166,14 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "AdminJWT",
-		Value:    user.Token,
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: true,
-		Secure:   true,
-		Path:     "/",
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "CSRF-Token",
-		Value:    user.CsrfToken,
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: false,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
-	})
+	jwtUtils.SetAuthCookies(w, user.Token, user.RefreshToken, user.CsrfToken)
 
 	w.Header().Set("X-CSRF-Token", user.CsrfToken)
 
+	if h.carts != nil {
+		h.carts.MergeGuestCartOnLogin(w, r, user.Token)
+	}
+
 	parsedUUID, err := uuid.FromString(user.Id)
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("некорректный id: %w", err), http.StatusUnauthorized)
@@ -208,6 +188,7 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}
 
 	data, err := json.Marshal(newModel)
@@ -225,44 +206,13 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Check(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookieCSRF, err := r.Cookie("CSRF-Token")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	headerCSRF := r.Header.Get("X-CSRF-Token")
-	if cookieCSRF.Value == "" || headerCSRF == "" || cookieCSRF.Value != headerCSRF {
-		w.WriteHeader(http.StatusForbidden)
-		return
-	}
-
-	cookieJWT, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-	JWTStr := cookieJWT.Value
-
-	claims := jwt.MapClaims{}
-
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
-	if !ok || login == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
-	user, err := h.client.Check(r.Context(), &gen.CheckRequest{
-		Login: login,
-	})
+	user, err := h.client.Check(r.Context(), &gen.CheckRequest{})
 
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -296,6 +246,7 @@ func (h *AuthHandler) Check(w http.ResponseWriter, r *http.Request) {
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}
 
 	data, err := json.Marshal(newModel)
@@ -313,60 +264,125 @@ func (h *AuthHandler) Check(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) LogOut(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil || cookie.Value == "" {
-		log.LogHandlerError(logger, fmt.Errorf("пользователь уже разлогинен: %w", err), http.StatusBadRequest)
+	// Сессию access-токена сервис auth берёт из пересланного токена, а без него — по refresh-токену.
+	req := &gen.SessionRequest{}
+	_, loggedIn := authmw.PrincipalFromContext(r.Context())
+	if cookie, err := r.Cookie(jwtUtils.RefreshCookieName); err == nil {
+		req.RefreshToken = cookie.Value
+	}
+	if !loggedIn && req.RefreshToken == "" {
+		log.LogHandlerError(logger, errors.New("пользователь уже разлогинен"), http.StatusBadRequest)
 		utils.SendError(w, "пользователь уже разлогинен", http.StatusBadRequest)
 		return
 	}
-	http.SetCookie(w, &http.Cookie{
-		Name:     "AdminJWT",
-		Value:    "",
-		Expires:  time.Unix(0, 0),
-		HttpOnly: true,
-		Path:     "/",
-	})
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "CSRF-Token",
-		Value:    "",
-		Expires:  time.Unix(0, 0),
-		HttpOnly: false,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
-	})
+	if _, err := h.client.LogOut(r.Context(), req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка завершения сессии: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "ошибка завершения сессии", http.StatusInternalServerError)
+		return
+	}
 
+	jwtUtils.ClearAuthCookies(w)
 	log.LogHandlerInfo(logger, "Successful", http.StatusOK)
 }
 
-func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+// Refresh меняет refresh-токен из куки на новую пару токенов. Куку с refresh-токеном браузер
+// отправляет только в /api/auth и только с нашего сайта (SameSite=Strict).
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
+	cookie, err := r.Cookie(jwtUtils.RefreshCookieName)
+	if err != nil || cookie.Value == "" {
+		log.LogHandlerError(logger, errors.New("refresh-токен отсутствует"), http.StatusUnauthorized)
+		utils.SendError(w, "refresh-токен отсутствует", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.client.Refresh(r.Context(), &gen.RefreshRequest{RefreshToken: cookie.Value})
 	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "токен отсутствует", http.StatusUnauthorized)
+		st, ok := status.FromError(err)
+		if !ok {
+			log.LogHandlerError(logger, fmt.Errorf("не gRPC ошибка: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "внутренняя ошибка", http.StatusInternalServerError)
 			return
 		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "ошибка при чтении куки", http.StatusBadRequest)
+
+		switch st.Code() {
+		case codes.Unauthenticated:
+			jwtUtils.ClearAuthCookies(w)
+			log.LogHandlerError(logger, err, http.StatusUnauthorized)
+			utils.SendError(w, st.Message(), http.StatusUnauthorized)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("неизвестная ошибка: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "неизвестная ошибка", http.StatusInternalServerError)
+		}
 		return
 	}
-	JWTStr := cookie.Value
 
-	claims := jwt.MapClaims{}
+	jwtUtils.SetAuthCookies(w, user.Token, user.RefreshToken, user.CsrfToken)
+	w.Header().Set("X-CSRF-Token", user.CsrfToken)
+
+	// Пока токен был просрочен, пользователь мог собрать гостевую корзину
+	if h.carts != nil {
+		h.carts.MergeGuestCartOnLogin(w, r, user.Token)
+	}
 
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
-	if !ok || login == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: login отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "недействительный токен: login отсутствует", http.StatusUnauthorized)
+	parsedUUID, err := uuid.FromString(user.Id)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("некорректный id: %w", err), http.StatusUnauthorized)
+		utils.SendError(w, "некорректный id", http.StatusUnauthorized)
 		return
 	}
 
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
+	newModel := models.User{
+		Login:       user.Login,
+		PhoneNumber: user.PhoneNumber,
+		Id:          parsedUUID,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Description: user.Description,
+		UserPic:     user.UserPic,
+		Roles:       user.Roles,
+	}
+
+	data, err := json.Marshal(newModel)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// LogOutAll завершает все сессии пользователя, включая текущую.
+func (h *AuthHandler) LogOutAll(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	if _, err := h.client.LogOutAll(r.Context(), &gen.LogOutAllRequest{}); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка завершения сессий: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "ошибка завершения сессий", http.StatusInternalServerError)
+		return
+	}
+
+	jwtUtils.ClearAuthCookies(w)
+	log.LogHandlerInfo(logger, "Successful", http.StatusOK)
+}
+
+func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
@@ -379,7 +395,6 @@ func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	updateData.Sanitize()
 
 	user, err := h.client.UpdateUser(r.Context(), &gen.UpdateUserRequest{
-		Login:       login,
 		Description: updateData.Description,
 		FirstName:   updateData.FirstName,
 		LastName:    updateData.LastName,
@@ -405,6 +420,11 @@ func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if updateData.Password != "" {
+		// После смены пароля все сессии отозваны, текущая — тоже.
+		jwtUtils.ClearAuthCookies(w)
+	}
+
 	parsedUUID, err := uuid.FromString(user.Id)
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("некорректный id: %w", err), http.StatusUnauthorized)
@@ -419,6 +439,7 @@ func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}
 
 	data, err := json.Marshal(newModel)
@@ -436,31 +457,9 @@ func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) UpdateUserPic(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "токен отсутствует", http.StatusUnauthorized)
-			return
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "ошибка при чтении куки", http.StatusBadRequest)
-		return
-	}
-	JWTStr := cookie.Value
-
-	claims := jwt.MapClaims{}
-
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
-	if !ok || login == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: login отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "недействительный токен: login отсутствует", http.StatusUnauthorized)
-		return
-	}
-
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
@@ -510,7 +509,6 @@ func (h *AuthHandler) UpdateUserPic(w http.ResponseWriter, r *http.Request) {
 	picBytes, _ := io.ReadAll(file)
 
 	user, err := h.client.UpdateUserPic(r.Context(), &gen.UpdateUserPicRequest{
-		Login:         login,
 		UserPic:       picBytes,
 		FileExtension: ext,
 	})
@@ -555,6 +553,7 @@ func (h *AuthHandler) UpdateUserPic(w http.ResponseWriter, r *http.Request) {
 		LastName:    user.LastName,
 		Description: user.Description,
 		UserPic:     user.UserPic,
+		Roles:       user.Roles,
 	}
 
 	data, err := json.Marshal(newModel)
@@ -572,37 +571,13 @@ func (h *AuthHandler) UpdateUserPic(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) GetUserAddresses(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "токен отсутствует", http.StatusUnauthorized)
-			return
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "ошибка при чтении куки", http.StatusBadRequest)
-		return
-	}
-	JWTStr := cookie.Value
-
-	claims := jwt.MapClaims{}
-
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
-	if !ok || login == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: login отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "недействительный токен: login отсутствует", http.StatusUnauthorized)
-		return
-	}
-
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
-	addresses, err := h.client.GetUserAddresses(r.Context(), &gen.AddressRequest{
-		Login: login,
-	})
+	addresses, err := h.client.GetUserAddresses(r.Context(), &gen.AddressRequest{})
 	if err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
@@ -656,11 +631,6 @@ func (h *AuthHandler) GetUserAddresses(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		return
-	}
-
 	var address models.Address
 	err := easyjson.UnmarshalFromReader(r.Body, &address)
 	if err != nil {
@@ -702,54 +672,24 @@ func (h *AuthHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) AddAddress(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "токен отсутствует", http.StatusUnauthorized)
-			return
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "ошибка при чтении куки", http.StatusBadRequest)
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
-	JWTStr := cookie.Value
-
-	claims := jwt.MapClaims{}
 
-	idStr, ok := jwtUtils.GetIdFromJWT(JWTStr, claims, h.secret)
-	if !ok || idStr == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: id отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "недействительный токен: id отсутствует", http.StatusUnauthorized)
-		return
-	}
-
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		return
-	}
-
-	id, err := uuid.FromString(idStr)
-	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusInternalServerError)
-		utils.SendError(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
 	var address models.Address
-	err = easyjson.UnmarshalFromReader(r.Body, &address)
+	err := easyjson.UnmarshalFromReader(r.Body, &address)
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("ошибка парсинга JSON: %w", err), http.StatusBadRequest)
 		utils.SendError(w, "ошибка парсинга JSON", http.StatusBadRequest)
 		return
 	}
-	address.UserId = id
 	address.Sanitize()
 
 	_, err = h.client.AddAddress(r.Context(), &gen.Address{
 		Id:      address.Id.String(),
 		Address: address.Address,
-		UserId:  address.UserId.String(),
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -775,4 +715,77 @@ func (h *AuthHandler) AddAddress(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	log.LogHandlerInfo(logger, "Successful", http.StatusOK)
-}
\ No newline at end of file
+}
+
+// GrantRole выдаёт пользователю роль. Доступна только администраторам.
+func (h *AuthHandler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	var req models.RoleReq
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка парсинга JSON: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "ошибка парсинга JSON", http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	resp, err := h.client.GrantRole(r.Context(), &gen.RoleRequest{UserId: mux.Vars(r)["userID"], Role: req.Role})
+	sendRoles(w, r, resp, err)
+}
+
+// RevokeRole снимает с пользователя роль и завершает все его сессии. Доступна только
+// администраторам; снять роль администратора с самого себя нельзя.
+func (h *AuthHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	principal, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if vars["role"] == models.RoleAdmin && vars["userID"] == principal.ID.String() {
+		log.LogHandlerError(logger, errors.New("администратор снимает роль с себя"), http.StatusConflict)
+		utils.SendError(w, "нельзя снять роль администратора с себя", http.StatusConflict)
+		return
+	}
+
+	resp, err := h.client.RevokeRole(r.Context(), &gen.RoleRequest{UserId: vars["userID"], Role: vars["role"]})
+	sendRoles(w, r, resp, err)
+}
+
+func sendRoles(w http.ResponseWriter, r *http.Request, resp *gen.RolesResponse, err error) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			log.LogHandlerError(logger, err, http.StatusBadRequest)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.NotFound:
+			log.LogHandlerError(logger, err, http.StatusNotFound)
+			utils.SendError(w, "пользователь не найден", http.StatusNotFound)
+		case codes.Unauthenticated:
+			authmw.SendUnauthorized(w, r)
+		case codes.PermissionDenied:
+			log.LogHandlerError(logger, err, http.StatusForbidden)
+			utils.SendError(w, authmw.ErrForbidden.Error(), http.StatusForbidden)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("ошибка изменения ролей: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "ошибка изменения ролей", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	data, err := easyjson.Marshal(models.UserRoles{UserID: uuid.FromStringOrNil(resp.UserId), Roles: resp.Roles})
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Successful", http.StatusOK)
+}
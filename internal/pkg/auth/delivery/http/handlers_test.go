@@ -104,7 +104,7 @@ func TestSignIn(t *testing.T) {
 			r := httptest.NewRequest("POST", "/api/auth/signin", bytes.NewBufferString(tt.requestBody))
 			w := httptest.NewRecorder()
 
-			handler := CreateAuthHandler(mockUsecase)
+			handler := CreateAuthHandler(mockUsecase, nil)
 			handler.SignIn(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -228,7 +228,7 @@ func TestSignUp(t *testing.T) {
 			r := httptest.NewRequest("POST", "/api/auth/signup", bytes.NewBufferString(tt.requestBody))
 			w := httptest.NewRecorder()
 
-			handler := CreateAuthHandler(mockUsecase)
+			handler := CreateAuthHandler(mockUsecase, nil)
 			handler.SignUp(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -251,7 +251,7 @@ func TestCheck(t *testing.T) {
 		{
 			name: "Missing CSRF Cookie",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.Header.Set("X-CSRF-Token", csrf_token)
 			},
@@ -260,7 +260,7 @@ func TestCheck(t *testing.T) {
 		{
 			name: "CSRF Mismatch",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", "blablabla")
@@ -287,7 +287,7 @@ func TestCheck(t *testing.T) {
 		{
 			name: "User Not Found",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, "unknown-user", secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, "unknown-user", userId)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
@@ -302,7 +302,7 @@ func TestCheck(t *testing.T) {
 		{
 			name: "Successful",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -329,7 +329,7 @@ func TestCheck(t *testing.T) {
 				tt.mockerUsecase(mockUsecase)
 			}
 
-			handler := AuthHandler{uc: mockUsecase, secret: secret}
+			handler := AuthHandler{uc: mockUsecase}
 			handler.Check(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -377,7 +377,7 @@ func TestLogOut(t *testing.T) {
 			w := httptest.NewRecorder()
 			tt.cookieSetup(r)
 
-			handler := CreateAuthHandler(mockUsecase)
+			handler := CreateAuthHandler(mockUsecase, nil)
 			handler.LogOut(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -422,7 +422,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			name: "CSRF Token Mismatch",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", "blablabla")
@@ -432,7 +432,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			name: "Error Parsing JSON Body",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -444,7 +444,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			name: "Invalid Update User Data (Password)",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -460,7 +460,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			name: "Successful Update User",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -534,7 +534,7 @@ func TestGetUserAddresses(t *testing.T) {
 		{
 			name: "CSRF Token Mismatch",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", "blablabla")
@@ -544,7 +544,7 @@ func TestGetUserAddresses(t *testing.T) {
 		{
 			name: "Usecase Error",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -559,7 +559,7 @@ func TestGetUserAddresses(t *testing.T) {
 		{
 			name: "Successful Response",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -588,7 +588,7 @@ func TestGetUserAddresses(t *testing.T) {
 			w := httptest.NewRecorder()
 			tt.cookieSetup(r)
 
-			handler := AuthHandler{uc: mockUsecase, secret: secret}
+			handler := AuthHandler{uc: mockUsecase}
 			handler.GetUserAddresses(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -616,7 +616,7 @@ func TestDeleteAddress(t *testing.T) {
 		{
 			name: "CSRF Token Mismatch",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", "blablabla")
@@ -626,7 +626,7 @@ func TestDeleteAddress(t *testing.T) {
 		{
 			name: "Error Parsing JSON Body",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -638,7 +638,7 @@ func TestDeleteAddress(t *testing.T) {
 		{
 			name: "Usecase Error",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -654,7 +654,7 @@ func TestDeleteAddress(t *testing.T) {
 		{
 			name: "Successful Delete",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -681,7 +681,7 @@ func TestDeleteAddress(t *testing.T) {
 			w := httptest.NewRecorder()
 			tt.cookieSetup(r)
 
-			handler := AuthHandler{uc: mockUsecase, secret: secret}
+			handler := AuthHandler{uc: mockUsecase}
 			handler.DeleteAddress(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -731,7 +731,7 @@ func TestAddAddress(t *testing.T) {
 		{
 			name: "CSRF Token Mismatch",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", "blablabla")
@@ -741,7 +741,7 @@ func TestAddAddress(t *testing.T) {
 		{
 			name: "Error Parsing JSON Body",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -753,7 +753,7 @@ func TestAddAddress(t *testing.T) {
 		{
 			name: "Usecase Error",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -769,7 +769,7 @@ func TestAddAddress(t *testing.T) {
 		{
 			name: "Successful Add",
 			cookieSetup: func(r *http.Request) {
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
+				tokenStr := utils.GenerateJWTForTest(t, login, userId)
 				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
@@ -796,7 +796,7 @@ func TestAddAddress(t *testing.T) {
 			w := httptest.NewRecorder()
 			tt.cookieSetup(r)
 
-			handler := AuthHandler{uc: mockUsecase, secret: secret}
+			handler := AuthHandler{uc: mockUsecase}
 			handler.AddAddress(w, r)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
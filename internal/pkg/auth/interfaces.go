@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/golang-jwt/jwt"
 	"github.com/satori/uuid"
 )
 
@@ -27,8 +29,20 @@ var (
 	ErrFileDeletion       = errors.New("Ошибка при удалении файла")
 	ErrDBError            = errors.New("Ошибка БД")
 	ErrAddressNotFound    = errors.New("Ошибка поиска адреса")
+	ErrSessionRevoked     = errors.New("Сессия завершена, войдите заново")
+
+	ErrInvalidRefreshToken = errors.New("Недействительный refresh-токен")
+	ErrRefreshTokenReused  = errors.New("Refresh-токен использован повторно, сессия завершена")
+
+	ErrUnknownRole = errors.New("Неизвестная роль")
 )
 
+// TokenSigner подписывает access-токены. Закрытые ключи есть только у сервиса auth,
+// остальные сервисы проверяют токены по открытым ключам из JWKS.
+type TokenSigner interface {
+	Sign(claims jwt.MapClaims) (string, error)
+}
+
 type AuthRepo interface {
 	InsertUser(ctx context.Context, user models.User) error
 	SelectUserByLogin(ctx context.Context, login string) (models.User, error)
@@ -38,15 +52,36 @@ type AuthRepo interface {
 	DeleteAddress(ctx context.Context, addressId uuid.UUID) error
 	SelectUserAddresses(ctx context.Context, login string) ([]models.Address, error)
 	AddressExists(ctx context.Context, address string, userID uuid.UUID) (bool, error)
+
+	InsertSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error
+	SessionActive(ctx context.Context, sessionID uuid.UUID) (bool, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	InsertRefreshToken(ctx context.Context, sessionID uuid.UUID, tokenHash []byte) error
+	ConsumeRefreshToken(ctx context.Context, tokenHash, successorSealed []byte) (uuid.UUID, string, error)
+	ReplayRefreshToken(ctx context.Context, tokenHash []byte, grace time.Duration) (uuid.UUID, string, []byte, error)
+	RevokeRefreshFamily(ctx context.Context, tokenHash []byte) (bool, error)
+
+	GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error)
+	RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error)
 }
 
 type AuthUsecase interface {
-	SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, error)
-	SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, error)
+	SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, string, error)
+	SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, string, error)
+	Refresh(ctx context.Context, refreshToken string) (models.User, string, string, string, error)
 	Check(ctx context.Context, login string) (models.User, error)
 	UpdateUser(ctx context.Context, login string, updateData models.UpdateUserReq) (models.User, error)
 	UpdateUserPic(ctx context.Context, login string, picture io.ReadSeeker, extension string) (models.User, error)
 	GetUserAddresses(ctx context.Context, login string) ([]models.Address, error)
 	DeleteAddress(ctx context.Context, addressId uuid.UUID) error
 	AddAddress(ctx context.Context, address models.Address) error
+
+	CheckSession(ctx context.Context, sessionID uuid.UUID) error
+	LogOut(ctx context.Context, sessionID uuid.UUID, refreshToken string) error
+	LogOutAll(ctx context.Context, userID uuid.UUID) error
+
+	GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error)
+	RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error)
 }
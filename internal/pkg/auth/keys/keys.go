@@ -0,0 +1,114 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang-jwt/jwt"
+)
+
+var ErrNoSigningKeys = errors.New("не найдено ни одного ключа подписи")
+
+// KeyRing — ключи Ed25519, которыми сервис auth подписывает access-токены. Подписывает
+// только активный ключ, а в JWKS публикуются все: так main и cart продолжают принимать
+// токены, выданные до смены активного ключа.
+//
+// Ротация: положить новый ключ и перезапустить auth (ключ появится в JWKS), затем сделать
+// его активным, а старый удалить не раньше чем через jwtUtils.AccessTokenTTL.
+type KeyRing struct {
+	activeKID string
+	keys      map[string]ed25519.PrivateKey
+}
+
+// LoadKeyRing читает из dir закрытые ключи в PEM (PKCS#8), kid — имя файла без .pem.
+// Ключ создаётся командой `openssl genpkey -algorithm ed25519 -out <kid>.pem`.
+// Если activeKID пуст, активным становится последний kid по алфавиту.
+func LoadKeyRing(dir, activeKID string) (*KeyRing, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]ed25519.PrivateKey, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwt.ParseEdPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("ключ %s: %w", path, err)
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("ключ %s: не Ed25519", path)
+		}
+		keys[strings.TrimSuffix(filepath.Base(path), ".pem")] = privateKey
+	}
+
+	return NewKeyRing(keys, activeKID)
+}
+
+func NewKeyRing(keys map[string]ed25519.PrivateKey, activeKID string) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoSigningKeys
+	}
+	if activeKID == "" {
+		for kid := range keys {
+			if kid > activeKID {
+				activeKID = kid
+			}
+		}
+	}
+	if _, ok := keys[activeKID]; !ok {
+		return nil, fmt.Errorf("активный ключ %q не найден", activeKID)
+	}
+	return &KeyRing{activeKID: activeKID, keys: keys}, nil
+}
+
+// Sign подписывает claims активным ключом и указывает его в заголовке kid.
+func (k *KeyRing) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = k.activeKID
+	return token.SignedString(k.keys[k.activeKID])
+}
+
+// PublicKeys возвращает открытые части всех ключей для проверки токенов.
+func (k *KeyRing) PublicKeys() jwtUtils.StaticKeySet {
+	set := make(jwtUtils.StaticKeySet, len(k.keys))
+	for kid, key := range k.keys {
+		set[kid] = key.Public().(ed25519.PublicKey)
+	}
+	return set
+}
+
+func (k *KeyRing) JWKS() jwtUtils.JWKS {
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := jwtUtils.JWKS{Keys: make([]jwtUtils.JWK, 0, len(kids))}
+	for _, kid := range kids {
+		set.Keys = append(set.Keys, jwtUtils.NewJWK(kid, k.keys[kid].Public().(ed25519.PublicKey)))
+	}
+	return set
+}
+
+// ServeHTTP отдаёт JWKS для сервисов, которые проверяют токены.
+func (k *KeyRing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err := json.NewEncoder(w).Encode(k.JWKS()); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,93 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, kid string) {
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0600))
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01")
+	writeKey(t, dir, "2026-02")
+
+	tests := []struct {
+		name      string
+		activeKID string
+		wantKID   string
+		wantErr   bool
+	}{
+		{name: "Newest key by default", wantKID: "2026-02"},
+		{name: "Explicit active key", activeKID: "2026-01", wantKID: "2026-01"},
+		{name: "Unknown active key", activeKID: "2025-12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring, err := LoadKeyRing(dir, tt.activeKID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			token, err := ring.Sign(jwt.MapClaims{"login": "testuser", "exp": time.Now().Add(time.Minute).Unix()})
+			require.NoError(t, err)
+
+			parsed, err := jwt.Parse(token, ring.PublicKeys().Keyfunc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKID, parsed.Header["kid"])
+			assert.Equal(t, jwt.SigningMethodEdDSA.Alg(), parsed.Header["alg"])
+		})
+	}
+
+	_, err := LoadKeyRing(t.TempDir(), "")
+	assert.ErrorIs(t, err, ErrNoSigningKeys)
+}
+
+func TestServeJWKS(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "old")
+	writeKey(t, dir, "new")
+	ring, err := LoadKeyRing(dir, "new")
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	ring.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/.well-known/jwks.json", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	assert.NotContains(t, rr.Body.String(), `"d"`, "закрытая часть ключа не публикуется")
+
+	var set jwtUtils.JWKS
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&set))
+	require.Len(t, set.Keys, 2, "публикуются и активный, и прежний ключ")
+	assert.Equal(t, "new", set.Keys[0].Kid)
+	assert.Equal(t, "old", set.Keys[1].Kid)
+
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, ring.PublicKeys()[jwk.Kid], key)
+	}
+}
@@ -77,6 +77,26 @@ func (mr *MockAuthServiceClientMockRecorder) Check(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockAuthServiceClient)(nil).Check), varargs...)
 }
 
+// CheckSession mocks base method.
+func (m *MockAuthServiceClient) CheckSession(arg0 context.Context, arg1 *gen.SessionRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckSession", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MockAuthServiceClientMockRecorder) CheckSession(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthServiceClient)(nil).CheckSession), varargs...)
+}
+
 // DeleteAddress mocks base method.
 func (m *MockAuthServiceClient) DeleteAddress(arg0 context.Context, arg1 *gen.DeleteAddressRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +137,106 @@ func (mr *MockAuthServiceClientMockRecorder) GetUserAddresses(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddresses", reflect.TypeOf((*MockAuthServiceClient)(nil).GetUserAddresses), varargs...)
 }
 
+// GrantRole mocks base method.
+func (m *MockAuthServiceClient) GrantRole(arg0 context.Context, arg1 *gen.RoleRequest, arg2 ...grpc.CallOption) (*gen.RolesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GrantRole", varargs...)
+	ret0, _ := ret[0].(*gen.RolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockAuthServiceClientMockRecorder) GrantRole(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockAuthServiceClient)(nil).GrantRole), varargs...)
+}
+
+// LogOut mocks base method.
+func (m *MockAuthServiceClient) LogOut(arg0 context.Context, arg1 *gen.SessionRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LogOut", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogOut indicates an expected call of LogOut.
+func (mr *MockAuthServiceClientMockRecorder) LogOut(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOut", reflect.TypeOf((*MockAuthServiceClient)(nil).LogOut), varargs...)
+}
+
+// LogOutAll mocks base method.
+func (m *MockAuthServiceClient) LogOutAll(arg0 context.Context, arg1 *gen.LogOutAllRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LogOutAll", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogOutAll indicates an expected call of LogOutAll.
+func (mr *MockAuthServiceClientMockRecorder) LogOutAll(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOutAll", reflect.TypeOf((*MockAuthServiceClient)(nil).LogOutAll), varargs...)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthServiceClient) Refresh(arg0 context.Context, arg1 *gen.RefreshRequest, arg2 ...grpc.CallOption) (*gen.UserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Refresh", varargs...)
+	ret0, _ := ret[0].(*gen.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceClientMockRecorder) Refresh(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthServiceClient)(nil).Refresh), varargs...)
+}
+
+// RevokeRole mocks base method.
+func (m *MockAuthServiceClient) RevokeRole(arg0 context.Context, arg1 *gen.RoleRequest, arg2 ...grpc.CallOption) (*gen.RolesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeRole", varargs...)
+	ret0, _ := ret[0].(*gen.RolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockAuthServiceClientMockRecorder) RevokeRole(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeRole), varargs...)
+}
+
 // SignIn mocks base method.
 func (m *MockAuthServiceClient) SignIn(arg0 context.Context, arg1 *gen.SignInRequest, arg2 ...grpc.CallOption) (*gen.UserResponse, error) {
 	m.ctrl.T.Helper()
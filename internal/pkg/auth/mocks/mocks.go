@@ -8,12 +8,52 @@ import (
 	context "context"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	jwt "github.com/golang-jwt/jwt"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/satori/uuid"
 )
 
+// MockTokenSigner is a mock of TokenSigner interface.
+type MockTokenSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenSignerMockRecorder
+}
+
+// MockTokenSignerMockRecorder is the mock recorder for MockTokenSigner.
+type MockTokenSignerMockRecorder struct {
+	mock *MockTokenSigner
+}
+
+// NewMockTokenSigner creates a new mock instance.
+func NewMockTokenSigner(ctrl *gomock.Controller) *MockTokenSigner {
+	mock := &MockTokenSigner{ctrl: ctrl}
+	mock.recorder = &MockTokenSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenSigner) EXPECT() *MockTokenSignerMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockTokenSigner) Sign(claims jwt.MapClaims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockTokenSignerMockRecorder) Sign(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockTokenSigner)(nil).Sign), claims)
+}
+
 // MockAuthRepo is a mock of AuthRepo interface.
 type MockAuthRepo struct {
 	ctrl     *gomock.Controller
@@ -52,6 +92,22 @@ func (mr *MockAuthRepoMockRecorder) AddressExists(ctx, address, userID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressExists", reflect.TypeOf((*MockAuthRepo)(nil).AddressExists), ctx, address, userID)
 }
 
+// ConsumeRefreshToken mocks base method.
+func (m *MockAuthRepo) ConsumeRefreshToken(ctx context.Context, tokenHash, successorSealed []byte) (uuid.UUID, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRefreshToken", ctx, tokenHash, successorSealed)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConsumeRefreshToken indicates an expected call of ConsumeRefreshToken.
+func (mr *MockAuthRepoMockRecorder) ConsumeRefreshToken(ctx, tokenHash, successorSealed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRefreshToken", reflect.TypeOf((*MockAuthRepo)(nil).ConsumeRefreshToken), ctx, tokenHash, successorSealed)
+}
+
 // DeleteAddress mocks base method.
 func (m *MockAuthRepo) DeleteAddress(ctx context.Context, addressId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -66,6 +122,21 @@ func (mr *MockAuthRepoMockRecorder) DeleteAddress(ctx, addressId interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAuthRepo)(nil).DeleteAddress), ctx, addressId)
 }
 
+// GrantRole mocks base method.
+func (m *MockAuthRepo) GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, userID, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockAuthRepoMockRecorder) GrantRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockAuthRepo)(nil).GrantRole), ctx, userID, role)
+}
+
 // InsertAddress mocks base method.
 func (m *MockAuthRepo) InsertAddress(ctx context.Context, address models.Address) error {
 	m.ctrl.T.Helper()
@@ -80,6 +151,34 @@ func (mr *MockAuthRepoMockRecorder) InsertAddress(ctx, address interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAddress", reflect.TypeOf((*MockAuthRepo)(nil).InsertAddress), ctx, address)
 }
 
+// InsertRefreshToken mocks base method.
+func (m *MockAuthRepo) InsertRefreshToken(ctx context.Context, sessionID uuid.UUID, tokenHash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRefreshToken", ctx, sessionID, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRefreshToken indicates an expected call of InsertRefreshToken.
+func (mr *MockAuthRepoMockRecorder) InsertRefreshToken(ctx, sessionID, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRefreshToken", reflect.TypeOf((*MockAuthRepo)(nil).InsertRefreshToken), ctx, sessionID, tokenHash)
+}
+
+// InsertSession mocks base method.
+func (m *MockAuthRepo) InsertSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSession", ctx, sessionID, userID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockAuthRepoMockRecorder) InsertSession(ctx, sessionID, userID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockAuthRepo)(nil).InsertSession), ctx, sessionID, userID, expiresAt)
+}
+
 // InsertUser mocks base method.
 func (m *MockAuthRepo) InsertUser(ctx context.Context, user models.User) error {
 	m.ctrl.T.Helper()
@@ -94,6 +193,82 @@ func (mr *MockAuthRepoMockRecorder) InsertUser(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockAuthRepo)(nil).InsertUser), ctx, user)
 }
 
+// ReplayRefreshToken mocks base method.
+func (m *MockAuthRepo) ReplayRefreshToken(ctx context.Context, tokenHash []byte, grace time.Duration) (uuid.UUID, string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayRefreshToken", ctx, tokenHash, grace)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].([]byte)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReplayRefreshToken indicates an expected call of ReplayRefreshToken.
+func (mr *MockAuthRepoMockRecorder) ReplayRefreshToken(ctx, tokenHash, grace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayRefreshToken", reflect.TypeOf((*MockAuthRepo)(nil).ReplayRefreshToken), ctx, tokenHash, grace)
+}
+
+// RevokeRefreshFamily mocks base method.
+func (m *MockAuthRepo) RevokeRefreshFamily(ctx context.Context, tokenHash []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshFamily", ctx, tokenHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRefreshFamily indicates an expected call of RevokeRefreshFamily.
+func (mr *MockAuthRepoMockRecorder) RevokeRefreshFamily(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshFamily", reflect.TypeOf((*MockAuthRepo)(nil).RevokeRefreshFamily), ctx, tokenHash)
+}
+
+// RevokeRole mocks base method.
+func (m *MockAuthRepo) RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userID, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockAuthRepoMockRecorder) RevokeRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockAuthRepo)(nil).RevokeRole), ctx, userID, role)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthRepo) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthRepoMockRecorder) RevokeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthRepo)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAuthRepo) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAuthRepoMockRecorder) RevokeUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAuthRepo)(nil).RevokeUserSessions), ctx, userID)
+}
+
 // SelectUserAddresses mocks base method.
 func (m *MockAuthRepo) SelectUserAddresses(ctx context.Context, login string) ([]models.Address, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +299,21 @@ func (mr *MockAuthRepoMockRecorder) SelectUserByLogin(ctx, login interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserByLogin", reflect.TypeOf((*MockAuthRepo)(nil).SelectUserByLogin), ctx, login)
 }
 
+// SessionActive mocks base method.
+func (m *MockAuthRepo) SessionActive(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionActive", ctx, sessionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SessionActive indicates an expected call of SessionActive.
+func (mr *MockAuthRepoMockRecorder) SessionActive(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionActive", reflect.TypeOf((*MockAuthRepo)(nil).SessionActive), ctx, sessionID)
+}
+
 // UpdateUser mocks base method.
 func (m *MockAuthRepo) UpdateUser(ctx context.Context, user models.User) error {
 	m.ctrl.T.Helper()
@@ -204,6 +394,20 @@ func (mr *MockAuthUsecaseMockRecorder) Check(ctx, login interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockAuthUsecase)(nil).Check), ctx, login)
 }
 
+// CheckSession mocks base method.
+func (m *MockAuthUsecase) CheckSession(ctx context.Context, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MockAuthUsecaseMockRecorder) CheckSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthUsecase)(nil).CheckSession), ctx, sessionID)
+}
+
 // DeleteAddress mocks base method.
 func (m *MockAuthUsecase) DeleteAddress(ctx context.Context, addressId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -233,15 +437,92 @@ func (mr *MockAuthUsecaseMockRecorder) GetUserAddresses(ctx, login interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddresses", reflect.TypeOf((*MockAuthUsecase)(nil).GetUserAddresses), ctx, login)
 }
 
+// GrantRole mocks base method.
+func (m *MockAuthUsecase) GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, userID, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockAuthUsecaseMockRecorder) GrantRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockAuthUsecase)(nil).GrantRole), ctx, userID, role)
+}
+
+// LogOut mocks base method.
+func (m *MockAuthUsecase) LogOut(ctx context.Context, sessionID uuid.UUID, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogOut", ctx, sessionID, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogOut indicates an expected call of LogOut.
+func (mr *MockAuthUsecaseMockRecorder) LogOut(ctx, sessionID, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOut", reflect.TypeOf((*MockAuthUsecase)(nil).LogOut), ctx, sessionID, refreshToken)
+}
+
+// LogOutAll mocks base method.
+func (m *MockAuthUsecase) LogOutAll(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogOutAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogOutAll indicates an expected call of LogOutAll.
+func (mr *MockAuthUsecaseMockRecorder) LogOutAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOutAll", reflect.TypeOf((*MockAuthUsecase)(nil).LogOutAll), ctx, userID)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthUsecase) Refresh(ctx context.Context, refreshToken string) (models.User, string, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(string)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthUsecaseMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthUsecase)(nil).Refresh), ctx, refreshToken)
+}
+
+// RevokeRole mocks base method.
+func (m *MockAuthUsecase) RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userID, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockAuthUsecaseMockRecorder) RevokeRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeRole), ctx, userID, role)
+}
+
 // SignIn mocks base method.
-func (m *MockAuthUsecase) SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, error) {
+func (m *MockAuthUsecase) SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignIn", ctx, data)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(string)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret3, _ := ret[3].(string)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
 }
 
 // SignIn indicates an expected call of SignIn.
@@ -251,14 +532,15 @@ func (mr *MockAuthUsecaseMockRecorder) SignIn(ctx, data interface{}) *gomock.Cal
 }
 
 // SignUp mocks base method.
-func (m *MockAuthUsecase) SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, error) {
+func (m *MockAuthUsecase) SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignUp", ctx, data)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(string)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret3, _ := ret[3].(string)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
 }
 
 // SignUp indicates an expected call of SignUp.
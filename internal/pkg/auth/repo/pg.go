@@ -4,17 +4,20 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
 	dbUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/db"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 )
 
 const (
 	insertUser          = "INSERT INTO users (id, login, first_name, last_name, phone_number, description, user_pic, password_hash) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
-	selectUserByLogin   = "SELECT id, first_name, last_name, phone_number, description, user_pic, password_hash FROM users WHERE login = $1"
+	selectUserByLogin   = "SELECT id, first_name, last_name, phone_number, description, user_pic, roles, password_hash FROM users WHERE login = $1"
 	updateUser          = "UPDATE users SET phone_number = $1, first_name = $2, last_name = $3, description = $4, password_hash = $5 WHERE id = $6;"
 	updateUserPic       = "UPDATE users SET user_pic = $1 WHERE login = $2"
 	selectUserAddresses = `
@@ -26,6 +29,42 @@ const (
 	deleteAddress = "DELETE FROM addresses WHERE id = $1;"
 	insertAddress = "INSERT INTO addresses (id, address, user_id) VALUES ($1, $2, $3)"
 	addressExists = "SELECT EXISTS(SELECT 1 FROM addresses WHERE address = $1 AND user_id = $2)"
+
+	insertSession      = "INSERT INTO sessions (id, user_id, expires_at) VALUES ($1, $2, $3)"
+	sessionActive      = "SELECT EXISTS(SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL AND expires_at > now())"
+	revokeSession      = "UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL"
+	revokeUserSessions = "UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL"
+
+	insertRefreshToken  = "INSERT INTO refresh_tokens (token_hash, session_id) VALUES ($1, $2)"
+	consumeRefreshToken = `
+		WITH used AS (
+			UPDATE refresh_tokens SET used_at = now(), successor_sealed = $2
+			WHERE token_hash = $1 AND used_at IS NULL
+			RETURNING session_id
+		)
+		SELECT s.id, u.login
+		FROM used
+		JOIN sessions s ON s.id = used.session_id
+		JOIN users u ON u.id = s.user_id
+		WHERE s.revoked_at IS NULL AND s.expires_at > now()
+	`
+	replayRefreshToken = `
+		SELECT s.id, u.login, rt.successor_sealed
+		FROM refresh_tokens rt
+		JOIN sessions s ON s.id = rt.session_id
+		JOIN users u ON u.id = s.user_id
+		WHERE rt.token_hash = $1 AND rt.successor_sealed IS NOT NULL
+			AND rt.used_at > now() - make_interval(secs => $2)
+			AND s.revoked_at IS NULL AND s.expires_at > now()
+	`
+	revokeRefreshFamily = `
+		UPDATE sessions SET revoked_at = now()
+		WHERE id = (SELECT session_id FROM refresh_tokens WHERE token_hash = $1)
+			AND revoked_at IS NULL AND expires_at > now()
+	`
+
+	grantRole  = "UPDATE users SET roles = CASE WHEN $2 = ANY(roles) THEN roles ELSE array_append(roles, $2) END WHERE id = $1 RETURNING roles"
+	revokeRole = "UPDATE users SET roles = array_remove(roles, $2) WHERE id = $1 RETURNING roles"
 )
 
 type AuthRepo struct {
@@ -62,6 +101,7 @@ func (repo *AuthRepo) SelectUserByLogin(ctx context.Context, login string) (mode
 		&resultUser.PhoneNumber,
 		&resultUser.Description,
 		&resultUser.UserPic,
+		&resultUser.Roles,
 		&resultUser.PasswordHash,
 	)
 
@@ -170,4 +210,149 @@ func (repo *AuthRepo) AddressExists(ctx context.Context, address string, userID
 	}
 
 	return exists, nil
-}
\ No newline at end of file
+}
+
+func (repo *AuthRepo) InsertSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := repo.db.Exec(ctx, insertSession, sessionID, userID, expiresAt)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Info("Successful")
+	return nil
+}
+
+func (repo *AuthRepo) SessionActive(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var active bool
+	err := repo.db.QueryRow(ctx, sessionActive, sessionID).Scan(&active)
+	if err != nil {
+		logger.Error(err.Error())
+		return false, err
+	}
+
+	return active, nil
+}
+
+func (repo *AuthRepo) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := repo.db.Exec(ctx, revokeSession, sessionID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Info("Successful")
+	return nil
+}
+
+func (repo *AuthRepo) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	result, err := repo.db.Exec(ctx, revokeUserSessions, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return 0, err
+	}
+
+	logger.Info("Successful", slog.Int64("revoked", result.RowsAffected()))
+	return result.RowsAffected(), nil
+}
+
+func (repo *AuthRepo) InsertRefreshToken(ctx context.Context, sessionID uuid.UUID, tokenHash []byte) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := repo.db.Exec(ctx, insertRefreshToken, tokenHash, sessionID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeRefreshToken помечает refresh-токен использованным, запоминает зашифрованного
+// преемника и возвращает живую сессию токена. Неизвестный, уже использованный или
+// принадлежащий завершённой сессии токен — ErrInvalidRefreshToken.
+func (repo *AuthRepo) ConsumeRefreshToken(ctx context.Context, tokenHash, successorSealed []byte) (uuid.UUID, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var sessionID uuid.UUID
+	var login string
+	err := repo.db.QueryRow(ctx, consumeRefreshToken, tokenHash, successorSealed).Scan(&sessionID, &login)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, "", auth.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return uuid.Nil, "", err
+	}
+
+	return sessionID, login, nil
+}
+
+// ReplayRefreshToken возвращает сессию и зашифрованного преемника refresh-токена, который
+// был использован не раньше чем grace назад. Иначе — ErrInvalidRefreshToken.
+func (repo *AuthRepo) ReplayRefreshToken(ctx context.Context, tokenHash []byte, grace time.Duration) (uuid.UUID, string, []byte, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var sessionID uuid.UUID
+	var login string
+	var successorSealed []byte
+	err := repo.db.QueryRow(ctx, replayRefreshToken, tokenHash, grace.Seconds()).Scan(&sessionID, &login, &successorSealed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, "", nil, auth.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return uuid.Nil, "", nil, err
+	}
+
+	return sessionID, login, successorSealed, nil
+}
+
+// RevokeRefreshFamily отзывает сессию, которой был выдан refresh-токен, и сообщает,
+// была ли она к этому моменту жива.
+func (repo *AuthRepo) RevokeRefreshFamily(ctx context.Context, tokenHash []byte) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	result, err := repo.db.Exec(ctx, revokeRefreshFamily, tokenHash)
+	if err != nil {
+		logger.Error(err.Error())
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GrantRole добавляет пользователю роль и возвращает его роли; повторная выдача ничего не меняет.
+func (repo *AuthRepo) GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	return repo.updateRoles(ctx, grantRole, userID, role)
+}
+
+// RevokeRole снимает с пользователя роль и возвращает оставшиеся роли.
+func (repo *AuthRepo) RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	return repo.updateRoles(ctx, revokeRole, userID, role)
+}
+
+func (repo *AuthRepo) updateRoles(ctx context.Context, query string, userID uuid.UUID, role string) ([]string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var roles []string
+	err := repo.db.QueryRow(ctx, query, userID, role).Scan(&roles)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, auth.ErrUserNotFound
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	logger.Info("Successful", slog.String("role", role))
+	return roles, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgconn"
@@ -81,7 +83,7 @@ func TestInsertUser(t *testing.T) {
 }
 
 func TestSelectUserByLogin(t *testing.T) {
-	columns := []string{"id", "first_name", "last_name", "phone_number", "description", "user_pic", "password_hash"}
+	columns := []string{"id", "first_name", "last_name", "phone_number", "description", "user_pic", "roles", "password_hash"}
 
 	salt := make([]byte, 8)
 	userId := uuid.NewV4()
@@ -94,6 +96,7 @@ func TestSelectUserByLogin(t *testing.T) {
 		PhoneNumber:  "88005553535",
 		Description:  "Some User",
 		UserPic:      "default.png",
+		Roles:        []string{models.RoleCustomer, models.RoleCourier},
 	}
 
 	tests := []struct {
@@ -128,15 +131,17 @@ func TestSelectUserByLogin(t *testing.T) {
 					testUser.PhoneNumber,
 					testUser.Description,
 					testUser.UserPic,
+					testUser.Roles,
 					testUser.PasswordHash,
 				).ToPgxRows()
 			pgxRows.Next()
 			test.repoMocker(mockPool, pgxRows, test.login)
 
 			repo := AuthRepo{db: mockPool}
-			_, err := repo.SelectUserByLogin(context.Background(), test.login)
+			user, err := repo.SelectUserByLogin(context.Background(), test.login)
 
 			assert.Equal(t, test.expectedErr, err)
+			assert.Equal(t, test.expectedUser.Roles, user.Roles)
 
 		})
 	}
@@ -464,3 +469,208 @@ func TestInsertAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionActive(t *testing.T) {
+	sessionID := uuid.NewV4()
+
+	tests := []struct {
+		name     string
+		active   bool
+		expected bool
+	}{
+		{name: "Active", active: true, expected: true},
+		{name: "Revoked", active: false, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			defer ctrl.Finish()
+
+			pgxRows := pgxpoolmock.NewRows([]string{"exists"}).AddRow(test.active).ToPgxRows()
+			pgxRows.Next()
+			mockPool.EXPECT().QueryRow(gomock.Any(), sessionActive, sessionID).Return(pgxRows)
+
+			repo := AuthRepo{db: mockPool}
+			active, err := repo.SessionActive(context.Background(), sessionID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, active)
+		})
+	}
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	defer ctrl.Finish()
+
+	userID := uuid.NewV4()
+	mockPool.EXPECT().
+		Exec(gomock.Any(), revokeUserSessions, userID).
+		Return(pgconn.CommandTag("UPDATE 2"), nil)
+
+	repo := AuthRepo{db: mockPool}
+	revoked, err := repo.RevokeUserSessions(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...interface{}) error { return r.err }
+
+func TestConsumeRefreshToken(t *testing.T) {
+	tokenHash := []byte("hash")
+	sessionID := uuid.NewV4()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		pgxRows := pgxpoolmock.NewRows([]string{"id", "login"}).AddRow(sessionID, "testuser").ToPgxRows()
+		pgxRows.Next()
+		mockPool.EXPECT().QueryRow(gomock.Any(), consumeRefreshToken, tokenHash, []byte("sealed")).Return(pgxRows)
+
+		repo := AuthRepo{db: mockPool}
+		gotSession, login, err := repo.ConsumeRefreshToken(context.Background(), tokenHash, []byte("sealed"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, sessionID, gotSession)
+		assert.Equal(t, "testuser", login)
+	})
+
+	t.Run("Used or unknown token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		mockPool.EXPECT().QueryRow(gomock.Any(), consumeRefreshToken, tokenHash, []byte("sealed")).Return(errRow{pgx.ErrNoRows})
+
+		repo := AuthRepo{db: mockPool}
+		_, _, err := repo.ConsumeRefreshToken(context.Background(), tokenHash, []byte("sealed"))
+
+		assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+}
+
+func TestReplayRefreshToken(t *testing.T) {
+	tokenHash := []byte("hash")
+	sessionID := uuid.NewV4()
+
+	t.Run("Used within grace window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		pgxRows := pgxpoolmock.NewRows([]string{"id", "login", "successor_sealed"}).
+			AddRow(sessionID, "testuser", []byte("sealed")).ToPgxRows()
+		pgxRows.Next()
+		mockPool.EXPECT().QueryRow(gomock.Any(), replayRefreshToken, tokenHash, float64(30)).Return(pgxRows)
+
+		repo := AuthRepo{db: mockPool}
+		gotSession, login, sealed, err := repo.ReplayRefreshToken(context.Background(), tokenHash, 30*time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sessionID, gotSession)
+		assert.Equal(t, "testuser", login)
+		assert.Equal(t, []byte("sealed"), sealed)
+	})
+
+	t.Run("Grace window passed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		mockPool.EXPECT().QueryRow(gomock.Any(), replayRefreshToken, tokenHash, float64(30)).Return(errRow{pgx.ErrNoRows})
+
+		repo := AuthRepo{db: mockPool}
+		_, _, _, err := repo.ReplayRefreshToken(context.Background(), tokenHash, 30*time.Second)
+
+		assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+}
+
+func TestRevokeRefreshFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected bool
+	}{
+		{name: "Live session revoked", tag: "UPDATE 1", expected: true},
+		{name: "Unknown token or dead session", tag: "UPDATE 0", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			defer ctrl.Finish()
+
+			mockPool.EXPECT().
+				Exec(gomock.Any(), revokeRefreshFamily, []byte("hash")).
+				Return(pgconn.CommandTag(test.tag), nil)
+
+			repo := AuthRepo{db: mockPool}
+			revoked, err := repo.RevokeRefreshFamily(context.Background(), []byte("hash"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, revoked)
+		})
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	userID := uuid.NewV4()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		roles := []string{models.RoleCustomer, models.RoleCourier}
+		pgxRows := pgxpoolmock.NewRows([]string{"roles"}).AddRow(roles).ToPgxRows()
+		pgxRows.Next()
+		mockPool.EXPECT().QueryRow(gomock.Any(), grantRole, userID, models.RoleCourier).Return(pgxRows)
+
+		repo := AuthRepo{db: mockPool}
+		got, err := repo.GrantRole(context.Background(), userID, models.RoleCourier)
+
+		assert.NoError(t, err)
+		assert.Equal(t, roles, got)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+		defer ctrl.Finish()
+
+		mockPool.EXPECT().QueryRow(gomock.Any(), grantRole, userID, models.RoleCourier).Return(errRow{pgx.ErrNoRows})
+
+		repo := AuthRepo{db: mockPool}
+		_, err := repo.GrantRole(context.Background(), userID, models.RoleCourier)
+
+		assert.ErrorIs(t, err, auth.ErrUserNotFound)
+	})
+}
+
+func TestRevokeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	defer ctrl.Finish()
+
+	userID := uuid.NewV4()
+	roles := []string{models.RoleCustomer}
+	pgxRows := pgxpoolmock.NewRows([]string{"roles"}).AddRow(roles).ToPgxRows()
+	pgxRows.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), revokeRole, userID, models.RoleAdmin).Return(pgxRows)
+
+	repo := AuthRepo{db: mockPool}
+	got, err := repo.RevokeRole(context.Background(), userID, models.RoleAdmin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, roles, got)
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// GrantRole выдаёт пользователю роль. Она попадёт в токен при следующем обмене refresh-токена.
+func (uc *AuthUsecase) GrantRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if !models.ValidRole(role) {
+		logger.Error(auth.ErrUnknownRole.Error(), slog.String("role", role))
+		return nil, auth.ErrUnknownRole
+	}
+
+	roles, err := uc.repo.GrantRole(ctx, userID, role)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	logger.Info("Successful", slog.String("role", role))
+	return roles, nil
+}
+
+// RevokeRole снимает с пользователя роль. Уже выданные access-токены содержат её до истечения,
+// поэтому все сессии пользователя отзываются и ему придётся войти заново.
+func (uc *AuthUsecase) RevokeRole(ctx context.Context, userID uuid.UUID, role string) ([]string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if !models.ValidRole(role) {
+		logger.Error(auth.ErrUnknownRole.Error(), slog.String("role", role))
+		return nil, auth.ErrUnknownRole
+	}
+
+	roles, err := uc.repo.RevokeRole(ctx, userID, role)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if _, err := uc.repo.RevokeUserSessions(ctx, userID); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	logger.Info("Successful", slog.String("role", role))
+	return roles, nil
+}
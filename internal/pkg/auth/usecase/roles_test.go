@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantRole(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name          string
+		role          string
+		setup         func(repo *mocks.MockAuthRepo)
+		expectedRoles []string
+		expectedErr   error
+	}{
+		{
+			name: "Success",
+			role: models.RoleCourier,
+			setup: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().GrantRole(gomock.Any(), userID, models.RoleCourier).
+					Return([]string{models.RoleCustomer, models.RoleCourier}, nil)
+			},
+			expectedRoles: []string{models.RoleCustomer, models.RoleCourier},
+		},
+		{
+			name:        "Unknown role",
+			role:        "superuser",
+			setup:       func(repo *mocks.MockAuthRepo) {},
+			expectedErr: auth.ErrUnknownRole,
+		},
+		{
+			name: "User not found",
+			role: models.RoleAdmin,
+			setup: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().GrantRole(gomock.Any(), userID, models.RoleAdmin).Return(nil, auth.ErrUserNotFound)
+			},
+			expectedErr: auth.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepo(ctrl)
+			tt.setup(repo)
+			uc := CreateAuthUsecase(repo, testSigner)
+
+			roles, err := uc.GrantRole(context.Background(), userID, tt.role)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRoles, roles)
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name          string
+		role          string
+		setup         func(repo *mocks.MockAuthRepo)
+		expectedRoles []string
+		expectedErr   error
+	}{
+		{
+			name: "Success ends user sessions",
+			role: models.RoleCourier,
+			setup: func(repo *mocks.MockAuthRepo) {
+				gomock.InOrder(
+					repo.EXPECT().RevokeRole(gomock.Any(), userID, models.RoleCourier).
+						Return([]string{models.RoleCustomer}, nil),
+					repo.EXPECT().RevokeUserSessions(gomock.Any(), userID).Return(int64(2), nil),
+				)
+			},
+			expectedRoles: []string{models.RoleCustomer},
+		},
+		{
+			name:        "Unknown role",
+			role:        "superuser",
+			setup:       func(repo *mocks.MockAuthRepo) {},
+			expectedErr: auth.ErrUnknownRole,
+		},
+		{
+			name: "Sessions not revoked",
+			role: models.RoleAdmin,
+			setup: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().RevokeRole(gomock.Any(), userID, models.RoleAdmin).Return([]string{models.RoleCustomer}, nil)
+				repo.EXPECT().RevokeUserSessions(gomock.Any(), userID).Return(int64(0), errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepo(ctrl)
+			tt.setup(repo)
+			uc := CreateAuthUsecase(repo, testSigner)
+
+			roles, err := uc.RevokeRole(context.Background(), userID, tt.role)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedRoles, roles)
+		})
+	}
+}
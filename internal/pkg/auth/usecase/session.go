@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+const refreshTokenBytes = 32
+
+// refreshGraceWindow — сколько уже использованный refresh-токен продолжает возвращать
+// своего преемника. Так два почти одновременных обмена (например, из двух вкладок)
+// получают одну и ту же пару, а не отзывают сессию как украденную.
+const refreshGraceWindow = 30 * time.Second
+
+func hashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// successorCipher — шифр преемника refresh-токена. Ключ выводится из самого предъявленного
+// токена, поэтому расшифровать преемника может только тот, у кого этот токен есть.
+func successorCipher(token string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte("refresh-successor:" + token))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealSuccessor(token, successor string) ([]byte, error) {
+	aead, err := successorCipher(token)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, []byte(successor), nil), nil
+}
+
+func openSuccessor(token string, sealed []byte) (string, error) {
+	aead, err := successorCipher(token)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", auth.ErrInvalidRefreshToken
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	successor, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", auth.ErrInvalidRefreshToken
+	}
+	return string(successor), nil
+}
+
+func generateRefreshToken() (string, error) {
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// startSession регистрирует новую сессию пользователя и выдаёт под неё пару токенов:
+// access-токен с id сессии в jti и первый refresh-токен семейства.
+func (uc *AuthUsecase) startSession(ctx context.Context, user models.User) (string, string, error) {
+	sessionID := uuid.NewV4()
+	now := time.Now()
+
+	token, err := uc.generateToken(user, sessionID, now.Add(jwtUtils.AccessTokenTTL))
+	if err != nil {
+		return "", "", auth.ErrGeneratingToken
+	}
+
+	if err := uc.repo.InsertSession(ctx, sessionID, user.Id, now.Add(jwtUtils.RefreshTokenTTL)); err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := uc.issueRefreshToken(ctx, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+	return token, refreshToken, nil
+}
+
+// issueRefreshToken создаёт очередной refresh-токен сессии; на сервере остаётся только его хэш.
+func (uc *AuthUsecase) issueRefreshToken(ctx context.Context, sessionID uuid.UUID) (string, error) {
+	token, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.repo.InsertRefreshToken(ctx, sessionID, hashRefreshToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Refresh меняет refresh-токен на новую пару токенов той же сессии. Каждый refresh-токен
+// одноразовый: если предъявлен уже использованный, его, скорее всего, украли, поэтому
+// сессия отзывается целиком — вместе с access-токеном и последним выданным refresh-токеном.
+// Исключение — повтор в пределах refreshGraceWindow: он получает того же преемника.
+func (uc *AuthUsecase) Refresh(ctx context.Context, refreshToken string) (models.User, string, string, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	newRefreshToken, err := generateRefreshToken()
+	if err != nil {
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", err
+	}
+	successorSealed, err := sealSuccessor(refreshToken, newRefreshToken)
+	if err != nil {
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", err
+	}
+
+	tokenHash := hashRefreshToken(refreshToken)
+	rotated := true
+	sessionID, login, err := uc.repo.ConsumeRefreshToken(ctx, tokenHash, successorSealed)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		sessionID, login, newRefreshToken, err = uc.replayRefreshToken(ctx, refreshToken)
+		rotated = false
+	}
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		reused, err := uc.repo.RevokeRefreshFamily(ctx, tokenHash)
+		if err != nil {
+			logger.Error(err.Error())
+			return models.User{}, "", "", "", err
+		}
+		if reused {
+			logger.Warn(auth.ErrRefreshTokenReused.Error())
+			return models.User{}, "", "", "", auth.ErrRefreshTokenReused
+		}
+		return models.User{}, "", "", "", auth.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", err
+	}
+
+	user, err := uc.repo.SelectUserByLogin(ctx, login)
+	if err != nil {
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", auth.ErrUserNotFound
+	}
+
+	token, err := uc.generateToken(user, sessionID, time.Now().Add(jwtUtils.AccessTokenTTL))
+	if err != nil {
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", auth.ErrGeneratingToken
+	}
+
+	if rotated {
+		if err := uc.repo.InsertRefreshToken(ctx, sessionID, hashRefreshToken(newRefreshToken)); err != nil {
+			logger.Error(err.Error())
+			return models.User{}, "", "", "", err
+		}
+	}
+
+	csrfToken := uuid.NewV4().String()
+
+	logger.Info("Successful")
+	return user, token, csrfToken, newRefreshToken, nil
+}
+
+// replayRefreshToken возвращает преемника refresh-токена, использованного в пределах
+// refreshGraceWindow. Иначе — ErrInvalidRefreshToken.
+func (uc *AuthUsecase) replayRefreshToken(ctx context.Context, refreshToken string) (uuid.UUID, string, string, error) {
+	sessionID, login, successorSealed, err := uc.repo.ReplayRefreshToken(ctx, hashRefreshToken(refreshToken), refreshGraceWindow)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	successor, err := openSuccessor(refreshToken, successorSealed)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	return sessionID, login, successor, nil
+}
+
+// CheckSession проверяет, что сессия токена не отозвана и не истекла.
+func (uc *AuthUsecase) CheckSession(ctx context.Context, sessionID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	active, err := uc.repo.SessionActive(ctx, sessionID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if !active {
+		return auth.ErrSessionRevoked
+	}
+	return nil
+}
+
+// LogOut отзывает сессию, с которой пришёл запрос. Её можно указать id из access-токена или
+// refresh-токеном, если access-токен уже истёк.
+func (uc *AuthUsecase) LogOut(ctx context.Context, sessionID uuid.UUID, refreshToken string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if sessionID != uuid.Nil {
+		if err := uc.repo.RevokeSession(ctx, sessionID); err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		if _, err := uc.repo.RevokeRefreshFamily(ctx, hashRefreshToken(refreshToken)); err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	logger.Info("Successful")
+	return nil
+}
+
+// LogOutAll отзывает все сессии пользователя на всех устройствах.
+func (uc *AuthUsecase) LogOutAll(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	revoked, err := uc.repo.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Info("Successful", slog.Int64("revoked", revoked))
+	return nil
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/keys"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/mocks"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigner = func() *keys.KeyRing {
+	ring, err := keys.NewKeyRing(map[string]ed25519.PrivateKey{
+		"test": ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)),
+	}, "")
+	if err != nil {
+		panic(err)
+	}
+	return ring
+}()
+
+type failingSigner struct{}
+
+func (failingSigner) Sign(jwt.MapClaims) (string, error) {
+	return "", errors.New("ключ подписи недоступен")
+}
+
+func signerOr(signer auth.TokenSigner) auth.TokenSigner {
+	if signer == nil {
+		return testSigner
+	}
+	return signer
+}
+
+func TestStartSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuthRepo(ctrl)
+	uc := CreateAuthUsecase(repo, testSigner)
+	user := models.User{Id: uuid.NewV4(), Login: "testuser", Roles: []string{models.RoleCustomer, models.RoleCourier}}
+
+	var inserted uuid.UUID
+	var storedHash []byte
+	repo.EXPECT().InsertSession(gomock.Any(), gomock.Any(), user.Id, gomock.Any()).
+		DoAndReturn(func(_ context.Context, sessionID, _ uuid.UUID, expiresAt time.Time) error {
+			inserted = sessionID
+			assert.WithinDuration(t, time.Now().Add(jwtUtils.RefreshTokenTTL), expiresAt, time.Minute)
+			return nil
+		})
+	repo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, sessionID uuid.UUID, tokenHash []byte) error {
+			assert.Equal(t, inserted, sessionID)
+			storedHash = tokenHash
+			return nil
+		})
+
+	token, refreshToken, err := uc.startSession(context.Background(), user)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	sessionID, ok := jwtUtils.GetSessionIDFromJWT(token, claims, testSigner.PublicKeys())
+	require.True(t, ok)
+	assert.Equal(t, inserted.String(), sessionID)
+	assert.InDelta(t, time.Now().Add(jwtUtils.AccessTokenTTL).Unix(), claims["exp"], 60)
+	assert.Equal(t, user.Roles, jwtUtils.GetRolesFromClaims(claims))
+
+	assert.Equal(t, hashRefreshToken(refreshToken), storedHash, "на сервере хранится только хэш")
+	assert.NotContains(t, string(storedHash), refreshToken)
+}
+
+func TestRefresh(t *testing.T) {
+	sessionID := uuid.NewV4()
+	user := models.User{Id: uuid.NewV4(), Login: "testuser"}
+	tokenHash := hashRefreshToken("refresh")
+	successorSealed, err := sealSuccessor("refresh", "successor")
+	require.NoError(t, err)
+	foreignSealed, err := sealSuccessor("other", "successor")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		repoMocker      func(*mocks.MockAuthRepo)
+		expectedRefresh string
+		expectedErr     error
+	}{
+		{
+			name: "Success rotates token",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(sessionID, user.Login, nil)
+				repo.EXPECT().SelectUserByLogin(gomock.Any(), user.Login).Return(user, nil)
+				repo.EXPECT().InsertRefreshToken(gomock.Any(), sessionID, gomock.Not(tokenHash)).Return(nil)
+			},
+		},
+		{
+			name: "Replay within grace window returns same successor",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(uuid.Nil, "", auth.ErrInvalidRefreshToken)
+				repo.EXPECT().ReplayRefreshToken(gomock.Any(), tokenHash, refreshGraceWindow).Return(sessionID, user.Login, successorSealed, nil)
+				repo.EXPECT().SelectUserByLogin(gomock.Any(), user.Login).Return(user, nil)
+			},
+			expectedRefresh: "successor",
+		},
+		{
+			name: "Successor sealed by another token",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(uuid.Nil, "", auth.ErrInvalidRefreshToken)
+				repo.EXPECT().ReplayRefreshToken(gomock.Any(), tokenHash, refreshGraceWindow).Return(sessionID, user.Login, foreignSealed, nil)
+				repo.EXPECT().RevokeRefreshFamily(gomock.Any(), tokenHash).Return(true, nil)
+			},
+			expectedErr: auth.ErrRefreshTokenReused,
+		},
+		{
+			name: "Unknown token",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(uuid.Nil, "", auth.ErrInvalidRefreshToken)
+				repo.EXPECT().ReplayRefreshToken(gomock.Any(), tokenHash, refreshGraceWindow).Return(uuid.Nil, "", nil, auth.ErrInvalidRefreshToken)
+				repo.EXPECT().RevokeRefreshFamily(gomock.Any(), tokenHash).Return(false, nil)
+			},
+			expectedErr: auth.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Reused token after grace window revokes family",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(uuid.Nil, "", auth.ErrInvalidRefreshToken)
+				repo.EXPECT().ReplayRefreshToken(gomock.Any(), tokenHash, refreshGraceWindow).Return(uuid.Nil, "", nil, auth.ErrInvalidRefreshToken)
+				repo.EXPECT().RevokeRefreshFamily(gomock.Any(), tokenHash).Return(true, nil)
+			},
+			expectedErr: auth.ErrRefreshTokenReused,
+		},
+		{
+			name: "DB error",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().ConsumeRefreshToken(gomock.Any(), tokenHash, gomock.Any()).Return(uuid.Nil, "", auth.ErrDBError)
+			},
+			expectedErr: auth.ErrDBError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepo(ctrl)
+			uc := CreateAuthUsecase(repo, testSigner)
+			tt.repoMocker(repo)
+
+			_, token, csrf, refresh, err := uc.Refresh(context.Background(), "refresh")
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Refresh() error = %v, wantErr = %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+
+			gotSession, ok := jwtUtils.GetSessionIDFromJWT(token, jwt.MapClaims{}, testSigner.PublicKeys())
+			require.True(t, ok)
+			assert.Equal(t, sessionID.String(), gotSession)
+			assert.NotEmpty(t, csrf)
+			assert.NotEqual(t, "refresh", refresh)
+			if tt.expectedRefresh != "" {
+				assert.Equal(t, tt.expectedRefresh, refresh)
+			}
+		})
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	sessionID := uuid.NewV4()
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*mocks.MockAuthRepo)
+		expectedErr error
+	}{
+		{
+			name: "Active",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().SessionActive(gomock.Any(), sessionID).Return(true, nil)
+			},
+		},
+		{
+			name: "Revoked or expired",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().SessionActive(gomock.Any(), sessionID).Return(false, nil)
+			},
+			expectedErr: auth.ErrSessionRevoked,
+		},
+		{
+			name: "DB error",
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().SessionActive(gomock.Any(), sessionID).Return(false, auth.ErrDBError)
+			},
+			expectedErr: auth.ErrDBError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepo(ctrl)
+			uc := CreateAuthUsecase(repo, testSigner)
+			tt.repoMocker(repo)
+
+			err := uc.CheckSession(context.Background(), sessionID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("CheckSession() error = %v, wantErr = %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestLogOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuthRepo(ctrl)
+	uc := CreateAuthUsecase(repo, testSigner)
+	sessionID, userID := uuid.NewV4(), uuid.NewV4()
+
+	repo.EXPECT().RevokeSession(gomock.Any(), sessionID).Return(nil)
+	repo.EXPECT().RevokeRefreshFamily(gomock.Any(), hashRefreshToken("refresh")).Return(false, nil)
+	assert.NoError(t, uc.LogOut(context.Background(), sessionID, "refresh"))
+
+	repo.EXPECT().RevokeRefreshFamily(gomock.Any(), hashRefreshToken("refresh")).Return(true, nil)
+	assert.NoError(t, uc.LogOut(context.Background(), uuid.Nil, "refresh"))
+
+	repo.EXPECT().RevokeUserSessions(gomock.Any(), userID).Return(int64(3), nil)
+	assert.NoError(t, uc.LogOutAll(context.Background(), userID))
+
+	repo.EXPECT().RevokeUserSessions(gomock.Any(), userID).Return(int64(0), auth.ErrDBError)
+	assert.ErrorIs(t, uc.LogOutAll(context.Background(), userID), auth.ErrDBError)
+}
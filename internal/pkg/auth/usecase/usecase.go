@@ -109,81 +109,77 @@ func validPassword(password string) bool {
 	return up && low && digit && special
 }
 
-func generateToken(login string, id uuid.UUID) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", auth.ErrGeneratingToken
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"login": login,
-		"id":    id,
-		"exp":   time.Now().Add(24 * time.Hour).Unix(),
+func (uc *AuthUsecase) generateToken(user models.User, sessionID uuid.UUID, expiresAt time.Time) (string, error) {
+	return uc.signer.Sign(jwt.MapClaims{
+		"login": user.Login,
+		"id":    user.Id,
+		"roles": user.Roles,
+		"jti":   sessionID.String(),
+		"exp":   expiresAt.Unix(),
 	})
-
-	return token.SignedString([]byte(secret))
 }
 
 type AuthUsecase struct {
-	repo auth.AuthRepo
+	repo   auth.AuthRepo
+	signer auth.TokenSigner
 }
 
-func CreateAuthUsecase(repo auth.AuthRepo) *AuthUsecase {
-	return &AuthUsecase{repo: repo}
+func CreateAuthUsecase(repo auth.AuthRepo, signer auth.TokenSigner) *AuthUsecase {
+	return &AuthUsecase{repo: repo, signer: signer}
 }
 
-func (uc *AuthUsecase) SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, error) {
+func (uc *AuthUsecase) SignIn(ctx context.Context, data models.SignInReq) (models.User, string, string, string, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
 	if !validLogin(data.Login) {
 		logger.Error(auth.ErrInvalidLogin.Error())
-		return models.User{}, "", "", auth.ErrInvalidLogin
+		return models.User{}, "", "", "", auth.ErrInvalidLogin
 	}
 
 	user, err := uc.repo.SelectUserByLogin(ctx, data.Login)
 	if err != nil {
 		logger.Error(auth.ErrUserNotFound.Error())
-		return models.User{}, "", "", auth.ErrUserNotFound
+		return models.User{}, "", "", "", auth.ErrUserNotFound
 	}
 
 	if !checkPassword(user.PasswordHash, data.Password) {
 		logger.Error(auth.ErrInvalidCredentials.Error())
-		return models.User{}, "", "", auth.ErrInvalidCredentials
+		return models.User{}, "", "", "", auth.ErrInvalidCredentials
 	}
 
-	token, err := generateToken(user.Login, user.Id)
+	token, refreshToken, err := uc.startSession(ctx, user)
 	if err != nil {
-		logger.Error(auth.ErrGeneratingToken.Error())
-		return models.User{}, "", "", auth.ErrGeneratingToken
+		logger.Error(err.Error())
+		return models.User{}, "", "", "", auth.ErrGeneratingToken
 	}
 
 	csrfToken := uuid.NewV4().String()
 
 	logger.Info("Successful")
-	return user, token, csrfToken, nil
+	return user, token, csrfToken, refreshToken, nil
 }
 
-func (uc *AuthUsecase) SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, error) {
+func (uc *AuthUsecase) SignUp(ctx context.Context, data models.SignUpReq) (models.User, string, string, string, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
 	if !validLogin(data.Login) {
 		logger.Error(auth.ErrInvalidLogin.Error())
-		return models.User{}, "", "", auth.ErrInvalidLogin
+		return models.User{}, "", "", "", auth.ErrInvalidLogin
 	}
 
 	if !validPassword(data.Password) {
 		logger.Error(auth.ErrInvalidPassword.Error())
-		return models.User{}, "", "", auth.ErrInvalidPassword
+		return models.User{}, "", "", "", auth.ErrInvalidPassword
 	}
 
 	if !isValidName(data.FirstName) || !isValidName(data.LastName) {
 		logger.Error(auth.ErrInvalidName.Error())
-		return models.User{}, "", "", auth.ErrInvalidName
+		return models.User{}, "", "", "", auth.ErrInvalidName
 	}
 
 	if !isValidPhone(data.PhoneNumber) {
 		logger.Error(auth.ErrInvalidPhone.Error())
-		return models.User{}, "", "", auth.ErrInvalidPhone
+		return models.User{}, "", "", "", auth.ErrInvalidPhone
 	}
 
 	salt := make([]byte, 8)
@@ -198,25 +194,26 @@ func (uc *AuthUsecase) SignUp(ctx context.Context, data models.SignUpReq) (model
 		LastName:     data.LastName,
 		Description:  "",
 		UserPic:      "default.png",
+		Roles:        []string{models.RoleCustomer},
 		PasswordHash: hashedPassword,
 	}
 
 	err := uc.repo.InsertUser(ctx, newUser)
 	if err != nil {
 		logger.Error(err.Error())
-		return models.User{}, "", "", auth.ErrCreatingUser
+		return models.User{}, "", "", "", auth.ErrCreatingUser
 	}
 
-	token, err := generateToken(newUser.Login, newUser.Id)
+	token, refreshToken, err := uc.startSession(ctx, newUser)
 	if err != nil {
 		logger.Error(err.Error())
-		return models.User{}, "", "", auth.ErrGeneratingToken
+		return models.User{}, "", "", "", auth.ErrGeneratingToken
 	}
 
 	csrfToken := uuid.NewV4().String()
 
 	logger.Info("Successful")
-	return newUser, token, csrfToken, nil
+	return newUser, token, csrfToken, refreshToken, nil
 }
 
 func (uc *AuthUsecase) Check(ctx context.Context, login string) (models.User, error) {
@@ -278,6 +275,13 @@ func (uc *AuthUsecase) UpdateUser(ctx context.Context, login string, updateData
 		return models.User{}, err
 	}
 
+	if updateData.Password != "" {
+		if _, err := uc.repo.RevokeUserSessions(ctx, user.Id); err != nil {
+			logger.Error(err.Error())
+			return models.User{}, err
+		}
+	}
+
 	logger.Info("Successful")
 	return user, nil
 }
@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"errors"
-	"os"
 	"testing"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
@@ -15,7 +14,6 @@ import (
 
 func TestSignIn(t *testing.T) {
 	salt := make([]byte, 8)
-	os.Setenv("JWT_SECRET", "testsecret")
 
 	type args struct {
 		data models.SignInReq
@@ -23,6 +21,7 @@ func TestSignIn(t *testing.T) {
 	tests := []struct {
 		name       string
 		repoMocker func(*mocks.MockAuthRepo, string, string)
+		signer     auth.TokenSigner
 		args       args
 		wantErr    error
 	}{
@@ -34,6 +33,8 @@ func TestSignIn(t *testing.T) {
 					Login:        login,
 					PasswordHash: HashPassword(salt, password),
 				}, nil).Times(1)
+				repo.EXPECT().InsertSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			args: args{
 				data: models.SignInReq{
@@ -85,16 +86,33 @@ func TestSignIn(t *testing.T) {
 			wantErr: auth.ErrInvalidCredentials,
 		},
 		{
-			name: "Token generation error (no secret)",
+			name: "Session insert error",
+			repoMocker: func(repo *mocks.MockAuthRepo, login, password string) {
+				repo.EXPECT().SelectUserByLogin(gomock.Any(), login).Return(models.User{
+					Id:           uuid.NewV4(),
+					Login:        login,
+					PasswordHash: HashPassword(salt, password),
+				}, nil).Times(1)
+				repo.EXPECT().InsertSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(auth.ErrDBError).Times(1)
+			},
+			args: args{
+				data: models.SignInReq{
+					Login:    "testuser",
+					Password: "Pass@123",
+				},
+			},
+			wantErr: auth.ErrGeneratingToken,
+		},
+		{
+			name: "Token signing error",
 			repoMocker: func(repo *mocks.MockAuthRepo, login, password string) {
 				repo.EXPECT().SelectUserByLogin(gomock.Any(), login).Return(models.User{
 					Id:           uuid.NewV4(),
 					Login:        login,
 					PasswordHash: HashPassword(salt, password),
 				}, nil).Times(1)
-
-				os.Setenv("JWT_SECRET", "")
 			},
+			signer: failingSigner{},
 			args: args{
 				data: models.SignInReq{
 					Login:    "testuser",
@@ -111,19 +129,19 @@ func TestSignIn(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(repo)
+			uc := CreateAuthUsecase(repo, signerOr(tt.signer))
 
 			tt.repoMocker(repo, tt.args.data.Login, tt.args.data.Password)
 
-			_, token, csrf, err := uc.SignIn(context.Background(), tt.args.data)
+			_, token, csrf, refresh, err := uc.SignIn(context.Background(), tt.args.data)
 
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("SignIn() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
 			if err == nil {
-				if token == "" || csrf == "" {
-					t.Errorf("Expected non-empty token and csrf, got token: '%s', csrf: '%s'", token, csrf)
+				if token == "" || csrf == "" || refresh == "" {
+					t.Errorf("Expected non-empty tokens, got token: '%s', csrf: '%s', refresh: '%s'", token, csrf, refresh)
 				}
 			}
 		})
@@ -131,8 +149,6 @@ func TestSignIn(t *testing.T) {
 }
 
 func TestSignUp(t *testing.T) {
-	os.Setenv("JWT_SECRET", "testsecret")
-
 	type args struct {
 		data models.SignUpReq
 	}
@@ -141,6 +157,7 @@ func TestSignUp(t *testing.T) {
 		name       string
 		args       args
 		repoMocker func(*mocks.MockAuthRepo, models.User)
+		signer     auth.TokenSigner
 		wantErr    error
 	}{
 		{
@@ -156,6 +173,8 @@ func TestSignUp(t *testing.T) {
 			},
 			repoMocker: func(repo *mocks.MockAuthRepo, user models.User) {
 				repo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().InsertSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			wantErr: nil,
 		},
@@ -232,7 +251,7 @@ func TestSignUp(t *testing.T) {
 			wantErr: auth.ErrCreatingUser,
 		},
 		{
-			name: "Token generation failure (no secret)",
+			name: "Token signing failure",
 			args: args{
 				data: models.SignUpReq{
 					Login:       "newuser",
@@ -243,9 +262,9 @@ func TestSignUp(t *testing.T) {
 				},
 			},
 			repoMocker: func(repo *mocks.MockAuthRepo, user models.User) {
-				os.Setenv("JWT_SECRET", "")
 				repo.EXPECT().InsertUser(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
+			signer:  failingSigner{},
 			wantErr: auth.ErrGeneratingToken,
 		},
 	}
@@ -256,7 +275,7 @@ func TestSignUp(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(repo)
+			uc := CreateAuthUsecase(repo, signerOr(tt.signer))
 
 			testUser := models.User{
 				Login:       tt.args.data.Login,
@@ -267,14 +286,14 @@ func TestSignUp(t *testing.T) {
 
 			tt.repoMocker(repo, testUser)
 
-			_, token, csrf, err := uc.SignUp(context.Background(), tt.args.data)
+			_, token, csrf, refresh, err := uc.SignUp(context.Background(), tt.args.data)
 
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("SignUp() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			if err == nil && (token == "" || csrf == "") {
-				t.Errorf("Expected non-empty token and csrf, got token: '%s', csrf: '%s'", token, csrf)
+			if err == nil && (token == "" || csrf == "" || refresh == "") {
+				t.Errorf("Expected non-empty tokens, got token: '%s', csrf: '%s', refresh: '%s'", token, csrf, refresh)
 			}
 		})
 	}
@@ -314,7 +333,7 @@ func TestCheck(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(repo)
+			uc := CreateAuthUsecase(repo, testSigner)
 
 			tt.repoMocker(repo, tt.login)
 
@@ -360,6 +379,27 @@ func TestUpdateUser(t *testing.T) {
 				PhoneNumber: "89223334455",
 				Description: "Новое описание",
 			},
+			repoMocker: func(repo *mocks.MockAuthRepo) {
+				repo.EXPECT().
+					SelectUserByLogin(gomock.Any(), oldUser.Login).
+					Return(oldUser, nil).Times(1)
+
+				repo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+
+				repo.EXPECT().
+					RevokeUserSessions(gomock.Any(), oldUser.Id).
+					Return(int64(2), nil).Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Profile update keeps sessions",
+			login: oldUser.Login,
+			updateData: models.UpdateUserReq{
+				Description: "Новое описание",
+			},
 			repoMocker: func(repo *mocks.MockAuthRepo) {
 				repo.EXPECT().
 					SelectUserByLogin(gomock.Any(), oldUser.Login).
@@ -401,7 +441,7 @@ func TestUpdateUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(repo)
+			uc := CreateAuthUsecase(repo, testSigner)
 
 			tt.repoMocker(repo)
 
@@ -454,7 +494,7 @@ func TestGetUserAddresses(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(mockRepo)
+			uc := CreateAuthUsecase(mockRepo, testSigner)
 			tt.repoMocker(mockRepo)
 
 			_, err := uc.GetUserAddresses(context.Background(), tt.login)
@@ -513,7 +553,7 @@ func TestDeleteAddress(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mocks.NewMockAuthRepo(ctrl)
-			uc := CreateAuthUsecase(mockRepo)
+			uc := CreateAuthUsecase(mockRepo, testSigner)
 			tt.repoMocker(mockRepo)
 
 			err := uc.DeleteAddress(context.Background(), tt.addressId)
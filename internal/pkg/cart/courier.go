@@ -0,0 +1,35 @@
+package cart
+
+import "errors"
+
+// Статусы назначения заказа курьеру. Статус самого заказа меняется только при
+// забирании из ресторана ("in_delivery") и вручении клиенту ("delivered").
+const (
+	AssignmentOffered   = "offered"
+	AssignmentAccepted  = "accepted"
+	AssignmentPickedUp  = "picked_up"
+	AssignmentDelivered = "delivered"
+)
+
+// Действия курьера над назначенным заказом.
+const (
+	CourierActionAccept  = "accept"
+	CourierActionDecline = "decline"
+	CourierActionPickup  = "pickup"
+	CourierActionDeliver = "deliver"
+)
+
+var (
+	ErrNotCourier           = errors.New("пользователь не является курьером")
+	ErrAssignmentNotFound   = errors.New("заказ не назначен этому курьеру")
+	ErrInvalidAssignment    = errors.New("действие недоступно на текущем этапе доставки")
+	ErrUnknownCourierAction = errors.New("неизвестное действие курьера")
+)
+
+func IsCourierAction(action string) bool {
+	switch action {
+	case CourierActionAccept, CourierActionDecline, CourierActionPickup, CourierActionDeliver:
+		return true
+	}
+	return false
+}
@@ -25,7 +25,7 @@ const (
 
 type GetCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
+	GuestId       string                 `protobuf:"bytes,1,opt,name=GuestId,proto3" json:"GuestId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -60,19 +60,20 @@ func (*GetCartRequest) Descriptor() ([]byte, []int) {
 	return file_proto_cart_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *GetCartRequest) GetLogin() string {
+func (x *GetCartRequest) GetGuestId() string {
 	if x != nil {
-		return x.Login
+		return x.GuestId
 	}
 	return ""
 }
 
 type UpdateQuantityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
+	GuestId       string                 `protobuf:"bytes,1,opt,name=GuestId,proto3" json:"GuestId,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=ProductId,proto3" json:"ProductId,omitempty"`
 	RestaurantId  string                 `protobuf:"bytes,3,opt,name=RestaurantId,proto3" json:"RestaurantId,omitempty"`
 	Quantity      int32                  `protobuf:"varint,4,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
+	Replace       bool                   `protobuf:"varint,5,opt,name=Replace,proto3" json:"Replace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -107,9 +108,9 @@ func (*UpdateQuantityRequest) Descriptor() ([]byte, []int) {
 	return file_proto_cart_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *UpdateQuantityRequest) GetLogin() string {
+func (x *UpdateQuantityRequest) GetGuestId() string {
 	if x != nil {
-		return x.Login
+		return x.GuestId
 	}
 	return ""
 }
@@ -135,9 +136,16 @@ func (x *UpdateQuantityRequest) GetQuantity() int32 {
 	return 0
 }
 
+func (x *UpdateQuantityRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
 type ClearCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Login         string                 `protobuf:"bytes,1,opt,name=Login,proto3" json:"Login,omitempty"`
+	GuestId       string                 `protobuf:"bytes,1,opt,name=GuestId,proto3" json:"GuestId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -172,16 +180,67 @@ func (*ClearCartRequest) Descriptor() ([]byte, []int) {
 	return file_proto_cart_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ClearCartRequest) GetLogin() string {
+func (x *ClearCartRequest) GetGuestId() string {
+	if x != nil {
+		return x.GuestId
+	}
+	return ""
+}
+
+type MergeGuestCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuestId       string                 `protobuf:"bytes,1,opt,name=GuestId,proto3" json:"GuestId,omitempty"`
+	Replace       bool                   `protobuf:"varint,3,opt,name=Replace,proto3" json:"Replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeGuestCartRequest) Reset() {
+	*x = MergeGuestCartRequest{}
+	mi := &file_proto_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeGuestCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeGuestCartRequest) ProtoMessage() {}
+
+func (x *MergeGuestCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeGuestCartRequest.ProtoReflect.Descriptor instead.
+func (*MergeGuestCartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MergeGuestCartRequest) GetGuestId() string {
 	if x != nil {
-		return x.Login
+		return x.GuestId
 	}
 	return ""
 }
 
+func (x *MergeGuestCartRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
 type CreateOrderRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
-	Status            string                 `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
 	Address           string                 `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
 	ApartmentOrOffice string                 `protobuf:"bytes,3,opt,name=ApartmentOrOffice,proto3" json:"ApartmentOrOffice,omitempty"`
 	Intercom          string                 `protobuf:"bytes,4,opt,name=Intercom,proto3" json:"Intercom,omitempty"`
@@ -191,14 +250,17 @@ type CreateOrderRequest struct {
 	LeaveAtDoor       bool                   `protobuf:"varint,8,opt,name=LeaveAtDoor,proto3" json:"LeaveAtDoor,omitempty"`
 	FinalPrice        float64                `protobuf:"fixed64,9,opt,name=FinalPrice,proto3" json:"FinalPrice,omitempty"`
 	Cart              *CartResponse          `protobuf:"bytes,10,opt,name=Cart,proto3" json:"Cart,omitempty"`
-	Login             string                 `protobuf:"bytes,11,opt,name=Login,proto3" json:"Login,omitempty"`
+	PromoCode         string                 `protobuf:"bytes,12,opt,name=PromoCode,proto3" json:"PromoCode,omitempty"`
+	DeliverAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=DeliverAt,proto3" json:"DeliverAt,omitempty"`
+	TipAmount         float64                `protobuf:"fixed64,14,opt,name=TipAmount,proto3" json:"TipAmount,omitempty"`
+	TipPercent        float64                `protobuf:"fixed64,15,opt,name=TipPercent,proto3" json:"TipPercent,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_proto_cart_proto_msgTypes[3]
+	mi := &file_proto_cart_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +272,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[3]
+	mi := &file_proto_cart_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,110 +285,1016 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{3}
+	return file_proto_cart_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateOrderRequest) GetStatus() string {
+func (x *CreateOrderRequest) GetAddress() string {
 	if x != nil {
-		return x.Status
+		return x.Address
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetAddress() string {
+func (x *CreateOrderRequest) GetApartmentOrOffice() string {
 	if x != nil {
-		return x.Address
+		return x.ApartmentOrOffice
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetApartmentOrOffice() string {
+func (x *CreateOrderRequest) GetIntercom() string {
 	if x != nil {
-		return x.ApartmentOrOffice
+		return x.Intercom
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetEntrance() string {
+	if x != nil {
+		return x.Entrance
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetFloor() string {
+	if x != nil {
+		return x.Floor
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetCourierComment() string {
+	if x != nil {
+		return x.CourierComment
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetLeaveAtDoor() bool {
+	if x != nil {
+		return x.LeaveAtDoor
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetFinalPrice() float64 {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetCart() *CartResponse {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetTipAmount() float64 {
+	if x != nil {
+		return x.TipAmount
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetTipPercent() float64 {
+	if x != nil {
+		return x.TipPercent
+	}
+	return 0
+}
+
+type PreviewPromoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode     string                 `protobuf:"bytes,2,opt,name=PromoCode,proto3" json:"PromoCode,omitempty"`
+	Cart          *CartResponse          `protobuf:"bytes,3,opt,name=Cart,proto3" json:"Cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewPromoRequest) Reset() {
+	*x = PreviewPromoRequest{}
+	mi := &file_proto_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewPromoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewPromoRequest) ProtoMessage() {}
+
+func (x *PreviewPromoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewPromoRequest.ProtoReflect.Descriptor instead.
+func (*PreviewPromoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PreviewPromoRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *PreviewPromoRequest) GetCart() *CartResponse {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+type PromoPreviewResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode      string                 `protobuf:"bytes,1,opt,name=PromoCode,proto3" json:"PromoCode,omitempty"`
+	Cart           *CartResponse          `protobuf:"bytes,2,opt,name=Cart,proto3" json:"Cart,omitempty"`
+	PriceBreakdown *PriceBreakdown        `protobuf:"bytes,3,opt,name=PriceBreakdown,proto3" json:"PriceBreakdown,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PromoPreviewResponse) Reset() {
+	*x = PromoPreviewResponse{}
+	mi := &file_proto_cart_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoPreviewResponse) ProtoMessage() {}
+
+func (x *PromoPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoPreviewResponse.ProtoReflect.Descriptor instead.
+func (*PromoPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PromoPreviewResponse) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetIntercom() string {
-	if x != nil {
-		return x.Intercom
-	}
-	return ""
+func (x *PromoPreviewResponse) GetCart() *CartResponse {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *PromoPreviewResponse) GetPriceBreakdown() *PriceBreakdown {
+	if x != nil {
+		return x.PriceBreakdown
+	}
+	return nil
+}
+
+type GetOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Statuses      []string               `protobuf:"bytes,4,rep,name=Statuses,proto3" json:"Statuses,omitempty"`
+	RestaurantId  string                 `protobuf:"bytes,5,opt,name=RestaurantId,proto3" json:"RestaurantId,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=From,proto3" json:"From,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=To,proto3" json:"To,omitempty"`
+	MinTotal      float64                `protobuf:"fixed64,8,opt,name=MinTotal,proto3" json:"MinTotal,omitempty"`
+	MaxTotal      float64                `protobuf:"fixed64,9,opt,name=MaxTotal,proto3" json:"MaxTotal,omitempty"`
+	OldestFirst   bool                   `protobuf:"varint,10,opt,name=OldestFirst,proto3" json:"OldestFirst,omitempty"`
+	Cursor        string                 `protobuf:"bytes,11,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersRequest) Reset() {
+	*x = GetOrdersRequest{}
+	mi := &file_proto_cart_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersRequest) ProtoMessage() {}
+
+func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrdersRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *GetOrdersRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetOrdersRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetOrdersRequest) GetMinTotal() float64 {
+	if x != nil {
+		return x.MinTotal
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetMaxTotal() float64 {
+	if x != nil {
+		return x.MaxTotal
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetOldestFirst() bool {
+	if x != nil {
+		return x.OldestFirst
+	}
+	return false
+}
+
+func (x *GetOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetOrderByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderByIdRequest) Reset() {
+	*x = GetOrderByIdRequest{}
+	mi := &file_proto_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderByIdRequest) ProtoMessage() {}
+
+func (x *GetOrderByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderByIdRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderByIdRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ConfirmPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  []byte                 `protobuf:"bytes,4,opt,name=Notification,proto3" json:"Notification,omitempty"`
+	Signature     string                 `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPaymentRequest) Reset() {
+	*x = ConfirmPaymentRequest{}
+	mi := &file_proto_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPaymentRequest) ProtoMessage() {}
+
+func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfirmPaymentRequest) GetNotification() []byte {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *ConfirmPaymentRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_cart_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateTipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	TipAmount     float64                `protobuf:"fixed64,3,opt,name=TipAmount,proto3" json:"TipAmount,omitempty"`
+	TipPercent    float64                `protobuf:"fixed64,4,opt,name=TipPercent,proto3" json:"TipPercent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTipRequest) Reset() {
+	*x = UpdateTipRequest{}
+	mi := &file_proto_cart_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTipRequest) ProtoMessage() {}
+
+func (x *UpdateTipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTipRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTipRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateTipRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateTipRequest) GetTipAmount() float64 {
+	if x != nil {
+		return x.TipAmount
+	}
+	return 0
+}
+
+func (x *UpdateTipRequest) GetTipPercent() float64 {
+	if x != nil {
+		return x.TipPercent
+	}
+	return 0
+}
+
+type RestaurantOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestaurantOrdersRequest) Reset() {
+	*x = RestaurantOrdersRequest{}
+	mi := &file_proto_cart_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestaurantOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestaurantOrdersRequest) ProtoMessage() {}
+
+func (x *RestaurantOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestaurantOrdersRequest.ProtoReflect.Descriptor instead.
+func (*RestaurantOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{12}
+}
+
+type RestaurantOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestaurantOrderStatusRequest) Reset() {
+	*x = RestaurantOrderStatusRequest{}
+	mi := &file_proto_cart_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestaurantOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestaurantOrderStatusRequest) ProtoMessage() {}
+
+func (x *RestaurantOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestaurantOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*RestaurantOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RestaurantOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RestaurantOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RestaurantOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CourierAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Available     bool                   `protobuf:"varint,2,opt,name=Available,proto3" json:"Available,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,3,opt,name=Latitude,proto3,oneof" json:"Latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,4,opt,name=Longitude,proto3,oneof" json:"Longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierAvailabilityRequest) Reset() {
+	*x = CourierAvailabilityRequest{}
+	mi := &file_proto_cart_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierAvailabilityRequest) ProtoMessage() {}
+
+func (x *CourierAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CourierAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CourierAvailabilityRequest) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CourierAvailabilityRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *CourierAvailabilityRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+type CourierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Available     bool                   `protobuf:"varint,3,opt,name=Available,proto3" json:"Available,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,4,opt,name=Latitude,proto3,oneof" json:"Latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,5,opt,name=Longitude,proto3,oneof" json:"Longitude,omitempty"`
+	ActiveOrders  int32                  `protobuf:"varint,6,opt,name=ActiveOrders,proto3" json:"ActiveOrders,omitempty"`
+	MaxOrders     int32                  `protobuf:"varint,7,opt,name=MaxOrders,proto3" json:"MaxOrders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierResponse) Reset() {
+	*x = CourierResponse{}
+	mi := &file_proto_cart_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierResponse) ProtoMessage() {}
+
+func (x *CourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierResponse.ProtoReflect.Descriptor instead.
+func (*CourierResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CourierResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CourierResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CourierResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CourierResponse) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *CourierResponse) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *CourierResponse) GetActiveOrders() int32 {
+	if x != nil {
+		return x.ActiveOrders
+	}
+	return 0
+}
+
+func (x *CourierResponse) GetMaxOrders() int32 {
+	if x != nil {
+		return x.MaxOrders
+	}
+	return 0
+}
+
+type CourierOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierOrdersRequest) Reset() {
+	*x = CourierOrdersRequest{}
+	mi := &file_proto_cart_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierOrdersRequest) ProtoMessage() {}
+
+func (x *CourierOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierOrdersRequest.ProtoReflect.Descriptor instead.
+func (*CourierOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{16}
+}
+
+type CourierOrderActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=Action,proto3" json:"Action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierOrderActionRequest) Reset() {
+	*x = CourierOrderActionRequest{}
+	mi := &file_proto_cart_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierOrderActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierOrderActionRequest) ProtoMessage() {}
+
+func (x *CourierOrderActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierOrderActionRequest.ProtoReflect.Descriptor instead.
+func (*CourierOrderActionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CourierOrderActionRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CourierOrderActionRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type ReorderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	Replace       bool                   `protobuf:"varint,4,opt,name=Replace,proto3" json:"Replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderRequest) Reset() {
+	*x = ReorderRequest{}
+	mi := &file_proto_cart_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderRequest) ProtoMessage() {}
+
+func (x *ReorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderRequest.ProtoReflect.Descriptor instead.
+func (*ReorderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReorderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReorderRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+type ReorderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Amount        int32                  `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	OldPrice      float64                `protobuf:"fixed64,4,opt,name=OldPrice,proto3" json:"OldPrice,omitempty"`
+	NewPrice      float64                `protobuf:"fixed64,5,opt,name=NewPrice,proto3" json:"NewPrice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderItem) Reset() {
+	*x = ReorderItem{}
+	mi := &file_proto_cart_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *CreateOrderRequest) GetEntrance() string {
+func (*ReorderItem) ProtoMessage() {}
+
+func (x *ReorderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[19]
 	if x != nil {
-		return x.Entrance
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateOrderRequest) GetFloor() string {
-	if x != nil {
-		return x.Floor
-	}
-	return ""
+// Deprecated: Use ReorderItem.ProtoReflect.Descriptor instead.
+func (*ReorderItem) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CreateOrderRequest) GetCourierComment() string {
+func (x *ReorderItem) GetId() string {
 	if x != nil {
-		return x.CourierComment
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetLeaveAtDoor() bool {
+func (x *ReorderItem) GetName() string {
 	if x != nil {
-		return x.LeaveAtDoor
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *CreateOrderRequest) GetFinalPrice() float64 {
+func (x *ReorderItem) GetAmount() int32 {
 	if x != nil {
-		return x.FinalPrice
+		return x.Amount
 	}
 	return 0
 }
 
-func (x *CreateOrderRequest) GetCart() *CartResponse {
+func (x *ReorderItem) GetOldPrice() float64 {
 	if x != nil {
-		return x.Cart
+		return x.OldPrice
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateOrderRequest) GetLogin() string {
+func (x *ReorderItem) GetNewPrice() float64 {
 	if x != nil {
-		return x.Login
+		return x.NewPrice
 	}
-	return ""
+	return 0
 }
 
-type GetOrdersRequest struct {
+type ReorderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Cart          *CartResponse          `protobuf:"bytes,1,opt,name=Cart,proto3" json:"Cart,omitempty"`
+	Dropped       []*ReorderItem         `protobuf:"bytes,2,rep,name=Dropped,proto3" json:"Dropped,omitempty"`
+	Repriced      []*ReorderItem         `protobuf:"bytes,3,rep,name=Repriced,proto3" json:"Repriced,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersRequest) Reset() {
-	*x = GetOrdersRequest{}
-	mi := &file_proto_cart_proto_msgTypes[4]
+func (x *ReorderResponse) Reset() {
+	*x = ReorderResponse{}
+	mi := &file_proto_cart_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersRequest) String() string {
+func (x *ReorderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersRequest) ProtoMessage() {}
+func (*ReorderResponse) ProtoMessage() {}
 
-func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[4]
+func (x *ReorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,55 +1305,54 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ReorderResponse.ProtoReflect.Descriptor instead.
+func (*ReorderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetOrdersRequest) GetUserId() string {
+func (x *ReorderResponse) GetCart() *CartResponse {
 	if x != nil {
-		return x.UserId
+		return x.Cart
 	}
-	return ""
+	return nil
 }
 
-func (x *GetOrdersRequest) GetCount() int32 {
+func (x *ReorderResponse) GetDropped() []*ReorderItem {
 	if x != nil {
-		return x.Count
+		return x.Dropped
 	}
-	return 0
+	return nil
 }
 
-func (x *GetOrdersRequest) GetOffset() int32 {
+func (x *ReorderResponse) GetRepriced() []*ReorderItem {
 	if x != nil {
-		return x.Offset
+		return x.Repriced
 	}
-	return 0
+	return nil
 }
 
-type GetOrderByIdRequest struct {
+type WatchOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderByIdRequest) Reset() {
-	*x = GetOrderByIdRequest{}
-	mi := &file_proto_cart_proto_msgTypes[5]
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_proto_cart_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderByIdRequest) String() string {
+func (x *WatchOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderByIdRequest) ProtoMessage() {}
+func (*WatchOrderRequest) ProtoMessage() {}
 
-func (x *GetOrderByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[5]
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,47 +1363,41 @@ func (x *GetOrderByIdRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderByIdRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderByIdRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GetOrderByIdRequest) GetOrderId() string {
+func (x *WatchOrderRequest) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
-func (x *GetOrderByIdRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-type UpdateOrderStatusRequest struct {
+type OrderUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+	Event         *OrderStatusEvent      `protobuf:"bytes,2,opt,name=Event,proto3" json:"Event,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusRequest) Reset() {
-	*x = UpdateOrderStatusRequest{}
-	mi := &file_proto_cart_proto_msgTypes[6]
+func (x *OrderUpdate) Reset() {
+	*x = OrderUpdate{}
+	mi := &file_proto_cart_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusRequest) String() string {
+func (x *OrderUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusRequest) ProtoMessage() {}
+func (*OrderUpdate) ProtoMessage() {}
 
-func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[6]
+func (x *OrderUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,18 +1408,25 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use OrderUpdate.ProtoReflect.Descriptor instead.
+func (*OrderUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpdateOrderStatusRequest) GetOrderId() string {
+func (x *OrderUpdate) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
+func (x *OrderUpdate) GetEvent() *OrderStatusEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
 type CartResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	RestaurantId   string                 `protobuf:"bytes,1,opt,name=RestaurantId,proto3" json:"RestaurantId,omitempty"`
@@ -471,7 +1439,7 @@ type CartResponse struct {
 
 func (x *CartResponse) Reset() {
 	*x = CartResponse{}
-	mi := &file_proto_cart_proto_msgTypes[7]
+	mi := &file_proto_cart_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -483,7 +1451,7 @@ func (x *CartResponse) String() string {
 func (*CartResponse) ProtoMessage() {}
 
 func (x *CartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[7]
+	mi := &file_proto_cart_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +1464,7 @@ func (x *CartResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
 func (*CartResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{7}
+	return file_proto_cart_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CartResponse) GetRestaurantId() string {
@@ -541,7 +1509,7 @@ type CartItem struct {
 
 func (x *CartItem) Reset() {
 	*x = CartItem{}
-	mi := &file_proto_cart_proto_msgTypes[8]
+	mi := &file_proto_cart_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -553,7 +1521,7 @@ func (x *CartItem) String() string {
 func (*CartItem) ProtoMessage() {}
 
 func (x *CartItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[8]
+	mi := &file_proto_cart_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +1534,7 @@ func (x *CartItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
 func (*CartItem) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{8}
+	return file_proto_cart_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CartItem) GetId() string {
@@ -626,13 +1594,20 @@ type OrderResponse struct {
 	LeaveAtDoor       bool                   `protobuf:"varint,11,opt,name=LeaveAtDoor,proto3" json:"LeaveAtDoor,omitempty"`
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
 	FinalPrice        float64                `protobuf:"fixed64,13,opt,name=FinalPrice,proto3" json:"FinalPrice,omitempty"`
+	PriceBreakdown    *PriceBreakdown        `protobuf:"bytes,14,opt,name=PriceBreakdown,proto3" json:"PriceBreakdown,omitempty"`
+	Timeline          []*OrderStatusEvent    `protobuf:"bytes,15,rep,name=Timeline,proto3" json:"Timeline,omitempty"`
+	PaymentId         string                 `protobuf:"bytes,16,opt,name=PaymentId,proto3" json:"PaymentId,omitempty"`
+	PaymentUrl        string                 `protobuf:"bytes,17,opt,name=PaymentUrl,proto3" json:"PaymentUrl,omitempty"`
+	PromoCode         string                 `protobuf:"bytes,18,opt,name=PromoCode,proto3" json:"PromoCode,omitempty"`
+	DeliverAt         *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=DeliverAt,proto3" json:"DeliverAt,omitempty"`
+	Eta               *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=Eta,proto3" json:"Eta,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *OrderResponse) Reset() {
 	*x = OrderResponse{}
-	mi := &file_proto_cart_proto_msgTypes[9]
+	mi := &file_proto_cart_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -644,7 +1619,7 @@ func (x *OrderResponse) String() string {
 func (*OrderResponse) ProtoMessage() {}
 
 func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[9]
+	mi := &file_proto_cart_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +1632,7 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
 func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{9}
+	return file_proto_cart_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *OrderResponse) GetId() string {
@@ -751,16 +1726,226 @@ func (x *OrderResponse) GetFinalPrice() float64 {
 	return 0
 }
 
+func (x *OrderResponse) GetPriceBreakdown() *PriceBreakdown {
+	if x != nil {
+		return x.PriceBreakdown
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetTimeline() []*OrderStatusEvent {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetPaymentUrl() string {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetEta() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+type OrderStatusEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=Actor,proto3" json:"Actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Eta           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=Eta,proto3" json:"Eta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusEvent) Reset() {
+	*x = OrderStatusEvent{}
+	mi := &file_proto_cart_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusEvent) ProtoMessage() {}
+
+func (x *OrderStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusEvent.ProtoReflect.Descriptor instead.
+func (*OrderStatusEvent) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OrderStatusEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderStatusEvent) GetEta() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+type PriceBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtotal      float64                `protobuf:"fixed64,1,opt,name=Subtotal,proto3" json:"Subtotal,omitempty"`
+	DeliveryFee   float64                `protobuf:"fixed64,2,opt,name=DeliveryFee,proto3" json:"DeliveryFee,omitempty"`
+	ServiceFee    float64                `protobuf:"fixed64,3,opt,name=ServiceFee,proto3" json:"ServiceFee,omitempty"`
+	Discount      float64                `protobuf:"fixed64,4,opt,name=Discount,proto3" json:"Discount,omitempty"`
+	Total         float64                `protobuf:"fixed64,5,opt,name=Total,proto3" json:"Total,omitempty"`
+	Tip           float64                `protobuf:"fixed64,6,opt,name=Tip,proto3" json:"Tip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceBreakdown) Reset() {
+	*x = PriceBreakdown{}
+	mi := &file_proto_cart_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceBreakdown) ProtoMessage() {}
+
+func (x *PriceBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceBreakdown.ProtoReflect.Descriptor instead.
+func (*PriceBreakdown) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PriceBreakdown) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *PriceBreakdown) GetDeliveryFee() float64 {
+	if x != nil {
+		return x.DeliveryFee
+	}
+	return 0
+}
+
+func (x *PriceBreakdown) GetServiceFee() float64 {
+	if x != nil {
+		return x.ServiceFee
+	}
+	return 0
+}
+
+func (x *PriceBreakdown) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *PriceBreakdown) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PriceBreakdown) GetTip() float64 {
+	if x != nil {
+		return x.Tip
+	}
+	return 0
+}
+
 type OrderListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*OrderResponse       `protobuf:"bytes,1,rep,name=Orders,proto3" json:"Orders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderListResponse) Reset() {
 	*x = OrderListResponse{}
-	mi := &file_proto_cart_proto_msgTypes[10]
+	mi := &file_proto_cart_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -772,7 +1957,7 @@ func (x *OrderListResponse) String() string {
 func (*OrderListResponse) ProtoMessage() {}
 
 func (x *OrderListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[10]
+	mi := &file_proto_cart_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -785,7 +1970,7 @@ func (x *OrderListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderListResponse.ProtoReflect.Descriptor instead.
 func (*OrderListResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{10}
+	return file_proto_cart_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *OrderListResponse) GetOrders() []*OrderResponse {
@@ -795,22 +1980,32 @@ func (x *OrderListResponse) GetOrders() []*OrderResponse {
 	return nil
 }
 
+func (x *OrderListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 var File_proto_cart_proto protoreflect.FileDescriptor
 
 const file_proto_cart_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/cart.proto\x12\x04cart\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"&\n" +
-	"\x0eGetCartRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\"\x8b\x01\n" +
-	"\x15UpdateQuantityRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\x12\x1c\n" +
+	"\x10proto/cart.proto\x12\x04cart\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"*\n" +
+	"\x0eGetCartRequest\x12\x18\n" +
+	"\aGuestId\x18\x01 \x01(\tR\aGuestId\"\xa9\x01\n" +
+	"\x15UpdateQuantityRequest\x12\x18\n" +
+	"\aGuestId\x18\x01 \x01(\tR\aGuestId\x12\x1c\n" +
 	"\tProductId\x18\x02 \x01(\tR\tProductId\x12\"\n" +
 	"\fRestaurantId\x18\x03 \x01(\tR\fRestaurantId\x12\x1a\n" +
-	"\bQuantity\x18\x04 \x01(\x05R\bQuantity\"(\n" +
-	"\x10ClearCartRequest\x12\x14\n" +
-	"\x05Login\x18\x01 \x01(\tR\x05Login\"\xea\x02\n" +
-	"\x12CreateOrderRequest\x12\x16\n" +
-	"\x06Status\x18\x01 \x01(\tR\x06Status\x12\x18\n" +
+	"\bQuantity\x18\x04 \x01(\x05R\bQuantity\x12\x18\n" +
+	"\aReplace\x18\x05 \x01(\bR\aReplace\",\n" +
+	"\x10ClearCartRequest\x12\x18\n" +
+	"\aGuestId\x18\x01 \x01(\tR\aGuestId\"Q\n" +
+	"\x15MergeGuestCartRequest\x12\x18\n" +
+	"\aGuestId\x18\x01 \x01(\tR\aGuestId\x12\x18\n" +
+	"\aReplace\x18\x03 \x01(\bR\aReplaceJ\x04\b\x02\x10\x03\"\xde\x03\n" +
+	"\x12CreateOrderRequest\x12\x18\n" +
 	"\aAddress\x18\x02 \x01(\tR\aAddress\x12,\n" +
 	"\x11ApartmentOrOffice\x18\x03 \x01(\tR\x11ApartmentOrOffice\x12\x1a\n" +
 	"\bIntercom\x18\x04 \x01(\tR\bIntercom\x12\x1a\n" +
@@ -822,17 +2017,90 @@ const file_proto_cart_proto_rawDesc = "" +
 	"FinalPrice\x18\t \x01(\x01R\n" +
 	"FinalPrice\x12&\n" +
 	"\x04Cart\x18\n" +
-	" \x01(\v2\x12.cart.CartResponseR\x04Cart\x12\x14\n" +
-	"\x05Login\x18\v \x01(\tR\x05Login\"X\n" +
-	"\x10GetOrdersRequest\x12\x16\n" +
-	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x14\n" +
-	"\x05Count\x18\x02 \x01(\x05R\x05Count\x12\x16\n" +
-	"\x06Offset\x18\x03 \x01(\x05R\x06Offset\"G\n" +
+	" \x01(\v2\x12.cart.CartResponseR\x04Cart\x12\x1c\n" +
+	"\tPromoCode\x18\f \x01(\tR\tPromoCode\x128\n" +
+	"\tDeliverAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tDeliverAt\x12\x1c\n" +
+	"\tTipAmount\x18\x0e \x01(\x01R\tTipAmount\x12\x1e\n" +
+	"\n" +
+	"TipPercent\x18\x0f \x01(\x01R\n" +
+	"TipPercentJ\x04\b\x01\x10\x02J\x04\b\v\x10\f\"a\n" +
+	"\x13PreviewPromoRequest\x12\x1c\n" +
+	"\tPromoCode\x18\x02 \x01(\tR\tPromoCode\x12&\n" +
+	"\x04Cart\x18\x03 \x01(\v2\x12.cart.CartResponseR\x04CartJ\x04\b\x01\x10\x02\"\x9a\x01\n" +
+	"\x14PromoPreviewResponse\x12\x1c\n" +
+	"\tPromoCode\x18\x01 \x01(\tR\tPromoCode\x12&\n" +
+	"\x04Cart\x18\x02 \x01(\v2\x12.cart.CartResponseR\x04Cart\x12<\n" +
+	"\x0ePriceBreakdown\x18\x03 \x01(\v2\x14.cart.PriceBreakdownR\x0ePriceBreakdown\"\xc2\x02\n" +
+	"\x10GetOrdersRequest\x12\x14\n" +
+	"\x05Count\x18\x02 \x01(\x05R\x05Count\x12\x1a\n" +
+	"\bStatuses\x18\x04 \x03(\tR\bStatuses\x12\"\n" +
+	"\fRestaurantId\x18\x05 \x01(\tR\fRestaurantId\x12.\n" +
+	"\x04From\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04From\x12*\n" +
+	"\x02To\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02To\x12\x1a\n" +
+	"\bMinTotal\x18\b \x01(\x01R\bMinTotal\x12\x1a\n" +
+	"\bMaxTotal\x18\t \x01(\x01R\bMaxTotal\x12 \n" +
+	"\vOldestFirst\x18\n" +
+	" \x01(\bR\vOldestFirst\x12\x16\n" +
+	"\x06Cursor\x18\v \x01(\tR\x06CursorJ\x04\b\x01\x10\x02J\x04\b\x03\x10\x04\"5\n" +
 	"\x13GetOrderByIdRequest\x12\x18\n" +
+	"\aOrderId\x18\x01 \x01(\tR\aOrderIdJ\x04\b\x02\x10\x03\"k\n" +
+	"\x15ConfirmPaymentRequest\x12\"\n" +
+	"\fNotification\x18\x04 \x01(\fR\fNotification\x12\x1c\n" +
+	"\tSignature\x18\x05 \x01(\tR\tSignatureJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"L\n" +
+	"\x12CancelOrderRequest\x12\x18\n" +
 	"\aOrderId\x18\x01 \x01(\tR\aOrderId\x12\x16\n" +
-	"\x06UserId\x18\x02 \x01(\tR\x06UserId\"4\n" +
-	"\x18UpdateOrderStatusRequest\x12\x18\n" +
-	"\aOrderId\x18\x01 \x01(\tR\aOrderId\"\xa2\x01\n" +
+	"\x06Reason\x18\x03 \x01(\tR\x06ReasonJ\x04\b\x02\x10\x03\"p\n" +
+	"\x10UpdateTipRequest\x12\x18\n" +
+	"\aOrderId\x18\x01 \x01(\tR\aOrderId\x12\x1c\n" +
+	"\tTipAmount\x18\x03 \x01(\x01R\tTipAmount\x12\x1e\n" +
+	"\n" +
+	"TipPercent\x18\x04 \x01(\x01R\n" +
+	"TipPercentJ\x04\b\x02\x10\x03\"\x1f\n" +
+	"\x17RestaurantOrdersRequestJ\x04\b\x01\x10\x02\"n\n" +
+	"\x1cRestaurantOrderStatusRequest\x12\x18\n" +
+	"\aOrderId\x18\x02 \x01(\tR\aOrderId\x12\x16\n" +
+	"\x06Status\x18\x03 \x01(\tR\x06Status\x12\x16\n" +
+	"\x06Reason\x18\x04 \x01(\tR\x06ReasonJ\x04\b\x01\x10\x02\"\x9f\x01\n" +
+	"\x1aCourierAvailabilityRequest\x12\x1c\n" +
+	"\tAvailable\x18\x02 \x01(\bR\tAvailable\x12\x1f\n" +
+	"\bLatitude\x18\x03 \x01(\x01H\x00R\bLatitude\x88\x01\x01\x12!\n" +
+	"\tLongitude\x18\x04 \x01(\x01H\x01R\tLongitude\x88\x01\x01B\v\n" +
+	"\t_LatitudeB\f\n" +
+	"\n" +
+	"_LongitudeJ\x04\b\x01\x10\x02\"\xf4\x01\n" +
+	"\x0fCourierResponse\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1c\n" +
+	"\tAvailable\x18\x03 \x01(\bR\tAvailable\x12\x1f\n" +
+	"\bLatitude\x18\x04 \x01(\x01H\x00R\bLatitude\x88\x01\x01\x12!\n" +
+	"\tLongitude\x18\x05 \x01(\x01H\x01R\tLongitude\x88\x01\x01\x12\"\n" +
+	"\fActiveOrders\x18\x06 \x01(\x05R\fActiveOrders\x12\x1c\n" +
+	"\tMaxOrders\x18\a \x01(\x05R\tMaxOrdersB\v\n" +
+	"\t_LatitudeB\f\n" +
+	"\n" +
+	"_Longitude\"\x1c\n" +
+	"\x14CourierOrdersRequestJ\x04\b\x01\x10\x02\"S\n" +
+	"\x19CourierOrderActionRequest\x12\x18\n" +
+	"\aOrderId\x18\x02 \x01(\tR\aOrderId\x12\x16\n" +
+	"\x06Action\x18\x03 \x01(\tR\x06ActionJ\x04\b\x01\x10\x02\"P\n" +
+	"\x0eReorderRequest\x12\x18\n" +
+	"\aOrderId\x18\x01 \x01(\tR\aOrderId\x12\x18\n" +
+	"\aReplace\x18\x04 \x01(\bR\aReplaceJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"\x81\x01\n" +
+	"\vReorderItem\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x16\n" +
+	"\x06Amount\x18\x03 \x01(\x05R\x06Amount\x12\x1a\n" +
+	"\bOldPrice\x18\x04 \x01(\x01R\bOldPrice\x12\x1a\n" +
+	"\bNewPrice\x18\x05 \x01(\x01R\bNewPrice\"\x95\x01\n" +
+	"\x0fReorderResponse\x12&\n" +
+	"\x04Cart\x18\x01 \x01(\v2\x12.cart.CartResponseR\x04Cart\x12+\n" +
+	"\aDropped\x18\x02 \x03(\v2\x11.cart.ReorderItemR\aDropped\x12-\n" +
+	"\bRepriced\x18\x03 \x03(\v2\x11.cart.ReorderItemR\bRepriced\"3\n" +
+	"\x11WatchOrderRequest\x12\x18\n" +
+	"\aOrderId\x18\x01 \x01(\tR\aOrderIdJ\x04\b\x02\x10\x03\"U\n" +
+	"\vOrderUpdate\x12\x18\n" +
+	"\aOrderId\x18\x01 \x01(\tR\aOrderId\x12,\n" +
+	"\x05Event\x18\x02 \x01(\v2\x16.cart.OrderStatusEventR\x05Event\"\xa2\x01\n" +
 	"\fCartResponse\x12\"\n" +
 	"\fRestaurantId\x18\x01 \x01(\tR\fRestaurantId\x12&\n" +
 	"\x0eRestaurantName\x18\x02 \x01(\tR\x0eRestaurantName\x12*\n" +
@@ -844,7 +2112,7 @@ const file_proto_cart_proto_rawDesc = "" +
 	"\x05Price\x18\x03 \x01(\x01R\x05Price\x12\x1a\n" +
 	"\bImageUrl\x18\x04 \x01(\tR\bImageUrl\x12\x16\n" +
 	"\x06Weight\x18\x05 \x01(\x05R\x06Weight\x12\x16\n" +
-	"\x06Amount\x18\x06 \x01(\x05R\x06Amount\"\xc3\x03\n" +
+	"\x06Amount\x18\x06 \x01(\x05R\x06Amount\"\xf9\x05\n" +
 	"\rOrderResponse\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x16\n" +
 	"\x06UserId\x18\x02 \x01(\tR\x06UserId\x12\x16\n" +
@@ -861,17 +2129,56 @@ const file_proto_cart_proto_rawDesc = "" +
 	"\tCreatedAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12\x1e\n" +
 	"\n" +
 	"FinalPrice\x18\r \x01(\x01R\n" +
-	"FinalPrice\"@\n" +
+	"FinalPrice\x12<\n" +
+	"\x0ePriceBreakdown\x18\x0e \x01(\v2\x14.cart.PriceBreakdownR\x0ePriceBreakdown\x122\n" +
+	"\bTimeline\x18\x0f \x03(\v2\x16.cart.OrderStatusEventR\bTimeline\x12\x1c\n" +
+	"\tPaymentId\x18\x10 \x01(\tR\tPaymentId\x12\x1e\n" +
+	"\n" +
+	"PaymentUrl\x18\x11 \x01(\tR\n" +
+	"PaymentUrl\x12\x1c\n" +
+	"\tPromoCode\x18\x12 \x01(\tR\tPromoCode\x128\n" +
+	"\tDeliverAt\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tDeliverAt\x12,\n" +
+	"\x03Eta\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\x03Eta\"\xc0\x01\n" +
+	"\x10OrderStatusEvent\x12\x16\n" +
+	"\x06Status\x18\x01 \x01(\tR\x06Status\x12\x14\n" +
+	"\x05Actor\x18\x02 \x01(\tR\x05Actor\x12\x16\n" +
+	"\x06Reason\x18\x03 \x01(\tR\x06Reason\x128\n" +
+	"\tCreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12,\n" +
+	"\x03Eta\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x03Eta\"\xb2\x01\n" +
+	"\x0ePriceBreakdown\x12\x1a\n" +
+	"\bSubtotal\x18\x01 \x01(\x01R\bSubtotal\x12 \n" +
+	"\vDeliveryFee\x18\x02 \x01(\x01R\vDeliveryFee\x12\x1e\n" +
+	"\n" +
+	"ServiceFee\x18\x03 \x01(\x01R\n" +
+	"ServiceFee\x12\x1a\n" +
+	"\bDiscount\x18\x04 \x01(\x01R\bDiscount\x12\x14\n" +
+	"\x05Total\x18\x05 \x01(\x01R\x05Total\x12\x10\n" +
+	"\x03Tip\x18\x06 \x01(\x01R\x03Tip\"`\n" +
 	"\x11OrderListResponse\x12+\n" +
-	"\x06Orders\x18\x01 \x03(\v2\x13.cart.OrderResponseR\x06Orders2\xe1\x03\n" +
+	"\x06Orders\x18\x01 \x03(\v2\x13.cart.OrderResponseR\x06Orders\x12\x1e\n" +
+	"\n" +
+	"NextCursor\x18\x02 \x01(\tR\n" +
+	"NextCursor2\xf7\t\n" +
 	"\vCartService\x125\n" +
 	"\aGetCart\x12\x14.cart.GetCartRequest\x1a\x12.cart.CartResponse\"\x00\x12K\n" +
 	"\x12UpdateItemQuantity\x12\x1b.cart.UpdateQuantityRequest\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
-	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x16.google.protobuf.Empty\"\x00\x12>\n" +
-	"\vCreateOrder\x12\x18.cart.CreateOrderRequest\x1a\x13.cart.OrderResponse\"\x00\x12>\n" +
+	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x16.google.protobuf.Empty\"\x00\x12G\n" +
+	"\x0eMergeGuestCart\x12\x1b.cart.MergeGuestCartRequest\x1a\x16.google.protobuf.Empty\"\x00\x12>\n" +
+	"\vCreateOrder\x12\x18.cart.CreateOrderRequest\x1a\x13.cart.OrderResponse\"\x00\x12G\n" +
+	"\fPreviewPromo\x12\x19.cart.PreviewPromoRequest\x1a\x1a.cart.PromoPreviewResponse\"\x00\x12>\n" +
 	"\tGetOrders\x12\x16.cart.GetOrdersRequest\x1a\x17.cart.OrderListResponse\"\x00\x12@\n" +
-	"\fGetOrderById\x12\x19.cart.GetOrderByIdRequest\x1a\x13.cart.OrderResponse\"\x00\x12M\n" +
-	"\x11UpdateOrderStatus\x12\x1e.cart.UpdateOrderStatusRequest\x1a\x16.google.protobuf.Empty\"\x00B'Z%./internal/pkg/cart/delivery/grpc/genb\x06proto3"
+	"\fGetOrderById\x12\x19.cart.GetOrderByIdRequest\x1a\x13.cart.OrderResponse\"\x00\x12G\n" +
+	"\x0eConfirmPayment\x12\x1b.cart.ConfirmPaymentRequest\x1a\x16.google.protobuf.Empty\"\x00\x12>\n" +
+	"\vCancelOrder\x12\x18.cart.CancelOrderRequest\x1a\x13.cart.OrderResponse\"\x00\x128\n" +
+	"\aReorder\x12\x14.cart.ReorderRequest\x1a\x15.cart.ReorderResponse\"\x00\x12<\n" +
+	"\n" +
+	"WatchOrder\x12\x17.cart.WatchOrderRequest\x1a\x11.cart.OrderUpdate\"\x000\x01\x12O\n" +
+	"\x13GetRestaurantOrders\x12\x1d.cart.RestaurantOrdersRequest\x1a\x17.cart.OrderListResponse\"\x00\x12U\n" +
+	"\x18SetRestaurantOrderStatus\x12\".cart.RestaurantOrderStatusRequest\x1a\x13.cart.OrderResponse\"\x00\x12S\n" +
+	"\x16SetCourierAvailability\x12 .cart.CourierAvailabilityRequest\x1a\x15.cart.CourierResponse\"\x00\x12I\n" +
+	"\x10GetCourierOrders\x12\x1a.cart.CourierOrdersRequest\x1a\x17.cart.OrderListResponse\"\x00\x12L\n" +
+	"\x12CourierOrderAction\x12\x1f.cart.CourierOrderActionRequest\x1a\x13.cart.OrderResponse\"\x00\x12:\n" +
+	"\tUpdateTip\x12\x16.cart.UpdateTipRequest\x1a\x13.cart.OrderResponse\"\x00B'Z%./internal/pkg/cart/delivery/grpc/genb\x06proto3"
 
 var (
 	file_proto_cart_proto_rawDescOnce sync.Once
@@ -885,47 +2192,103 @@ func file_proto_cart_proto_rawDescGZIP() []byte {
 	return file_proto_cart_proto_rawDescData
 }
 
-var file_proto_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_proto_cart_proto_goTypes = []any{
-	(*GetCartRequest)(nil),           // 0: cart.GetCartRequest
-	(*UpdateQuantityRequest)(nil),    // 1: cart.UpdateQuantityRequest
-	(*ClearCartRequest)(nil),         // 2: cart.ClearCartRequest
-	(*CreateOrderRequest)(nil),       // 3: cart.CreateOrderRequest
-	(*GetOrdersRequest)(nil),         // 4: cart.GetOrdersRequest
-	(*GetOrderByIdRequest)(nil),      // 5: cart.GetOrderByIdRequest
-	(*UpdateOrderStatusRequest)(nil), // 6: cart.UpdateOrderStatusRequest
-	(*CartResponse)(nil),             // 7: cart.CartResponse
-	(*CartItem)(nil),                 // 8: cart.CartItem
-	(*OrderResponse)(nil),            // 9: cart.OrderResponse
-	(*OrderListResponse)(nil),        // 10: cart.OrderListResponse
-	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),            // 12: google.protobuf.Empty
+	(*GetCartRequest)(nil),               // 0: cart.GetCartRequest
+	(*UpdateQuantityRequest)(nil),        // 1: cart.UpdateQuantityRequest
+	(*ClearCartRequest)(nil),             // 2: cart.ClearCartRequest
+	(*MergeGuestCartRequest)(nil),        // 3: cart.MergeGuestCartRequest
+	(*CreateOrderRequest)(nil),           // 4: cart.CreateOrderRequest
+	(*PreviewPromoRequest)(nil),          // 5: cart.PreviewPromoRequest
+	(*PromoPreviewResponse)(nil),         // 6: cart.PromoPreviewResponse
+	(*GetOrdersRequest)(nil),             // 7: cart.GetOrdersRequest
+	(*GetOrderByIdRequest)(nil),          // 8: cart.GetOrderByIdRequest
+	(*ConfirmPaymentRequest)(nil),        // 9: cart.ConfirmPaymentRequest
+	(*CancelOrderRequest)(nil),           // 10: cart.CancelOrderRequest
+	(*UpdateTipRequest)(nil),             // 11: cart.UpdateTipRequest
+	(*RestaurantOrdersRequest)(nil),      // 12: cart.RestaurantOrdersRequest
+	(*RestaurantOrderStatusRequest)(nil), // 13: cart.RestaurantOrderStatusRequest
+	(*CourierAvailabilityRequest)(nil),   // 14: cart.CourierAvailabilityRequest
+	(*CourierResponse)(nil),              // 15: cart.CourierResponse
+	(*CourierOrdersRequest)(nil),         // 16: cart.CourierOrdersRequest
+	(*CourierOrderActionRequest)(nil),    // 17: cart.CourierOrderActionRequest
+	(*ReorderRequest)(nil),               // 18: cart.ReorderRequest
+	(*ReorderItem)(nil),                  // 19: cart.ReorderItem
+	(*ReorderResponse)(nil),              // 20: cart.ReorderResponse
+	(*WatchOrderRequest)(nil),            // 21: cart.WatchOrderRequest
+	(*OrderUpdate)(nil),                  // 22: cart.OrderUpdate
+	(*CartResponse)(nil),                 // 23: cart.CartResponse
+	(*CartItem)(nil),                     // 24: cart.CartItem
+	(*OrderResponse)(nil),                // 25: cart.OrderResponse
+	(*OrderStatusEvent)(nil),             // 26: cart.OrderStatusEvent
+	(*PriceBreakdown)(nil),               // 27: cart.PriceBreakdown
+	(*OrderListResponse)(nil),            // 28: cart.OrderListResponse
+	(*timestamppb.Timestamp)(nil),        // 29: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 30: google.protobuf.Empty
 }
 var file_proto_cart_proto_depIdxs = []int32{
-	7,  // 0: cart.CreateOrderRequest.Cart:type_name -> cart.CartResponse
-	8,  // 1: cart.CartResponse.Products:type_name -> cart.CartItem
-	7,  // 2: cart.OrderResponse.OrderProducts:type_name -> cart.CartResponse
-	11, // 3: cart.OrderResponse.CreatedAt:type_name -> google.protobuf.Timestamp
-	9,  // 4: cart.OrderListResponse.Orders:type_name -> cart.OrderResponse
-	0,  // 5: cart.CartService.GetCart:input_type -> cart.GetCartRequest
-	1,  // 6: cart.CartService.UpdateItemQuantity:input_type -> cart.UpdateQuantityRequest
-	2,  // 7: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
-	3,  // 8: cart.CartService.CreateOrder:input_type -> cart.CreateOrderRequest
-	4,  // 9: cart.CartService.GetOrders:input_type -> cart.GetOrdersRequest
-	5,  // 10: cart.CartService.GetOrderById:input_type -> cart.GetOrderByIdRequest
-	6,  // 11: cart.CartService.UpdateOrderStatus:input_type -> cart.UpdateOrderStatusRequest
-	7,  // 12: cart.CartService.GetCart:output_type -> cart.CartResponse
-	12, // 13: cart.CartService.UpdateItemQuantity:output_type -> google.protobuf.Empty
-	12, // 14: cart.CartService.ClearCart:output_type -> google.protobuf.Empty
-	9,  // 15: cart.CartService.CreateOrder:output_type -> cart.OrderResponse
-	10, // 16: cart.CartService.GetOrders:output_type -> cart.OrderListResponse
-	9,  // 17: cart.CartService.GetOrderById:output_type -> cart.OrderResponse
-	12, // 18: cart.CartService.UpdateOrderStatus:output_type -> google.protobuf.Empty
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	23, // 0: cart.CreateOrderRequest.Cart:type_name -> cart.CartResponse
+	29, // 1: cart.CreateOrderRequest.DeliverAt:type_name -> google.protobuf.Timestamp
+	23, // 2: cart.PreviewPromoRequest.Cart:type_name -> cart.CartResponse
+	23, // 3: cart.PromoPreviewResponse.Cart:type_name -> cart.CartResponse
+	27, // 4: cart.PromoPreviewResponse.PriceBreakdown:type_name -> cart.PriceBreakdown
+	29, // 5: cart.GetOrdersRequest.From:type_name -> google.protobuf.Timestamp
+	29, // 6: cart.GetOrdersRequest.To:type_name -> google.protobuf.Timestamp
+	23, // 7: cart.ReorderResponse.Cart:type_name -> cart.CartResponse
+	19, // 8: cart.ReorderResponse.Dropped:type_name -> cart.ReorderItem
+	19, // 9: cart.ReorderResponse.Repriced:type_name -> cart.ReorderItem
+	26, // 10: cart.OrderUpdate.Event:type_name -> cart.OrderStatusEvent
+	24, // 11: cart.CartResponse.Products:type_name -> cart.CartItem
+	23, // 12: cart.OrderResponse.OrderProducts:type_name -> cart.CartResponse
+	29, // 13: cart.OrderResponse.CreatedAt:type_name -> google.protobuf.Timestamp
+	27, // 14: cart.OrderResponse.PriceBreakdown:type_name -> cart.PriceBreakdown
+	26, // 15: cart.OrderResponse.Timeline:type_name -> cart.OrderStatusEvent
+	29, // 16: cart.OrderResponse.DeliverAt:type_name -> google.protobuf.Timestamp
+	29, // 17: cart.OrderResponse.Eta:type_name -> google.protobuf.Timestamp
+	29, // 18: cart.OrderStatusEvent.CreatedAt:type_name -> google.protobuf.Timestamp
+	29, // 19: cart.OrderStatusEvent.Eta:type_name -> google.protobuf.Timestamp
+	25, // 20: cart.OrderListResponse.Orders:type_name -> cart.OrderResponse
+	0,  // 21: cart.CartService.GetCart:input_type -> cart.GetCartRequest
+	1,  // 22: cart.CartService.UpdateItemQuantity:input_type -> cart.UpdateQuantityRequest
+	2,  // 23: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
+	3,  // 24: cart.CartService.MergeGuestCart:input_type -> cart.MergeGuestCartRequest
+	4,  // 25: cart.CartService.CreateOrder:input_type -> cart.CreateOrderRequest
+	5,  // 26: cart.CartService.PreviewPromo:input_type -> cart.PreviewPromoRequest
+	7,  // 27: cart.CartService.GetOrders:input_type -> cart.GetOrdersRequest
+	8,  // 28: cart.CartService.GetOrderById:input_type -> cart.GetOrderByIdRequest
+	9,  // 29: cart.CartService.ConfirmPayment:input_type -> cart.ConfirmPaymentRequest
+	10, // 30: cart.CartService.CancelOrder:input_type -> cart.CancelOrderRequest
+	18, // 31: cart.CartService.Reorder:input_type -> cart.ReorderRequest
+	21, // 32: cart.CartService.WatchOrder:input_type -> cart.WatchOrderRequest
+	12, // 33: cart.CartService.GetRestaurantOrders:input_type -> cart.RestaurantOrdersRequest
+	13, // 34: cart.CartService.SetRestaurantOrderStatus:input_type -> cart.RestaurantOrderStatusRequest
+	14, // 35: cart.CartService.SetCourierAvailability:input_type -> cart.CourierAvailabilityRequest
+	16, // 36: cart.CartService.GetCourierOrders:input_type -> cart.CourierOrdersRequest
+	17, // 37: cart.CartService.CourierOrderAction:input_type -> cart.CourierOrderActionRequest
+	11, // 38: cart.CartService.UpdateTip:input_type -> cart.UpdateTipRequest
+	23, // 39: cart.CartService.GetCart:output_type -> cart.CartResponse
+	30, // 40: cart.CartService.UpdateItemQuantity:output_type -> google.protobuf.Empty
+	30, // 41: cart.CartService.ClearCart:output_type -> google.protobuf.Empty
+	30, // 42: cart.CartService.MergeGuestCart:output_type -> google.protobuf.Empty
+	25, // 43: cart.CartService.CreateOrder:output_type -> cart.OrderResponse
+	6,  // 44: cart.CartService.PreviewPromo:output_type -> cart.PromoPreviewResponse
+	28, // 45: cart.CartService.GetOrders:output_type -> cart.OrderListResponse
+	25, // 46: cart.CartService.GetOrderById:output_type -> cart.OrderResponse
+	30, // 47: cart.CartService.ConfirmPayment:output_type -> google.protobuf.Empty
+	25, // 48: cart.CartService.CancelOrder:output_type -> cart.OrderResponse
+	20, // 49: cart.CartService.Reorder:output_type -> cart.ReorderResponse
+	22, // 50: cart.CartService.WatchOrder:output_type -> cart.OrderUpdate
+	28, // 51: cart.CartService.GetRestaurantOrders:output_type -> cart.OrderListResponse
+	25, // 52: cart.CartService.SetRestaurantOrderStatus:output_type -> cart.OrderResponse
+	15, // 53: cart.CartService.SetCourierAvailability:output_type -> cart.CourierResponse
+	28, // 54: cart.CartService.GetCourierOrders:output_type -> cart.OrderListResponse
+	25, // 55: cart.CartService.CourierOrderAction:output_type -> cart.OrderResponse
+	25, // 56: cart.CartService.UpdateTip:output_type -> cart.OrderResponse
+	39, // [39:57] is the sub-list for method output_type
+	21, // [21:39] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_proto_cart_proto_init() }
@@ -933,13 +2296,15 @@ func file_proto_cart_proto_init() {
 	if File_proto_cart_proto != nil {
 		return
 	}
+	file_proto_cart_proto_msgTypes[14].OneofWrappers = []any{}
+	file_proto_cart_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cart_proto_rawDesc), len(file_proto_cart_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
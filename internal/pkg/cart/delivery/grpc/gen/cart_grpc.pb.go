@@ -20,13 +20,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CartService_GetCart_FullMethodName            = "/cart.CartService/GetCart"
-	CartService_UpdateItemQuantity_FullMethodName = "/cart.CartService/UpdateItemQuantity"
-	CartService_ClearCart_FullMethodName          = "/cart.CartService/ClearCart"
-	CartService_CreateOrder_FullMethodName        = "/cart.CartService/CreateOrder"
-	CartService_GetOrders_FullMethodName          = "/cart.CartService/GetOrders"
-	CartService_GetOrderById_FullMethodName       = "/cart.CartService/GetOrderById"
-	CartService_UpdateOrderStatus_FullMethodName  = "/cart.CartService/UpdateOrderStatus"
+	CartService_GetCart_FullMethodName                  = "/cart.CartService/GetCart"
+	CartService_UpdateItemQuantity_FullMethodName       = "/cart.CartService/UpdateItemQuantity"
+	CartService_ClearCart_FullMethodName                = "/cart.CartService/ClearCart"
+	CartService_MergeGuestCart_FullMethodName           = "/cart.CartService/MergeGuestCart"
+	CartService_CreateOrder_FullMethodName              = "/cart.CartService/CreateOrder"
+	CartService_PreviewPromo_FullMethodName             = "/cart.CartService/PreviewPromo"
+	CartService_GetOrders_FullMethodName                = "/cart.CartService/GetOrders"
+	CartService_GetOrderById_FullMethodName             = "/cart.CartService/GetOrderById"
+	CartService_ConfirmPayment_FullMethodName           = "/cart.CartService/ConfirmPayment"
+	CartService_CancelOrder_FullMethodName              = "/cart.CartService/CancelOrder"
+	CartService_Reorder_FullMethodName                  = "/cart.CartService/Reorder"
+	CartService_WatchOrder_FullMethodName               = "/cart.CartService/WatchOrder"
+	CartService_GetRestaurantOrders_FullMethodName      = "/cart.CartService/GetRestaurantOrders"
+	CartService_SetRestaurantOrderStatus_FullMethodName = "/cart.CartService/SetRestaurantOrderStatus"
+	CartService_SetCourierAvailability_FullMethodName   = "/cart.CartService/SetCourierAvailability"
+	CartService_GetCourierOrders_FullMethodName         = "/cart.CartService/GetCourierOrders"
+	CartService_CourierOrderAction_FullMethodName       = "/cart.CartService/CourierOrderAction"
+	CartService_UpdateTip_FullMethodName                = "/cart.CartService/UpdateTip"
 )
 
 // CartServiceClient is the client API for CartService service.
@@ -36,10 +47,21 @@ type CartServiceClient interface {
 	GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*CartResponse, error)
 	UpdateItemQuantity(ctx context.Context, in *UpdateQuantityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ClearCart(ctx context.Context, in *ClearCartRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MergeGuestCart(ctx context.Context, in *MergeGuestCartRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	PreviewPromo(ctx context.Context, in *PreviewPromoRequest, opts ...grpc.CallOption) (*PromoPreviewResponse, error)
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error)
 	GetOrderById(ctx context.Context, in *GetOrderByIdRequest, opts ...grpc.CallOption) (*OrderResponse, error)
-	UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	Reorder(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error)
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderUpdate], error)
+	GetRestaurantOrders(ctx context.Context, in *RestaurantOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error)
+	SetRestaurantOrderStatus(ctx context.Context, in *RestaurantOrderStatusRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	SetCourierAvailability(ctx context.Context, in *CourierAvailabilityRequest, opts ...grpc.CallOption) (*CourierResponse, error)
+	GetCourierOrders(ctx context.Context, in *CourierOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error)
+	CourierOrderAction(ctx context.Context, in *CourierOrderActionRequest, opts ...grpc.CallOption) (*OrderResponse, error)
+	UpdateTip(ctx context.Context, in *UpdateTipRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 }
 
 type cartServiceClient struct {
@@ -80,6 +102,16 @@ func (c *cartServiceClient) ClearCart(ctx context.Context, in *ClearCartRequest,
 	return out, nil
 }
 
+func (c *cartServiceClient) MergeGuestCart(ctx context.Context, in *MergeGuestCartRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CartService_MergeGuestCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OrderResponse)
@@ -90,6 +122,16 @@ func (c *cartServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequ
 	return out, nil
 }
 
+func (c *cartServiceClient) PreviewPromo(ctx context.Context, in *PreviewPromoRequest, opts ...grpc.CallOption) (*PromoPreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoPreviewResponse)
+	err := c.cc.Invoke(ctx, CartService_PreviewPromo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartServiceClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OrderListResponse)
@@ -110,10 +152,109 @@ func (c *cartServiceClient) GetOrderById(ctx context.Context, in *GetOrderByIdRe
 	return out, nil
 }
 
-func (c *cartServiceClient) UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *cartServiceClient) ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, CartService_UpdateOrderStatus_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, CartService_ConfirmPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, CartService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) Reorder(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderResponse)
+	err := c.cc.Invoke(ctx, CartService_Reorder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CartService_ServiceDesc.Streams[0], CartService_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderRequest, OrderUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CartService_WatchOrderClient = grpc.ServerStreamingClient[OrderUpdate]
+
+func (c *cartServiceClient) GetRestaurantOrders(ctx context.Context, in *RestaurantOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderListResponse)
+	err := c.cc.Invoke(ctx, CartService_GetRestaurantOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) SetRestaurantOrderStatus(ctx context.Context, in *RestaurantOrderStatusRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, CartService_SetRestaurantOrderStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) SetCourierAvailability(ctx context.Context, in *CourierAvailabilityRequest, opts ...grpc.CallOption) (*CourierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourierResponse)
+	err := c.cc.Invoke(ctx, CartService_SetCourierAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) GetCourierOrders(ctx context.Context, in *CourierOrdersRequest, opts ...grpc.CallOption) (*OrderListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderListResponse)
+	err := c.cc.Invoke(ctx, CartService_GetCourierOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) CourierOrderAction(ctx context.Context, in *CourierOrderActionRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, CartService_CourierOrderAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) UpdateTip(ctx context.Context, in *UpdateTipRequest, opts ...grpc.CallOption) (*OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderResponse)
+	err := c.cc.Invoke(ctx, CartService_UpdateTip_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +268,21 @@ type CartServiceServer interface {
 	GetCart(context.Context, *GetCartRequest) (*CartResponse, error)
 	UpdateItemQuantity(context.Context, *UpdateQuantityRequest) (*emptypb.Empty, error)
 	ClearCart(context.Context, *ClearCartRequest) (*emptypb.Empty, error)
+	MergeGuestCart(context.Context, *MergeGuestCartRequest) (*emptypb.Empty, error)
 	CreateOrder(context.Context, *CreateOrderRequest) (*OrderResponse, error)
+	PreviewPromo(context.Context, *PreviewPromoRequest) (*PromoPreviewResponse, error)
 	GetOrders(context.Context, *GetOrdersRequest) (*OrderListResponse, error)
 	GetOrderById(context.Context, *GetOrderByIdRequest) (*OrderResponse, error)
-	UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*emptypb.Empty, error)
+	ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*emptypb.Empty, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error)
+	Reorder(context.Context, *ReorderRequest) (*ReorderResponse, error)
+	WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderUpdate]) error
+	GetRestaurantOrders(context.Context, *RestaurantOrdersRequest) (*OrderListResponse, error)
+	SetRestaurantOrderStatus(context.Context, *RestaurantOrderStatusRequest) (*OrderResponse, error)
+	SetCourierAvailability(context.Context, *CourierAvailabilityRequest) (*CourierResponse, error)
+	GetCourierOrders(context.Context, *CourierOrdersRequest) (*OrderListResponse, error)
+	CourierOrderAction(context.Context, *CourierOrderActionRequest) (*OrderResponse, error)
+	UpdateTip(context.Context, *UpdateTipRequest) (*OrderResponse, error)
 	mustEmbedUnimplementedCartServiceServer()
 }
 
@@ -150,17 +302,50 @@ func (UnimplementedCartServiceServer) UpdateItemQuantity(context.Context, *Updat
 func (UnimplementedCartServiceServer) ClearCart(context.Context, *ClearCartRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearCart not implemented")
 }
+func (UnimplementedCartServiceServer) MergeGuestCart(context.Context, *MergeGuestCartRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeGuestCart not implemented")
+}
 func (UnimplementedCartServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*OrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
 }
+func (UnimplementedCartServiceServer) PreviewPromo(context.Context, *PreviewPromoRequest) (*PromoPreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewPromo not implemented")
+}
 func (UnimplementedCartServiceServer) GetOrders(context.Context, *GetOrdersRequest) (*OrderListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
 func (UnimplementedCartServiceServer) GetOrderById(context.Context, *GetOrderByIdRequest) (*OrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderById not implemented")
 }
-func (UnimplementedCartServiceServer) UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
+func (UnimplementedCartServiceServer) ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPayment not implemented")
+}
+func (UnimplementedCartServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedCartServiceServer) Reorder(context.Context, *ReorderRequest) (*ReorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reorder not implemented")
+}
+func (UnimplementedCartServiceServer) WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
+func (UnimplementedCartServiceServer) GetRestaurantOrders(context.Context, *RestaurantOrdersRequest) (*OrderListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRestaurantOrders not implemented")
+}
+func (UnimplementedCartServiceServer) SetRestaurantOrderStatus(context.Context, *RestaurantOrderStatusRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRestaurantOrderStatus not implemented")
+}
+func (UnimplementedCartServiceServer) SetCourierAvailability(context.Context, *CourierAvailabilityRequest) (*CourierResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCourierAvailability not implemented")
+}
+func (UnimplementedCartServiceServer) GetCourierOrders(context.Context, *CourierOrdersRequest) (*OrderListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourierOrders not implemented")
+}
+func (UnimplementedCartServiceServer) CourierOrderAction(context.Context, *CourierOrderActionRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CourierOrderAction not implemented")
+}
+func (UnimplementedCartServiceServer) UpdateTip(context.Context, *UpdateTipRequest) (*OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTip not implemented")
 }
 func (UnimplementedCartServiceServer) mustEmbedUnimplementedCartServiceServer() {}
 func (UnimplementedCartServiceServer) testEmbeddedByValue()                     {}
@@ -237,6 +422,24 @@ func _CartService_ClearCart_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_MergeGuestCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeGuestCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).MergeGuestCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_MergeGuestCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).MergeGuestCart(ctx, req.(*MergeGuestCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateOrderRequest)
 	if err := dec(in); err != nil {
@@ -255,6 +458,24 @@ func _CartService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_PreviewPromo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewPromoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).PreviewPromo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_PreviewPromo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).PreviewPromo(ctx, req.(*PreviewPromoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartService_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOrdersRequest)
 	if err := dec(in); err != nil {
@@ -291,20 +512,175 @@ func _CartService_GetOrderById_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CartService_UpdateOrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateOrderStatusRequest)
+func _CartService_ConfirmPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).ConfirmPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_ConfirmPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).ConfirmPayment(ctx, req.(*ConfirmPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_Reorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Reorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_Reorder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Reorder(ctx, req.(*ReorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CartServiceServer).WatchOrder(m, &grpc.GenericServerStream[WatchOrderRequest, OrderUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CartService_WatchOrderServer = grpc.ServerStreamingServer[OrderUpdate]
+
+func _CartService_GetRestaurantOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestaurantOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).GetRestaurantOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_GetRestaurantOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).GetRestaurantOrders(ctx, req.(*RestaurantOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_SetRestaurantOrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestaurantOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).SetRestaurantOrderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_SetRestaurantOrderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).SetRestaurantOrderStatus(ctx, req.(*RestaurantOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_SetCourierAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CourierAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).SetCourierAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_SetCourierAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).SetCourierAvailability(ctx, req.(*CourierAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_GetCourierOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CourierOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).GetCourierOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_GetCourierOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).GetCourierOrders(ctx, req.(*CourierOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_CourierOrderAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CourierOrderActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).CourierOrderAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_CourierOrderAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).CourierOrderAction(ctx, req.(*CourierOrderActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_UpdateTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTipRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CartServiceServer).UpdateOrderStatus(ctx, in)
+		return srv.(CartServiceServer).UpdateTip(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CartService_UpdateOrderStatus_FullMethodName,
+		FullMethod: CartService_UpdateTip_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CartServiceServer).UpdateOrderStatus(ctx, req.(*UpdateOrderStatusRequest))
+		return srv.(CartServiceServer).UpdateTip(ctx, req.(*UpdateTipRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -328,10 +704,18 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearCart",
 			Handler:    _CartService_ClearCart_Handler,
 		},
+		{
+			MethodName: "MergeGuestCart",
+			Handler:    _CartService_MergeGuestCart_Handler,
+		},
 		{
 			MethodName: "CreateOrder",
 			Handler:    _CartService_CreateOrder_Handler,
 		},
+		{
+			MethodName: "PreviewPromo",
+			Handler:    _CartService_PreviewPromo_Handler,
+		},
 		{
 			MethodName: "GetOrders",
 			Handler:    _CartService_GetOrders_Handler,
@@ -341,10 +725,48 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CartService_GetOrderById_Handler,
 		},
 		{
-			MethodName: "UpdateOrderStatus",
-			Handler:    _CartService_UpdateOrderStatus_Handler,
+			MethodName: "ConfirmPayment",
+			Handler:    _CartService_ConfirmPayment_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _CartService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "Reorder",
+			Handler:    _CartService_Reorder_Handler,
+		},
+		{
+			MethodName: "GetRestaurantOrders",
+			Handler:    _CartService_GetRestaurantOrders_Handler,
+		},
+		{
+			MethodName: "SetRestaurantOrderStatus",
+			Handler:    _CartService_SetRestaurantOrderStatus_Handler,
+		},
+		{
+			MethodName: "SetCourierAvailability",
+			Handler:    _CartService_SetCourierAvailability_Handler,
+		},
+		{
+			MethodName: "GetCourierOrders",
+			Handler:    _CartService_GetCourierOrders_Handler,
+		},
+		{
+			MethodName: "CourierOrderAction",
+			Handler:    _CartService_CourierOrderAction_Handler,
+		},
+		{
+			MethodName: "UpdateTip",
+			Handler:    _CartService_UpdateTip_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _CartService_WatchOrder_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/cart.proto",
 }
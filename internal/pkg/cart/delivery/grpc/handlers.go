@@ -2,12 +2,16 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/converter"
+	"github.com/mailru/easyjson"
 	"github.com/satori/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,17 +19,45 @@ import (
 )
 
 type CartHandler struct {
-	uc     cart.CartUsecase
-	secret string
+	uc            cart.CartUsecase
+	paymentSecret string
 	gen.CartServiceServer
 }
 
 func CreateCartHandler(uc cart.CartUsecase) *CartHandler {
-	return &CartHandler{uc: uc, secret: os.Getenv("JWT_SECRET")}
+	return &CartHandler{
+		uc:            uc,
+		paymentSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+	}
+}
+
+// requireCaller возвращает пользователя, от имени которого пришёл вызов, из его access-токена.
+func requireCaller(ctx context.Context) (grpcauth.Caller, error) {
+	user, ok := grpcauth.CallerFromContext(ctx)
+	if !ok {
+		return grpcauth.Caller{}, status.Error(codes.Unauthenticated, "требуется авторизация")
+	}
+	return user, nil
+}
+
+// cartOwner возвращает владельца корзины: пользователя из токена, а для вызова без токена —
+// гостя из запроса. Корзину пользователя по логину из запроса не отдаём.
+func cartOwner(ctx context.Context, guestID string) (string, error) {
+	if user, ok := grpcauth.CallerFromContext(ctx); ok {
+		return user.Login, nil
+	}
+	if !cart.IsGuestOwner(guestID) {
+		return "", status.Error(codes.Unauthenticated, "требуется авторизация")
+	}
+	return guestID, nil
 }
 
 func (h *CartHandler) GetCart(ctx context.Context, in *gen.GetCartRequest) (*gen.CartResponse, error) {
-	cart, err, full_cart := h.uc.GetCart(ctx, in.Login)
+	owner, err := cartOwner(ctx, in.GuestId)
+	if err != nil {
+		return nil, err
+	}
+	cart, err, full_cart := h.uc.GetCart(ctx, owner)
 
 	if err != nil {
 		return &gen.CartResponse{}, status.Errorf(codes.Internal, "ошибка получения корзины")
@@ -40,8 +72,20 @@ func (h *CartHandler) GetCart(ctx context.Context, in *gen.GetCartRequest) (*gen
 }
 
 func (h *CartHandler) UpdateItemQuantity(ctx context.Context, in *gen.UpdateQuantityRequest) (*emptypb.Empty, error) {
-	err := h.uc.UpdateItemQuantity(ctx, in.Login, in.ProductId, in.RestaurantId, int(in.Quantity))
+	owner, err := cartOwner(ctx, in.GuestId)
+	if err != nil {
+		return nil, err
+	}
+	err = h.uc.UpdateItemQuantity(ctx, owner, in.ProductId, in.RestaurantId, int(in.Quantity), in.Replace)
 	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrProductNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrUnknownProduct):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, cart.ErrRestaurantConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
@@ -49,7 +93,11 @@ func (h *CartHandler) UpdateItemQuantity(ctx context.Context, in *gen.UpdateQuan
 }
 
 func (h *CartHandler) ClearCart(ctx context.Context, in *gen.ClearCartRequest) (*emptypb.Empty, error) {
-	err := h.uc.ClearCart(ctx, in.Login)
+	owner, err := cartOwner(ctx, in.GuestId)
+	if err != nil {
+		return nil, err
+	}
+	err = h.uc.ClearCart(ctx, owner)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
@@ -57,9 +105,32 @@ func (h *CartHandler) ClearCart(ctx context.Context, in *gen.ClearCartRequest) (
 	return &emptypb.Empty{}, nil
 }
 
+func (h *CartHandler) MergeGuestCart(ctx context.Context, in *gen.MergeGuestCartRequest) (*emptypb.Empty, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.uc.MergeGuestCart(ctx, in.GuestId, user.Login, in.Replace)
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrInvalidCartOwner):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, cart.ErrRestaurantConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to merge cart: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 func (h *CartHandler) CreateOrder(ctx context.Context, in *gen.CreateOrderRequest) (*gen.OrderResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := models.OrderInReq{
-		Status:            in.Status,
 		Address:           in.Address,
 		ApartmentOrOffice: in.ApartmentOrOffice,
 		Intercom:          in.Intercom,
@@ -68,7 +139,16 @@ func (h *CartHandler) CreateOrder(ctx context.Context, in *gen.CreateOrderReques
 		CourierComment:    in.CourierComment,
 		LeaveAtDoor:       in.LeaveAtDoor,
 		FinalPrice:        in.FinalPrice,
+		PromoCode:         in.PromoCode,
+		TipAmount:         in.TipAmount,
+		TipPercent:        in.TipPercent,
+	}
+
+	deliverAt, err := converter.ProtoToOptionalTime(in.DeliverAt)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delivery time: %v", err)
 	}
+	req.DeliverAt = deliverAt
 
 	restId, err := uuid.FromString(in.Cart.RestaurantId)
 	if err != nil {
@@ -80,33 +160,82 @@ func (h *CartHandler) CreateOrder(ctx context.Context, in *gen.CreateOrderReques
 		return nil, status.Errorf(codes.InvalidArgument, "failed to convert cart items: %v", err)
 	}
 
-	cart := models.Cart{
+	orderCart := models.Cart{
 		Id:        restId,
 		Name:      in.Cart.RestaurantName,
 		CartItems: cartItems,
 	}
 
-	order, err := h.uc.CreateOrder(ctx, in.Login, req, cart)
+	order, err := h.uc.CreateOrder(ctx, user.Login, req, orderCart)
 	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrPriceMismatch):
+			return nil, status.Errorf(codes.Aborted, "%v", err)
+		case errors.Is(err, cart.ErrRestaurantClosed), errors.Is(err, cart.ErrOutsideDeliveryWindow):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		case errors.Is(err, cart.ErrEmptyCart), errors.Is(err, cart.ErrUnknownProduct), errors.Is(err, cart.ErrRestaurantNotFound),
+			errors.Is(err, cart.ErrInvalidDeliverAt), cart.IsPromoError(err):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
-	return converter.OrderToProto(order, in.Login)
+	return converter.OrderToProto(order, user.Login)
 }
 
-func (h *CartHandler) GetOrders(ctx context.Context, in *gen.GetOrdersRequest) (*gen.OrderListResponse, error) {
-	userId, err := uuid.FromString(in.UserId)
+func (h *CartHandler) PreviewPromo(ctx context.Context, in *gen.PreviewPromoRequest) (*gen.PromoPreviewResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Cart == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", cart.ErrEmptyCart)
+	}
+	restId, err := uuid.FromString(in.Cart.RestaurantId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid restaurant ID: %v", err)
 	}
-	orders, err := h.uc.GetOrders(ctx, userId, int(in.Count), int(in.Offset))
+	cartItems, err := converter.ProtoToCartItems(in.Cart.Products)
 	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to convert cart items: %v", err)
+	}
+
+	preview, err := h.uc.PreviewPromo(ctx, user.Login, in.PromoCode, models.Cart{
+		Id:        restId,
+		Name:      in.Cart.RestaurantName,
+		CartItems: cartItems,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrEmptyCart), errors.Is(err, cart.ErrUnknownProduct), cart.IsPromoError(err):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to preview promo code: %v", err)
+	}
+
+	return converter.PromoPreviewToProto(preview), nil
+}
+
+func (h *CartHandler) GetOrders(ctx context.Context, in *gen.GetOrdersRequest) (*gen.OrderListResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := converter.ProtoToOrderFilter(in)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	page, err := h.uc.GetOrders(ctx, user.ID, filter, in.Cursor, int(in.Count))
+	if err != nil {
+		if errors.Is(err, cart.ErrInvalidOrderFilter) || errors.Is(err, cart.ErrInvalidOrderCursor) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
-	protoOrders := make([]*gen.OrderResponse, 0, len(orders))
-	for _, order := range orders {
-		protoOrder, err := converter.OrderToProto(order, in.UserId)
+	protoOrders := make([]*gen.OrderResponse, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		protoOrder, err := converter.OrderToProto(order, user.ID.String())
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "order conversion failed: %v", err)
 		}
@@ -114,35 +243,296 @@ func (h *CartHandler) GetOrders(ctx context.Context, in *gen.GetOrdersRequest) (
 	}
 
 	return &gen.OrderListResponse{
-		Orders: protoOrders,
+		Orders:     protoOrders,
+		NextCursor: page.NextCursor,
 	}, nil
 }
 
 func (h *CartHandler) GetOrderById(ctx context.Context, in *gen.GetOrderByIdRequest) (*gen.OrderResponse, error) {
-	userId, err := uuid.FromString(in.UserId)
+	user, err := requireCaller(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+		return nil, err
 	}
+	userId := user.ID
 	orderId, err := uuid.FromString(in.OrderId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
 	}
 	order, err := h.uc.GetOrderById(ctx, orderId, userId)
+	if errors.Is(err, cart.ErrOrderNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
-	return converter.OrderToProto(order, in.UserId)
+	return converter.OrderToProto(order, userId.String())
+}
+
+// ConfirmPayment подтверждает оплату по уведомлению провайдера. Метод вызывается без токена,
+// поэтому подпись уведомления проверяется здесь, а не только в вебхуке main.
+func (h *CartHandler) ConfirmPayment(ctx context.Context, in *gen.ConfirmPaymentRequest) (*emptypb.Empty, error) {
+	if !payment.VerifySignature(in.Notification, in.Signature, h.paymentSecret) {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid notification signature")
+	}
+	var notification models.PaymentNotification
+	if err := easyjson.Unmarshal(in.Notification, &notification); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid notification: %v", err)
+	}
+	orderId, err := uuid.FromString(notification.OrderID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	if notification.PaymentID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "payment ID is required")
+	}
+	err = h.uc.ConfirmPayment(ctx, orderId, notification.PaymentID, notification.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrPaymentAmountMismatch):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, cart.ErrPaymentConflict), errors.Is(err, cart.ErrInvalidTransition), errors.Is(err, cart.ErrStatusConflict),
+			errors.Is(err, payment.ErrIntentNotFound), errors.Is(err, payment.ErrInvalidIntentState):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to confirm payment: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CartHandler) CancelOrder(ctx context.Context, in *gen.CancelOrderRequest) (*gen.OrderResponse, error) {
+	orderId, err := uuid.FromString(in.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	order, err := h.uc.CancelOrder(ctx, orderId, userId, in.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrInvalidTransition), errors.Is(err, cart.ErrStatusConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+	}
+
+	return converter.OrderToProto(order, userId.String())
+}
+
+func (h *CartHandler) UpdateTip(ctx context.Context, in *gen.UpdateTipRequest) (*gen.OrderResponse, error) {
+	orderId, err := uuid.FromString(in.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	order, err := h.uc.UpdateTip(ctx, orderId, userId, models.TipReq{Amount: in.TipAmount, Percent: in.TipPercent})
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrTipNotEditable), errors.Is(err, cart.ErrTipWindowClosed), errors.Is(err, cart.ErrStatusConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update tip: %v", err)
+	}
+
+	return converter.OrderToProto(order, userId.String())
+}
+
+func (h *CartHandler) GetRestaurantOrders(ctx context.Context, in *gen.RestaurantOrdersRequest) (*gen.OrderListResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	orders, err := h.uc.GetRestaurantOrders(ctx, userId)
+	if err != nil {
+		if errors.Is(err, cart.ErrNotRestaurantStaff) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get restaurant orders: %v", err)
+	}
+
+	protoOrders := make([]*gen.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		protoOrder, err := converter.OrderToProto(order, order.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "order conversion failed: %v", err)
+		}
+		protoOrders = append(protoOrders, protoOrder)
+	}
+
+	return &gen.OrderListResponse{Orders: protoOrders}, nil
 }
 
-func (h *CartHandler) UpdateOrderStatus(ctx context.Context, in *gen.UpdateOrderStatusRequest) (*emptypb.Empty, error) {
+func (h *CartHandler) SetRestaurantOrderStatus(ctx context.Context, in *gen.RestaurantOrderStatusRequest) (*gen.OrderResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
 	orderId, err := uuid.FromString(in.OrderId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
 	}
-	err = h.uc.UpdateOrderStatus(ctx, orderId)
+
+	order, err := h.uc.SetRestaurantOrderStatus(ctx, userId, orderId, in.Status, in.Reason)
 	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrNotRestaurantStaff):
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		case errors.Is(err, cart.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrInvalidTransition), errors.Is(err, cart.ErrStatusConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
 	}
-	return &emptypb.Empty{}, nil
+
+	return converter.OrderToProto(order, order.UserID)
+}
+
+func (h *CartHandler) Reorder(ctx context.Context, in *gen.ReorderRequest) (*gen.ReorderResponse, error) {
+	orderId, err := uuid.FromString(in.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	result, err := h.uc.Reorder(ctx, orderId, userId, user.Login, in.Replace)
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrRestaurantConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reorder: %v", err)
+	}
+
+	return converter.ReorderResultToProto(result), nil
+}
+
+func (h *CartHandler) WatchOrder(in *gen.WatchOrderRequest, stream gen.CartService_WatchOrderServer) error {
+	user, err := requireCaller(stream.Context())
+	if err != nil {
+		return err
+	}
+	userId := user.ID
+	orderId, err := uuid.FromString(in.OrderId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+
+	updates, err := h.uc.WatchOrder(stream.Context(), orderId, userId)
+	if errors.Is(err, cart.ErrOrderNotFound) {
+		return status.Errorf(codes.NotFound, "%v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to watch order: %v", err)
+	}
+
+	for event := range updates {
+		if err := stream.Send(&gen.OrderUpdate{
+			OrderId: in.OrderId,
+			Event:   converter.OrderStatusEventToProto(event),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *CartHandler) SetCourierAvailability(ctx context.Context, in *gen.CourierAvailabilityRequest) (*gen.CourierResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	req := models.CourierAvailabilityReq{Available: in.Available, Latitude: in.Latitude, Longitude: in.Longitude}
+	courier, err := h.uc.SetCourierAvailability(ctx, userId, req)
+	if err != nil {
+		if errors.Is(err, cart.ErrNotCourier) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update courier: %v", err)
+	}
+
+	return converter.CourierToProto(courier), nil
+}
+
+func (h *CartHandler) GetCourierOrders(ctx context.Context, in *gen.CourierOrdersRequest) (*gen.OrderListResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+
+	orders, err := h.uc.GetCourierOrders(ctx, userId)
+	if err != nil {
+		if errors.Is(err, cart.ErrNotCourier) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get courier orders: %v", err)
+	}
+
+	protoOrders := make([]*gen.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		protoOrder, err := converter.OrderToProto(order, order.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "order conversion failed: %v", err)
+		}
+		protoOrders = append(protoOrders, protoOrder)
+	}
+
+	return &gen.OrderListResponse{Orders: protoOrders}, nil
+}
+
+func (h *CartHandler) CourierOrderAction(ctx context.Context, in *gen.CourierOrderActionRequest) (*gen.OrderResponse, error) {
+	user, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userId := user.ID
+	orderId, err := uuid.FromString(in.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+
+	order, err := h.uc.CourierOrderAction(ctx, userId, orderId, in.Action)
+	if err != nil {
+		switch {
+		case errors.Is(err, cart.ErrUnknownCourierAction):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, cart.ErrNotCourier):
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		case errors.Is(err, cart.ErrAssignmentNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errors.Is(err, cart.ErrInvalidAssignment), errors.Is(err, cart.ErrInvalidTransition),
+			errors.Is(err, cart.ErrStatusConflict):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to apply courier action: %v", err)
+	}
+
+	return converter.OrderToProto(order, order.UserID)
 }
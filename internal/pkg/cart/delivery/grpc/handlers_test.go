@@ -3,21 +3,38 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/mocks"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/mailru/easyjson"
 	"github.com/satori/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// userContext — контекст вызова с проверенным access-токеном пользователя.
+func userContext(id uuid.UUID, login string) context.Context {
+	return grpcauth.ContextWithClaims(context.Background(), jwt.MapClaims{
+		"id":    id.String(),
+		"login": login,
+		"jti":   uuid.NewV4().String(),
+	})
+}
+
 func TestGetCart(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -28,18 +45,20 @@ func TestGetCart(t *testing.T) {
 	restaurantID := uuid.NewV4()
 	productID := uuid.NewV4()
 	login := "testuser"
+	guest := cart.GuestOwner(uuid.NewV4())
 
 	tests := []struct {
 		name           string
-		login          string
+		ctx            context.Context
+		guestID        string
 		mockSetup      func()
 		expected       *gen.CartResponse
 		expectedErr    error
 		expectedStatus codes.Code
 	}{
 		{
-			name:  "Success",
-			login: login,
+			name: "Success",
+			ctx:  userContext(uuid.NewV4(), login),
 			mockSetup: func() {
 				mockUsecase.EXPECT().GetCart(gomock.Any(), login).
 					Return(models.Cart{
@@ -75,8 +94,34 @@ func TestGetCart(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:  "Error",
-			login: login,
+			name:    "Guest cart without token",
+			ctx:     context.Background(),
+			guestID: guest,
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCart(gomock.Any(), guest).Return(models.Cart{}, nil, false)
+			},
+			expected: &gen.CartResponse{RestaurantId: uuid.Nil.String()},
+		},
+		{
+			name:    "Token user wins over guest id",
+			ctx:     userContext(uuid.NewV4(), login),
+			guestID: guest,
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCart(gomock.Any(), login).Return(models.Cart{}, nil, false)
+			},
+			expected: &gen.CartResponse{RestaurantId: uuid.Nil.String()},
+		},
+		{
+			name:           "User login without token",
+			ctx:            context.Background(),
+			guestID:        login,
+			mockSetup:      func() {},
+			expectedErr:    status.Error(codes.Unauthenticated, "требуется авторизация"),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name: "Error",
+			ctx:  userContext(uuid.NewV4(), login),
 			mockSetup: func() {
 				mockUsecase.EXPECT().GetCart(gomock.Any(), login).
 					Return(models.Cart{}, errors.New("some error"), false)
@@ -91,8 +136,8 @@ func TestGetCart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			req := &gen.GetCartRequest{Login: tt.login}
-			resp, err := h.GetCart(context.Background(), req)
+			req := &gen.GetCartRequest{GuestId: tt.guestID}
+			resp, err := h.GetCart(tt.ctx, req)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -124,7 +169,6 @@ func TestUpdateItemQuantity(t *testing.T) {
 		{
 			name: "Success",
 			input: &gen.UpdateQuantityRequest{
-				Login:        "testuser",
 				ProductId:    "product123",
 				RestaurantId: "restaurant456",
 				Quantity:     2,
@@ -136,6 +180,7 @@ func TestUpdateItemQuantity(t *testing.T) {
 					"product123",
 					"restaurant456",
 					2,
+					false,
 				).Return(nil)
 			},
 			expected:    &emptypb.Empty{},
@@ -144,7 +189,6 @@ func TestUpdateItemQuantity(t *testing.T) {
 		{
 			name: "ErrorFromUsecase",
 			input: &gen.UpdateQuantityRequest{
-				Login:        "testuser",
 				ProductId:    "product123",
 				RestaurantId: "restaurant456",
 				Quantity:     2,
@@ -156,19 +200,92 @@ func TestUpdateItemQuantity(t *testing.T) {
 					"product123",
 					"restaurant456",
 					2,
+					false,
 				).Return(errors.New("some error"))
 			},
 			expected:       nil,
 			expectedErr:    status.Errorf(codes.Internal, "some error"),
 			expectedStatus: codes.Internal,
 		},
+		{
+			name: "OtherRestaurantInCart",
+			input: &gen.UpdateQuantityRequest{
+				ProductId:    "product123",
+				RestaurantId: "restaurant789",
+				Quantity:     1,
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateItemQuantity(
+					gomock.Any(),
+					"testuser",
+					"product123",
+					"restaurant789",
+					1,
+					false,
+				).Return(cart.ErrRestaurantConflict)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.FailedPrecondition, "%v", cart.ErrRestaurantConflict),
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name: "ProductNotFound",
+			input: &gen.UpdateQuantityRequest{
+				ProductId:    "product123",
+				RestaurantId: "restaurant456",
+				Quantity:     1,
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateItemQuantity(gomock.Any(), "testuser", "product123", "restaurant456", 1, false).
+					Return(cart.ErrProductNotFound)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.NotFound, "%v", cart.ErrProductNotFound),
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "ProductOfAnotherRestaurant",
+			input: &gen.UpdateQuantityRequest{
+				ProductId:    "product123",
+				RestaurantId: "restaurant456",
+				Quantity:     1,
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateItemQuantity(gomock.Any(), "testuser", "product123", "restaurant456", 1, false).
+					Return(cart.ErrUnknownProduct)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.InvalidArgument, "%v", cart.ErrUnknownProduct),
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "ReplaceCart",
+			input: &gen.UpdateQuantityRequest{
+				ProductId:    "product123",
+				RestaurantId: "restaurant789",
+				Quantity:     1,
+				Replace:      true,
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateItemQuantity(
+					gomock.Any(),
+					"testuser",
+					"product123",
+					"restaurant789",
+					1,
+					true,
+				).Return(nil)
+			},
+			expected:    &emptypb.Empty{},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.UpdateItemQuantity(context.Background(), tt.input)
+			resp, err := h.UpdateItemQuantity(userContext(uuid.NewV4(), "testuser"), tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -200,7 +317,6 @@ func TestClearCart(t *testing.T) {
 		{
 			name: "Success",
 			input: &gen.ClearCartRequest{
-				Login: "testuser",
 			},
 			mockSetup: func() {
 				mockUsecase.EXPECT().ClearCart(
@@ -214,7 +330,6 @@ func TestClearCart(t *testing.T) {
 		{
 			name: "ErrorFromUsecase",
 			input: &gen.ClearCartRequest{
-				Login: "testuser",
 			},
 			mockSetup: func() {
 				mockUsecase.EXPECT().ClearCart(
@@ -232,7 +347,7 @@ func TestClearCart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.ClearCart(context.Background(), tt.input)
+			resp, err := h.ClearCart(userContext(uuid.NewV4(), "testuser"), tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -246,6 +361,56 @@ func TestClearCart(t *testing.T) {
 	}
 }
 
+func TestMergeGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+
+	guest := cart.GuestOwner(uuid.NewV4())
+
+	tests := []struct {
+		name           string
+		input          *gen.MergeGuestCartRequest
+		mockErr        error
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "Success",
+			input:          &gen.MergeGuestCartRequest{GuestId: guest},
+			expectedStatus: codes.OK,
+		},
+		{
+			name:           "RestaurantConflict",
+			input:          &gen.MergeGuestCartRequest{GuestId: guest},
+			mockErr:        cart.ErrRestaurantConflict,
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name:           "InvalidOwner",
+			input:          &gen.MergeGuestCartRequest{GuestId: "testuser", Replace: true},
+			mockErr:        cart.ErrInvalidCartOwner,
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:           "RepoError",
+			input:          &gen.MergeGuestCartRequest{GuestId: guest},
+			mockErr:        errors.New("redis down"),
+			expectedStatus: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().MergeGuestCart(gomock.Any(), tt.input.GuestId, "testuser", tt.input.Replace).Return(tt.mockErr)
+
+			_, err := h.MergeGuestCart(userContext(uuid.NewV4(), "testuser"), tt.input)
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+		})
+	}
+}
+
 func TestCreateOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -268,7 +433,6 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Success",
 			input: &gen.CreateOrderRequest{
-				Status:            "pending",
 				Address:           "Test Address",
 				ApartmentOrOffice: "42",
 				Intercom:          "1234",
@@ -277,7 +441,6 @@ func TestCreateOrder(t *testing.T) {
 				CourierComment:    "Call me",
 				LeaveAtDoor:       true,
 				FinalPrice:        100.50,
-				Login:             login,
 				Cart: &gen.CartResponse{
 					RestaurantId:   restaurantID.String(),
 					RestaurantName: "Test Restaurant",
@@ -298,7 +461,6 @@ func TestCreateOrder(t *testing.T) {
 					gomock.Any(),
 					login,
 					models.OrderInReq{
-						Status:            "pending",
 						Address:           "Test Address",
 						ApartmentOrOffice: "42",
 						Intercom:          "1234",
@@ -384,13 +546,87 @@ func TestCreateOrder(t *testing.T) {
 			expectedErr:    status.Errorf(codes.Internal, "database error"),
 			expectedStatus: codes.Internal,
 		},
+		{
+			name: "PriceMismatch",
+			input: &gen.CreateOrderRequest{
+				FinalPrice: 1,
+				Cart: &gen.CartResponse{
+					RestaurantId: restaurantID.String(),
+					Products: []*gen.CartItem{
+						{
+							Id:     productID.String(),
+							Amount: 2,
+						},
+					},
+				},
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateOrder(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(models.Order{}, fmt.Errorf("%w: ожидалось 21.00", cart.ErrPriceMismatch))
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.Aborted, "%v", cart.ErrPriceMismatch),
+			expectedStatus: codes.Aborted,
+		},
+		{
+			name: "UnknownProduct",
+			input: &gen.CreateOrderRequest{
+				Cart: &gen.CartResponse{
+					RestaurantId: restaurantID.String(),
+					Products: []*gen.CartItem{
+						{
+							Id: productID.String(),
+						},
+					},
+				},
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateOrder(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(models.Order{}, cart.ErrUnknownProduct)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.InvalidArgument, "%v", cart.ErrUnknownProduct),
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "RestaurantClosed",
+			input: &gen.CreateOrderRequest{
+				Cart: &gen.CartResponse{
+					RestaurantId: restaurantID.String(),
+					Products: []*gen.CartItem{
+						{
+							Id: productID.String(),
+						},
+					},
+				},
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateOrder(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(models.Order{}, fmt.Errorf("%w, откроется 11.05 в 11:00", cart.ErrRestaurantClosed))
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.FailedPrecondition, "%v", cart.ErrRestaurantClosed),
+			expectedStatus: codes.FailedPrecondition,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.CreateOrder(context.Background(), tt.input)
+			resp, err := h.CreateOrder(userContext(uuid.NewV4(), "testuser"), tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -407,6 +643,78 @@ func TestCreateOrder(t *testing.T) {
 	}
 }
 
+func TestPreviewPromo(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	protoCart := &gen.CartResponse{
+		RestaurantId: restaurantID.String(),
+		Products:     []*gen.CartItem{{Id: productID.String(), Price: 500, Amount: 2}},
+	}
+	preview := models.PromoPreview{
+		PromoCode:      "WELCOME",
+		Cart:           models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Price: 500, Amount: 2}}},
+		PriceBreakdown: models.PriceBreakdown{Subtotal: 1000, Discount: 200, Total: 800},
+	}
+
+	tests := []struct {
+		name           string
+		input          *gen.PreviewPromoRequest
+		mockSetup      func(mockUsecase *mocks.MockCartUsecase)
+		expectedStatus codes.Code
+	}{
+		{
+			name:  "Success",
+			input: &gen.PreviewPromoRequest{PromoCode: "welcome", Cart: protoCart},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().PreviewPromo(gomock.Any(), "testuser", "welcome", gomock.Any()).Return(preview, nil)
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name:  "PromoRejected",
+			input: &gen.PreviewPromoRequest{PromoCode: "welcome", Cart: protoCart},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().PreviewPromo(gomock.Any(), "testuser", "welcome", gomock.Any()).
+					Return(models.PromoPreview{}, fmt.Errorf("%w: от 1500.00", cart.ErrPromoMinSubtotal))
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:           "InvalidRestaurant",
+			input:          &gen.PreviewPromoRequest{PromoCode: "welcome", Cart: &gen.CartResponse{RestaurantId: "bad"}},
+			mockSetup:      func(mockUsecase *mocks.MockCartUsecase) {},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:  "RepoError",
+			input: &gen.PreviewPromoRequest{PromoCode: "welcome", Cart: protoCart},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().PreviewPromo(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.PromoPreview{}, errors.New("db down"))
+			},
+			expectedStatus: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockCartUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			h := CreateCartHandler(mockUsecase)
+
+			resp, err := h.PreviewPromo(userContext(uuid.NewV4(), "testuser"), tt.input)
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Equal(t, "WELCOME", resp.PromoCode)
+				assert.Equal(t, 800.0, resp.PriceBreakdown.Total)
+			}
+		})
+	}
+}
+
 func TestGetOrders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -416,6 +724,8 @@ func TestGetOrders(t *testing.T) {
 
 	userID := uuid.NewV4()
 	orderID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name           string
@@ -428,17 +738,16 @@ func TestGetOrders(t *testing.T) {
 		{
 			name: "Success",
 			input: &gen.GetOrdersRequest{
-				UserId: userID.String(),
 				Count:  10,
-				Offset: 0,
 			},
 			mockSetup: func() {
 				mockUsecase.EXPECT().GetOrders(
 					gomock.Any(),
 					userID,
+					models.OrderFilter{},
+					"",
 					10,
-					0,
-				).Return([]models.Order{
+				).Return(models.OrderPage{Orders: []models.Order{
 					{
 						ID:                orderID,
 						Status:            "delivered",
@@ -447,7 +756,7 @@ func TestGetOrders(t *testing.T) {
 						FinalPrice:        100.50,
 						CreatedAt:         time.Now(),
 					},
-				}, nil)
+				}, NextCursor: "next"}, nil)
 			},
 			expected: &gen.OrderListResponse{
 				Orders: []*gen.OrderResponse{
@@ -460,33 +769,77 @@ func TestGetOrders(t *testing.T) {
 						UserId:            userID.String(),
 					},
 				},
+				NextCursor: "next",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Filters",
+			input: &gen.GetOrdersRequest{
+				Count:        5,
+				Statuses:     []string{"delivered"},
+				RestaurantId: restaurantID.String(),
+				From:         timestamppb.New(from),
+				MinTotal:     500,
+				OldestFirst:  true,
+				Cursor:       "cursor",
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetOrders(
+					gomock.Any(),
+					userID,
+					models.OrderFilter{
+						Statuses:     []string{"delivered"},
+						RestaurantID: restaurantID,
+						From:         &from,
+						MinTotal:     500,
+						OldestFirst:  true,
+					},
+					"cursor",
+					5,
+				).Return(models.OrderPage{Orders: []models.Order{}}, nil)
+			},
+			expected: &gen.OrderListResponse{
+				Orders: []*gen.OrderResponse{},
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "InvalidUserID",
+			name: "InvalidRestaurantID",
 			input: &gen.GetOrdersRequest{
-				UserId: "invalid-uuid",
+				RestaurantId: "bad",
 			},
 			mockSetup:      func() {},
 			expected:       nil,
 			expectedErr:    status.Errorf(codes.InvalidArgument, "invalid restaurant ID"),
 			expectedStatus: codes.InvalidArgument,
 		},
+		{
+			name: "InvalidFilter",
+			input: &gen.GetOrdersRequest{
+				Statuses: []string{"lost"},
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetOrders(gomock.Any(), userID, gomock.Any(), "", 0).
+					Return(models.OrderPage{}, fmt.Errorf("%w: неизвестный статус", cart.ErrInvalidOrderFilter))
+			},
+			expected:       nil,
+			expectedErr:    cart.ErrInvalidOrderFilter,
+			expectedStatus: codes.InvalidArgument,
+		},
 		{
 			name: "EmptyResult",
 			input: &gen.GetOrdersRequest{
-				UserId: userID.String(),
 				Count:  10,
-				Offset: 0,
 			},
 			mockSetup: func() {
 				mockUsecase.EXPECT().GetOrders(
 					gomock.Any(),
 					userID,
+					models.OrderFilter{},
+					"",
 					10,
-					0,
-				).Return([]models.Order{}, nil)
+				).Return(models.OrderPage{Orders: []models.Order{}}, nil)
 			},
 			expected: &gen.OrderListResponse{
 				Orders: []*gen.OrderResponse{},
@@ -496,17 +849,16 @@ func TestGetOrders(t *testing.T) {
 		{
 			name: "UsecaseError",
 			input: &gen.GetOrdersRequest{
-				UserId: userID.String(),
 				Count:  10,
-				Offset: 0,
 			},
 			mockSetup: func() {
 				mockUsecase.EXPECT().GetOrders(
 					gomock.Any(),
 					userID,
+					models.OrderFilter{},
+					"",
 					10,
-					0,
-				).Return(nil, errors.New("database error"))
+				).Return(models.OrderPage{}, errors.New("database error"))
 			},
 			expected:       nil,
 			expectedErr:    status.Errorf(codes.Internal, "database error"),
@@ -518,7 +870,7 @@ func TestGetOrders(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.GetOrders(context.Background(), tt.input)
+			resp, err := h.GetOrders(userContext(userID, "testuser"), tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -527,6 +879,7 @@ func TestGetOrders(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				require.Equal(t, len(tt.expected.Orders), len(resp.Orders))
+				assert.Equal(t, tt.expected.NextCursor, resp.NextCursor)
 
 				if len(tt.expected.Orders) > 0 {
 					expectedOrder := tt.expected.Orders[0]
@@ -565,7 +918,6 @@ func TestGetOrderById(t *testing.T) {
 		{
 			name: "Success",
 			input: &gen.GetOrderByIdRequest{
-				UserId:  userID.String(),
 				OrderId: orderID.String(),
 			},
 			mockSetup: func() {
@@ -602,21 +954,9 @@ func TestGetOrderById(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
-		{
-			name: "InvalidUserID",
-			input: &gen.GetOrderByIdRequest{
-				UserId:  "invalid-uuid",
-				OrderId: orderID.String(),
-			},
-			mockSetup:      func() {},
-			expected:       nil,
-			expectedErr:    status.Errorf(codes.InvalidArgument, "invalid user ID"),
-			expectedStatus: codes.InvalidArgument,
-		},
 		{
 			name: "InvalidOrderID",
 			input: &gen.GetOrderByIdRequest{
-				UserId:  userID.String(),
 				OrderId: "invalid-uuid",
 			},
 			mockSetup:      func() {},
@@ -627,7 +967,6 @@ func TestGetOrderById(t *testing.T) {
 		{
 			name: "OrderNotFound",
 			input: &gen.GetOrderByIdRequest{
-				UserId:  userID.String(),
 				OrderId: orderID.String(),
 			},
 			mockSetup: func() {
@@ -644,7 +983,6 @@ func TestGetOrderById(t *testing.T) {
 		{
 			name: "UsecaseError",
 			input: &gen.GetOrderByIdRequest{
-				UserId:  userID.String(),
 				OrderId: orderID.String(),
 			},
 			mockSetup: func() {
@@ -664,7 +1002,7 @@ func TestGetOrderById(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.GetOrderById(context.Background(), tt.input)
+			resp, err := h.GetOrderById(userContext(userID, "testuser"), tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -684,76 +1022,63 @@ func TestGetOrderById(t *testing.T) {
 	}
 }
 
-func TestUpdateOrderStatus(t *testing.T) {
+func TestCancelOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUsecase := mocks.NewMockCartUsecase(ctrl)
 	h := CreateCartHandler(mockUsecase)
 
+	userID := uuid.NewV4()
 	orderID := uuid.NewV4()
+	request := &gen.CancelOrderRequest{OrderId: orderID.String(), Reason: "передумал"}
 
 	tests := []struct {
 		name           string
-		input          *gen.UpdateOrderStatusRequest
+		input          *gen.CancelOrderRequest
 		mockSetup      func()
-		expected       *emptypb.Empty
-		expectedErr    error
 		expectedStatus codes.Code
 	}{
 		{
-			name: "Success",
-			input: &gen.UpdateOrderStatusRequest{
-				OrderId: orderID.String(),
-			},
+			name:  "Success",
+			input: request,
 			mockSetup: func() {
-				mockUsecase.EXPECT().UpdateOrderStatus(
-					gomock.Any(),
-					orderID,
-				).Return(nil)
+				mockUsecase.EXPECT().CancelOrder(gomock.Any(), orderID, userID, "передумал").
+					Return(models.Order{ID: orderID, Status: cart.StatusCancelled, CreatedAt: time.Now()}, nil)
 			},
-			expected:       &emptypb.Empty{},
-			expectedErr:    nil,
 			expectedStatus: codes.OK,
 		},
 		{
-			name: "InvalidOrderID",
-			input: &gen.UpdateOrderStatusRequest{
-				OrderId: "invalid-uuid",
-			},
+			name:           "InvalidOrderID",
+			input:          &gen.CancelOrderRequest{OrderId: "invalid-uuid"},
 			mockSetup:      func() {},
-			expected:       nil,
-			expectedErr:    status.Errorf(codes.InvalidArgument, "invalid order ID"),
 			expectedStatus: codes.InvalidArgument,
 		},
 		{
-			name: "OrderNotFound",
-			input: &gen.UpdateOrderStatusRequest{
-				OrderId: orderID.String(),
-			},
+			name:  "OrderNotFound",
+			input: request,
 			mockSetup: func() {
-				mockUsecase.EXPECT().UpdateOrderStatus(
-					gomock.Any(),
-					orderID,
-				).Return(errors.New("order not found"))
+				mockUsecase.EXPECT().CancelOrder(gomock.Any(), orderID, userID, "передумал").
+					Return(models.Order{}, cart.ErrOrderNotFound)
 			},
-			expected:       nil,
-			expectedErr:    status.Errorf(codes.Internal, "failed to update order status: order not found"),
-			expectedStatus: codes.Internal,
+			expectedStatus: codes.NotFound,
 		},
 		{
-			name: "UsecaseError",
-			input: &gen.UpdateOrderStatusRequest{
-				OrderId: orderID.String(),
+			name:  "AlreadyInDelivery",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().CancelOrder(gomock.Any(), orderID, userID, "передумал").
+					Return(models.Order{}, cart.ErrInvalidTransition)
 			},
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name:  "RefundError",
+			input: request,
 			mockSetup: func() {
-				mockUsecase.EXPECT().UpdateOrderStatus(
-					gomock.Any(),
-					orderID,
-				).Return(errors.New("database error"))
+				mockUsecase.EXPECT().CancelOrder(gomock.Any(), orderID, userID, "передумал").
+					Return(models.Order{}, errors.New("provider unavailable"))
 			},
-			expected:       nil,
-			expectedErr:    status.Errorf(codes.Internal, "failed to update order status: database error"),
 			expectedStatus: codes.Internal,
 		},
 	}
@@ -762,16 +1087,561 @@ func TestUpdateOrderStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := h.UpdateOrderStatus(context.Background(), tt.input)
+			resp, err := h.CancelOrder(userContext(userID, "testuser"), tt.input)
 
-			if tt.expectedErr != nil {
-				assert.Error(t, err)
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Equal(t, cart.StatusCancelled, resp.Status)
+			}
+		})
+	}
+}
+
+func TestUpdateTip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+
+	userID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	request := &gen.UpdateTipRequest{OrderId: orderID.String(), TipPercent: 10}
+	tipReq := models.TipReq{Percent: 10}
+
+	tests := []struct {
+		name           string
+		input          *gen.UpdateTipRequest
+		mockSetup      func()
+		expectedStatus codes.Code
+	}{
+		{
+			name:  "Success",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateTip(gomock.Any(), orderID, userID, tipReq).
+					Return(models.Order{ID: orderID, Status: cart.StatusDelivered, PriceBreakdown: models.PriceBreakdown{Tip: 100},
+						CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name:           "InvalidOrderID",
+			input:          &gen.UpdateTipRequest{OrderId: "invalid-uuid"},
+			mockSetup:      func() {},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:  "NotDelivered",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateTip(gomock.Any(), orderID, userID, tipReq).
+					Return(models.Order{}, cart.ErrTipNotEditable)
+			},
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name:  "WindowClosed",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateTip(gomock.Any(), orderID, userID, tipReq).
+					Return(models.Order{}, cart.ErrTipWindowClosed)
+			},
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name:  "OrderNotFound",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UpdateTip(gomock.Any(), orderID, userID, tipReq).
+					Return(models.Order{}, cart.ErrOrderNotFound)
+			},
+			expectedStatus: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := h.UpdateTip(userContext(userID, "testuser"), tt.input)
+
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Equal(t, 100.0, resp.PriceBreakdown.Tip)
+			}
+		})
+	}
+}
+
+func TestConfirmPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+	h.paymentSecret = "webhook-secret"
+
+	orderID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		input          *gen.ConfirmPaymentRequest
+		mockSetup      func()
+		expected       *emptypb.Empty
+		expectedErr    error
+		expectedStatus codes.Code
+	}{
+		{
+			name: "Success",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String(), PaymentID: "pay_1", Amount: 500}),
+			mockSetup: func() {
+				mockUsecase.EXPECT().ConfirmPayment(gomock.Any(), orderID, "pay_1", 500.0).Return(nil)
+			},
+			expected:       &emptypb.Empty{},
+			expectedErr:    nil,
+			expectedStatus: codes.OK,
+		},
+		{
+			name: "ForgedSignature",
+			input: &gen.ConfirmPaymentRequest{
+				Notification: []byte(`{"order_id":"` + orderID.String() + `","payment_id":"pay_1","amount":500}`),
+				Signature:    payment.Sign([]byte(`{}`), "webhook-secret"),
+			},
+			mockSetup:      func() {},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.Unauthenticated, "invalid notification signature"),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name: "InvalidOrderID",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: "invalid-uuid", PaymentID: "pay_1"}),
+			mockSetup:      func() {},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.InvalidArgument, "invalid order ID"),
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "MissingPaymentID",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String()}),
+			mockSetup:      func() {},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.InvalidArgument, "payment ID is required"),
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "OrderNotFound",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String(), PaymentID: "pay_1", Amount: 500}),
+			mockSetup: func() {
+				mockUsecase.EXPECT().ConfirmPayment(gomock.Any(), orderID, "pay_1", 500.0).Return(cart.ErrOrderNotFound)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.NotFound, "%v", cart.ErrOrderNotFound),
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "AmountMismatch",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String(), PaymentID: "pay_1", Amount: 1}),
+			mockSetup: func() {
+				mockUsecase.EXPECT().ConfirmPayment(gomock.Any(), orderID, "pay_1", 1.0).Return(cart.ErrPaymentAmountMismatch)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.InvalidArgument, "%v", cart.ErrPaymentAmountMismatch),
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "PaidByAnotherPayment",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String(), PaymentID: "pay_2", Amount: 500}),
+			mockSetup: func() {
+				mockUsecase.EXPECT().ConfirmPayment(gomock.Any(), orderID, "pay_2", 500.0).Return(cart.ErrPaymentConflict)
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.FailedPrecondition, "%v", cart.ErrPaymentConflict),
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name: "UsecaseError",
+			input: signedNotification(h.paymentSecret, models.PaymentNotification{OrderID: orderID.String(), PaymentID: "pay_1", Amount: 500}),
+			mockSetup: func() {
+				mockUsecase.EXPECT().ConfirmPayment(gomock.Any(), orderID, "pay_1", 500.0).Return(errors.New("database error"))
+			},
+			expected:       nil,
+			expectedErr:    status.Errorf(codes.Internal, "failed to confirm payment: database error"),
+			expectedStatus: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := h.ConfirmPayment(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
 				assert.Equal(t, tt.expectedStatus, status.Code(err))
-				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				assert.Contains(t, err.Error(), status.Convert(tt.expectedErr).Message())
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected, resp)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func signedNotification(secret string, notification models.PaymentNotification) *gen.ConfirmPaymentRequest {
+	body, _ := easyjson.Marshal(notification)
+	return &gen.ConfirmPaymentRequest{Notification: body, Signature: payment.Sign(body, secret)}
+}
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*gen.OrderUpdate
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(update *gen.OrderUpdate) error {
+	s.sent = append(s.sent, update)
+	return nil
+}
+
+func TestWatchOrder(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name         string
+		input        *gen.WatchOrderRequest
+		mockSetup    func(*mocks.MockCartUsecase)
+		expectedCode codes.Code
+		expectedSent []string
+	}{
+		{
+			name:  "Success",
+			input: &gen.WatchOrderRequest{OrderId: orderID.String()},
+			mockSetup: func(uc *mocks.MockCartUsecase) {
+				updates := make(chan models.OrderStatusEvent, 2)
+				updates <- models.OrderStatusEvent{Status: cart.StatusInDelivery, CreatedAt: time.Now()}
+				updates <- models.OrderStatusEvent{Status: cart.StatusDelivered, CreatedAt: time.Now()}
+				close(updates)
+				uc.EXPECT().WatchOrder(gomock.Any(), orderID, userID).Return((<-chan models.OrderStatusEvent)(updates), nil)
+			},
+			expectedCode: codes.OK,
+			expectedSent: []string{cart.StatusInDelivery, cart.StatusDelivered},
+		},
+		{
+			name:         "Invalid order ID",
+			input:        &gen.WatchOrderRequest{OrderId: "invalid"},
+			mockSetup:    func(uc *mocks.MockCartUsecase) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:  "Order not found",
+			input: &gen.WatchOrderRequest{OrderId: orderID.String()},
+			mockSetup: func(uc *mocks.MockCartUsecase) {
+				uc.EXPECT().WatchOrder(gomock.Any(), orderID, userID).Return(nil, cart.ErrOrderNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockCartUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			h := CreateCartHandler(mockUsecase)
+
+			stream := &fakeWatchStream{ctx: userContext(userID, "testuser")}
+			err := h.WatchOrder(tt.input, stream)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			var sent []string
+			for _, update := range stream.sent {
+				assert.Equal(t, tt.input.OrderId, update.OrderId)
+				sent = append(sent, update.Event.Status)
+			}
+			assert.Equal(t, tt.expectedSent, sent)
+		})
+	}
+}
+
+func TestReorder(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+	productID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		input          *gen.ReorderRequest
+		mockSetup      func(mockUsecase *mocks.MockCartUsecase)
+		expectedStatus codes.Code
+	}{
+		{
+			name:  "Success",
+			input: &gen.ReorderRequest{OrderId: orderID.String()},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().Reorder(gomock.Any(), orderID, userID, "testuser", false).Return(models.ReorderResult{
+					Cart:     models.Cart{Id: uuid.NewV4()},
+					Dropped:  []models.ReorderItem{},
+					Repriced: []models.ReorderItem{{Id: productID, Name: "Хинкали", Amount: 5, OldPrice: 90, NewPrice: 110}},
+				}, nil)
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name:  "Conflict",
+			input: &gen.ReorderRequest{OrderId: orderID.String()},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().Reorder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.ReorderResult{}, cart.ErrRestaurantConflict)
+			},
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name:  "NotFound",
+			input: &gen.ReorderRequest{OrderId: orderID.String()},
+			mockSetup: func(mockUsecase *mocks.MockCartUsecase) {
+				mockUsecase.EXPECT().Reorder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.ReorderResult{}, cart.ErrOrderNotFound)
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name:           "InvalidOrderID",
+			input:          &gen.ReorderRequest{OrderId: "bad"},
+			mockSetup:      func(mockUsecase *mocks.MockCartUsecase) {},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockCartUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			h := CreateCartHandler(mockUsecase)
+
+			resp, err := h.Reorder(userContext(userID, "testuser"), tt.input)
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Len(t, resp.Repriced, 1)
+				assert.Equal(t, 110.0, resp.Repriced[0].NewPrice)
+			}
+		})
+	}
+}
+
+func TestSetRestaurantOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+
+	staffID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	request := &gen.RestaurantOrderStatusRequest{OrderId: orderID.String(), Status: cart.StatusAccepted}
+
+	tests := []struct {
+		name           string
+		input          *gen.RestaurantOrderStatusRequest
+		mockSetup      func()
+		expectedStatus codes.Code
+	}{
+		{
+			name:  "Success",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().SetRestaurantOrderStatus(gomock.Any(), staffID, orderID, cart.StatusAccepted, "").
+					Return(models.Order{ID: orderID, UserID: uuid.NewV4().String(), Status: cart.StatusAccepted, CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name:           "InvalidOrderID",
+			input:          &gen.RestaurantOrderStatusRequest{OrderId: "invalid-uuid"},
+			mockSetup:      func() {},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:  "NotStaff",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().SetRestaurantOrderStatus(gomock.Any(), staffID, orderID, cart.StatusAccepted, "").
+					Return(models.Order{}, cart.ErrNotRestaurantStaff)
+			},
+			expectedStatus: codes.PermissionDenied,
+		},
+		{
+			name:  "ForeignOrder",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().SetRestaurantOrderStatus(gomock.Any(), staffID, orderID, cart.StatusAccepted, "").
+					Return(models.Order{}, cart.ErrOrderNotFound)
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name:  "InvalidTransition",
+			input: request,
+			mockSetup: func() {
+				mockUsecase.EXPECT().SetRestaurantOrderStatus(gomock.Any(), staffID, orderID, cart.StatusAccepted, "").
+					Return(models.Order{}, cart.ErrInvalidTransition)
+			},
+			expectedStatus: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := h.SetRestaurantOrderStatus(userContext(staffID, "testuser"), tt.input)
+
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Equal(t, cart.StatusAccepted, resp.Status)
+			}
+		})
+	}
+}
+
+func TestGetRestaurantOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+	staffID := uuid.NewV4()
+
+	mockUsecase.EXPECT().GetRestaurantOrders(gomock.Any(), staffID).
+		Return([]models.Order{{ID: uuid.NewV4(), UserID: uuid.NewV4().String(), Status: cart.StatusPaid, CreatedAt: time.Now()}}, nil)
+	resp, err := h.GetRestaurantOrders(userContext(staffID, "testuser"), &gen.RestaurantOrdersRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Orders, 1)
+
+	mockUsecase.EXPECT().GetRestaurantOrders(gomock.Any(), staffID).Return(nil, cart.ErrNotRestaurantStaff)
+	_, err = h.GetRestaurantOrders(userContext(staffID, "testuser"), &gen.RestaurantOrdersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCourierOrderAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+
+	courierID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	request := &gen.CourierOrderActionRequest{OrderId: orderID.String(), Action: cart.CourierActionPickup}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+	}{
+		{name: "Success", expectedStatus: codes.OK},
+		{name: "UnknownAction", err: cart.ErrUnknownCourierAction, expectedStatus: codes.InvalidArgument},
+		{name: "NotCourier", err: cart.ErrNotCourier, expectedStatus: codes.PermissionDenied},
+		{name: "NotAssigned", err: cart.ErrAssignmentNotFound, expectedStatus: codes.NotFound},
+		{name: "WrongStage", err: cart.ErrInvalidAssignment, expectedStatus: codes.FailedPrecondition},
+		{name: "NotReady", err: cart.ErrInvalidTransition, expectedStatus: codes.FailedPrecondition},
+		{name: "Internal", err: errors.New("db error"), expectedStatus: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.Order{ID: orderID, UserID: uuid.NewV4().String(), Status: cart.StatusInDelivery, CreatedAt: time.Now()}
+			if tt.err != nil {
+				order = models.Order{}
+			}
+			mockUsecase.EXPECT().CourierOrderAction(gomock.Any(), courierID, orderID, cart.CourierActionPickup).Return(order, tt.err)
+
+			resp, err := h.CourierOrderAction(userContext(courierID, "testuser"), request)
+
+			assert.Equal(t, tt.expectedStatus, status.Code(err))
+			if tt.expectedStatus == codes.OK {
+				assert.Equal(t, cart.StatusInDelivery, resp.Status)
+			}
+		})
+	}
+}
+
+func TestSetCourierAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUsecase(ctrl)
+	h := CreateCartHandler(mockUsecase)
+
+	userID := uuid.NewV4()
+	lat, lon := 55.75, 37.62
+	courier := models.Courier{ID: uuid.NewV4(), Name: "Иван", Available: true, Latitude: &lat, Longitude: &lon, MaxOrders: 2}
+
+	mockUsecase.EXPECT().
+		SetCourierAvailability(gomock.Any(), userID, models.CourierAvailabilityReq{Available: true, Latitude: &lat, Longitude: &lon}).
+		Return(courier, nil)
+	resp, err := h.SetCourierAvailability(userContext(userID, "testuser"),
+		&gen.CourierAvailabilityRequest{Available: true, Latitude: &lat, Longitude: &lon})
+	assert.NoError(t, err)
+	assert.Equal(t, courier.ID.String(), resp.Id)
+	assert.True(t, resp.Available)
+
+	mockUsecase.EXPECT().SetCourierAvailability(gomock.Any(), userID, gomock.Any()).Return(models.Courier{}, cart.ErrNotCourier)
+	_, err = h.SetCourierAvailability(userContext(userID, "testuser"), &gen.CourierAvailabilityRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUserMethodsRequireToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := CreateCartHandler(mocks.NewMockCartUsecase(ctrl))
+	ctx := context.Background()
+	orderID := uuid.NewV4().String()
+
+	calls := map[string]func() error{
+		"MergeGuestCart": func() error {
+			_, err := h.MergeGuestCart(ctx, &gen.MergeGuestCartRequest{GuestId: cart.GuestOwner(uuid.NewV4())})
+			return err
+		},
+		"UpdateItemQuantity": func() error {
+			_, err := h.UpdateItemQuantity(ctx, &gen.UpdateQuantityRequest{GuestId: "testuser", ProductId: "p", Quantity: 1})
+			return err
+		},
+		"GetOrders": func() error {
+			_, err := h.GetOrders(ctx, &gen.GetOrdersRequest{})
+			return err
+		},
+		"GetOrderById": func() error {
+			_, err := h.GetOrderById(ctx, &gen.GetOrderByIdRequest{OrderId: orderID})
+			return err
+		},
+		"CancelOrder": func() error {
+			_, err := h.CancelOrder(ctx, &gen.CancelOrderRequest{OrderId: orderID})
+			return err
+		},
+		"GetCourierOrders": func() error {
+			_, err := h.GetCourierOrders(ctx, &gen.CourierOrdersRequest{})
+			return err
+		},
+		"WatchOrder": func() error {
+			return h.WatchOrder(&gen.WatchOrderRequest{OrderId: orderID}, &fakeWatchStream{ctx: ctx})
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, codes.Unauthenticated, status.Code(call()))
+		})
+	}
+}
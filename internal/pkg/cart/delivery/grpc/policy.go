@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+)
+
+// RolePolicy — методы CartService, доступные только пользователям с определёнными ролями.
+// К какому ресторану относится сотрудник, по-прежнему решает usecase по restaurant_staff.
+var RolePolicy = grpcauth.Policy{
+	gen.CartService_GetRestaurantOrders_FullMethodName:      {models.RoleRestaurantStaff},
+	gen.CartService_SetRestaurantOrderStatus_FullMethodName: {models.RoleRestaurantStaff},
+
+	gen.CartService_SetCourierAvailability_FullMethodName: {models.RoleCourier},
+	gen.CartService_GetCourierOrders_FullMethodName:       {models.RoleCourier},
+	gen.CartService_CourierOrderAction_FullMethodName:     {models.RoleCourier},
+}
+
+// AnonymousMethods — методы CartService, которые можно вызывать без токена: гостевая корзина.
+// Подтверждение оплаты сюда не входит: у вебхука провайдера нет токена пользователя, поэтому
+// ConfirmPayment пропускается только с подписанным уведомлением (см. SignedMethods).
+var AnonymousMethods = grpcauth.Anonymous{
+	gen.CartService_GetCart_FullMethodName:            true,
+	gen.CartService_UpdateItemQuantity_FullMethodName: true,
+	gen.CartService_ClearCart_FullMethodName:          true,
+}
+
+// SignedMethods — методы CartService, которые вызываются с подписанным уведомлением провайдера
+// вместо токена пользователя.
+func SignedMethods(paymentSecret string) grpcauth.Signed {
+	return grpcauth.Signed{
+		gen.CartService_ConfirmPayment_FullMethodName: func(req interface{}) bool {
+			in, ok := req.(*gen.ConfirmPaymentRequest)
+			return ok && payment.VerifySignature(in.Notification, in.Signature, paymentSecret)
+		},
+	}
+}
@@ -4,69 +4,156 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	cartPkg "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
 	"github.com/satori/uuid"
 
+	authmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/auth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/converter"
 	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/send_error"
 	validation "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/validation"
-	"github.com/golang-jwt/jwt"
 	"github.com/gorilla/mux"
 	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	sseHeartbeatInterval = 15 * time.Second
+	maxWebhookBodySize   = 1 << 20
 )
 
 type CartHandler struct {
-	client gen.CartServiceClient
-	secret string
+	client        gen.CartServiceClient
+	guestSecret   string
+	paymentSecret string
+	guestTTL      time.Duration
 }
 
 func NewCartHandler(client gen.CartServiceClient) *CartHandler {
-	return &CartHandler{client: client, secret: os.Getenv("JWT_SECRET")}
+	return &CartHandler{
+		client:        client,
+		guestSecret:   os.Getenv("GUEST_COOKIE_SECRET"),
+		paymentSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		guestTTL:      cartPkg.CartTTLFromEnv(),
+	}
+}
+
+// cartOwner определяет владельца корзины: вошедшего пользователя или гостя по подписанной куке.
+func (h *CartHandler) cartOwner(r *http.Request) (string, error) {
+	if principal, ok := authmw.PrincipalFromContext(r.Context()); ok {
+		return principal.Login, nil
+	}
+
+	cookie, err := r.Cookie(jwtUtils.GuestCookieName)
+	if err != nil {
+		return "", fmt.Errorf("токен отсутствует")
+	}
+	guestID, ok := jwtUtils.GetGuestIDFromCookie(cookie.Value, h.guestSecret)
+	if !ok {
+		return "", fmt.Errorf("невалидная гостевая кука")
+	}
+	return cartPkg.GuestOwner(guestID), nil
+}
+
+// guestCartID возвращает GuestId для запроса к сервису корзин. Корзину вошедшего пользователя
+// сервис определяет по пересланному токену, поэтому логин в запрос не передаётся.
+func guestCartID(owner string) string {
+	if cartPkg.IsGuestOwner(owner) {
+		return owner
+	}
+	return ""
 }
 
 func (h *CartHandler) getCartData(r *http.Request) (models.Cart, string, error, bool) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
+	owner, err := h.cartOwner(r)
 	if err != nil {
-		if errors.Is(err, http.ErrNoCookie) {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			return models.Cart{}, "", fmt.Errorf("токен отсутствует"), false
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		return models.Cart{}, "", fmt.Errorf("ошибка при чтении куки"), false
+		log.LogHandlerError(logger, err, http.StatusUnauthorized)
+		return models.Cart{}, "", err, false
 	}
 
-	JWTStr := cookie.Value
-	claims := jwt.MapClaims{}
-
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
-	if !ok || login == "" {
-		log.LogHandlerError(logger, fmt.Errorf("невалидный токен"), http.StatusUnauthorized)
-		return models.Cart{}, "", fmt.Errorf("невалидный токен"), false
+	userCart, err, fullCart := h.fetchCart(r, owner)
+	if err != nil {
+		return models.Cart{}, "", err, false
 	}
+	return userCart, owner, nil, fullCart
+}
+
+func (h *CartHandler) fetchCart(r *http.Request, owner string) (models.Cart, error, bool) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	grpcResponse, err := h.client.GetCart(r.Context(), &gen.GetCartRequest{Login: login})
+	grpcResponse, err := h.client.GetCart(r.Context(), &gen.GetCartRequest{GuestId: guestCartID(owner)})
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("ошибка gRPC вызова: %w", err), http.StatusInternalServerError)
-		return models.Cart{}, "", fmt.Errorf("ошибка получения корзины"), false
+		return models.Cart{}, fmt.Errorf("ошибка получения корзины"), false
 	}
 
-	cart, err := converter.ProtoToCart(grpcResponse)
+	userCart, err := converter.ProtoToCart(grpcResponse)
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации корзины: %w", err), http.StatusInternalServerError)
-		return models.Cart{}, "", fmt.Errorf("ошибка обработки данных корзины"), false
+		return models.Cart{}, fmt.Errorf("ошибка обработки данных корзины"), false
 	}
 
-	return cart, login, nil, grpcResponse.FullCart
+	return userCart, nil, grpcResponse.FullCart
+}
+
+// startGuestSession выдаёт новому посетителю подписанную гостевую куку и CSRF-токен,
+// чтобы он мог собирать корзину до входа.
+func (h *CartHandler) startGuestSession(w http.ResponseWriter) string {
+	guestID := uuid.NewV4()
+	expires := time.Now().Add(h.guestTTL)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     jwtUtils.GuestCookieName,
+		Value:    jwtUtils.SignGuestID(guestID, h.guestSecret),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  expires,
+		Path:     "/",
+	})
+
+	csrfToken := uuid.NewV4().String()
+	http.SetCookie(w, &http.Cookie{
+		Name:     "CSRF-Token",
+		Value:    csrfToken,
+		Expires:  expires,
+		HttpOnly: false,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+	w.Header().Set("X-CSRF-Token", csrfToken)
+
+	return cartPkg.GuestOwner(guestID)
+}
+
+func clearGuestCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     jwtUtils.GuestCookieName,
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		MaxAge:   -1,
+		Path:     "/",
+	})
 }
 
 func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
@@ -80,12 +167,6 @@ func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
 	}
 	cart.Sanitize()
 
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		return
-	}
-
 	if !full_cart {
 		log.LogHandlerError(logger, fmt.Errorf("корзина пуста"), http.StatusNotFound)
 		utils.SendError(w, "корзина пуста", http.StatusNotFound)
@@ -107,22 +188,19 @@ func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
 func (h *CartHandler) UpdateQuantityInCart(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	_, login, err, _ := h.getCartData(r)
+	// Посетитель без входа и без гостевой куки получает новую гостевую корзину. CSRF здесь
+	// не проверяется: подделанный запрос лишь создаст пустую корзину, которой никто не владеет.
+	// Вошедшему пользователю с истёкшим токеном гостевая корзина не заводится: товары остались
+	// бы в ней после обмена refresh-токена, поэтому он получает 401 и сначала обновляет токен.
+	login, err := h.cartOwner(r)
 	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusUnauthorized)
-		utils.SendError(w, "некорректный JWT-токен", http.StatusUnauthorized)
-		return
-	}
-
-	if login == "" {
-		log.LogHandlerError(logger, errors.New("невалидный токен"), http.StatusUnauthorized)
-		utils.SendError(w, "невалидный токен", http.StatusUnauthorized)
-		return
-	}
-
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
+		if authmw.LoginExpired(r) {
+			log.LogHandlerError(logger, fmt.Errorf("access-токен истёк"), http.StatusUnauthorized)
+			utils.SendError(w, "токен истёк, обновите его", http.StatusUnauthorized)
+			return
+		}
+		login = h.startGuestSession(w)
+	} else if !authmw.RequireCSRF(w, r) {
 		return
 	}
 
@@ -138,22 +216,38 @@ func (h *CartHandler) UpdateQuantityInCart(w http.ResponseWriter, r *http.Reques
 
 	requestBody.Sanitize()
 
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+
 	_, err = h.client.UpdateItemQuantity(r.Context(), &gen.UpdateQuantityRequest{
-		Login:        login,
+		GuestId:      guestCartID(login),
 		ProductId:    productID,
 		RestaurantId: requestBody.RestaurantId,
 		Quantity:     int32(requestBody.Quantity),
+		Replace:      replace,
 	})
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		h.sendCartConflict(w, r, login, status.Convert(err).Message())
+		return
+	case codes.NotFound:
+		log.LogHandlerError(logger, fmt.Errorf("товар не найден: %w", err), http.StatusNotFound)
+		utils.SendError(w, "Товар не найден", http.StatusNotFound)
+		return
+	case codes.InvalidArgument:
+		log.LogHandlerError(logger, fmt.Errorf("некорректный товар: %w", err), http.StatusBadRequest)
+		utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("не удалось обновить количество: %w", err), http.StatusInternalServerError)
 		utils.SendError(w, "Не удалось обновить количество товара в корзине", http.StatusInternalServerError)
 		return
 	}
 
-	cart, _, err, full_cart := h.getCartData(r)
+	cart, err, full_cart := h.fetchCart(r, login)
 	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusUnauthorized)
-		utils.SendError(w, "некорректный JWT-токен", http.StatusUnauthorized)
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось получить корзину", http.StatusInternalServerError)
 		return
 	}
 
@@ -162,6 +256,7 @@ func (h *CartHandler) UpdateQuantityInCart(w http.ResponseWriter, r *http.Reques
 		utils.SendError(w, "корзина пуста", http.StatusOK)
 		return
 	}
+	cart.Sanitize()
 
 	w.Header().Set("Content-Type", "application/json")
 
@@ -175,45 +270,220 @@ func (h *CartHandler) UpdateQuantityInCart(w http.ResponseWriter, r *http.Reques
 	w.Write(data)
 }
 
+// sendCartConflict отвечает 409 и сообщает, из какого ресторана уже собрана корзина,
+// чтобы клиент мог предложить заменить её повторным запросом с replace=true.
+func (h *CartHandler) sendCartConflict(w http.ResponseWriter, r *http.Request, owner, message string) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	conflict := models.CartConflict{Error: message}
+	if current, err, _ := h.fetchCart(r, owner); err == nil {
+		conflict.RestaurantID = current.Id.String()
+		conflict.RestaurantName = current.Name
+	}
+
+	data, err := easyjson.Marshal(conflict)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка сериализации ответа", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	w.Write(data)
+	log.LogHandlerError(logger, errors.New(message), http.StatusConflict)
+}
+
 func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
-	cookie, err := r.Cookie("AdminJWT")
+
+	login, err := h.cartOwner(r)
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusUnauthorized)
+		utils.SendError(w, authmw.ErrUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	_, err = h.client.ClearCart(r.Context(), &gen.ClearCartRequest{GuestId: guestCartID(login)})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Ошибка при очистке корзины: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+// PreviewPromo показывает корзину пользователя со скидкой по промокоду; промокод при этом не расходуется.
+func (h *CartHandler) PreviewPromo(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	userCart, login, err, fullCart := h.getCartData(r)
 	if err != nil {
-		if errors.Is(err, http.ErrNoCookie) {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "JWT cookie not found", http.StatusUnauthorized)
-			return
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "Bad request", http.StatusBadRequest)
+		log.LogHandlerError(logger, err, http.StatusUnauthorized)
+		utils.SendError(w, authmw.ErrUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+	if cartPkg.IsGuestOwner(login) {
+		log.LogHandlerError(logger, errors.New("гость применяет промокод"), http.StatusUnauthorized)
+		utils.SendError(w, "войдите, чтобы применить промокод", http.StatusUnauthorized)
 		return
 	}
 
-	JWTStr := cookie.Value
-	claims := jwt.MapClaims{}
+	if !fullCart {
+		log.LogHandlerError(logger, fmt.Errorf("корзина пуста"), http.StatusNotFound)
+		utils.SendError(w, "корзина пуста", http.StatusNotFound)
+		return
+	}
 
-	login, _ := jwtUtils.GetLoginFromJWT(JWTStr, claims, h.secret)
+	var req models.PromoReq
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка чтения тела запроса: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
+		return
+	}
+	if err := validation.ValidatePromoCode(req.PromoCode); err != nil {
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		utils.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if login == "" {
-		log.LogHandlerError(logger, errors.New("пустой login из токена"), http.StatusUnauthorized)
-		utils.SendError(w, "некорректный JWT токен", http.StatusForbidden)
+	grpcResponse, err := h.client.PreviewPromo(r.Context(), &gen.PreviewPromoRequest{
+		PromoCode: req.PromoCode,
+		Cart:      converter.CartToProto(userCart),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		log.LogHandlerError(logger, fmt.Errorf("промокод не применён: %w", err), http.StatusBadRequest)
+		utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка gRPC вызова: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось применить промокод", http.StatusInternalServerError)
 		return
 	}
 
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
+	preview, err := converter.ProtoToPromoPreview(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось применить промокод", http.StatusInternalServerError)
 		return
 	}
+	preview.Sanitize()
 
-	_, err = h.client.ClearCart(r.Context(), &gen.ClearCartRequest{Login: login})
+	data, err := easyjson.Marshal(preview)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка при очистке корзины: %v", err), http.StatusInternalServerError)
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка сериализации ответа", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// guestCartConflictHeader сообщает клиенту после входа, что гостевую корзину не удалось перенести:
+// у пользователя уже есть корзина другого ресторана. Решение принимается через POST /cart/merge.
+const guestCartConflictHeader = "X-Guest-Cart-Conflict"
+
+// MergeGuestCartOnLogin переносит гостевую корзину в корзину только что вошедшего пользователя,
+// от имени которого действует выданный при входе access-токен. Ошибки не мешают входу:
+// при конфликте ресторанов гостевая корзина сохраняется.
+func (h *CartHandler) MergeGuestCartOnLogin(w http.ResponseWriter, r *http.Request, token string) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	cookie, err := r.Cookie(jwtUtils.GuestCookieName)
+	if err != nil {
+		return
+	}
+	guestID, ok := jwtUtils.GetGuestIDFromCookie(cookie.Value, h.guestSecret)
+	if !ok {
+		clearGuestCookie(w)
+		return
+	}
+
+	ctx := grpcauth.ContextWithToken(r.Context(), token)
+	_, err = h.client.MergeGuestCart(ctx, &gen.MergeGuestCartRequest{
+		GuestId: cartPkg.GuestOwner(guestID),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		clearGuestCookie(w)
+	case codes.FailedPrecondition:
+		w.Header().Set(guestCartConflictHeader, "true")
+		logger.Info("гостевая корзина не перенесена: корзина другого ресторана")
+	default:
+		logger.Error("не удалось перенести гостевую корзину", slog.String("error", err.Error()))
+	}
+}
+
+// MergeGuestCart повторяет перенос гостевой корзины после конфликта при входе;
+// с replace=true корзина пользователя заменяется гостевой.
+func (h *CartHandler) MergeGuestCart(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	login, err := h.cartOwner(r)
+	if err != nil || cartPkg.IsGuestOwner(login) {
+		log.LogHandlerError(logger, errors.New("пользователь не авторизован"), http.StatusUnauthorized)
+		utils.SendError(w, "ошибка авторизации", http.StatusUnauthorized)
+		return
+	}
+
+	cookie, err := r.Cookie(jwtUtils.GuestCookieName)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("гостевая кука отсутствует: %w", err), http.StatusNotFound)
+		utils.SendError(w, "гостевая корзина не найдена", http.StatusNotFound)
+		return
+	}
+	guestID, ok := jwtUtils.GetGuestIDFromCookie(cookie.Value, h.guestSecret)
+	if !ok {
+		clearGuestCookie(w)
+		log.LogHandlerError(logger, errors.New("невалидная гостевая кука"), http.StatusNotFound)
+		utils.SendError(w, "гостевая корзина не найдена", http.StatusNotFound)
+		return
+	}
+
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+
+	_, err = h.client.MergeGuestCart(r.Context(), &gen.MergeGuestCartRequest{
+		GuestId: cartPkg.GuestOwner(guestID),
+		Replace: replace,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		h.sendCartConflict(w, r, login, status.Convert(err).Message())
+		return
+	}
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("не удалось перенести корзину: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось перенести корзину", http.StatusInternalServerError)
+		return
+	}
+	clearGuestCookie(w)
+
+	userCart, err, fullCart := h.fetchCart(r, login)
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось получить корзину", http.StatusInternalServerError)
+		return
+	}
+	if !fullCart {
+		log.LogHandlerError(logger, fmt.Errorf("корзина пуста"), http.StatusNotFound)
+		utils.SendError(w, "корзина пуста", http.StatusNotFound)
+		return
+	}
+	userCart.Sanitize()
+
+	data, err := json.Marshal(userCart)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать корзину", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
 }
 
 func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
@@ -222,13 +492,13 @@ func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	cart, login, err, full_cart := h.getCartData(r)
 	if err != nil {
 		log.LogHandlerError(logger, err, http.StatusUnauthorized)
-		utils.SendError(w, "некорректный JWT-токен", http.StatusUnauthorized)
+		utils.SendError(w, authmw.ErrUnauthorized.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
+	if cartPkg.IsGuestOwner(login) {
+		log.LogHandlerError(logger, errors.New("гость оформляет заказ"), http.StatusUnauthorized)
+		utils.SendError(w, "войдите, чтобы оформить заказ", http.StatusUnauthorized)
 		return
 	}
 
@@ -244,7 +514,6 @@ func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
 		return
 	}
-	cart.Sanitize()
 
 	if err := validation.ValidateOrderInput(&req); err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("валидация заказа: %w", err), http.StatusBadRequest)
@@ -252,12 +521,21 @@ func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	grpcReq := converter.OrderInReqToProto(req, cart, login)
+	grpcReq := converter.OrderInReqToProto(req, cart)
 
 	grpcResponse, err := h.client.CreateOrder(r.Context(), grpcReq)
 	if err != nil {
-		log.LogHandlerError(logger, fmt.Errorf("не удалось создать заказ: %w", err), http.StatusInternalServerError)
-		utils.SendError(w, "Ошибка при создании заказа", http.StatusInternalServerError)
+		switch status.Code(err) {
+		case codes.Aborted, codes.FailedPrecondition:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось создать заказ: %w", err), http.StatusConflict)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusConflict)
+		case codes.InvalidArgument:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось создать заказ: %w", err), http.StatusBadRequest)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось создать заказ: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "Ошибка при создании заказа", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -268,7 +546,7 @@ func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.client.ClearCart(r.Context(), &gen.ClearCartRequest{Login: login})
+	_, err = h.client.ClearCart(r.Context(), &gen.ClearCartRequest{GuestId: guestCartID(login)})
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("ошибка при очистке корзины: %w", err), http.StatusInternalServerError)
 		utils.SendError(w, fmt.Sprintf("ошибка при очистке корзины: %v", err), http.StatusInternalServerError)
@@ -287,62 +565,32 @@ func (h *CartHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
+// nextCursorHeader передаёт курсор следующей страницы истории заказов: тело ответа
+// остаётся массивом заказов, как до появления курсоров.
+const nextCursorHeader = "X-Next-Cursor"
+
 func (h *CartHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "Токен отсутствует", http.StatusUnauthorized)
-			return
-		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "Ошибка при чтении куки", http.StatusBadRequest)
-		return
-	}
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
-	JWTStr := cookie.Value
-
-	claims := jwt.MapClaims{}
-
-	userIdStr, ok := jwtUtils.GetIdFromJWT(JWTStr, claims, h.secret)
-	if !ok || userIdStr == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: id отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "Недействительный токен: id отсутствует", http.StatusUnauthorized)
-		return
-	}
-	userId, err := uuid.FromString(userIdStr)
+	request, err := parseOrdersQuery(r.URL.Query())
 	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusInternalServerError)
-		utils.SendError(w, err.Error(), http.StatusInternalServerError)
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		utils.SendError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	countStr := r.URL.Query().Get("count")
-	offsetStr := r.URL.Query().Get("offset")
-
-	count, err := strconv.Atoi(countStr)
-	if err != nil {
-		count = 15
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
-		offset = 0
-	}
-
-	grpcResponse, err := h.client.GetOrders(r.Context(), &gen.GetOrdersRequest{
-		UserId: userId.String(),
-		Count:  int32(count),
-		Offset: int32(offset),
-	})
+	grpcResponse, err := h.client.GetOrders(r.Context(), request)
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			log.LogHandlerError(logger, err, http.StatusBadRequest)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
 		log.LogHandlerError(logger, fmt.Errorf("ошибка уровнем ниже (usecase): %w", err), http.StatusInternalServerError)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -366,45 +614,98 @@ func (h *CartHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if grpcResponse.NextCursor != "" {
+		w.Header().Set(nextCursorHeader, grpcResponse.NextCursor)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(data)
 	log.LogHandlerInfo(logger, "Success", http.StatusOK)
 }
 
-func (h *CartHandler) GetOrderById(w http.ResponseWriter, r *http.Request) {
-	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+// parseOrdersQuery разбирает фильтры истории заказов: status (можно несколько через запятую),
+// restaurant_id, from/to (RFC 3339, to не включается), min_total/max_total, sort=newest|oldest, cursor и count.
+// Пагинация по offset больше не поддерживается: с новыми заказами она давала пропуски и повторы.
+func parseOrdersQuery(query url.Values) (*gen.GetOrdersRequest, error) {
+	if query.Has("offset") {
+		return nil, errors.New("параметр offset не поддерживается, используйте cursor из заголовка " + nextCursorHeader)
+	}
 
-	cookie, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			log.LogHandlerError(logger, fmt.Errorf("токен отсутствует: %w", err), http.StatusUnauthorized)
-			utils.SendError(w, "Токен отсутствует", http.StatusUnauthorized)
-			return
+	request := &gen.GetOrdersRequest{
+		RestaurantId: query.Get("restaurant_id"),
+		Cursor:       query.Get("cursor"),
+	}
+
+	if countStr := query.Get("count"); countStr != "" {
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count <= 0 {
+			return nil, errors.New("некорректное количество заказов")
 		}
-		log.LogHandlerError(logger, fmt.Errorf("ошибка при чтении куки: %w", err), http.StatusBadRequest)
-		utils.SendError(w, "Ошибка при чтении куки", http.StatusBadRequest)
-		return
+		request.Count = int32(count)
 	}
-	if !jwtUtils.CheckDoubleSubmitCookie(w, r) {
-		log.LogHandlerError(logger, errors.New("некорректный CSRF-токен"), http.StatusForbidden)
-		utils.SendError(w, "некорректный CSRF-токен", http.StatusForbidden)
-		return
+
+	for _, value := range query["status"] {
+		for _, orderStatus := range strings.Split(value, ",") {
+			if orderStatus = strings.TrimSpace(orderStatus); orderStatus != "" {
+				request.Statuses = append(request.Statuses, orderStatus)
+			}
+		}
 	}
 
-	JWTStr := cookie.Value
+	var err error
+	if request.From, err = parseQueryTime(query, "from"); err != nil {
+		return nil, err
+	}
+	if request.To, err = parseQueryTime(query, "to"); err != nil {
+		return nil, err
+	}
+	if request.MinTotal, err = parseQueryTotal(query, "min_total"); err != nil {
+		return nil, err
+	}
+	if request.MaxTotal, err = parseQueryTotal(query, "max_total"); err != nil {
+		return nil, err
+	}
 
-	claims := jwt.MapClaims{}
+	switch query.Get("sort") {
+	case "", "newest":
+	case "oldest":
+		request.OldestFirst = true
+	default:
+		return nil, errors.New("некорректная сортировка: ожидается newest или oldest")
+	}
 
-	userIdStr, ok := jwtUtils.GetIdFromJWT(JWTStr, claims, h.secret)
-	if !ok || userIdStr == "" {
-		log.LogHandlerError(logger, errors.New("недействительный токен: id отсутствует"), http.StatusUnauthorized)
-		utils.SendError(w, "Недействительный токен: id отсутствует", http.StatusUnauthorized)
-		return
+	return request, nil
+}
+
+func parseQueryTime(query url.Values, param string) (*timestamppb.Timestamp, error) {
+	value := query.Get(param)
+	if value == "" {
+		return nil, nil
 	}
-	userId, err := uuid.FromString(userIdStr)
+	t, err := time.Parse(time.RFC3339, value)
 	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusInternalServerError)
-		utils.SendError(w, err.Error(), http.StatusInternalServerError)
+		return nil, fmt.Errorf("некорректная дата %s: ожидается формат RFC 3339", param)
+	}
+	return timestamppb.New(t), nil
+}
+
+func parseQueryTotal(query url.Values, param string) (float64, error) {
+	value := query.Get(param)
+	if value == "" {
+		return 0, nil
+	}
+	total, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("некорректная сумма %s", param)
+	}
+	return total, nil
+}
+
+func (h *CartHandler) GetOrderById(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
 
@@ -417,7 +718,12 @@ func (h *CartHandler) GetOrderById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	grpcResponse, err := h.client.GetOrderById(r.Context(), &gen.GetOrderByIdRequest{OrderId: orderID.String(), UserId: userId.String()})
+	grpcResponse, err := h.client.GetOrderById(r.Context(), &gen.GetOrderByIdRequest{OrderId: orderID.String()})
+	if status.Code(err) == codes.NotFound {
+		log.LogHandlerError(logger, fmt.Errorf("заказ не найден: %w", err), http.StatusNotFound)
+		utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		log.LogHandlerError(logger, fmt.Errorf("не удалось получить заказ: %w", err), http.StatusInternalServerError)
 		utils.SendError(w, "не удалось получить заказ", http.StatusInternalServerError)
@@ -443,30 +749,650 @@ func (h *CartHandler) GetOrderById(w http.ResponseWriter, r *http.Request) {
 	log.LogHandlerInfo(logger, "Success", http.StatusOK)
 }
 
-func (h *CartHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+func (h *CartHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 
-	err := r.ParseForm()
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
 	if err != nil {
-		log.LogHandlerError(logger, err, http.StatusBadRequest)
-		utils.SendError(w, "не удалось распарсить форму", http.StatusBadRequest)
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
 		return
 	}
 
-	orderID := r.FormValue("label")
-	if orderID == "" {
-		log.LogHandlerError(logger, fmt.Errorf("label not found"), http.StatusBadRequest)
-		utils.SendError(w, "не передан id заказа (label)", http.StatusBadRequest)
+	var req models.CancelOrderReq
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка чтения тела запроса: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
+		return
+	}
+	if err := validation.ValidateCancelReason(req.Reason); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("валидация причины отмены: %w", err), http.StatusBadRequest)
+		utils.SendError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	_, err = h.client.UpdateOrderStatus(r.Context(), &gen.UpdateOrderStatusRequest{OrderId: orderID})
+	grpcResponse, err := h.client.CancelOrder(r.Context(), &gen.CancelOrderRequest{
+		OrderId: orderID.String(),
+		Reason:  req.Reason,
+	})
 	if err != nil {
-		log.LogHandlerError(logger, fmt.Errorf("не удалось получить заказ: %w", err), http.StatusInternalServerError)
-		utils.SendError(w, "не удалось получить заказ", http.StatusInternalServerError)
+		switch status.Code(err) {
+		case codes.NotFound:
+			log.LogHandlerError(logger, fmt.Errorf("заказ не найден: %w", err), http.StatusNotFound)
+			utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось отменить заказ: %w", err), http.StatusConflict)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusConflict)
+		case codes.InvalidArgument:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось отменить заказ: %w", err), http.StatusBadRequest)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось отменить заказ: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "не удалось отменить заказ", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	order, err := converter.ProtoToOrder(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// UpdateTip меняет чаевые курьеру, пока после вручения заказа не истекло время на изменение.
+func (h *CartHandler) UpdateTip(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
+		return
+	}
+
+	var req models.TipReq
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка чтения тела запроса: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
+		return
+	}
+	if err := validation.ValidateTip(req.Amount, req.Percent); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("валидация чаевых: %w", err), http.StatusBadRequest)
+		utils.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	grpcResponse, err := h.client.UpdateTip(r.Context(), &gen.UpdateTipRequest{
+		OrderId:    orderID.String(),
+		TipAmount:  req.Amount,
+		TipPercent: req.Percent,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			log.LogHandlerError(logger, fmt.Errorf("заказ не найден: %w", err), http.StatusNotFound)
+			utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось изменить чаевые: %w", err), http.StatusConflict)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusConflict)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось изменить чаевые: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "не удалось изменить чаевые", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	order, err := converter.ProtoToOrder(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// Reorder собирает корзину из прошлого заказа. С ?replace=true корзина другого ресторана заменяется.
+func (h *CartHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	principal, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+	login := principal.Login
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
+		return
+	}
+
+	grpcResponse, err := h.client.Reorder(r.Context(), &gen.ReorderRequest{
+		OrderId: orderID.String(),
+		Replace: r.URL.Query().Get("replace") == "true",
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			log.LogHandlerError(logger, fmt.Errorf("заказ не найден: %w", err), http.StatusNotFound)
+			utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			h.sendCartConflict(w, r, login, status.Convert(err).Message())
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось повторить заказ: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "не удалось повторить заказ", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	result, err := converter.ProtoToReorderResult(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации ответа: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+		return
+	}
+	result.Sanitize()
+
+	data, err := easyjson.Marshal(result)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// PaymentWebhook принимает уведомление платёжного провайдера об успешной оплате.
+// Тело запроса должно быть подписано HMAC-SHA256 общим секретом. Сервис корзин проверяет
+// подпись ещё раз, поэтому уведомление пересылается ему без изменений.
+func (h *CartHandler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("не удалось прочитать тело запроса: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "не удалось прочитать тело запроса", http.StatusBadRequest)
+		return
+	}
+
+	if !payment.VerifySignature(body, r.Header.Get(payment.SignatureHeader), h.paymentSecret) {
+		log.LogHandlerError(logger, fmt.Errorf("невалидная подпись уведомления"), http.StatusUnauthorized)
+		utils.SendError(w, "невалидная подпись", http.StatusUnauthorized)
+		return
+	}
+
+	var notification models.PaymentNotification
+	if err := easyjson.Unmarshal(body, &notification); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка парсинга JSON: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "Ошибка парсинга JSON", http.StatusBadRequest)
+		return
+	}
+	if notification.OrderID == "" || notification.PaymentID == "" {
+		log.LogHandlerError(logger, fmt.Errorf("не переданы id заказа или платежа"), http.StatusBadRequest)
+		utils.SendError(w, "не переданы id заказа или платежа", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.client.ConfirmPayment(r.Context(), &gen.ConfirmPaymentRequest{
+		Notification: body,
+		Signature:    r.Header.Get(payment.SignatureHeader),
+	})
+	if err != nil {
+		code := http.StatusInternalServerError
+		msg := "не удалось подтвердить оплату"
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			code, msg = http.StatusUnauthorized, "невалидная подпись"
+		case codes.InvalidArgument:
+			code, msg = http.StatusBadRequest, status.Convert(err).Message()
+		case codes.NotFound:
+			code, msg = http.StatusNotFound, "Заказ не найден"
+		case codes.FailedPrecondition:
+			code, msg = http.StatusConflict, status.Convert(err).Message()
+		}
+		log.LogHandlerError(logger, fmt.Errorf("не удалось подтвердить оплату: %w", err), code)
+		utils.SendError(w, msg, code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// OrderEvents транслирует смены статуса заказа в формате Server-Sent Events.
+func (h *CartHandler) OrderEvents(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
+		return
+	}
+
+	stream, err := h.client.WatchOrder(r.Context(), &gen.WatchOrderRequest{OrderId: orderID.String()})
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("не удалось подписаться на заказ: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось подписаться на заказ", http.StatusInternalServerError)
+		return
+	}
+
+	// Ошибки серверного стрима приходят только с первым Recv, поэтому читаем его до отправки заголовков.
+	update, err := stream.Recv()
+	if err != nil {
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		log.LogHandlerError(logger, fmt.Errorf("не удалось получить статус заказа: %w", err), code)
+		utils.SendError(w, "не удалось получить статус заказа", code)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// Поток живёт дольше WriteTimeout сервера.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	updates := make(chan *gen.OrderUpdate)
+	go func(next *gen.OrderUpdate) {
+		defer close(updates)
+		for {
+			select {
+			case updates <- next:
+			case <-r.Context().Done():
+				return
+			}
+			var err error
+			if next, err = stream.Recv(); err != nil {
+				return
+			}
+		}
+	}(update)
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case update, ok := <-updates:
+			if !ok {
+				log.LogHandlerInfo(logger, "Stream closed", http.StatusOK)
+				return
+			}
+			event, err := converter.ProtoToOrderStatusEvent(update.Event)
+			if err != nil {
+				logger.Error("ошибка конвертации события", slog.String("error", err.Error()))
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Error("ошибка маршалинга события", slog.String("error", err.Error()))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// restaurantOrderActions сопоставляет действие персонала ресторана из URL с новым статусом заказа.
+var restaurantOrderActions = map[string]string{
+	"accept":  cartPkg.StatusAccepted,
+	"reject":  cartPkg.StatusCancelled,
+	"cooking": cartPkg.StatusCooking,
+	"ready":   cartPkg.StatusReadyForPickup,
+}
+
+// GetRestaurantOrders отдаёт сотруднику ресторана необработанные заказы его ресторана.
+func (h *CartHandler) GetRestaurantOrders(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	grpcResponse, err := h.client.GetRestaurantOrders(r.Context(), &gen.RestaurantOrdersRequest{})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			log.LogHandlerError(logger, fmt.Errorf("нет доступа к заказам ресторана: %w", err), http.StatusForbidden)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		log.LogHandlerError(logger, fmt.Errorf("не удалось получить заказы ресторана: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось получить заказы ресторана", http.StatusInternalServerError)
+		return
+	}
+
+	orders := make([]models.Order, 0, len(grpcResponse.Orders))
+	for _, grpcOrder := range grpcResponse.Orders {
+		order, err := converter.ProtoToOrder(grpcOrder)
+		if err != nil {
+			log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+			return
+		}
+		orders = append(orders, order)
+	}
+
+	data, err := json.Marshal(orders)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+// UpdateRestaurantOrder выполняет действие персонала над заказом: accept, reject (с причиной в теле),
+// cooking или ready.
+func (h *CartHandler) UpdateRestaurantOrder(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
+		return
+	}
+	newStatus, ok := restaurantOrderActions[mux.Vars(r)["action"]]
+	if !ok {
+		log.LogHandlerError(logger, errors.New("неизвестное действие с заказом"), http.StatusBadRequest)
+		utils.SendError(w, "неизвестное действие с заказом", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CancelOrderReq
+	if newStatus == cartPkg.StatusCancelled {
+		if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+			log.LogHandlerError(logger, fmt.Errorf("ошибка чтения тела запроса: %w", err), http.StatusBadRequest)
+			utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
+			return
+		}
+		if err := validation.ValidateCancelReason(req.Reason); err != nil {
+			log.LogHandlerError(logger, fmt.Errorf("валидация причины отказа: %w", err), http.StatusBadRequest)
+			utils.SendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	grpcResponse, err := h.client.SetRestaurantOrderStatus(r.Context(), &gen.RestaurantOrderStatusRequest{
+		OrderId: orderID.String(),
+		Status:  newStatus,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.PermissionDenied:
+			log.LogHandlerError(logger, fmt.Errorf("нет доступа к заказам ресторана: %w", err), http.StatusForbidden)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusForbidden)
+		case codes.NotFound:
+			log.LogHandlerError(logger, fmt.Errorf("заказ не найден: %w", err), http.StatusNotFound)
+			utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось сменить статус заказа: %w", err), http.StatusConflict)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusConflict)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось сменить статус заказа: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "не удалось сменить статус заказа", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	order, err := converter.ProtoToOrder(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+func (h *CartHandler) SetCourierAvailability(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	var req models.CourierAvailabilityReq
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка чтения тела запроса: %w", err), http.StatusBadRequest)
+		utils.SendError(w, "Некорректный формат данных", http.StatusBadRequest)
+		return
+	}
+	if err := validation.ValidateCourierPosition(req.Latitude, req.Longitude); err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("валидация координат: %w", err), http.StatusBadRequest)
+		utils.SendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	grpcResponse, err := h.client.SetCourierAvailability(r.Context(), &gen.CourierAvailabilityRequest{
+		Available: req.Available,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			log.LogHandlerError(logger, fmt.Errorf("пользователь не курьер: %w", err), http.StatusForbidden)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		log.LogHandlerError(logger, fmt.Errorf("не удалось обновить статус курьера: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось обновить статус курьера", http.StatusInternalServerError)
+		return
+	}
+
+	courier, err := converter.ProtoToCourier(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации курьера: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных курьера", http.StatusInternalServerError)
+		return
+	}
+	courier.Sanitize()
+
+	data, err := json.Marshal(courier)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+func (h *CartHandler) GetCourierOrders(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	grpcResponse, err := h.client.GetCourierOrders(r.Context(), &gen.CourierOrdersRequest{})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			log.LogHandlerError(logger, fmt.Errorf("пользователь не курьер: %w", err), http.StatusForbidden)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		log.LogHandlerError(logger, fmt.Errorf("не удалось получить заказы курьера: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "не удалось получить заказы курьера", http.StatusInternalServerError)
+		return
+	}
+
+	orders := make([]models.Order, 0, len(grpcResponse.Orders))
+	for _, grpcOrder := range grpcResponse.Orders {
+		order, err := converter.ProtoToOrder(grpcOrder)
+		if err != nil {
+			log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+			return
+		}
+		orders = append(orders, order)
+	}
+
+	data, err := json.Marshal(orders)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+	log.LogHandlerInfo(logger, "Success", http.StatusOK)
+}
+
+func (h *CartHandler) UpdateCourierOrder(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	_, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
+		return
+	}
+
+	orderID, err := uuid.FromString(mux.Vars(r)["orderID"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("невалидный id заказа"), http.StatusBadRequest)
+		utils.SendError(w, "невалидный id заказа", http.StatusBadRequest)
+		return
+	}
+	action := mux.Vars(r)["action"]
+	if !cartPkg.IsCourierAction(action) {
+		log.LogHandlerError(logger, errors.New("неизвестное действие курьера"), http.StatusBadRequest)
+		utils.SendError(w, "неизвестное действие курьера", http.StatusBadRequest)
+		return
+	}
+
+	grpcResponse, err := h.client.CourierOrderAction(r.Context(), &gen.CourierOrderActionRequest{
+		OrderId: orderID.String(),
+		Action:  action,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			log.LogHandlerError(logger, fmt.Errorf("некорректное действие курьера: %w", err), http.StatusBadRequest)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.PermissionDenied:
+			log.LogHandlerError(logger, fmt.Errorf("пользователь не курьер: %w", err), http.StatusForbidden)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusForbidden)
+		case codes.NotFound:
+			log.LogHandlerError(logger, fmt.Errorf("заказ не назначен курьеру: %w", err), http.StatusNotFound)
+			utils.SendError(w, "заказ не найден", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			log.LogHandlerError(logger, fmt.Errorf("действие курьера недоступно: %w", err), http.StatusConflict)
+			utils.SendError(w, status.Convert(err).Message(), http.StatusConflict)
+		default:
+			log.LogHandlerError(logger, fmt.Errorf("не удалось выполнить действие курьера: %w", err), http.StatusInternalServerError)
+			utils.SendError(w, "не удалось выполнить действие курьера", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	order, err := converter.ProtoToOrder(grpcResponse)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка конвертации заказа: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Ошибка обработки данных заказа", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.LogHandlerError(logger, fmt.Errorf("ошибка маршалинга: %w", err), http.StatusInternalServerError)
+		utils.SendError(w, "Не удалось сериализовать данные", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
 	log.LogHandlerInfo(logger, "Success", http.StatusOK)
 }
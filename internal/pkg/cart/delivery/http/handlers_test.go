@@ -1,22 +1,34 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	cartPkg "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/mocks"
+	authmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/auth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
 	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/gorilla/mux"
 	"github.com/satori/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestGetCart(t *testing.T) {
@@ -55,8 +67,7 @@ func TestGetCart(t *testing.T) {
 			grpcErr: nil,
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("GET", "/cart", nil)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userId)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
 				return r
@@ -75,8 +86,7 @@ func TestGetCart(t *testing.T) {
 			grpcErr: nil,
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("GET", "/cart", nil)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userId)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userId)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrf_token})
 				r.Header.Set("X-CSRF-Token", csrf_token)
 				return r
@@ -95,13 +105,13 @@ func TestGetCart(t *testing.T) {
 			if tt.login != "" && tt.grpcResponse != nil {
 				mockClient.EXPECT().GetCart(
 					gomock.Any(),
-					&gen.GetCartRequest{Login: tt.login},
+					&gen.GetCartRequest{},
 				).Return(tt.grpcResponse, tt.grpcErr)
 			}
 
 			handler := CartHandler{
-				client: mockClient,
-				secret: secret,
+				client:      mockClient,
+				guestSecret: secret,
 			}
 
 			req := tt.setupRequest()
@@ -128,6 +138,7 @@ func TestUpdateQuantityInCart(t *testing.T) {
 	userID := uuid.NewV4()
 	productID := uuid.NewV4().String()
 	restaurantID := uuid.NewV4().String()
+	otherRestaurantID := uuid.NewV4().String()
 
 	validCart := &gen.CartResponse{
 		RestaurantId:   restaurantID,
@@ -150,6 +161,7 @@ func TestUpdateQuantityInCart(t *testing.T) {
 		requestBody      string
 		setupRequest     func() *http.Request
 		expectStatus     int
+		expectBody       string
 		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
 	}{
 		{
@@ -159,16 +171,14 @@ func TestUpdateQuantityInCart(t *testing.T) {
 			setupRequest: func() *http.Request {
 				body := strings.NewReader(fmt.Sprintf(`{"quantity": 3, "restaurant_id": "%s"}`, restaurantID))
 				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s", productID), body)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userID)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
 				r.Header.Set("X-CSRF-Token", csrfToken)
 				return r
 			},
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
-				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{Login: login}).Return(validCart, nil).Times(2)
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(validCart, nil)
 				mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), &gen.UpdateQuantityRequest{
-					Login:        login,
 					ProductId:    productID,
 					RestaurantId: restaurantID,
 					Quantity:     3,
@@ -182,12 +192,86 @@ func TestUpdateQuantityInCart(t *testing.T) {
 			setupRequest: func() *http.Request {
 				body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
 				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s", productID), body)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userID)
 				return r
 			},
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
-				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{Login: login}).Return(validCart, nil).AnyTimes()
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(validCart, nil).AnyTimes()
+			},
+		},
+		{
+			name:         "UpdateQuantity_OtherRestaurant",
+			expectStatus: http.StatusConflict,
+			expectBody:   fmt.Sprintf(`"restaurant_id":"%s"`, restaurantID),
+			setupRequest: func() *http.Request {
+				body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, otherRestaurantID))
+				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s", productID), body)
+				r = withPrincipal(r, login, userID)
+				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				return r
+			},
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(validCart, nil)
+				mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), &gen.UpdateQuantityRequest{
+					ProductId:    productID,
+					RestaurantId: otherRestaurantID,
+					Quantity:     1,
+				}).Return(nil, status.Error(codes.FailedPrecondition, "в корзине уже есть товары из другого ресторана"))
+			},
+		},
+		{
+			name:         "UpdateQuantity_ProductNotFound",
+			expectStatus: http.StatusNotFound,
+			setupRequest: func() *http.Request {
+				body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
+				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s", productID), body)
+				r = withPrincipal(r, login, userID)
+				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				return r
+			},
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "товар не найден"))
+			},
+		},
+		{
+			name:         "UpdateQuantity_ProductOfAnotherRestaurant",
+			expectStatus: http.StatusBadRequest,
+			expectBody:   "товар не найден в меню ресторана",
+			setupRequest: func() *http.Request {
+				body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
+				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s", productID), body)
+				r = withPrincipal(r, login, userID)
+				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				return r
+			},
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "товар не найден в меню ресторана"))
+			},
+		},
+		{
+			name:         "UpdateQuantity_ReplaceCart",
+			expectStatus: http.StatusOK,
+			setupRequest: func() *http.Request {
+				body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, otherRestaurantID))
+				r := httptest.NewRequest("PUT", fmt.Sprintf("/cart/%s?replace=true", productID), body)
+				r = withPrincipal(r, login, userID)
+				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+				r.Header.Set("X-CSRF-Token", csrfToken)
+				return r
+			},
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(validCart, nil)
+				mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), &gen.UpdateQuantityRequest{
+					ProductId:    productID,
+					RestaurantId: otherRestaurantID,
+					Quantity:     1,
+					Replace:      true,
+				}).Return(&empty.Empty{}, nil)
 			},
 		},
 	}
@@ -201,8 +285,8 @@ func TestUpdateQuantityInCart(t *testing.T) {
 			tt.mockGrpcBehavior(mockClient)
 
 			handler := CartHandler{
-				client: mockClient,
-				secret: secret,
+				client:      mockClient,
+				guestSecret: secret,
 			}
 
 			req := tt.setupRequest()
@@ -212,6 +296,7 @@ func TestUpdateQuantityInCart(t *testing.T) {
 			handler.UpdateQuantityInCart(w, req)
 
 			assert.Equal(t, tt.expectStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectBody)
 		})
 	}
 }
@@ -232,72 +317,48 @@ func TestClearCart(t *testing.T) {
 			name: "ClearCart_Success",
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("DELETE", "/cart", nil)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userID)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
 				r.Header.Set("X-CSRF-Token", csrfToken)
 				return r
 			},
 			expectStatus: http.StatusOK,
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
-				mockClient.EXPECT().ClearCart(gomock.Any(), &gen.ClearCartRequest{
-					Login: login,
-				}).Return(&empty.Empty{}, nil)
+				mockClient.EXPECT().ClearCart(gomock.Any(), &gen.ClearCartRequest{}).Return(&empty.Empty{}, nil)
 			},
 		},
 		{
 			name: "ClearCart_NoToken",
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("DELETE", "/cart", nil)
-				// No AdminJWT cookie
+				// Ни пользователя, ни гостевой куки
 				return r
 			},
 			expectStatus:     http.StatusUnauthorized,
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
 		},
 		{
-			name: "ClearCart_InvalidLogin",
+			name: "ClearCart_InvalidGuestCookie",
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("DELETE", "/cart", nil)
-				// Генерация токена с некорректным логином
-				tokenStr := utils.GenerateJWTForTest(t, "", secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
-				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
-				r.Header.Set("X-CSRF-Token", csrfToken)
+				r.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: "forged"})
 				return r
 			},
-			expectStatus:     http.StatusForbidden,
+			expectStatus:     http.StatusUnauthorized,
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
 		},
-		{
-			name: "ClearCart_InvalidCSRF",
-			setupRequest: func() *http.Request {
-				r := httptest.NewRequest("DELETE", "/cart", nil)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
-				// No CSRF token
-				return r
-			},
-			expectStatus: http.StatusUnauthorized,
-			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
-				// CSRF error happens before ClearCart
-			},
-		},
 		{
 			name: "ClearCart_GRPCError",
 			setupRequest: func() *http.Request {
 				r := httptest.NewRequest("DELETE", "/cart", nil)
-				tokenStr := utils.GenerateJWTForTest(t, login, secret, userID)
-				r.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tokenStr})
+				r = withPrincipal(r, login, userID)
 				r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
 				r.Header.Set("X-CSRF-Token", csrfToken)
 				return r
 			},
 			expectStatus: http.StatusInternalServerError,
 			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
-				mockClient.EXPECT().ClearCart(gomock.Any(), &gen.ClearCartRequest{
-					Login: login,
-				}).Return(nil, fmt.Errorf("gRPC error"))
+				mockClient.EXPECT().ClearCart(gomock.Any(), &gen.ClearCartRequest{}).Return(nil, fmt.Errorf("gRPC error"))
 			},
 		},
 	}
@@ -311,8 +372,8 @@ func TestClearCart(t *testing.T) {
 			tt.mockGrpcBehavior(mockClient)
 
 			handler := CartHandler{
-				client: mockClient,
-				secret: secret,
+				client:      mockClient,
+				guestSecret: secret,
 			}
 
 			req := tt.setupRequest()
@@ -324,3 +385,1323 @@ func TestClearCart(t *testing.T) {
 		})
 	}
 }
+
+type fakeOrderStream struct {
+	grpc.ClientStream
+	updates []*gen.OrderUpdate
+	err     error
+}
+
+func (s *fakeOrderStream) Recv() (*gen.OrderUpdate, error) {
+	if len(s.updates) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	update := s.updates[0]
+	s.updates = s.updates[1:]
+	return update, nil
+}
+
+// withPrincipal имитирует запрос, прошедший authmw.CreateAuthMiddleware.
+func withPrincipal(r *http.Request, login string, id uuid.UUID) *http.Request {
+	return r.WithContext(authmw.ContextWithPrincipal(r.Context(), authmw.Principal{ID: id, Login: login}))
+}
+
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestGuestCart(t *testing.T) {
+	secret := "secret-value"
+	csrfToken := "test-csrf"
+	productID := uuid.NewV4().String()
+	restaurantID := uuid.NewV4().String()
+	guestID := uuid.NewV4()
+	guest := cartPkg.GuestOwner(guestID)
+
+	guestCart := &gen.CartResponse{
+		RestaurantId:   restaurantID,
+		RestaurantName: "Test Restaurant",
+		Products:       []*gen.CartItem{{Id: productID, Name: "Product 1", Price: 10.5, Amount: 1}},
+		FullCart:       true,
+	}
+
+	t.Run("FirstItemStartsGuestSession", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := mocks.NewMockCartServiceClient(ctrl)
+		var owner string
+		mockClient.EXPECT().UpdateItemQuantity(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, in *gen.UpdateQuantityRequest, _ ...interface{}) (*empty.Empty, error) {
+				owner = in.GuestId
+				return &empty.Empty{}, nil
+			})
+		mockClient.EXPECT().GetCart(gomock.Any(), gomock.Any()).Return(guestCart, nil)
+
+		handler := CartHandler{client: mockClient, guestSecret: secret}
+
+		body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
+		req := mux.SetURLVars(httptest.NewRequest("POST", "/cart/update/"+productID, body), map[string]string{"productID": productID})
+		w := httptest.NewRecorder()
+
+		handler.UpdateQuantityInCart(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookie := findCookie(w, utils.GuestCookieName)
+		if assert.NotNil(t, cookie) {
+			id, ok := utils.GetGuestIDFromCookie(cookie.Value, secret)
+			assert.True(t, ok)
+			assert.Equal(t, cartPkg.GuestOwner(id), owner)
+		}
+		assert.NotNil(t, findCookie(w, "CSRF-Token"))
+		assert.NotEmpty(t, w.Header().Get("X-CSRF-Token"))
+	})
+
+	t.Run("ExistingGuestNeedsCSRF", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := CartHandler{client: mocks.NewMockCartServiceClient(ctrl), guestSecret: secret}
+
+		body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
+		req := httptest.NewRequest("POST", "/cart/update/"+productID, body)
+		req.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+		w := httptest.NewRecorder()
+
+		handler.UpdateQuantityInCart(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ExpiredLoginDoesNotStartGuestSession", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := CartHandler{client: mocks.NewMockCartServiceClient(ctrl), guestSecret: secret}
+
+		body := strings.NewReader(fmt.Sprintf(`{"quantity": 1, "restaurant_id": "%s"}`, restaurantID))
+		req := mux.SetURLVars(httptest.NewRequest("POST", "/cart/update/"+productID, body), map[string]string{"productID": productID})
+		req.AddCookie(&http.Cookie{Name: utils.RefreshCookieName, Value: "refresh-token"})
+		w := httptest.NewRecorder()
+
+		handler.UpdateQuantityInCart(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, findCookie(w, utils.GuestCookieName))
+		assert.Nil(t, findCookie(w, "CSRF-Token"), "CSRF-куку пользователя не перезаписываем")
+	})
+
+	t.Run("GetGuestCart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := mocks.NewMockCartServiceClient(ctrl)
+		mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{GuestId: guest}).Return(guestCart, nil)
+
+		handler := CartHandler{client: mockClient, guestSecret: secret}
+
+		req := httptest.NewRequest("GET", "/cart", nil)
+		req.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+		req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		w := httptest.NewRecorder()
+
+		handler.GetCart(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ForgedGuestCookie", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := CartHandler{client: mocks.NewMockCartServiceClient(ctrl), guestSecret: secret}
+
+		req := httptest.NewRequest("GET", "/cart", nil)
+		req.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, "other-secret")})
+		req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		w := httptest.NewRecorder()
+
+		handler.GetCart(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("GuestCannotCreateOrder", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := mocks.NewMockCartServiceClient(ctrl)
+		mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{GuestId: guest}).Return(guestCart, nil)
+
+		handler := CartHandler{client: mockClient, guestSecret: secret}
+
+		req := httptest.NewRequest("POST", "/order/create", strings.NewReader(`{}`))
+		req.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+		req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		w := httptest.NewRecorder()
+
+		handler.CreateOrder(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestCreateOrderRestaurantClosed(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockCartServiceClient(ctrl)
+	mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(&gen.CartResponse{
+		RestaurantId: uuid.NewV4().String(),
+		Products:     []*gen.CartItem{{Id: uuid.NewV4().String(), Price: 500, Amount: 1}},
+		FullCart:     true,
+	}, nil)
+	mockClient.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.FailedPrecondition, "ресторан сейчас закрыт, откроется 11.05 в 11:00"))
+
+	handler := CartHandler{client: mockClient, guestSecret: secret}
+
+	body := `{"address": "Москва, ул. Тверская, 1", "apartment_or_office": "1", "intercom": "1", "entrance": "1", "floor": "1", "final_price": 500}`
+	req := httptest.NewRequest("POST", "/order/create", strings.NewReader(body))
+	req = withPrincipal(req, login, userID)
+	req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+	req.Header.Set("X-CSRF-Token", csrfToken)
+	w := httptest.NewRecorder()
+
+	handler.CreateOrder(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "ресторан сейчас закрыт")
+}
+
+func TestMergeGuestCart(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	guestID := uuid.NewV4()
+	guest := cartPkg.GuestOwner(guestID)
+	restaurantID := uuid.NewV4().String()
+
+	userCart := &gen.CartResponse{
+		RestaurantId:   restaurantID,
+		RestaurantName: "Test Restaurant",
+		Products:       []*gen.CartItem{{Id: uuid.NewV4().String(), Name: "Product 1", Price: 10.5, Amount: 2}},
+		FullCart:       true,
+	}
+
+	newRequest := func(target string, withGuest bool) *http.Request {
+		r := httptest.NewRequest("POST", target, nil)
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		if withGuest {
+			r.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name          string
+		request       *http.Request
+		mockSetup     func(mockClient *mocks.MockCartServiceClient)
+		expectStatus  int
+		expectCleared bool
+	}{
+		{
+			name:    "Success",
+			request: newRequest("/cart/merge", true),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().MergeGuestCart(gomock.Any(), &gen.MergeGuestCartRequest{GuestId: guest}).Return(&empty.Empty{}, nil)
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(userCart, nil)
+			},
+			expectStatus:  http.StatusOK,
+			expectCleared: true,
+		},
+		{
+			name:    "Conflict",
+			request: newRequest("/cart/merge", true),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().MergeGuestCart(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "в корзине уже есть товары из другого ресторана"))
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(userCart, nil)
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:    "Replace",
+			request: newRequest("/cart/merge?replace=true", true),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().MergeGuestCart(gomock.Any(), &gen.MergeGuestCartRequest{GuestId: guest, Replace: true}).Return(&empty.Empty{}, nil)
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(userCart, nil)
+			},
+			expectStatus:  http.StatusOK,
+			expectCleared: true,
+		},
+		{
+			name:         "NoGuestCart",
+			request:      newRequest("/cart/merge", false),
+			mockSetup:    func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name: "NotSignedIn",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/cart/merge", nil)
+				r.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+				return r
+			}(),
+			mockSetup:    func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			handler := CartHandler{client: mockClient, guestSecret: secret}
+			w := httptest.NewRecorder()
+
+			handler.MergeGuestCart(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			cookie := findCookie(w, utils.GuestCookieName)
+			assert.Equal(t, tt.expectCleared, cookie != nil && cookie.MaxAge < 0)
+		})
+	}
+}
+
+func TestMergeGuestCartOnLogin(t *testing.T) {
+	secret := "secret-value"
+	token := "access-token"
+	guestID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		grpcErr        error
+		expectCleared  bool
+		expectConflict bool
+	}{
+		{name: "Merged", expectCleared: true},
+		{name: "Conflict", grpcErr: status.Error(codes.FailedPrecondition, "conflict"), expectConflict: true},
+		{name: "Unavailable", grpcErr: status.Error(codes.Unavailable, "down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			mockClient.EXPECT().MergeGuestCart(gomock.Any(), &gen.MergeGuestCartRequest{
+				GuestId: cartPkg.GuestOwner(guestID),
+			}).DoAndReturn(func(ctx context.Context, _ *gen.MergeGuestCartRequest, _ ...grpc.CallOption) (*empty.Empty, error) {
+				// Пользователь ещё не вошёл: сервис корзин узнаёт его по только что выданному токену
+				var md metadata.MD
+				_ = grpcauth.UnaryClientInterceptor()(ctx, "", nil, nil, nil,
+					func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+						md, _ = metadata.FromOutgoingContext(ctx)
+						return nil
+					})
+				assert.Equal(t, []string{"Bearer " + token}, md.Get("authorization"))
+				return &empty.Empty{}, tt.grpcErr
+			})
+
+			handler := CartHandler{client: mockClient, guestSecret: secret}
+
+			req := httptest.NewRequest("POST", "/auth/signin", nil)
+			req.AddCookie(&http.Cookie{Name: utils.GuestCookieName, Value: utils.SignGuestID(guestID, secret)})
+			w := httptest.NewRecorder()
+
+			handler.MergeGuestCartOnLogin(w, req, token)
+
+			cookie := findCookie(w, utils.GuestCookieName)
+			assert.Equal(t, tt.expectCleared, cookie != nil && cookie.MaxAge < 0)
+			assert.Equal(t, tt.expectConflict, w.Header().Get(guestCartConflictHeader) != "")
+		})
+	}
+
+	t.Run("NoGuestCookie", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := CartHandler{client: mocks.NewMockCartServiceClient(ctrl), guestSecret: secret}
+		w := httptest.NewRecorder()
+
+		handler.MergeGuestCartOnLogin(w, httptest.NewRequest("POST", "/auth/signin", nil), token)
+
+		assert.Nil(t, findCookie(w, utils.GuestCookieName))
+	})
+}
+
+func TestPreviewPromo(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	restaurantID := uuid.NewV4().String()
+	productID := uuid.NewV4().String()
+
+	userCart := &gen.CartResponse{
+		RestaurantId:   restaurantID,
+		RestaurantName: "Test Restaurant",
+		Products:       []*gen.CartItem{{Id: productID, Name: "Product 1", Price: 500, Amount: 2}},
+		FullCart:       true,
+	}
+
+	newRequest := func(body string) *http.Request {
+		r := httptest.NewRequest("POST", "/cart/promo", strings.NewReader(body))
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name         string
+		request      *http.Request
+		mockSetup    func(mockClient *mocks.MockCartServiceClient)
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:    "Success",
+			request: newRequest(`{"promo_code": "welcome"}`),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).Return(userCart, nil)
+				mockClient.EXPECT().PreviewPromo(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, in *gen.PreviewPromoRequest, _ ...interface{}) (*gen.PromoPreviewResponse, error) {
+						assert.Equal(t, "welcome", in.PromoCode)
+						assert.Equal(t, restaurantID, in.Cart.RestaurantId)
+						return &gen.PromoPreviewResponse{
+							PromoCode:      "WELCOME",
+							Cart:           userCart,
+							PriceBreakdown: &gen.PriceBreakdown{Subtotal: 1000, Discount: 200, Total: 800},
+						}, nil
+					})
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"total":800`,
+		},
+		{
+			name:    "Rejected",
+			request: newRequest(`{"promo_code": "welcome"}`),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), gomock.Any()).Return(userCart, nil)
+				mockClient.EXPECT().PreviewPromo(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "промокод действует только на первый заказ"))
+			},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   "промокод действует только на первый заказ",
+		},
+		{
+			name:    "InvalidCode",
+			request: newRequest(`{"promo_code": "<script>"}`),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), gomock.Any()).Return(userCart, nil)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "EmptyCart",
+			request: newRequest(`{"promo_code": "welcome"}`),
+			mockSetup: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetCart(gomock.Any(), gomock.Any()).
+					Return(&gen.CartResponse{RestaurantId: uuid.Nil.String()}, nil)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "Unauthorized",
+			request:      httptest.NewRequest("POST", "/cart/promo", strings.NewReader(`{"promo_code": "welcome"}`)),
+			mockSetup:    func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockSetup(mockClient)
+
+			handler := CartHandler{client: mockClient, guestSecret: secret}
+			w := httptest.NewRecorder()
+
+			handler.PreviewPromo(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			if tt.expectBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectBody)
+			}
+		})
+	}
+}
+
+func TestOrderEvents(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func() *http.Request {
+		r := httptest.NewRequest("GET", fmt.Sprintf("/order/%s/events", orderID), nil)
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		setupRequest     func() *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+		expectBody       []string
+	}{
+		{
+			name:         "Streams status changes",
+			setupRequest: authorized,
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				stream := &fakeOrderStream{updates: []*gen.OrderUpdate{
+					{OrderId: orderID.String(), Event: &gen.OrderStatusEvent{Status: "cooking", Actor: "system", CreatedAt: timestamppb.Now()}},
+					{OrderId: orderID.String(), Event: &gen.OrderStatusEvent{Status: "in_delivery", Actor: "system", CreatedAt: timestamppb.Now()}},
+				}}
+				mockClient.EXPECT().WatchOrder(gomock.Any(), &gen.WatchOrderRequest{
+					OrderId: orderID.String(),
+				}).Return(stream, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   []string{"event: status\ndata: {\"status\":\"cooking\"", "\"status\":\"in_delivery\""},
+		},
+		{
+			name:         "Order not found",
+			setupRequest: authorized,
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				stream := &fakeOrderStream{err: status.Error(codes.NotFound, "заказ не найден")}
+				mockClient.EXPECT().WatchOrder(gomock.Any(), gomock.Any()).Return(stream, nil)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name: "Unauthenticated",
+			setupRequest: func() *http.Request {
+				return httptest.NewRequest("GET", fmt.Sprintf("/order/%s/events", orderID), nil)
+			},
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			req := mux.SetURLVars(tt.setupRequest(), map[string]string{"orderID": orderID.String()})
+			w := httptest.NewRecorder()
+
+			handler.OrderEvents(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			for _, part := range tt.expectBody {
+				assert.Contains(t, w.Body.String(), part)
+			}
+			if tt.expectStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	paymentSecret := "webhook-secret"
+	orderID := uuid.NewV4()
+	body := fmt.Sprintf(`{"order_id":%q,"payment_id":"pay_1","amount":500}`, orderID)
+
+	signed := func(body, secret string) *http.Request {
+		r := httptest.NewRequest("POST", "/payment", strings.NewReader(body))
+		r.Header.Set(payment.SignatureHeader, payment.Sign([]byte(body), secret))
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Success",
+			request: signed(body, paymentSecret),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().ConfirmPayment(gomock.Any(), &gen.ConfirmPaymentRequest{
+					Notification: []byte(body),
+					Signature:    payment.Sign([]byte(body), paymentSecret),
+				}).Return(&empty.Empty{}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Wrong signature",
+			request:          signed(body, "other-secret"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:             "No signature",
+			request:          httptest.NewRequest("POST", "/payment", strings.NewReader(body)),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:             "Missing payment ID",
+			request:          signed(fmt.Sprintf(`{"order_id":%q,"amount":500}`, orderID), paymentSecret),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Amount mismatch",
+			request: signed(body, paymentSecret),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().ConfirmPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "сумма платежа не совпадает с суммой заказа"))
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Paid by another payment",
+			request: signed(body, paymentSecret),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().ConfirmPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "заказ уже оплачен другим платежом"))
+			},
+			expectStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:        mockClient,
+				paymentSecret: paymentSecret,
+			}
+
+			w := httptest.NewRecorder()
+			handler.PaymentWebhook(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func(body string) *http.Request {
+		r := httptest.NewRequest("POST", fmt.Sprintf("/order/%s/cancel", orderID), strings.NewReader(body))
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Success",
+			request: authorized(`{"reason":"передумал"}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CancelOrder(gomock.Any(), &gen.CancelOrderRequest{
+					OrderId: orderID.String(),
+					Reason:  "передумал",
+				}).Return(&gen.OrderResponse{
+					Id:            orderID.String(),
+					Status:        "cancelled",
+					OrderProducts: &gen.CartResponse{RestaurantId: uuid.NewV4().String()},
+					CreatedAt:     timestamppb.Now(),
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Empty reason",
+			request:          authorized(`{"reason":""}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Order not found",
+			request: authorized(`{"reason":"передумал"}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "заказ не найден"))
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:    "Order already in delivery",
+			request: authorized(`{"reason":"передумал"}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "недопустимая смена статуса заказа"))
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("POST", fmt.Sprintf("/order/%s/cancel", orderID), nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			req := mux.SetURLVars(tt.request, map[string]string{"orderID": orderID.String()})
+			w := httptest.NewRecorder()
+
+			handler.CancelOrder(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
+
+func TestUpdateTip(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func(body string) *http.Request {
+		r := httptest.NewRequest("POST", fmt.Sprintf("/orders/%s/tip", orderID), strings.NewReader(body))
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Success",
+			request: authorized(`{"tip_amount":150}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().UpdateTip(gomock.Any(), &gen.UpdateTipRequest{
+					OrderId:   orderID.String(),
+					TipAmount: 150,
+				}).Return(&gen.OrderResponse{
+					Id:             orderID.String(),
+					Status:         "delivered",
+					OrderProducts:  &gen.CartResponse{RestaurantId: uuid.NewV4().String()},
+					PriceBreakdown: &gen.PriceBreakdown{Tip: 150},
+					CreatedAt:      timestamppb.Now(),
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Amount and percent together",
+			request:          authorized(`{"tip_amount":150,"tip_percent":10}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:             "Tip too large",
+			request:          authorized(`{"tip_percent":80}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Edit window closed",
+			request: authorized(`{"tip_amount":150}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().UpdateTip(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "время на изменение чаевых истекло"))
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("POST", fmt.Sprintf("/orders/%s/tip", orderID), nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			req := mux.SetURLVars(tt.request, map[string]string{"orderID": orderID.String()})
+			w := httptest.NewRecorder()
+
+			handler.UpdateTip(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
+
+func TestReorder(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	restaurantID := uuid.NewV4().String()
+	productID := uuid.NewV4().String()
+
+	authorized := func(target string) *http.Request {
+		r := httptest.NewRequest("POST", target, nil)
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+	target := fmt.Sprintf("/order/%s/reorder", orderID)
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+		expectBody       string
+	}{
+		{
+			name:    "Success",
+			request: authorized(target + "?replace=true"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().Reorder(gomock.Any(), &gen.ReorderRequest{
+					OrderId: orderID.String(),
+					Replace: true,
+				}).Return(&gen.ReorderResponse{
+					Cart: &gen.CartResponse{
+						RestaurantId: restaurantID,
+						Products:     []*gen.CartItem{{Id: productID, Name: "Хинкали", Price: 110, Amount: 5}},
+					},
+					Dropped:  []*gen.ReorderItem{{Id: uuid.NewV4().String(), Name: "Лобио", Amount: 2, OldPrice: 300}},
+					Repriced: []*gen.ReorderItem{{Id: productID, Name: "Хинкали", Amount: 5, OldPrice: 90, NewPrice: 110}},
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"new_price":110`,
+		},
+		{
+			name:    "Cart of another restaurant",
+			request: authorized(target),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().Reorder(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "в корзине уже есть товары из другого ресторана"))
+				mockClient.EXPECT().GetCart(gomock.Any(), &gen.GetCartRequest{}).
+					Return(&gen.CartResponse{RestaurantId: restaurantID, RestaurantName: "Том Ям"}, nil)
+			},
+			expectStatus: http.StatusConflict,
+			expectBody:   "Том Ям",
+		},
+		{
+			name:    "Order not found",
+			request: authorized(target),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().Reorder(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "заказ не найден"))
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("POST", target, nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{client: mockClient, guestSecret: secret}
+
+			req := mux.SetURLVars(tt.request, map[string]string{"orderID": orderID.String()})
+			w := httptest.NewRecorder()
+
+			handler.Reorder(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			if tt.expectBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectBody)
+			}
+		})
+	}
+}
+
+func TestGetOrders(t *testing.T) {
+	secret := "secret-value"
+	login := "testuser"
+	csrfToken := "test-csrf"
+	userID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func(target string) *http.Request {
+		r := httptest.NewRequest("GET", target, nil)
+		r = withPrincipal(r, login, userID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+		expectBody       string
+		expectCursor     string
+	}{
+		{
+			name: "Filters and cursor",
+			request: authorized("/order?status=delivered,cancelled&restaurant_id=" + restaurantID.String() +
+				"&from=2025-05-01T00:00:00Z&max_total=2000&sort=oldest&cursor=abc&count=5"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetOrders(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *gen.GetOrdersRequest, _ ...grpc.CallOption) (*gen.OrderListResponse, error) {
+						assert.Equal(t, []string{"delivered", "cancelled"}, in.Statuses)
+						assert.Equal(t, restaurantID.String(), in.RestaurantId)
+						assert.Equal(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), in.From.AsTime())
+						assert.Nil(t, in.To)
+						assert.Equal(t, 2000.0, in.MaxTotal)
+						assert.True(t, in.OldestFirst)
+						assert.Equal(t, "abc", in.Cursor)
+						assert.Equal(t, int32(5), in.Count)
+						return &gen.OrderListResponse{
+							Orders: []*gen.OrderResponse{{
+								Id:            orderID.String(),
+								UserId:        userID.String(),
+								Status:        "delivered",
+								OrderProducts: &gen.CartResponse{RestaurantId: restaurantID.String()},
+							}},
+							NextCursor: "next",
+						}, nil
+					})
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `[{"id":"` + orderID.String(),
+			expectCursor: "next",
+		},
+		{
+			name:             "Offset is rejected",
+			request:          authorized("/order?count=15&offset=15"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+			expectBody:       "cursor",
+		},
+		{
+			name:             "Invalid date",
+			request:          authorized("/order?from=yesterday"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:             "Invalid sort",
+			request:          authorized("/order?sort=cheapest"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Rejected filter",
+			request: authorized("/order?status=lost"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().GetOrders(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "некорректный фильтр заказов: неизвестный статус \"lost\""))
+			},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   "неизвестный статус",
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("GET", "/order", nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{client: mockClient, guestSecret: secret}
+			w := httptest.NewRecorder()
+
+			handler.GetOrders(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			if tt.expectBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectBody)
+			}
+			assert.Equal(t, tt.expectCursor, w.Header().Get(nextCursorHeader))
+		})
+	}
+}
+
+func TestUpdateRestaurantOrder(t *testing.T) {
+	secret := "secret-value"
+	login := "staff"
+	csrfToken := "test-csrf"
+	staffID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func(action, body string) *http.Request {
+		r := httptest.NewRequest("POST", fmt.Sprintf("/staff/orders/%s/%s", orderID, action), strings.NewReader(body))
+		r = withPrincipal(r, login, staffID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return mux.SetURLVars(r, map[string]string{"orderID": orderID.String(), "action": action})
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Accept",
+			request: authorized("accept", ""),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetRestaurantOrderStatus(gomock.Any(), &gen.RestaurantOrderStatusRequest{
+					OrderId: orderID.String(),
+					Status:  "accepted",
+				}).Return(&gen.OrderResponse{
+					Id:            orderID.String(),
+					Status:        "accepted",
+					OrderProducts: &gen.CartResponse{RestaurantId: uuid.NewV4().String()},
+					CreatedAt:     timestamppb.Now(),
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:    "Reject with reason",
+			request: authorized("reject", `{"reason":"закончились продукты"}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetRestaurantOrderStatus(gomock.Any(), &gen.RestaurantOrderStatusRequest{
+					OrderId: orderID.String(),
+					Status:  "cancelled",
+					Reason:  "закончились продукты",
+				}).Return(&gen.OrderResponse{
+					Id:            orderID.String(),
+					Status:        "cancelled",
+					OrderProducts: &gen.CartResponse{RestaurantId: uuid.NewV4().String()},
+					CreatedAt:     timestamppb.Now(),
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Reject without reason",
+			request:          authorized("reject", `{"reason":""}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:             "Unknown action",
+			request:          authorized("deliver", ""),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Not a staff member",
+			request: authorized("accept", ""),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetRestaurantOrderStatus(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.PermissionDenied, "пользователь не является сотрудником ресторана"))
+			},
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:    "Invalid transition",
+			request: authorized("ready", ""),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetRestaurantOrderStatus(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "недопустимая смена статуса заказа"))
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("POST", fmt.Sprintf("/staff/orders/%s/accept", orderID), nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			w := httptest.NewRecorder()
+			handler.UpdateRestaurantOrder(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
+
+func TestUpdateCourierOrder(t *testing.T) {
+	secret := "secret-value"
+	login := "courier"
+	csrfToken := "test-csrf"
+	courierID := uuid.NewV4()
+	orderID := uuid.NewV4()
+
+	authorized := func(action string) *http.Request {
+		r := httptest.NewRequest("POST", fmt.Sprintf("/courier/orders/%s/%s", orderID, action), nil)
+		r = withPrincipal(r, login, courierID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return mux.SetURLVars(r, map[string]string{"orderID": orderID.String(), "action": action})
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Pick up",
+			request: authorized("pickup"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CourierOrderAction(gomock.Any(), &gen.CourierOrderActionRequest{
+					OrderId: orderID.String(),
+					Action:  "pickup",
+				}).Return(&gen.OrderResponse{
+					Id:            orderID.String(),
+					Status:        "in_delivery",
+					OrderProducts: &gen.CartResponse{RestaurantId: uuid.NewV4().String()},
+					CreatedAt:     timestamppb.Now(),
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Unknown action",
+			request:          authorized("cook"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Not a courier",
+			request: authorized("accept"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CourierOrderAction(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.PermissionDenied, "пользователь не является курьером"))
+			},
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:    "Order of another courier",
+			request: authorized("deliver"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CourierOrderAction(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "заказ не назначен этому курьеру"))
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:    "Deliver before pick up",
+			request: authorized("deliver"),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().CourierOrderAction(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "действие недоступно на текущем этапе доставки"))
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:             "No token",
+			request:          httptest.NewRequest("POST", fmt.Sprintf("/courier/orders/%s/accept", orderID), nil),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			w := httptest.NewRecorder()
+			handler.UpdateCourierOrder(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
+
+func TestSetCourierAvailability(t *testing.T) {
+	secret := "secret-value"
+	csrfToken := "test-csrf"
+	courierID := uuid.NewV4()
+
+	authorized := func(body string) *http.Request {
+		r := httptest.NewRequest("POST", "/courier/availability", strings.NewReader(body))
+		r = withPrincipal(r, "courier", courierID)
+		r.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: csrfToken})
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		request          *http.Request
+		mockGrpcBehavior func(mockClient *mocks.MockCartServiceClient)
+		expectStatus     int
+	}{
+		{
+			name:    "Go online",
+			request: authorized(`{"available":true,"latitude":55.75,"longitude":37.62}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetCourierAvailability(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *gen.CourierAvailabilityRequest, _ ...interface{}) (*gen.CourierResponse, error) {
+						assert.True(t, in.Available)
+						assert.Equal(t, 55.75, in.GetLatitude())
+						return &gen.CourierResponse{Id: uuid.NewV4().String(), Available: true, MaxOrders: 2}, nil
+					})
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:             "Only one coordinate",
+			request:          authorized(`{"available":true,"latitude":55.75}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name:    "Not a courier",
+			request: authorized(`{"available":false}`),
+			mockGrpcBehavior: func(mockClient *mocks.MockCartServiceClient) {
+				mockClient.EXPECT().SetCourierAvailability(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.PermissionDenied, "пользователь не является курьером"))
+			},
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockCartServiceClient(ctrl)
+			tt.mockGrpcBehavior(mockClient)
+
+			handler := CartHandler{
+				client:      mockClient,
+				guestSecret: secret,
+			}
+
+			w := httptest.NewRecorder()
+			handler.SetCourierAvailability(w, tt.request)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+		})
+	}
+}
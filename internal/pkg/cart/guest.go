@@ -0,0 +1,31 @@
+package cart
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const defaultCartTTL = 7 * 24 * time.Hour
+
+// GuestOwnerPrefix отличает корзины гостей от корзин пользователей: логин не может содержать ':'.
+const GuestOwnerPrefix = "guest:"
+
+func GuestOwner(guestID uuid.UUID) string {
+	return GuestOwnerPrefix + guestID.String()
+}
+
+func IsGuestOwner(owner string) bool {
+	return strings.HasPrefix(owner, GuestOwnerPrefix)
+}
+
+// CartTTLFromEnv возвращает время жизни корзины с последнего изменения (CART_TTL, по умолчанию неделя).
+// Столько же живёт гостевая кука.
+func CartTTLFromEnv() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("CART_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return defaultCartTTL
+}
@@ -2,34 +2,102 @@ package cart
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	"github.com/satori/uuid"
 )
 
+var (
+	ErrEmptyCart       = errors.New("корзина пуста")
+	ErrUnknownProduct  = errors.New("товар не найден в меню ресторана")
+	ErrProductNotFound = errors.New("товар не найден")
+	ErrPriceMismatch   = errors.New("итоговая сумма заказа не совпадает с расчётной")
+
+	ErrRestaurantConflict    = errors.New("в корзине уже есть товары из другого ресторана")
+	ErrInvalidCartOwner      = errors.New("некорректный владелец корзины")
+	ErrRestaurantNotFound    = errors.New("ресторан не найден")
+	ErrRestaurantClosed      = errors.New("ресторан сейчас закрыт")
+	ErrOutsideDeliveryWindow = errors.New("ресторан не успеет доставить заказ до закрытия")
+
+	ErrPaymentAmountMismatch = errors.New("сумма платежа не совпадает с суммой заказа")
+	ErrPaymentConflict       = errors.New("заказ уже оплачен другим платежом")
+)
+
 type CartRepo interface {
 	GetCart(ctx context.Context, userID string) (map[string]int, string, error)
-	UpdateItemQuantity(ctx context.Context, userID, productID string, restaurantId string, quantity int) error
+	UpdateItemQuantity(ctx context.Context, userID, productID string, restaurantId string, quantity int, replace bool) error
 	ClearCart(ctx context.Context, userID string) error
+	MergeCart(ctx context.Context, fromUserID, toUserID string, replace bool) error
+	FillCart(ctx context.Context, userID, restaurantID string, quantities map[string]int, replace bool) error
 }
 
 type CartUsecase interface {
 	GetCart(ctx context.Context, userID string) (models.Cart, error, bool)
-	UpdateItemQuantity(ctx context.Context, userID, productID string, restaurantId string, quantity int) error
+	UpdateItemQuantity(ctx context.Context, userID, productID string, restaurantId string, quantity int, replace bool) error
 	ClearCart(ctx context.Context, userID string) error
+	MergeGuestCart(ctx context.Context, guestID, userID string, replace bool) error
 
 	CreateOrder(ctx context.Context, userID string, details models.OrderInReq, cart models.Cart) (models.Order, error)
-	GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error)
+	PreviewPromo(ctx context.Context, userID, code string, cart models.Cart) (models.PromoPreview, error)
+	GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, cursor string, count int) (models.OrderPage, error)
 	GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error)
-	UpdateOrderStatus(ctx context.Context, order_id uuid.UUID) error
+	ConfirmPayment(ctx context.Context, orderID uuid.UUID, paymentID string, amount float64) error
+	CancelOrder(ctx context.Context, orderID, userID uuid.UUID, reason string) (models.Order, error)
+	Reorder(ctx context.Context, orderID, userID uuid.UUID, login string, replace bool) (models.ReorderResult, error)
+	WatchOrder(ctx context.Context, orderID, userID uuid.UUID) (<-chan models.OrderStatusEvent, error)
+	UpdateTip(ctx context.Context, orderID, userID uuid.UUID, req models.TipReq) (models.Order, error)
+
+	GetRestaurantOrders(ctx context.Context, staffID uuid.UUID) ([]models.Order, error)
+	SetRestaurantOrderStatus(ctx context.Context, staffID, orderID uuid.UUID, status, reason string) (models.Order, error)
+
+	SetCourierAvailability(ctx context.Context, userID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error)
+	GetCourierOrders(ctx context.Context, userID uuid.UUID) ([]models.Order, error)
+	CourierOrderAction(ctx context.Context, userID, orderID uuid.UUID, action string) (models.Order, error)
 }
 
 type RestaurantRepo interface {
 	GetCartItem(ctx context.Context, productIDs []string, productAmounts map[string]int, restaurantID string) (models.Cart, error)
+	GetProductRestaurant(ctx context.Context, productID uuid.UUID) (uuid.UUID, error)
+	GetWorkingMode(ctx context.Context, restaurantID uuid.UUID) (models.WorkingMode, error)
+	GetPromoCode(ctx context.Context, code string, restaurantID uuid.UUID) (models.PromoCode, error)
+	GetPromoUsage(ctx context.Context, promoID uuid.UUID, userLogin string) (models.PromoUsage, error)
 
 	Save(ctx context.Context, order models.Order, userLogin string) error
-	GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error)
+	GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, limit int) ([]models.Order, error)
 	GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error)
-	UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, status string) error
-	ScheduleDeliveryStatusChange(ctx context.Context, orderID uuid.UUID) error
+	GetOrderStatus(ctx context.Context, orderID uuid.UUID) (string, error)
+	GetOrderForPayment(ctx context.Context, orderID uuid.UUID) (models.Order, error)
+	GetDeliveryEstimate(ctx context.Context, restaurantID uuid.UUID) (models.DeliveryEstimate, error)
+	GetOrderEstimate(ctx context.Context, orderID uuid.UUID) (models.DeliveryEstimate, error)
+	UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, from string, event models.OrderStatusEvent) error
+	UpdateOrderTip(ctx context.Context, orderID uuid.UUID, from, to float64) error
+	SaveTipPayment(ctx context.Context, tip models.TipPayment) error
+	GetTipPayments(ctx context.Context, orderID uuid.UUID) ([]models.TipPayment, error)
+	IsTipPayment(ctx context.Context, paymentID string) (bool, error)
+
+	GetStaffRestaurant(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	GetRestaurantOrders(ctx context.Context, restaurantID uuid.UUID, statuses []string) ([]models.Order, error)
+	GetRestaurantOrder(ctx context.Context, orderID, restaurantID uuid.UUID) (models.Order, error)
+	GetSimulatedKitchenOrders(ctx context.Context, statuses []string, limit int) ([]models.KitchenOrder, error)
+
+	ScheduleStatusTransition(ctx context.Context, transition models.StatusTransition) error
+	GetDueStatusTransitions(ctx context.Context, limit int) ([]models.StatusTransition, error)
+	DeleteStatusTransition(ctx context.Context, id uuid.UUID) error
+}
+
+type CourierRepo interface {
+	GetCourierByUser(ctx context.Context, userID uuid.UUID) (models.Courier, error)
+	SetCourierAvailability(ctx context.Context, courierID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error)
+	GetAvailableCouriers(ctx context.Context, includeSimulated bool) ([]models.Courier, error)
+	GetCourierOrders(ctx context.Context, courierID uuid.UUID) ([]models.Order, error)
+
+	GetUnassignedOrders(ctx context.Context, limit int) ([]models.DispatchOrder, error)
+	AssignCourier(ctx context.Context, orderID, courierID uuid.UUID) error
+	GetAssignment(ctx context.Context, orderID, courierID uuid.UUID) (models.CourierAssignment, error)
+	UpdateAssignment(ctx context.Context, orderID, courierID uuid.UUID, from, to string) error
+	DeleteAssignment(ctx context.Context, orderID, courierID uuid.UUID) error
+	ReleaseExpiredOffers(ctx context.Context, before time.Time) (int64, error)
+	GetSimulatedAssignments(ctx context.Context) ([]models.CourierAssignment, error)
 }
@@ -6,12 +6,11 @@ package mocks
 
 import (
 	context "context"
-	reflect "reflect"
-
 	gen "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/delivery/grpc/gen"
 	gomock "github.com/golang/mock/gomock"
 	grpc "google.golang.org/grpc"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
 )
 
 // MockCartServiceClient is a mock of CartServiceClient interface.
@@ -37,6 +36,26 @@ func (m *MockCartServiceClient) EXPECT() *MockCartServiceClientMockRecorder {
 	return m.recorder
 }
 
+// CancelOrder mocks base method.
+func (m *MockCartServiceClient) CancelOrder(arg0 context.Context, arg1 *gen.CancelOrderRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelOrder", varargs...)
+	ret0, _ := ret[0].(*gen.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockCartServiceClientMockRecorder) CancelOrder(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockCartServiceClient)(nil).CancelOrder), varargs...)
+}
+
 // ClearCart mocks base method.
 func (m *MockCartServiceClient) ClearCart(arg0 context.Context, arg1 *gen.ClearCartRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +76,46 @@ func (mr *MockCartServiceClientMockRecorder) ClearCart(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartServiceClient)(nil).ClearCart), varargs...)
 }
 
+// ConfirmPayment mocks base method.
+func (m *MockCartServiceClient) ConfirmPayment(arg0 context.Context, arg1 *gen.ConfirmPaymentRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmPayment", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPayment indicates an expected call of ConfirmPayment.
+func (mr *MockCartServiceClientMockRecorder) ConfirmPayment(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockCartServiceClient)(nil).ConfirmPayment), varargs...)
+}
+
+// CourierOrderAction mocks base method.
+func (m *MockCartServiceClient) CourierOrderAction(arg0 context.Context, arg1 *gen.CourierOrderActionRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CourierOrderAction", varargs...)
+	ret0, _ := ret[0].(*gen.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CourierOrderAction indicates an expected call of CourierOrderAction.
+func (mr *MockCartServiceClientMockRecorder) CourierOrderAction(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CourierOrderAction", reflect.TypeOf((*MockCartServiceClient)(nil).CourierOrderAction), varargs...)
+}
+
 // CreateOrder mocks base method.
 func (m *MockCartServiceClient) CreateOrder(arg0 context.Context, arg1 *gen.CreateOrderRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +156,26 @@ func (mr *MockCartServiceClientMockRecorder) GetCart(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartServiceClient)(nil).GetCart), varargs...)
 }
 
+// GetCourierOrders mocks base method.
+func (m *MockCartServiceClient) GetCourierOrders(arg0 context.Context, arg1 *gen.CourierOrdersRequest, arg2 ...grpc.CallOption) (*gen.OrderListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCourierOrders", varargs...)
+	ret0, _ := ret[0].(*gen.OrderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierOrders indicates an expected call of GetCourierOrders.
+func (mr *MockCartServiceClientMockRecorder) GetCourierOrders(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierOrders", reflect.TypeOf((*MockCartServiceClient)(nil).GetCourierOrders), varargs...)
+}
+
 // GetOrderById mocks base method.
 func (m *MockCartServiceClient) GetOrderById(arg0 context.Context, arg1 *gen.GetOrderByIdRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +216,126 @@ func (mr *MockCartServiceClientMockRecorder) GetOrders(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockCartServiceClient)(nil).GetOrders), varargs...)
 }
 
+// GetRestaurantOrders mocks base method.
+func (m *MockCartServiceClient) GetRestaurantOrders(arg0 context.Context, arg1 *gen.RestaurantOrdersRequest, arg2 ...grpc.CallOption) (*gen.OrderListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRestaurantOrders", varargs...)
+	ret0, _ := ret[0].(*gen.OrderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestaurantOrders indicates an expected call of GetRestaurantOrders.
+func (mr *MockCartServiceClientMockRecorder) GetRestaurantOrders(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestaurantOrders", reflect.TypeOf((*MockCartServiceClient)(nil).GetRestaurantOrders), varargs...)
+}
+
+// MergeGuestCart mocks base method.
+func (m *MockCartServiceClient) MergeGuestCart(arg0 context.Context, arg1 *gen.MergeGuestCartRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeGuestCart", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockCartServiceClientMockRecorder) MergeGuestCart(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockCartServiceClient)(nil).MergeGuestCart), varargs...)
+}
+
+// PreviewPromo mocks base method.
+func (m *MockCartServiceClient) PreviewPromo(arg0 context.Context, arg1 *gen.PreviewPromoRequest, arg2 ...grpc.CallOption) (*gen.PromoPreviewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PreviewPromo", varargs...)
+	ret0, _ := ret[0].(*gen.PromoPreviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewPromo indicates an expected call of PreviewPromo.
+func (mr *MockCartServiceClientMockRecorder) PreviewPromo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewPromo", reflect.TypeOf((*MockCartServiceClient)(nil).PreviewPromo), varargs...)
+}
+
+// Reorder mocks base method.
+func (m *MockCartServiceClient) Reorder(arg0 context.Context, arg1 *gen.ReorderRequest, arg2 ...grpc.CallOption) (*gen.ReorderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Reorder", varargs...)
+	ret0, _ := ret[0].(*gen.ReorderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockCartServiceClientMockRecorder) Reorder(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockCartServiceClient)(nil).Reorder), varargs...)
+}
+
+// SetCourierAvailability mocks base method.
+func (m *MockCartServiceClient) SetCourierAvailability(arg0 context.Context, arg1 *gen.CourierAvailabilityRequest, arg2 ...grpc.CallOption) (*gen.CourierResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetCourierAvailability", varargs...)
+	ret0, _ := ret[0].(*gen.CourierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCourierAvailability indicates an expected call of SetCourierAvailability.
+func (mr *MockCartServiceClientMockRecorder) SetCourierAvailability(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourierAvailability", reflect.TypeOf((*MockCartServiceClient)(nil).SetCourierAvailability), varargs...)
+}
+
+// SetRestaurantOrderStatus mocks base method.
+func (m *MockCartServiceClient) SetRestaurantOrderStatus(arg0 context.Context, arg1 *gen.RestaurantOrderStatusRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetRestaurantOrderStatus", varargs...)
+	ret0, _ := ret[0].(*gen.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRestaurantOrderStatus indicates an expected call of SetRestaurantOrderStatus.
+func (mr *MockCartServiceClientMockRecorder) SetRestaurantOrderStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestaurantOrderStatus", reflect.TypeOf((*MockCartServiceClient)(nil).SetRestaurantOrderStatus), varargs...)
+}
+
 // UpdateItemQuantity mocks base method.
 func (m *MockCartServiceClient) UpdateItemQuantity(arg0 context.Context, arg1 *gen.UpdateQuantityRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -157,22 +356,42 @@ func (mr *MockCartServiceClientMockRecorder) UpdateItemQuantity(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQuantity", reflect.TypeOf((*MockCartServiceClient)(nil).UpdateItemQuantity), varargs...)
 }
 
-// UpdateOrderStatus mocks base method.
-func (m *MockCartServiceClient) UpdateOrderStatus(arg0 context.Context, arg1 *gen.UpdateOrderStatusRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+// UpdateTip mocks base method.
+func (m *MockCartServiceClient) UpdateTip(arg0 context.Context, arg1 *gen.UpdateTipRequest, arg2 ...grpc.CallOption) (*gen.OrderResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", varargs...)
-	ret0, _ := ret[0].(*emptypb.Empty)
+	ret := m.ctrl.Call(m, "UpdateTip", varargs...)
+	ret0, _ := ret[0].(*gen.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTip indicates an expected call of UpdateTip.
+func (mr *MockCartServiceClientMockRecorder) UpdateTip(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTip", reflect.TypeOf((*MockCartServiceClient)(nil).UpdateTip), varargs...)
+}
+
+// WatchOrder mocks base method.
+func (m *MockCartServiceClient) WatchOrder(arg0 context.Context, arg1 *gen.WatchOrderRequest, arg2 ...grpc.CallOption) (grpc.ServerStreamingClient[gen.OrderUpdate], error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchOrder", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[gen.OrderUpdate])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockCartServiceClientMockRecorder) UpdateOrderStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+// WatchOrder indicates an expected call of WatchOrder.
+func (mr *MockCartServiceClientMockRecorder) WatchOrder(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockCartServiceClient)(nil).UpdateOrderStatus), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOrder", reflect.TypeOf((*MockCartServiceClient)(nil).WatchOrder), varargs...)
 }
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,20 @@ func (mr *MockCartRepoMockRecorder) ClearCart(ctx, userID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartRepo)(nil).ClearCart), ctx, userID)
 }
 
+// FillCart mocks base method.
+func (m *MockCartRepo) FillCart(ctx context.Context, userID, restaurantID string, quantities map[string]int, replace bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FillCart", ctx, userID, restaurantID, quantities, replace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FillCart indicates an expected call of FillCart.
+func (mr *MockCartRepoMockRecorder) FillCart(ctx, userID, restaurantID, quantities, replace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillCart", reflect.TypeOf((*MockCartRepo)(nil).FillCart), ctx, userID, restaurantID, quantities, replace)
+}
+
 // GetCart mocks base method.
 func (m *MockCartRepo) GetCart(ctx context.Context, userID string) (map[string]int, string, error) {
 	m.ctrl.T.Helper()
@@ -66,18 +81,32 @@ func (mr *MockCartRepoMockRecorder) GetCart(ctx, userID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartRepo)(nil).GetCart), ctx, userID)
 }
 
+// MergeCart mocks base method.
+func (m *MockCartRepo) MergeCart(ctx context.Context, fromUserID, toUserID string, replace bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCart", ctx, fromUserID, toUserID, replace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCart indicates an expected call of MergeCart.
+func (mr *MockCartRepoMockRecorder) MergeCart(ctx, fromUserID, toUserID, replace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCart", reflect.TypeOf((*MockCartRepo)(nil).MergeCart), ctx, fromUserID, toUserID, replace)
+}
+
 // UpdateItemQuantity mocks base method.
-func (m *MockCartRepo) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantId string, quantity int) error {
+func (m *MockCartRepo) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantId string, quantity int, replace bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemQuantity", ctx, userID, productID, restaurantId, quantity)
+	ret := m.ctrl.Call(m, "UpdateItemQuantity", ctx, userID, productID, restaurantId, quantity, replace)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateItemQuantity indicates an expected call of UpdateItemQuantity.
-func (mr *MockCartRepoMockRecorder) UpdateItemQuantity(ctx, userID, productID, restaurantId, quantity interface{}) *gomock.Call {
+func (mr *MockCartRepoMockRecorder) UpdateItemQuantity(ctx, userID, productID, restaurantId, quantity, replace interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQuantity", reflect.TypeOf((*MockCartRepo)(nil).UpdateItemQuantity), ctx, userID, productID, restaurantId, quantity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQuantity", reflect.TypeOf((*MockCartRepo)(nil).UpdateItemQuantity), ctx, userID, productID, restaurantId, quantity, replace)
 }
 
 // MockCartUsecase is a mock of CartUsecase interface.
@@ -103,6 +132,21 @@ func (m *MockCartUsecase) EXPECT() *MockCartUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CancelOrder mocks base method.
+func (m *MockCartUsecase) CancelOrder(ctx context.Context, orderID, userID uuid.UUID, reason string) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, orderID, userID, reason)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockCartUsecaseMockRecorder) CancelOrder(ctx, orderID, userID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockCartUsecase)(nil).CancelOrder), ctx, orderID, userID, reason)
+}
+
 // ClearCart mocks base method.
 func (m *MockCartUsecase) ClearCart(ctx context.Context, userID string) error {
 	m.ctrl.T.Helper()
@@ -117,6 +161,35 @@ func (mr *MockCartUsecaseMockRecorder) ClearCart(ctx, userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartUsecase)(nil).ClearCart), ctx, userID)
 }
 
+// ConfirmPayment mocks base method.
+func (m *MockCartUsecase) ConfirmPayment(ctx context.Context, orderID uuid.UUID, paymentID string, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, orderID, paymentID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPayment indicates an expected call of ConfirmPayment.
+func (mr *MockCartUsecaseMockRecorder) ConfirmPayment(ctx, orderID, paymentID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockCartUsecase)(nil).ConfirmPayment), ctx, orderID, paymentID, amount)
+}
+
+// CourierOrderAction mocks base method.
+func (m *MockCartUsecase) CourierOrderAction(ctx context.Context, userID, orderID uuid.UUID, action string) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CourierOrderAction", ctx, userID, orderID, action)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CourierOrderAction indicates an expected call of CourierOrderAction.
+func (mr *MockCartUsecaseMockRecorder) CourierOrderAction(ctx, userID, orderID, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CourierOrderAction", reflect.TypeOf((*MockCartUsecase)(nil).CourierOrderAction), ctx, userID, orderID, action)
+}
+
 // CreateOrder mocks base method.
 func (m *MockCartUsecase) CreateOrder(ctx context.Context, userID string, details models.OrderInReq, cart models.Cart) (models.Order, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +221,21 @@ func (mr *MockCartUsecaseMockRecorder) GetCart(ctx, userID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartUsecase)(nil).GetCart), ctx, userID)
 }
 
+// GetCourierOrders mocks base method.
+func (m *MockCartUsecase) GetCourierOrders(ctx context.Context, userID uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierOrders", ctx, userID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierOrders indicates an expected call of GetCourierOrders.
+func (mr *MockCartUsecaseMockRecorder) GetCourierOrders(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierOrders", reflect.TypeOf((*MockCartUsecase)(nil).GetCourierOrders), ctx, userID)
+}
+
 // GetOrderById mocks base method.
 func (m *MockCartUsecase) GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error) {
 	m.ctrl.T.Helper()
@@ -164,46 +252,151 @@ func (mr *MockCartUsecaseMockRecorder) GetOrderById(ctx, order_id, user_id inter
 }
 
 // GetOrders mocks base method.
-func (m *MockCartUsecase) GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error) {
+func (m *MockCartUsecase) GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, cursor string, count int) (models.OrderPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrders", ctx, user_id, count, offset)
-	ret0, _ := ret[0].([]models.Order)
+	ret := m.ctrl.Call(m, "GetOrders", ctx, user_id, filter, cursor, count)
+	ret0, _ := ret[0].(models.OrderPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrders indicates an expected call of GetOrders.
-func (mr *MockCartUsecaseMockRecorder) GetOrders(ctx, user_id, count, offset interface{}) *gomock.Call {
+func (mr *MockCartUsecaseMockRecorder) GetOrders(ctx, user_id, filter, cursor, count interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockCartUsecase)(nil).GetOrders), ctx, user_id, count, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockCartUsecase)(nil).GetOrders), ctx, user_id, filter, cursor, count)
 }
 
-// UpdateItemQuantity mocks base method.
-func (m *MockCartUsecase) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantId string, quantity int) error {
+// GetRestaurantOrders mocks base method.
+func (m *MockCartUsecase) GetRestaurantOrders(ctx context.Context, staffID uuid.UUID) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemQuantity", ctx, userID, productID, restaurantId, quantity)
+	ret := m.ctrl.Call(m, "GetRestaurantOrders", ctx, staffID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestaurantOrders indicates an expected call of GetRestaurantOrders.
+func (mr *MockCartUsecaseMockRecorder) GetRestaurantOrders(ctx, staffID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestaurantOrders", reflect.TypeOf((*MockCartUsecase)(nil).GetRestaurantOrders), ctx, staffID)
+}
+
+// MergeGuestCart mocks base method.
+func (m *MockCartUsecase) MergeGuestCart(ctx context.Context, guestID, userID string, replace bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuestCart", ctx, guestID, userID, replace)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateItemQuantity indicates an expected call of UpdateItemQuantity.
-func (mr *MockCartUsecaseMockRecorder) UpdateItemQuantity(ctx, userID, productID, restaurantId, quantity interface{}) *gomock.Call {
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockCartUsecaseMockRecorder) MergeGuestCart(ctx, guestID, userID, replace interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQuantity", reflect.TypeOf((*MockCartUsecase)(nil).UpdateItemQuantity), ctx, userID, productID, restaurantId, quantity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockCartUsecase)(nil).MergeGuestCart), ctx, guestID, userID, replace)
 }
 
-// UpdateOrderStatus mocks base method.
-func (m *MockCartUsecase) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID) error {
+// PreviewPromo mocks base method.
+func (m *MockCartUsecase) PreviewPromo(ctx context.Context, userID, code string, cart models.Cart) (models.PromoPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewPromo", ctx, userID, code, cart)
+	ret0, _ := ret[0].(models.PromoPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewPromo indicates an expected call of PreviewPromo.
+func (mr *MockCartUsecaseMockRecorder) PreviewPromo(ctx, userID, code, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewPromo", reflect.TypeOf((*MockCartUsecase)(nil).PreviewPromo), ctx, userID, code, cart)
+}
+
+// Reorder mocks base method.
+func (m *MockCartUsecase) Reorder(ctx context.Context, orderID, userID uuid.UUID, login string, replace bool) (models.ReorderResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, orderID, userID, login, replace)
+	ret0, _ := ret[0].(models.ReorderResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockCartUsecaseMockRecorder) Reorder(ctx, orderID, userID, login, replace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockCartUsecase)(nil).Reorder), ctx, orderID, userID, login, replace)
+}
+
+// SetCourierAvailability mocks base method.
+func (m *MockCartUsecase) SetCourierAvailability(ctx context.Context, userID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, order_id)
+	ret := m.ctrl.Call(m, "SetCourierAvailability", ctx, userID, req)
+	ret0, _ := ret[0].(models.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCourierAvailability indicates an expected call of SetCourierAvailability.
+func (mr *MockCartUsecaseMockRecorder) SetCourierAvailability(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourierAvailability", reflect.TypeOf((*MockCartUsecase)(nil).SetCourierAvailability), ctx, userID, req)
+}
+
+// SetRestaurantOrderStatus mocks base method.
+func (m *MockCartUsecase) SetRestaurantOrderStatus(ctx context.Context, staffID, orderID uuid.UUID, status, reason string) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestaurantOrderStatus", ctx, staffID, orderID, status, reason)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRestaurantOrderStatus indicates an expected call of SetRestaurantOrderStatus.
+func (mr *MockCartUsecaseMockRecorder) SetRestaurantOrderStatus(ctx, staffID, orderID, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestaurantOrderStatus", reflect.TypeOf((*MockCartUsecase)(nil).SetRestaurantOrderStatus), ctx, staffID, orderID, status, reason)
+}
+
+// UpdateItemQuantity mocks base method.
+func (m *MockCartUsecase) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantId string, quantity int, replace bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemQuantity", ctx, userID, productID, restaurantId, quantity, replace)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockCartUsecaseMockRecorder) UpdateOrderStatus(ctx, order_id interface{}) *gomock.Call {
+// UpdateItemQuantity indicates an expected call of UpdateItemQuantity.
+func (mr *MockCartUsecaseMockRecorder) UpdateItemQuantity(ctx, userID, productID, restaurantId, quantity, replace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemQuantity", reflect.TypeOf((*MockCartUsecase)(nil).UpdateItemQuantity), ctx, userID, productID, restaurantId, quantity, replace)
+}
+
+// UpdateTip mocks base method.
+func (m *MockCartUsecase) UpdateTip(ctx context.Context, orderID, userID uuid.UUID, req models.TipReq) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTip", ctx, orderID, userID, req)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTip indicates an expected call of UpdateTip.
+func (mr *MockCartUsecaseMockRecorder) UpdateTip(ctx, orderID, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTip", reflect.TypeOf((*MockCartUsecase)(nil).UpdateTip), ctx, orderID, userID, req)
+}
+
+// WatchOrder mocks base method.
+func (m *MockCartUsecase) WatchOrder(ctx context.Context, orderID, userID uuid.UUID) (<-chan models.OrderStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchOrder", ctx, orderID, userID)
+	ret0, _ := ret[0].(<-chan models.OrderStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchOrder indicates an expected call of WatchOrder.
+func (mr *MockCartUsecaseMockRecorder) WatchOrder(ctx, orderID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockCartUsecase)(nil).UpdateOrderStatus), ctx, order_id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOrder", reflect.TypeOf((*MockCartUsecase)(nil).WatchOrder), ctx, orderID, userID)
 }
 
 // MockRestaurantRepo is a mock of RestaurantRepo interface.
@@ -229,6 +422,20 @@ func (m *MockRestaurantRepo) EXPECT() *MockRestaurantRepoMockRecorder {
 	return m.recorder
 }
 
+// DeleteStatusTransition mocks base method.
+func (m *MockRestaurantRepo) DeleteStatusTransition(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStatusTransition", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStatusTransition indicates an expected call of DeleteStatusTransition.
+func (mr *MockRestaurantRepoMockRecorder) DeleteStatusTransition(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatusTransition", reflect.TypeOf((*MockRestaurantRepo)(nil).DeleteStatusTransition), ctx, id)
+}
+
 // GetCartItem mocks base method.
 func (m *MockRestaurantRepo) GetCartItem(ctx context.Context, productIDs []string, productAmounts map[string]int, restaurantID string) (models.Cart, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +451,36 @@ func (mr *MockRestaurantRepoMockRecorder) GetCartItem(ctx, productIDs, productAm
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItem", reflect.TypeOf((*MockRestaurantRepo)(nil).GetCartItem), ctx, productIDs, productAmounts, restaurantID)
 }
 
+// GetDeliveryEstimate mocks base method.
+func (m *MockRestaurantRepo) GetDeliveryEstimate(ctx context.Context, restaurantID uuid.UUID) (models.DeliveryEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryEstimate", ctx, restaurantID)
+	ret0, _ := ret[0].(models.DeliveryEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryEstimate indicates an expected call of GetDeliveryEstimate.
+func (mr *MockRestaurantRepoMockRecorder) GetDeliveryEstimate(ctx, restaurantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryEstimate", reflect.TypeOf((*MockRestaurantRepo)(nil).GetDeliveryEstimate), ctx, restaurantID)
+}
+
+// GetDueStatusTransitions mocks base method.
+func (m *MockRestaurantRepo) GetDueStatusTransitions(ctx context.Context, limit int) ([]models.StatusTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueStatusTransitions", ctx, limit)
+	ret0, _ := ret[0].([]models.StatusTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueStatusTransitions indicates an expected call of GetDueStatusTransitions.
+func (mr *MockRestaurantRepoMockRecorder) GetDueStatusTransitions(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueStatusTransitions", reflect.TypeOf((*MockRestaurantRepo)(nil).GetDueStatusTransitions), ctx, limit)
+}
+
 // GetOrderById mocks base method.
 func (m *MockRestaurantRepo) GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error) {
 	m.ctrl.T.Helper()
@@ -259,19 +496,214 @@ func (mr *MockRestaurantRepoMockRecorder) GetOrderById(ctx, order_id, user_id in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrderById), ctx, order_id, user_id)
 }
 
+// GetOrderEstimate mocks base method.
+func (m *MockRestaurantRepo) GetOrderEstimate(ctx context.Context, orderID uuid.UUID) (models.DeliveryEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderEstimate", ctx, orderID)
+	ret0, _ := ret[0].(models.DeliveryEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderEstimate indicates an expected call of GetOrderEstimate.
+func (mr *MockRestaurantRepoMockRecorder) GetOrderEstimate(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderEstimate", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrderEstimate), ctx, orderID)
+}
+
+// GetOrderForPayment mocks base method.
+func (m *MockRestaurantRepo) GetOrderForPayment(ctx context.Context, orderID uuid.UUID) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderForPayment", ctx, orderID)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderForPayment indicates an expected call of GetOrderForPayment.
+func (mr *MockRestaurantRepoMockRecorder) GetOrderForPayment(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderForPayment", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrderForPayment), ctx, orderID)
+}
+
+// GetOrderStatus mocks base method.
+func (m *MockRestaurantRepo) GetOrderStatus(ctx context.Context, orderID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatus", ctx, orderID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatus indicates an expected call of GetOrderStatus.
+func (mr *MockRestaurantRepoMockRecorder) GetOrderStatus(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrderStatus), ctx, orderID)
+}
+
 // GetOrders mocks base method.
-func (m *MockRestaurantRepo) GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error) {
+func (m *MockRestaurantRepo) GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, limit int) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrders", ctx, user_id, count, offset)
+	ret := m.ctrl.Call(m, "GetOrders", ctx, user_id, filter, limit)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrders indicates an expected call of GetOrders.
-func (mr *MockRestaurantRepoMockRecorder) GetOrders(ctx, user_id, count, offset interface{}) *gomock.Call {
+func (mr *MockRestaurantRepoMockRecorder) GetOrders(ctx, user_id, filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrders), ctx, user_id, filter, limit)
+}
+
+// GetProductRestaurant mocks base method.
+func (m *MockRestaurantRepo) GetProductRestaurant(ctx context.Context, productID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRestaurant", ctx, productID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRestaurant indicates an expected call of GetProductRestaurant.
+func (mr *MockRestaurantRepoMockRecorder) GetProductRestaurant(ctx, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockRestaurantRepo)(nil).GetOrders), ctx, user_id, count, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRestaurant", reflect.TypeOf((*MockRestaurantRepo)(nil).GetProductRestaurant), ctx, productID)
+}
+
+// GetPromoCode mocks base method.
+func (m *MockRestaurantRepo) GetPromoCode(ctx context.Context, code string, restaurantID uuid.UUID) (models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCode", ctx, code, restaurantID)
+	ret0, _ := ret[0].(models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCode indicates an expected call of GetPromoCode.
+func (mr *MockRestaurantRepoMockRecorder) GetPromoCode(ctx, code, restaurantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCode", reflect.TypeOf((*MockRestaurantRepo)(nil).GetPromoCode), ctx, code, restaurantID)
+}
+
+// GetPromoUsage mocks base method.
+func (m *MockRestaurantRepo) GetPromoUsage(ctx context.Context, promoID uuid.UUID, userLogin string) (models.PromoUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoUsage", ctx, promoID, userLogin)
+	ret0, _ := ret[0].(models.PromoUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoUsage indicates an expected call of GetPromoUsage.
+func (mr *MockRestaurantRepoMockRecorder) GetPromoUsage(ctx, promoID, userLogin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoUsage", reflect.TypeOf((*MockRestaurantRepo)(nil).GetPromoUsage), ctx, promoID, userLogin)
+}
+
+// GetRestaurantOrder mocks base method.
+func (m *MockRestaurantRepo) GetRestaurantOrder(ctx context.Context, orderID, restaurantID uuid.UUID) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestaurantOrder", ctx, orderID, restaurantID)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestaurantOrder indicates an expected call of GetRestaurantOrder.
+func (mr *MockRestaurantRepoMockRecorder) GetRestaurantOrder(ctx, orderID, restaurantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestaurantOrder", reflect.TypeOf((*MockRestaurantRepo)(nil).GetRestaurantOrder), ctx, orderID, restaurantID)
+}
+
+// GetRestaurantOrders mocks base method.
+func (m *MockRestaurantRepo) GetRestaurantOrders(ctx context.Context, restaurantID uuid.UUID, statuses []string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestaurantOrders", ctx, restaurantID, statuses)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestaurantOrders indicates an expected call of GetRestaurantOrders.
+func (mr *MockRestaurantRepoMockRecorder) GetRestaurantOrders(ctx, restaurantID, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestaurantOrders", reflect.TypeOf((*MockRestaurantRepo)(nil).GetRestaurantOrders), ctx, restaurantID, statuses)
+}
+
+// GetSimulatedKitchenOrders mocks base method.
+func (m *MockRestaurantRepo) GetSimulatedKitchenOrders(ctx context.Context, statuses []string, limit int) ([]models.KitchenOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimulatedKitchenOrders", ctx, statuses, limit)
+	ret0, _ := ret[0].([]models.KitchenOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimulatedKitchenOrders indicates an expected call of GetSimulatedKitchenOrders.
+func (mr *MockRestaurantRepoMockRecorder) GetSimulatedKitchenOrders(ctx, statuses, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimulatedKitchenOrders", reflect.TypeOf((*MockRestaurantRepo)(nil).GetSimulatedKitchenOrders), ctx, statuses, limit)
+}
+
+// GetStaffRestaurant mocks base method.
+func (m *MockRestaurantRepo) GetStaffRestaurant(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaffRestaurant", ctx, userID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaffRestaurant indicates an expected call of GetStaffRestaurant.
+func (mr *MockRestaurantRepoMockRecorder) GetStaffRestaurant(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaffRestaurant", reflect.TypeOf((*MockRestaurantRepo)(nil).GetStaffRestaurant), ctx, userID)
+}
+
+// GetTipPayments mocks base method.
+func (m *MockRestaurantRepo) GetTipPayments(ctx context.Context, orderID uuid.UUID) ([]models.TipPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTipPayments", ctx, orderID)
+	ret0, _ := ret[0].([]models.TipPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTipPayments indicates an expected call of GetTipPayments.
+func (mr *MockRestaurantRepoMockRecorder) GetTipPayments(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTipPayments", reflect.TypeOf((*MockRestaurantRepo)(nil).GetTipPayments), ctx, orderID)
+}
+
+// GetWorkingMode mocks base method.
+func (m *MockRestaurantRepo) GetWorkingMode(ctx context.Context, restaurantID uuid.UUID) (models.WorkingMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkingMode", ctx, restaurantID)
+	ret0, _ := ret[0].(models.WorkingMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkingMode indicates an expected call of GetWorkingMode.
+func (mr *MockRestaurantRepoMockRecorder) GetWorkingMode(ctx, restaurantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkingMode", reflect.TypeOf((*MockRestaurantRepo)(nil).GetWorkingMode), ctx, restaurantID)
+}
+
+// IsTipPayment mocks base method.
+func (m *MockRestaurantRepo) IsTipPayment(ctx context.Context, paymentID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTipPayment", ctx, paymentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTipPayment indicates an expected call of IsTipPayment.
+func (mr *MockRestaurantRepoMockRecorder) IsTipPayment(ctx, paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTipPayment", reflect.TypeOf((*MockRestaurantRepo)(nil).IsTipPayment), ctx, paymentID)
 }
 
 // Save mocks base method.
@@ -288,30 +720,243 @@ func (mr *MockRestaurantRepoMockRecorder) Save(ctx, order, userLogin interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRestaurantRepo)(nil).Save), ctx, order, userLogin)
 }
 
-// ScheduleDeliveryStatusChange mocks base method.
-func (m *MockRestaurantRepo) ScheduleDeliveryStatusChange(ctx context.Context, orderID uuid.UUID) error {
+// SaveTipPayment mocks base method.
+func (m *MockRestaurantRepo) SaveTipPayment(ctx context.Context, tip models.TipPayment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScheduleDeliveryStatusChange", ctx, orderID)
+	ret := m.ctrl.Call(m, "SaveTipPayment", ctx, tip)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ScheduleDeliveryStatusChange indicates an expected call of ScheduleDeliveryStatusChange.
-func (mr *MockRestaurantRepoMockRecorder) ScheduleDeliveryStatusChange(ctx, orderID interface{}) *gomock.Call {
+// SaveTipPayment indicates an expected call of SaveTipPayment.
+func (mr *MockRestaurantRepoMockRecorder) SaveTipPayment(ctx, tip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDeliveryStatusChange", reflect.TypeOf((*MockRestaurantRepo)(nil).ScheduleDeliveryStatusChange), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTipPayment", reflect.TypeOf((*MockRestaurantRepo)(nil).SaveTipPayment), ctx, tip)
+}
+
+// ScheduleStatusTransition mocks base method.
+func (m *MockRestaurantRepo) ScheduleStatusTransition(ctx context.Context, transition models.StatusTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleStatusTransition", ctx, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleStatusTransition indicates an expected call of ScheduleStatusTransition.
+func (mr *MockRestaurantRepoMockRecorder) ScheduleStatusTransition(ctx, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleStatusTransition", reflect.TypeOf((*MockRestaurantRepo)(nil).ScheduleStatusTransition), ctx, transition)
 }
 
 // UpdateOrderStatus mocks base method.
-func (m *MockRestaurantRepo) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, status string) error {
+func (m *MockRestaurantRepo) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, from string, event models.OrderStatusEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, order_id, status)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, order_id, from, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockRestaurantRepoMockRecorder) UpdateOrderStatus(ctx, order_id, status interface{}) *gomock.Call {
+func (mr *MockRestaurantRepoMockRecorder) UpdateOrderStatus(ctx, order_id, from, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockRestaurantRepo)(nil).UpdateOrderStatus), ctx, order_id, from, event)
+}
+
+// UpdateOrderTip mocks base method.
+func (m *MockRestaurantRepo) UpdateOrderTip(ctx context.Context, orderID uuid.UUID, from, to float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderTip", ctx, orderID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderTip indicates an expected call of UpdateOrderTip.
+func (mr *MockRestaurantRepoMockRecorder) UpdateOrderTip(ctx, orderID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderTip", reflect.TypeOf((*MockRestaurantRepo)(nil).UpdateOrderTip), ctx, orderID, from, to)
+}
+
+// MockCourierRepo is a mock of CourierRepo interface.
+type MockCourierRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourierRepoMockRecorder
+}
+
+// MockCourierRepoMockRecorder is the mock recorder for MockCourierRepo.
+type MockCourierRepoMockRecorder struct {
+	mock *MockCourierRepo
+}
+
+// NewMockCourierRepo creates a new mock instance.
+func NewMockCourierRepo(ctrl *gomock.Controller) *MockCourierRepo {
+	mock := &MockCourierRepo{ctrl: ctrl}
+	mock.recorder = &MockCourierRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourierRepo) EXPECT() *MockCourierRepoMockRecorder {
+	return m.recorder
+}
+
+// AssignCourier mocks base method.
+func (m *MockCourierRepo) AssignCourier(ctx context.Context, orderID, courierID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderID, courierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignCourier indicates an expected call of AssignCourier.
+func (mr *MockCourierRepoMockRecorder) AssignCourier(ctx, orderID, courierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockCourierRepo)(nil).AssignCourier), ctx, orderID, courierID)
+}
+
+// DeleteAssignment mocks base method.
+func (m *MockCourierRepo) DeleteAssignment(ctx context.Context, orderID, courierID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignment", ctx, orderID, courierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignment indicates an expected call of DeleteAssignment.
+func (mr *MockCourierRepoMockRecorder) DeleteAssignment(ctx, orderID, courierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignment", reflect.TypeOf((*MockCourierRepo)(nil).DeleteAssignment), ctx, orderID, courierID)
+}
+
+// GetAssignment mocks base method.
+func (m *MockCourierRepo) GetAssignment(ctx context.Context, orderID, courierID uuid.UUID) (models.CourierAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignment", ctx, orderID, courierID)
+	ret0, _ := ret[0].(models.CourierAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignment indicates an expected call of GetAssignment.
+func (mr *MockCourierRepoMockRecorder) GetAssignment(ctx, orderID, courierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignment", reflect.TypeOf((*MockCourierRepo)(nil).GetAssignment), ctx, orderID, courierID)
+}
+
+// GetAvailableCouriers mocks base method.
+func (m *MockCourierRepo) GetAvailableCouriers(ctx context.Context, includeSimulated bool) ([]models.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableCouriers", ctx, includeSimulated)
+	ret0, _ := ret[0].([]models.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableCouriers indicates an expected call of GetAvailableCouriers.
+func (mr *MockCourierRepoMockRecorder) GetAvailableCouriers(ctx, includeSimulated interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableCouriers", reflect.TypeOf((*MockCourierRepo)(nil).GetAvailableCouriers), ctx, includeSimulated)
+}
+
+// GetCourierByUser mocks base method.
+func (m *MockCourierRepo) GetCourierByUser(ctx context.Context, userID uuid.UUID) (models.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierByUser", ctx, userID)
+	ret0, _ := ret[0].(models.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierByUser indicates an expected call of GetCourierByUser.
+func (mr *MockCourierRepoMockRecorder) GetCourierByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierByUser", reflect.TypeOf((*MockCourierRepo)(nil).GetCourierByUser), ctx, userID)
+}
+
+// GetCourierOrders mocks base method.
+func (m *MockCourierRepo) GetCourierOrders(ctx context.Context, courierID uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierOrders", ctx, courierID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierOrders indicates an expected call of GetCourierOrders.
+func (mr *MockCourierRepoMockRecorder) GetCourierOrders(ctx, courierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierOrders", reflect.TypeOf((*MockCourierRepo)(nil).GetCourierOrders), ctx, courierID)
+}
+
+// GetSimulatedAssignments mocks base method.
+func (m *MockCourierRepo) GetSimulatedAssignments(ctx context.Context) ([]models.CourierAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimulatedAssignments", ctx)
+	ret0, _ := ret[0].([]models.CourierAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimulatedAssignments indicates an expected call of GetSimulatedAssignments.
+func (mr *MockCourierRepoMockRecorder) GetSimulatedAssignments(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimulatedAssignments", reflect.TypeOf((*MockCourierRepo)(nil).GetSimulatedAssignments), ctx)
+}
+
+// GetUnassignedOrders mocks base method.
+func (m *MockCourierRepo) GetUnassignedOrders(ctx context.Context, limit int) ([]models.DispatchOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnassignedOrders", ctx, limit)
+	ret0, _ := ret[0].([]models.DispatchOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnassignedOrders indicates an expected call of GetUnassignedOrders.
+func (mr *MockCourierRepoMockRecorder) GetUnassignedOrders(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnassignedOrders", reflect.TypeOf((*MockCourierRepo)(nil).GetUnassignedOrders), ctx, limit)
+}
+
+// ReleaseExpiredOffers mocks base method.
+func (m *MockCourierRepo) ReleaseExpiredOffers(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredOffers", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredOffers indicates an expected call of ReleaseExpiredOffers.
+func (mr *MockCourierRepoMockRecorder) ReleaseExpiredOffers(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredOffers", reflect.TypeOf((*MockCourierRepo)(nil).ReleaseExpiredOffers), ctx, before)
+}
+
+// SetCourierAvailability mocks base method.
+func (m *MockCourierRepo) SetCourierAvailability(ctx context.Context, courierID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourierAvailability", ctx, courierID, req)
+	ret0, _ := ret[0].(models.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCourierAvailability indicates an expected call of SetCourierAvailability.
+func (mr *MockCourierRepoMockRecorder) SetCourierAvailability(ctx, courierID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourierAvailability", reflect.TypeOf((*MockCourierRepo)(nil).SetCourierAvailability), ctx, courierID, req)
+}
+
+// UpdateAssignment mocks base method.
+func (m *MockCourierRepo) UpdateAssignment(ctx context.Context, orderID, courierID uuid.UUID, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssignment", ctx, orderID, courierID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssignment indicates an expected call of UpdateAssignment.
+func (mr *MockCourierRepoMockRecorder) UpdateAssignment(ctx, orderID, courierID, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockRestaurantRepo)(nil).UpdateOrderStatus), ctx, order_id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssignment", reflect.TypeOf((*MockCourierRepo)(nil).UpdateAssignment), ctx, orderID, courierID, from, to)
 }
@@ -0,0 +1,71 @@
+package cart
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/satori/uuid"
+)
+
+var (
+	ErrInvalidOrderFilter = errors.New("некорректный фильтр заказов")
+	ErrInvalidOrderCursor = errors.New("некорректный курсор")
+)
+
+// EncodeOrderCursor упаковывает позицию заказа в непрозрачную для клиента строку. В курсор
+// входит отпечаток фильтров и сортировки, с которыми он выдан.
+func EncodeOrderCursor(cursor models.OrderCursor, filter models.OrderFilter) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "." + cursor.ID.String() + "." + filterFingerprint(filter)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeOrderCursor разбирает курсор и отклоняет его, если следующую страницу запрашивают
+// с другими фильтрами или сортировкой: позиция из другой выборки дала бы пропуски и повторы.
+func DecodeOrderCursor(s string, filter models.OrderFilter) (models.OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return models.OrderCursor{}, ErrInvalidOrderCursor
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 {
+		return models.OrderCursor{}, ErrInvalidOrderCursor
+	}
+	nanos, id, fingerprint := parts[0], parts[1], parts[2]
+	if fingerprint != filterFingerprint(filter) {
+		return models.OrderCursor{}, fmt.Errorf("%w: фильтры или сортировка изменились", ErrInvalidOrderCursor)
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return models.OrderCursor{}, ErrInvalidOrderCursor
+	}
+	orderID, err := uuid.FromString(id)
+	if err != nil {
+		return models.OrderCursor{}, ErrInvalidOrderCursor
+	}
+	return models.OrderCursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: orderID}, nil
+}
+
+// filterFingerprint — короткий хеш фильтров и сортировки; позиция курсора (After) в него не входит.
+func filterFingerprint(filter models.OrderFilter) string {
+	statuses := append([]string(nil), filter.Statuses...)
+	sort.Strings(statuses)
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%s|%s|%g|%g|%t", strings.Join(statuses, ","), filter.RestaurantID,
+		formatBound(filter.From), formatBound(filter.To), filter.MinTotal, filter.MaxTotal, filter.OldestFirst)
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func formatBound(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
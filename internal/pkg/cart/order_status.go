@@ -0,0 +1,84 @@
+package cart
+
+import "errors"
+
+const (
+	StatusCreated        = "created"
+	StatusPaid           = "paid"
+	StatusAccepted       = "accepted"
+	StatusScheduled      = "scheduled"
+	StatusCooking        = "cooking"
+	StatusReadyForPickup = "ready_for_pickup"
+	StatusInDelivery     = "in_delivery"
+	StatusDelivered      = "delivered"
+	StatusCancelled      = "cancelled"
+	StatusRefunded       = "refunded"
+)
+
+const (
+	ActorUser       = "user"
+	ActorSystem     = "system"
+	ActorRestaurant = "restaurant"
+	ActorCourier    = "courier"
+)
+
+var (
+	ErrInvalidTransition = errors.New("недопустимая смена статуса заказа")
+	ErrStatusConflict    = errors.New("статус заказа был изменён параллельно")
+	ErrOrderNotFound     = errors.New("заказ не найден")
+	ErrInvalidDeliverAt  = errors.New("некорректное время доставки")
+
+	ErrNotRestaurantStaff = errors.New("пользователь не является сотрудником ресторана")
+)
+
+// orderTransitions описывает жизненный цикл заказа: из какого статуса в какие можно перейти.
+var orderTransitions = map[string][]string{
+	StatusCreated:        {StatusPaid, StatusCancelled},
+	StatusPaid:           {StatusAccepted, StatusCancelled},
+	StatusAccepted:       {StatusScheduled, StatusCooking, StatusCancelled},
+	StatusScheduled:      {StatusCooking, StatusCancelled},
+	StatusCooking:        {StatusReadyForPickup, StatusCancelled},
+	StatusReadyForPickup: {StatusInDelivery},
+	StatusInDelivery:     {StatusDelivered},
+	StatusCancelled:      {StatusRefunded},
+	StatusDelivered:      {},
+	StatusRefunded:       {},
+}
+
+// restaurantTransitions — подмножество orderTransitions, доступное персоналу ресторана.
+// Отказаться от заказа (перевести в "cancelled") можно только до начала готовки.
+var restaurantTransitions = map[string][]string{
+	StatusPaid:      {StatusAccepted, StatusCancelled},
+	StatusAccepted:  {StatusCooking, StatusCancelled},
+	StatusScheduled: {StatusCooking, StatusCancelled},
+	StatusCooking:   {StatusReadyForPickup},
+}
+
+// RestaurantActiveStatuses — статусы заказов, которые ресторану ещё предстоит обработать.
+var RestaurantActiveStatuses = []string{StatusPaid, StatusAccepted, StatusScheduled, StatusCooking, StatusReadyForPickup}
+
+// KitchenQueueStatuses — статусы заказов, которые занимают кухню ресторана: ждут готовки или готовятся.
+var KitchenQueueStatuses = []string{StatusPaid, StatusAccepted, StatusCooking}
+
+func IsKnownStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+func CanTransition(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func CanRestaurantTransition(from, to string) bool {
+	for _, next := range restaurantTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package cart
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	PromoPercent      = "percent"
+	PromoFixed        = "fixed"
+	PromoFreeDelivery = "free_delivery"
+)
+
+var (
+	ErrPromoNotFound      = errors.New("промокод не найден")
+	ErrPromoInactive      = errors.New("промокод сейчас не действует")
+	ErrPromoNotApplicable = errors.New("промокод не действует для этого ресторана")
+	ErrPromoMinSubtotal   = errors.New("сумма заказа меньше минимальной для промокода")
+	ErrPromoExhausted     = errors.New("промокод больше нельзя использовать")
+	ErrPromoFirstOrder    = errors.New("промокод действует только на первый заказ")
+)
+
+// IsPromoError сообщает, что промокод отклонён по его условиям, а не из-за сбоя.
+func IsPromoError(err error) bool {
+	return errors.Is(err, ErrPromoNotFound) || errors.Is(err, ErrPromoInactive) ||
+		errors.Is(err, ErrPromoNotApplicable) || errors.Is(err, ErrPromoMinSubtotal) ||
+		errors.Is(err, ErrPromoExhausted) || errors.Is(err, ErrPromoFirstOrder)
+}
+
+// NormalizePromoCode приводит введённый код к виду, в котором коды хранятся в базе.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
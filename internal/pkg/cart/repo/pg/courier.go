@@ -0,0 +1,235 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/jackc/pgx/v4"
+	"github.com/satori/uuid"
+)
+
+const (
+	// courierFields — поля курьера и число его незавершённых заказов.
+	courierFields = `c.id, c.name, c.available, c.latitude, c.longitude, c.max_orders,
+		(SELECT count(*) FROM courier_assignments a WHERE a.courier_id = c.id AND a.status <> 'delivered')`
+	getCourierByUser       = `SELECT ` + courierFields + ` FROM couriers c WHERE c.user_id = $1;`
+	setCourierAvailability = `WITH c AS (
+		UPDATE couriers SET available = $2,
+			latitude = COALESCE($3, latitude), longitude = COALESCE($4, longitude), updated_at = now()
+		WHERE id = $1 RETURNING *
+	)
+	SELECT ` + courierFields + ` FROM c;`
+	getAvailableCouriers = `SELECT ` + courierFields + ` FROM couriers c
+		WHERE c.available AND (NOT c.simulated OR $1);`
+	getCourierOrders = ordersList + `
+JOIN courier_assignments ca ON ca.order_id = o.id
+WHERE ca.courier_id = $1 AND ca.status <> 'delivered'
+ORDER BY ca.created_at, o.id;`
+
+	getUnassignedOrders = `SELECT o.id, r.latitude, r.longitude
+		FROM orders o LEFT JOIN restaurants r ON r.id = o.restaurant_id
+		WHERE o.status = $1 AND NOT EXISTS (SELECT 1 FROM courier_assignments a WHERE a.order_id = o.id)
+		ORDER BY o.created_at, o.id LIMIT $2;`
+	assignCourier = `INSERT INTO courier_assignments (order_id, courier_id, status) VALUES ($1, $2, $3)
+		ON CONFLICT (order_id) DO NOTHING;`
+	assignmentFields = `a.order_id, a.courier_id, a.status, o.status, o.user_id, a.updated_at
+		FROM courier_assignments a JOIN orders o ON o.id = a.order_id`
+	getAssignment           = `SELECT ` + assignmentFields + ` WHERE a.order_id = $1 AND a.courier_id = $2;`
+	getSimulatedAssignments = `SELECT ` + assignmentFields + `
+		JOIN couriers c ON c.id = a.courier_id
+		WHERE c.simulated AND a.status <> 'delivered' ORDER BY a.updated_at;`
+	updateAssignment = `UPDATE courier_assignments SET status = $4, updated_at = now()
+		WHERE order_id = $1 AND courier_id = $2 AND status = $3;`
+	deleteAssignment     = `DELETE FROM courier_assignments WHERE order_id = $1 AND courier_id = $2;`
+	releaseExpiredOffers = `DELETE FROM courier_assignments WHERE status = $1 AND updated_at < $2;`
+)
+
+func scanCourier(row pgx.Row) (models.Courier, error) {
+	var courier models.Courier
+	err := row.Scan(&courier.ID, &courier.Name, &courier.Available, &courier.Latitude, &courier.Longitude,
+		&courier.MaxOrders, &courier.ActiveOrders)
+	return courier, err
+}
+
+func (r *RestaurantRepository) GetCourierByUser(ctx context.Context, userID uuid.UUID) (models.Courier, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	courier, err := scanCourier(r.db.QueryRow(ctx, getCourierByUser, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Courier{}, cart.ErrNotCourier
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении курьера", slog.String("error", err.Error()))
+		return models.Courier{}, err
+	}
+	courier.Sanitize()
+	return courier, nil
+}
+
+func (r *RestaurantRepository) SetCourierAvailability(ctx context.Context, courierID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	courier, err := scanCourier(r.db.QueryRow(ctx, setCourierAvailability, courierID, req.Available, req.Latitude, req.Longitude))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Courier{}, cart.ErrNotCourier
+	}
+	if err != nil {
+		logger.Error("Ошибка при обновлении доступности курьера", slog.String("error", err.Error()))
+		return models.Courier{}, err
+	}
+	courier.Sanitize()
+	return courier, nil
+}
+
+func (r *RestaurantRepository) GetAvailableCouriers(ctx context.Context, includeSimulated bool) ([]models.Courier, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getAvailableCouriers, includeSimulated)
+	if err != nil {
+		logger.Error("Ошибка при получении свободных курьеров", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var couriers []models.Courier
+	for rows.Next() {
+		courier, err := scanCourier(rows)
+		if err != nil {
+			logger.Error("Ошибка при чтении курьера", slog.String("error", err.Error()))
+			return nil, err
+		}
+		couriers = append(couriers, courier)
+	}
+	return couriers, rows.Err()
+}
+
+func (r *RestaurantRepository) GetCourierOrders(ctx context.Context, courierID uuid.UUID) ([]models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	orders, err := r.queryOrders(ctx, getCourierOrders, courierID)
+	if err != nil {
+		logger.Error("Ошибка при получении заказов курьера", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *RestaurantRepository) GetUnassignedOrders(ctx context.Context, limit int) ([]models.DispatchOrder, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getUnassignedOrders, cart.StatusReadyForPickup, limit)
+	if err != nil {
+		logger.Error("Ошибка при получении заказов без курьера", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.DispatchOrder
+	for rows.Next() {
+		var order models.DispatchOrder
+		if err := rows.Scan(&order.OrderID, &order.Latitude, &order.Longitude); err != nil {
+			logger.Error("Ошибка при чтении заказа", slog.String("error", err.Error()))
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (r *RestaurantRepository) AssignCourier(ctx context.Context, orderID, courierID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	tag, err := r.db.Exec(ctx, assignCourier, orderID, courierID, cart.AssignmentOffered)
+	if err != nil {
+		logger.Error("Ошибка при назначении курьера", slog.String("error", err.Error()))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: заказ %s уже назначен курьеру", cart.ErrStatusConflict, orderID)
+	}
+	return nil
+}
+
+func scanAssignment(row pgx.Row) (models.CourierAssignment, error) {
+	var assignment models.CourierAssignment
+	err := row.Scan(&assignment.OrderID, &assignment.CourierID, &assignment.Status, &assignment.OrderStatus,
+		&assignment.UserID, &assignment.UpdatedAt)
+	return assignment, err
+}
+
+func (r *RestaurantRepository) GetAssignment(ctx context.Context, orderID, courierID uuid.UUID) (models.CourierAssignment, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	assignment, err := scanAssignment(r.db.QueryRow(ctx, getAssignment, orderID, courierID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CourierAssignment{}, cart.ErrAssignmentNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении назначения", slog.String("error", err.Error()))
+		return models.CourierAssignment{}, err
+	}
+	return assignment, nil
+}
+
+func (r *RestaurantRepository) UpdateAssignment(ctx context.Context, orderID, courierID uuid.UUID, from, to string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	tag, err := r.db.Exec(ctx, updateAssignment, orderID, courierID, from, to)
+	if err != nil {
+		logger.Error("Ошибка при обновлении назначения", slog.String("error", err.Error()))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: назначение заказа %s не в статусе %s", cart.ErrStatusConflict, orderID, from)
+	}
+	return nil
+}
+
+func (r *RestaurantRepository) DeleteAssignment(ctx context.Context, orderID, courierID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if _, err := r.db.Exec(ctx, deleteAssignment, orderID, courierID); err != nil {
+		logger.Error("Ошибка при снятии назначения", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (r *RestaurantRepository) ReleaseExpiredOffers(ctx context.Context, before time.Time) (int64, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	tag, err := r.db.Exec(ctx, releaseExpiredOffers, cart.AssignmentOffered, before)
+	if err != nil {
+		logger.Error("Ошибка при снятии просроченных назначений", slog.String("error", err.Error()))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *RestaurantRepository) GetSimulatedAssignments(ctx context.Context) ([]models.CourierAssignment, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getSimulatedAssignments)
+	if err != nil {
+		logger.Error("Ошибка при получении назначений симулятора", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []models.CourierAssignment
+	for rows.Next() {
+		assignment, err := scanAssignment(rows)
+		if err != nil {
+			logger.Error("Ошибка при чтении назначения", slog.String("error", err.Error()))
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
@@ -2,60 +2,190 @@ package pg
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/db"
 	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 )
 
 const (
-	getFieldProduct   = "SELECT id, name, price, image_url, weight FROM products WHERE id = ANY($1)"
+	// Товары другого ресторана в корзину не попадают, даже если их id прислал клиент.
+	getFieldProduct   = "SELECT id, name, price, image_url, weight FROM products WHERE id = ANY($1) AND restaurant_id = $2"
 	getRestaurantName = "SELECT name FROM restaurants WHERE id = $1"
-	insertOrder       = `INSERT INTO orders (id, user_id, status, address_id, order_products,
+	getProductRestaurant = "SELECT restaurant_id FROM products WHERE id = $1"
+	getWorkingMode       = "SELECT working_mode_from, working_mode_to FROM restaurants WHERE id = $1"
+	insertOrder       = `WITH inserted AS (
+		INSERT INTO orders (id, user_id, status, address_id, restaurant_id,
 		apartment_or_office, intercom, entrance, floor,
-		courier_comment, leave_at_door, created_at, final_price) 
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-	getAllOrders = `SELECT
-    id,
-    user_id,
-    status,
-    address_id,
-    order_products,
-    apartment_or_office,
-    intercom,
-    entrance,
-    floor,
-    courier_comment,
-    leave_at_door,
-    final_price,
-    created_at
-FROM orders WHERE user_id = $1 LIMIT $2 OFFSET $3;`
+		courier_comment, leave_at_door, created_at, final_price,
+		subtotal, delivery_fee, service_fee, discount, payment_id, promo_code, deliver_at, eta, tip) 
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,NULLIF($18, ''),NULLIF($19, ''),$20,$26,$27)
+		RETURNING id, status, eta, created_at
+	), items AS (
+		INSERT INTO order_items (order_id, product_id, name, price, quantity, weight)
+		SELECT inserted.id, i.product_id, i.name, i.price, i.quantity, i.weight
+		FROM inserted, unnest($21::uuid[], $22::text[], $23::numeric[], $24::int[], $25::int[])
+			WITH ORDINALITY AS i(product_id, name, price, quantity, weight, position)
+		ORDER BY i.position
+	)
+	INSERT INTO order_status_events (order_id, status, actor, eta, created_at)
+	SELECT id, status, 'user', eta, created_at FROM inserted`
+	ordersList = `SELECT
+    o.id,
+    o.user_id,
+    o.status,
+    o.address_id,
+    COALESCE(o.restaurant_id, uuid_nil()),
+    COALESCE(r.name, ''),
+    o.apartment_or_office,
+    o.intercom,
+    o.entrance,
+    o.floor,
+    o.courier_comment,
+    o.leave_at_door,
+    o.final_price,
+    o.subtotal,
+    o.delivery_fee,
+    o.service_fee,
+    o.discount,
+    o.tip,
+    o.deliver_at,
+    o.eta,
+    o.created_at
+FROM orders o LEFT JOIN restaurants r ON r.id = o.restaurant_id`
+	ordersHistory = ordersList + `
+WHERE o.user_id = $1
+    AND (COALESCE(cardinality($2::text[]), 0) = 0 OR o.status = ANY($2::text[]))
+    AND (NULLIF($3::text, '') IS NULL OR o.restaurant_id = NULLIF($3::text, '')::uuid)
+    AND ($4::timestamptz IS NULL OR o.created_at >= $4::timestamptz)
+    AND ($5::timestamptz IS NULL OR o.created_at < $5::timestamptz)
+    AND ($6::numeric = 0 OR o.final_price >= $6::numeric)
+    AND ($7::numeric = 0 OR o.final_price <= $7::numeric)`
+	// Курсор — пара (created_at, id) последнего заказа предыдущей страницы.
+	getOrdersNewestFirst = ordersHistory + `
+    AND ($8::timestamptz IS NULL OR (o.created_at, o.id) < ($8::timestamptz, NULLIF($9::text, '')::uuid))
+ORDER BY o.created_at DESC, o.id DESC LIMIT $10;`
+	getOrdersOldestFirst = ordersHistory + `
+    AND ($8::timestamptz IS NULL OR (o.created_at, o.id) > ($8::timestamptz, NULLIF($9::text, '')::uuid))
+ORDER BY o.created_at, o.id LIMIT $10;`
+	getRestaurantOrders = ordersList + `
+WHERE o.restaurant_id = $1 AND o.status = ANY($2::text[])
+ORDER BY o.created_at, o.id;`
 	getOrderById = `SELECT
-    id,
-    user_id,
-    status,
-    address_id,
-    order_products,
-    apartment_or_office,
-    intercom,
-    entrance,
-    floor,
-    courier_comment,
-    leave_at_door,
-    final_price,
-    created_at
-FROM orders WHERE id = $1 AND user_id = $2;`
-	updateOrderStatus = `UPDATE orders SET status = $1 WHERE id = $2;`
-	scheduleDeliveryStatusChange = `SELECT cron.schedule_in('20 seconds', $$UPDATE orders SET status = 'in delivery' WHERE id = $1$$);`
+    o.id,
+    o.user_id,
+    o.status,
+    o.address_id,
+    COALESCE(o.restaurant_id, uuid_nil()),
+    COALESCE(r.name, ''),
+    o.apartment_or_office,
+    o.intercom,
+    o.entrance,
+    o.floor,
+    o.courier_comment,
+    o.leave_at_door,
+    o.final_price,
+    o.subtotal,
+    o.delivery_fee,
+    o.service_fee,
+    o.discount,
+    o.tip,
+    COALESCE(o.payment_id, ''),
+    COALESCE(o.promo_code, ''),
+    o.deliver_at,
+    o.eta,
+    o.created_at
+FROM orders o LEFT JOIN restaurants r ON r.id = o.restaurant_id
+WHERE o.id = $1 AND o.user_id = $2;`
+	getOrderItems = `SELECT oi.order_id, oi.product_id, oi.name, oi.price,
+		COALESCE(p.image_url, 'default_product.jpg'), oi.weight, oi.quantity
+		FROM order_items oi LEFT JOIN products p ON p.id = oi.product_id
+		WHERE oi.order_id = ANY($1) ORDER BY oi.id;`
+	getOrderStatus    = `SELECT status FROM orders WHERE id = $1;`
+	getRestaurantOrder = `SELECT id, user_id, status, COALESCE(payment_id, ''), deliver_at
+		FROM orders WHERE id = $1 AND restaurant_id = $2;`
+	// Ресторан-симулятор — ресторан без единого сотрудника: его заказы некому вести вручную.
+	getSimulatedKitchenOrders = `SELECT o.id, o.status, o.deliver_at,
+		COALESCE(r.delivery_time_from, 0), COALESCE(r.delivery_time_to, 0),
+		COALESCE((SELECT max(e.created_at) FROM order_status_events e WHERE e.order_id = o.id), o.created_at)
+		FROM orders o LEFT JOIN restaurants r ON r.id = o.restaurant_id
+		WHERE o.status = ANY($1::text[]) AND o.restaurant_id IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM restaurant_staff s WHERE s.restaurant_id = o.restaurant_id)
+		ORDER BY o.created_at, o.id LIMIT $2;`
+	getStaffRestaurant = `SELECT restaurant_id FROM restaurant_staff WHERE user_id = $1;`
+	getOrderPayment   = `SELECT id, status, final_price, COALESCE(payment_id, '') FROM orders WHERE id = $1;`
+	getDeliveryEstimate = `SELECT COALESCE(r.delivery_time_from, 0), COALESCE(r.delivery_time_to, 0),
+		(SELECT count(*) FROM orders q WHERE q.restaurant_id = r.id AND q.status = ANY($2::text[]))
+		FROM restaurants r WHERE r.id = $1;`
+	// В очереди перед заказом — заказы того же ресторана, оформленные раньше него.
+	getOrderEstimate = `SELECT COALESCE(r.delivery_time_from, 0), COALESCE(r.delivery_time_to, 0), o.deliver_at,
+		(SELECT count(*) FROM orders q WHERE q.restaurant_id = o.restaurant_id AND q.status = ANY($2::text[])
+			AND (q.created_at, q.id) < (o.created_at, o.id))
+		FROM orders o LEFT JOIN restaurants r ON r.id = o.restaurant_id WHERE o.id = $1;`
+	// Отменённый заказ возвращает использование промокода вместе со сменой статуса.
+	updateOrderStatus = `WITH updated AS (
+		UPDATE orders SET status = $1, eta = $7 WHERE id = $2 AND status = $3 RETURNING id
+	), released AS (
+		DELETE FROM promo_code_uses WHERE $1 = 'cancelled' AND order_id IN (SELECT id FROM updated)
+	)
+	INSERT INTO order_status_events (order_id, status, actor, reason, eta, created_at)
+	SELECT id, $1, $4, NULLIF($5, ''), $7, $6 FROM updated;`
+	// Итог заказа меняется вместе с чаевыми; tip = $2 защищает от параллельного изменения.
+	updateOrderTip = `UPDATE orders SET final_price = final_price - tip + $3, tip = $3
+		WHERE id = $1 AND tip = $2::numeric;`
+	insertTipPayment = `INSERT INTO order_tip_payments (payment_id, order_id, amount) VALUES ($1, $2, $3);`
+	// Последние доплаты идут первыми: уменьшение чаевых возвращается с них.
+	getTipPayments = `SELECT payment_id, order_id, amount FROM order_tip_payments
+		WHERE order_id = $1 ORDER BY created_at DESC, payment_id;`
+	isTipPayment     = `SELECT EXISTS (SELECT 1 FROM order_tip_payments WHERE payment_id = $1);`
+	getOrderTimeline = `SELECT status, actor, COALESCE(reason, ''), eta, created_at
+		FROM order_status_events WHERE order_id = $1 ORDER BY id;`
+
+	getPromoCode = `SELECT id, code, type, value, first_order_only, min_subtotal,
+		COALESCE(max_uses, 0), COALESCE(max_uses_per_user, 0), starts_at, ends_at,
+		COALESCE(restaurant_id, uuid_nil()), COALESCE(tag_id, uuid_nil()),
+		EXISTS (SELECT 1 FROM restaurant_tags_relations rt WHERE rt.restaurant_id = $2 AND rt.tag_id = promo_codes.tag_id)
+		FROM promo_codes WHERE code = $1;`
+	getPromoUsage = `SELECT
+		(SELECT count(*) FROM promo_code_uses WHERE promo_code_id = $1),
+		(SELECT count(*) FROM promo_code_uses pu JOIN users u ON u.id = pu.user_id
+			WHERE pu.promo_code_id = $1 AND u.login = $2),
+		(SELECT count(*) FROM orders o JOIN users u ON u.id = o.user_id
+			WHERE u.login = $2 AND o.status NOT IN ('cancelled', 'refunded'));`
+	// lockPromoCode выстраивает параллельные заказы с одним промокодом в очередь до конца
+	// транзакции: следующий заказ считает использования уже после того, как предыдущий записан.
+	lockPromoCode = `SELECT id FROM promo_codes WHERE id = $1 FOR UPDATE;`
+	// redeemPromoCode записывает использование, только если лимиты ещё не исчерпаны.
+	redeemPromoCode = `INSERT INTO promo_code_uses (promo_code_id, user_id, order_id)
+		SELECT p.id, $2, $3 FROM promo_codes p
+		WHERE p.id = $1
+		AND (p.max_uses IS NULL OR (SELECT count(*) FROM promo_code_uses WHERE promo_code_id = p.id) < p.max_uses)
+		AND (p.max_uses_per_user IS NULL OR
+			(SELECT count(*) FROM promo_code_uses WHERE promo_code_id = p.id AND user_id = $2) < p.max_uses_per_user);`
+
+	insertStatusTransition = `INSERT INTO order_status_jobs (id, order_id, from_status, to_status, run_at)
+		VALUES ($1, $2, $3, $4, $5);`
+	getDueStatusTransitions = `SELECT id, order_id, from_status, to_status, run_at
+		FROM order_status_jobs WHERE run_at <= now() ORDER BY run_at LIMIT $1;`
+	deleteStatusTransition = `DELETE FROM order_status_jobs WHERE id = $1;`
 )
 
+// dbPool — пул соединений, в котором можно выполнить несколько запросов одной транзакцией.
+type dbPool interface {
+	pgxtype.Querier
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+}
+
 type RestaurantRepository struct {
-	db pgxtype.Querier
+	db dbPool
 }
 
 func NewRestaurantRepository() (*RestaurantRepository, error) {
@@ -66,7 +196,7 @@ func NewRestaurantRepository() (*RestaurantRepository, error) {
 func (r *RestaurantRepository) GetCartItem(ctx context.Context, productIDs []string, productAmounts map[string]int, restaurantID string) (models.Cart, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	rows, err := r.db.Query(ctx, getFieldProduct, productIDs)
+	rows, err := r.db.Query(ctx, getFieldProduct, productIDs, restaurantID)
 	if err != nil {
 		logger.Error("Ошибка при выполнении запроса", slog.String("error", err.Error()))
 		return models.Cart{}, err
@@ -84,11 +214,14 @@ func (r *RestaurantRepository) GetCartItem(ctx context.Context, productIDs []str
 		}
 		item.Amount = productAmounts[item.Id.String()]
 		items = append(items, item)
-		item.Sanitize()
 	}
 
 	var restaurantName string
 	err = r.db.QueryRow(ctx, getRestaurantName, restaurantID).Scan(&restaurantName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		logger.Warn("Ресторан не найден", slog.String("restaurant_id", restaurantID))
+		return models.Cart{}, cart.ErrRestaurantNotFound
+	}
 	if err != nil {
 		logger.Error("Ошибка при получении имени ресторана", slog.String("error", err.Error()))
 		return models.Cart{}, fmt.Errorf("не удалось получить имя ресторана: %w %s %s", err, restaurantName, restaurantID)
@@ -105,12 +238,74 @@ func (r *RestaurantRepository) GetCartItem(ctx context.Context, productIDs []str
 		Name:      restaurantName,
 		CartItems: items,
 	}
-	cart.Sanitize()
 
 	logger.Info("Успешно получена корзина", slog.String("restaurant_name", restaurantName), slog.Int("items_count", len(items)))
 	return cart, nil
 }
 
+func (r *RestaurantRepository) GetProductRestaurant(ctx context.Context, productID uuid.UUID) (uuid.UUID, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var restaurantID uuid.UUID
+	err := r.db.QueryRow(ctx, getProductRestaurant, productID).Scan(&restaurantID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, cart.ErrProductNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении ресторана товара", slog.String("error", err.Error()))
+		return uuid.Nil, err
+	}
+
+	return restaurantID, nil
+}
+
+func (r *RestaurantRepository) GetWorkingMode(ctx context.Context, restaurantID uuid.UUID) (models.WorkingMode, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var mode models.WorkingMode
+	err := r.db.QueryRow(ctx, getWorkingMode, restaurantID).Scan(&mode.From, &mode.To)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.WorkingMode{}, cart.ErrRestaurantNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении часов работы ресторана", slog.String("error", err.Error()))
+		return models.WorkingMode{}, err
+	}
+
+	return mode, nil
+}
+
+func (r *RestaurantRepository) GetPromoCode(ctx context.Context, code string, restaurantID uuid.UUID) (models.PromoCode, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var promo models.PromoCode
+	err := r.db.QueryRow(ctx, getPromoCode, code, restaurantID).Scan(&promo.ID, &promo.Code, &promo.Type, &promo.Value,
+		&promo.FirstOrderOnly, &promo.MinSubtotal, &promo.MaxUses, &promo.MaxUsesPerUser, &promo.StartsAt, &promo.EndsAt,
+		&promo.RestaurantID, &promo.TagID, &promo.RestaurantHasTag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.PromoCode{}, cart.ErrPromoNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении промокода", slog.String("error", err.Error()))
+		return models.PromoCode{}, err
+	}
+
+	return promo, nil
+}
+
+func (r *RestaurantRepository) GetPromoUsage(ctx context.Context, promoID uuid.UUID, userLogin string) (models.PromoUsage, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var usage models.PromoUsage
+	err := r.db.QueryRow(ctx, getPromoUsage, promoID, userLogin).Scan(&usage.Total, &usage.ByUser, &usage.UserOrders)
+	if err != nil {
+		logger.Error("Ошибка при подсчёте использований промокода", slog.String("error", err.Error()))
+		return models.PromoUsage{}, err
+	}
+
+	return usage, nil
+}
+
 func (r *RestaurantRepository) Save(ctx context.Context, order models.Order, userLogin string) error {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("user_login", userLogin))
 
@@ -121,18 +316,29 @@ func (r *RestaurantRepository) Save(ctx context.Context, order models.Order, use
 		return fmt.Errorf("не удалось найти пользователя по логину %s: %w", userLogin, err)
 	}
 
-	orderProductsStr, err := order.OrderProducts.MarshalJSON()
-	if err != nil {
-		logger.Error("Ошибка при маршалинге заказанных товаров", slog.String("error", err.Error()))
-		return err
-	}
-	order.Sanitize()
+	productIDs, names, prices, quantities, weights := orderItemsArgs(order.OrderProducts.CartItems)
 
-	_, err = r.db.Exec(ctx, insertOrder,
-		order.ID, userID, order.Status, order.Address, string(orderProductsStr),
-		order.ApartmentOrOffice, order.Intercom, order.Entrance, order.Floor,
-		order.CourierComment, order.LeaveAtDoor, order.CreatedAt, order.FinalPrice)
+	// Промокод списывается в одной транзакции с заказом: если заказ не запишется,
+	// использование откатится вместе с ним.
+	err = r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if order.PromoCodeID != uuid.Nil {
+			if err := redeemPromo(ctx, tx, order.PromoCodeID, userID, order.ID); err != nil {
+				return err
+			}
+		}
 
+		_, err := tx.Exec(ctx, insertOrder,
+			order.ID, userID, order.Status, order.Address, order.OrderProducts.Id,
+			order.ApartmentOrOffice, order.Intercom, order.Entrance, order.Floor,
+			order.CourierComment, order.LeaveAtDoor, order.CreatedAt, order.FinalPrice,
+			order.PriceBreakdown.Subtotal, order.PriceBreakdown.DeliveryFee,
+			order.PriceBreakdown.ServiceFee, order.PriceBreakdown.Discount, order.PaymentID, order.PromoCode, order.DeliverAt,
+			productIDs, names, prices, quantities, weights, order.ETA, order.PriceBreakdown.Tip)
+		return err
+	})
+	if errors.Is(err, cart.ErrPromoExhausted) {
+		return err
+	}
 	if err != nil {
 		logger.Error("Ошибка при вставке заказа в базу данных", slog.String("error", err.Error()))
 		return err
@@ -143,12 +349,53 @@ func (r *RestaurantRepository) Save(ctx context.Context, order models.Order, use
 	return nil
 }
 
-func (r *RestaurantRepository) GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error) {
+// redeemPromo записывает использование промокода заказом, если лимиты ещё позволяют.
+func redeemPromo(ctx context.Context, tx pgx.Tx, promoID, userID, orderID uuid.UUID) error {
+	if _, err := tx.Exec(ctx, lockPromoCode, promoID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, redeemPromoCode, promoID, userID, orderID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return cart.ErrPromoExhausted
+	}
+	return nil
+}
+
+func (r *RestaurantRepository) GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, limit int) ([]models.Order, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	rows, err := r.db.Query(ctx, getAllOrders, user_id, count, offset)
+	query := getOrdersNewestFirst
+	if filter.OldestFirst {
+		query = getOrdersOldestFirst
+	}
+	orders, err := r.queryOrders(ctx, query, orderHistoryArgs(user_id, filter, limit)...)
+	if err != nil {
+		logger.Error("Ошибка при получении истории заказов", slog.String("error", err.Error()))
+		return nil, err
+	}
+	logger.Info("Successful")
+	return orders, nil
+}
+
+// GetRestaurantOrders возвращает заказы ресторана в указанных статусах, начиная с самых старых.
+func (r *RestaurantRepository) GetRestaurantOrders(ctx context.Context, restaurantID uuid.UUID, statuses []string) ([]models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	orders, err := r.queryOrders(ctx, getRestaurantOrders, restaurantID, statuses)
+	if err != nil {
+		logger.Error("Ошибка при получении заказов ресторана", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return orders, nil
+}
+
+// queryOrders выполняет запрос на основе ordersList и подгружает состав найденных заказов.
+func (r *RestaurantRepository) queryOrders(ctx context.Context, query string, args ...interface{}) ([]models.Order, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		logger.Error(err.Error())
 		return nil, err
 	}
 	defer rows.Close()
@@ -156,82 +403,385 @@ func (r *RestaurantRepository) GetOrders(ctx context.Context, user_id uuid.UUID,
 	var orders []models.Order
 	for rows.Next() {
 		var order models.Order
-		var orderProductsJSON string
-		if err := rows.Scan(&order.ID, &order.UserID, &order.Status, &order.Address, &orderProductsJSON,
+		if err := rows.Scan(&order.ID, &order.UserID, &order.Status, &order.Address,
+			&order.OrderProducts.Id, &order.OrderProducts.Name,
 			&order.ApartmentOrOffice, &order.Intercom, &order.Entrance, &order.Floor, &order.CourierComment,
-			&order.LeaveAtDoor, &order.FinalPrice, &order.CreatedAt); err != nil {
-			logger.Error(err.Error())
+			&order.LeaveAtDoor, &order.FinalPrice, &order.PriceBreakdown.Subtotal, &order.PriceBreakdown.DeliveryFee,
+			&order.PriceBreakdown.ServiceFee, &order.PriceBreakdown.Discount, &order.PriceBreakdown.Tip, &order.DeliverAt, &order.ETA, &order.CreatedAt); err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal([]byte(orderProductsJSON), &order.OrderProducts); err != nil {
-			logger.Error("ошибка анмаршалинга JSON: " + err.Error())
+		order.PriceBreakdown.Total = order.FinalPrice
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.fillOrderItems(ctx, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetRestaurantOrder возвращает статус и платёж заказа, только если он принадлежит ресторану.
+func (r *RestaurantRepository) GetRestaurantOrder(ctx context.Context, orderID, restaurantID uuid.UUID) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var order models.Order
+	err := r.db.QueryRow(ctx, getRestaurantOrder, orderID, restaurantID).Scan(&order.ID, &order.UserID, &order.Status, &order.PaymentID, &order.DeliverAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Order{}, cart.ErrOrderNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении заказа ресторана", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+// GetSimulatedKitchenOrders возвращает заказы ресторанов без персонала в указанных статусах.
+func (r *RestaurantRepository) GetSimulatedKitchenOrders(ctx context.Context, statuses []string, limit int) ([]models.KitchenOrder, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getSimulatedKitchenOrders, statuses, limit)
+	if err != nil {
+		logger.Error("Ошибка при получении заказов ресторанов-симуляторов", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.KitchenOrder
+	for rows.Next() {
+		var order models.KitchenOrder
+		if err := rows.Scan(&order.OrderID, &order.Status, &order.DeliverAt,
+			&order.DeliveryTime.From, &order.DeliveryTime.To, &order.UpdatedAt); err != nil {
+			logger.Error("Ошибка при сканировании заказа", slog.String("error", err.Error()))
 			return nil, err
 		}
-		order.Sanitize()
 		orders = append(orders, order)
 	}
-	logger.Info("Successful")
 	return orders, rows.Err()
 }
 
+func (r *RestaurantRepository) GetStaffRestaurant(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var restaurantID uuid.UUID
+	err := r.db.QueryRow(ctx, getStaffRestaurant, userID).Scan(&restaurantID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, cart.ErrNotRestaurantStaff
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении ресторана сотрудника", slog.String("error", err.Error()))
+		return uuid.Nil, err
+	}
+	return restaurantID, nil
+}
+
 func (r *RestaurantRepository) GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
 	var order models.Order
-	var orderProductsJSON string
 
-	err := r.db.QueryRow(ctx, getOrderById, order_id, user_id).Scan(&order.ID, &order.UserID, &order.Status, &order.Address, &orderProductsJSON,
+	err := r.db.QueryRow(ctx, getOrderById, order_id, user_id).Scan(&order.ID, &order.UserID, &order.Status, &order.Address,
+		&order.OrderProducts.Id, &order.OrderProducts.Name,
 		&order.ApartmentOrOffice, &order.Intercom, &order.Entrance, &order.Floor, &order.CourierComment,
-		&order.LeaveAtDoor, &order.FinalPrice, &order.CreatedAt)
+		&order.LeaveAtDoor, &order.FinalPrice, &order.PriceBreakdown.Subtotal, &order.PriceBreakdown.DeliveryFee,
+		&order.PriceBreakdown.ServiceFee, &order.PriceBreakdown.Discount, &order.PriceBreakdown.Tip, &order.PaymentID, &order.PromoCode, &order.DeliverAt, &order.ETA, &order.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Order{}, cart.ErrOrderNotFound
+	}
 	if err != nil {
 		logger.Error("Ошибка при получении заказа", slog.String("error", err.Error()))
 		return models.Order{}, fmt.Errorf("не удалось получить заказ: %w", err)
 	}
+	order.PriceBreakdown.Total = order.FinalPrice
 
-	if err = json.Unmarshal([]byte(orderProductsJSON), &order.OrderProducts); err != nil {
-		logger.Error("ошибка анмаршалинга JSON: " + err.Error())
+	orders := []models.Order{order}
+	if err = r.fillOrderItems(ctx, orders); err != nil {
+		logger.Error("Ошибка при получении состава заказа", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+	order = orders[0]
+
+	order.Timeline, err = r.getOrderTimeline(ctx, order_id)
+	if err != nil {
+		logger.Error("Ошибка при получении истории заказа", slog.String("error", err.Error()))
 		return models.Order{}, err
 	}
 	logger.Info("Successful")
 	return order, nil
 }
 
-func (r *RestaurantRepository) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, status string) error {
+// fillOrderItems подгружает состав заказов одним запросом к order_items.
+func (r *RestaurantRepository) fillOrderItems(ctx context.Context, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	byID := make(map[uuid.UUID]*models.Order, len(orders))
+	for i := range orders {
+		orderIDs = append(orderIDs, orders[i].ID.String())
+		orders[i].OrderProducts.CartItems = []models.CartItem{}
+		byID[orders[i].ID] = &orders[i]
+	}
+
+	rows, err := r.db.Query(ctx, getOrderItems, orderIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID uuid.UUID
+		var item models.CartItem
+		if err := rows.Scan(&orderID, &item.Id, &item.Name, &item.Price, &item.ImageURL, &item.Weight, &item.Amount); err != nil {
+			return err
+		}
+		if order, ok := byID[orderID]; ok {
+			order.OrderProducts.CartItems = append(order.OrderProducts.CartItems, item)
+		}
+	}
+
+	return rows.Err()
+}
+
+func orderHistoryArgs(userID uuid.UUID, filter models.OrderFilter, limit int) []interface{} {
+	restaurantID := ""
+	if filter.RestaurantID != uuid.Nil {
+		restaurantID = filter.RestaurantID.String()
+	}
+	var afterTime *time.Time
+	afterID := ""
+	if filter.After != nil {
+		afterTime = &filter.After.CreatedAt
+		afterID = filter.After.ID.String()
+	}
+	return []interface{}{userID, filter.Statuses, restaurantID, filter.From, filter.To,
+		filter.MinTotal, filter.MaxTotal, afterTime, afterID, limit}
+}
+
+// orderItemsArgs раскладывает позиции заказа по массивам для unnest в insertOrder.
+func orderItemsArgs(items []models.CartItem) (productIDs, names []string, prices []float64, quantities, weights []int) {
+	for _, item := range items {
+		productIDs = append(productIDs, item.Id.String())
+		names = append(names, item.Name)
+		prices = append(prices, item.Price)
+		quantities = append(quantities, item.Amount)
+		weights = append(weights, item.Weight)
+	}
+	return productIDs, names, prices, quantities, weights
+}
+
+func (r *RestaurantRepository) getOrderTimeline(ctx context.Context, orderID uuid.UUID) ([]models.OrderStatusEvent, error) {
+	rows, err := r.db.Query(ctx, getOrderTimeline, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	timeline := []models.OrderStatusEvent{}
+	for rows.Next() {
+		var event models.OrderStatusEvent
+		if err := rows.Scan(&event.Status, &event.Actor, &event.Reason, &event.ETA, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		timeline = append(timeline, event)
+	}
+
+	return timeline, rows.Err()
+}
+
+func (r *RestaurantRepository) GetOrderStatus(ctx context.Context, orderID uuid.UUID) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var status string
+	err := r.db.QueryRow(ctx, getOrderStatus, orderID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", cart.ErrOrderNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении статуса заказа", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	return status, nil
+}
+
+func (r *RestaurantRepository) GetOrderForPayment(ctx context.Context, orderID uuid.UUID) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var order models.Order
+	err := r.db.QueryRow(ctx, getOrderPayment, orderID).Scan(&order.ID, &order.Status, &order.FinalPrice, &order.PaymentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Order{}, cart.ErrOrderNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении данных об оплате заказа", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	return order, nil
+}
+
+// GetDeliveryEstimate возвращает окно доставки ресторана и число заказов, которые сейчас занимают его кухню.
+func (r *RestaurantRepository) GetDeliveryEstimate(ctx context.Context, restaurantID uuid.UUID) (models.DeliveryEstimate, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var estimate models.DeliveryEstimate
+	err := r.db.QueryRow(ctx, getDeliveryEstimate, restaurantID, cart.KitchenQueueStatuses).Scan(
+		&estimate.DeliveryTime.From, &estimate.DeliveryTime.To, &estimate.QueueLength)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DeliveryEstimate{}, cart.ErrRestaurantNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении данных для оценки времени доставки", slog.String("error", err.Error()))
+		return models.DeliveryEstimate{}, err
+	}
+
+	return estimate, nil
+}
+
+// GetOrderEstimate возвращает окно доставки ресторана заказа, выбранное время доставки
+// и число заказов ресторана, которые стоят в очереди кухни перед ним.
+func (r *RestaurantRepository) GetOrderEstimate(ctx context.Context, orderID uuid.UUID) (models.DeliveryEstimate, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	res, err := r.db.Exec(ctx, updateOrderStatus, status, order_id)
+	var estimate models.DeliveryEstimate
+	err := r.db.QueryRow(ctx, getOrderEstimate, orderID, cart.KitchenQueueStatuses).Scan(
+		&estimate.DeliveryTime.From, &estimate.DeliveryTime.To, &estimate.DeliverAt, &estimate.QueueLength)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DeliveryEstimate{}, cart.ErrOrderNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении данных для оценки времени доставки", slog.String("error", err.Error()))
+		return models.DeliveryEstimate{}, err
+	}
+
+	return estimate, nil
+}
+
+// UpdateOrderStatus меняет статус, только если заказ всё ещё находится в статусе from,
+// и в том же запросе записывает событие в историю заказа.
+func (r *RestaurantRepository) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID, from string, event models.OrderStatusEvent) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	res, err := r.db.Exec(ctx, updateOrderStatus, event.Status, order_id, from, event.Actor, event.Reason, event.CreatedAt, event.ETA)
 	if err != nil {
 		logger.Error("Ошибка при обновлении статуса заказа", slog.String("error", err.Error()))
 		return err
 	}
 	if rows := res.RowsAffected(); rows == 0 {
-		return fmt.Errorf("заказ с id %s не найден", order_id)
+		return fmt.Errorf("%w: заказ %s не в статусе %s", cart.ErrStatusConflict, order_id, from)
+	}
+
+	logger.Info("Successful", slog.String("from", from), slog.String("to", event.Status))
+	return nil
+}
+
+func (r *RestaurantRepository) UpdateOrderTip(ctx context.Context, orderID uuid.UUID, from, to float64) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	res, err := r.db.Exec(ctx, updateOrderTip, orderID, from, to)
+	if err != nil {
+		logger.Error("Ошибка при обновлении чаевых", slog.String("error", err.Error()))
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%w: чаевые заказа %s уже изменены", cart.ErrStatusConflict, orderID)
+	}
+	return nil
+}
+
+func (r *RestaurantRepository) SaveTipPayment(ctx context.Context, tip models.TipPayment) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := r.db.Exec(ctx, insertTipPayment, tip.PaymentID, tip.OrderID, tip.Amount)
+	if err != nil {
+		logger.Error("Ошибка при сохранении платежа чаевых", slog.String("error", err.Error()))
+	}
+	return err
+}
+
+func (r *RestaurantRepository) GetTipPayments(ctx context.Context, orderID uuid.UUID) ([]models.TipPayment, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getTipPayments, orderID)
+	if err != nil {
+		logger.Error("Ошибка при получении платежей чаевых", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tips []models.TipPayment
+	for rows.Next() {
+		var tip models.TipPayment
+		if err := rows.Scan(&tip.PaymentID, &tip.OrderID, &tip.Amount); err != nil {
+			logger.Error("Ошибка при сканировании платежа чаевых", slog.String("error", err.Error()))
+			return nil, err
+		}
+		tips = append(tips, tip)
+	}
+	return tips, rows.Err()
+}
+
+func (r *RestaurantRepository) IsTipPayment(ctx context.Context, paymentID string) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, isTipPayment, paymentID).Scan(&exists); err != nil {
+		logger.Error("Ошибка при проверке платежа чаевых", slog.String("error", err.Error()))
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *RestaurantRepository) ScheduleStatusTransition(ctx context.Context, transition models.StatusTransition) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := r.db.Exec(ctx, insertStatusTransition, transition.ID, transition.OrderID,
+		transition.FromStatus, transition.ToStatus, transition.RunAt)
+	if err != nil {
+		logger.Error("Ошибка при планировании смены статуса", slog.String("error", err.Error()))
+		return err
 	}
 
-	logger.Info("Successful")
 	return nil
 }
 
-func (r *RestaurantRepository) ScheduleDeliveryStatusChange(ctx context.Context, orderID uuid.UUID) error {
-    logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-
-    // Безопасный параметризованный запрос
-    query := `
-        SELECT cron.schedule(
-            'delivery_status_' || $1,
-            '20 seconds',
-            'SELECT set_order_in_delivery($1)'
-        )
-    `
-    
-    _, err := r.db.Exec(ctx, query, orderID)
-    if err != nil {
-        logger.Error("Failed to schedule delivery status update",
-            slog.String("error", err.Error()),
-        )
-        return fmt.Errorf("failed to schedule delivery update: %w", err)
-    }
-
-    logger.Info("Delivery status update scheduled successfully")
-    return nil
-}
\ No newline at end of file
+func (r *RestaurantRepository) GetDueStatusTransitions(ctx context.Context, limit int) ([]models.StatusTransition, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, getDueStatusTransitions, limit)
+	if err != nil {
+		logger.Error("Ошибка при получении запланированных смен статуса", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []models.StatusTransition
+	for rows.Next() {
+		var transition models.StatusTransition
+		if err := rows.Scan(&transition.ID, &transition.OrderID, &transition.FromStatus,
+			&transition.ToStatus, &transition.RunAt); err != nil {
+			logger.Error("Ошибка при сканировании строки", slog.String("error", err.Error()))
+			return nil, err
+		}
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, rows.Err()
+}
+
+func (r *RestaurantRepository) DeleteStatusTransition(ctx context.Context, id uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if _, err := r.db.Exec(ctx, deleteStatusTransition, id); err != nil {
+		logger.Error("Ошибка при удалении запланированной смены статуса", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
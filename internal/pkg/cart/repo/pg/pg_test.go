@@ -2,15 +2,17 @@ package pg
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/jackc/pgx/v4"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
 	"github.com/satori/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,11 +22,11 @@ func TestGetCartItem(t *testing.T) {
 	testRestaurantID := uuid.NewV4()
 	testProductIDs := []string{testProductID.String()}
 	testProductAmounts := map[string]int{testProductID.String(): 2}
-	testRestaurantName := "Test Restaurant"
+	testRestaurantName := "Fish & Chips"
 
 	product := models.CartItem{
 		Id:       testProductID,
-		Name:     "Burger",
+		Name:     "Burger & Fries",
 		Price:    499,
 		ImageURL: "default.png",
 		Weight:   250,
@@ -39,6 +41,7 @@ func TestGetCartItem(t *testing.T) {
 		repoMocker     func(*pgxpoolmock.MockPgxPool)
 		expectedResult models.Cart
 		expectError    bool
+		expectedErr    error
 	}{
 		{
 			name: "Success",
@@ -48,7 +51,7 @@ func TestGetCartItem(t *testing.T) {
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), getFieldProduct, testProductIDs).
+					Query(gomock.Any(), getFieldProduct, testProductIDs, testRestaurantID.String()).
 					Return(productRows, nil)
 
 				restaurantRow := pgxpoolmock.NewRows(restaurantColumn).
@@ -72,12 +75,25 @@ func TestGetCartItem(t *testing.T) {
 			name: "Product query error",
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					Query(gomock.Any(), getFieldProduct, testProductIDs).
+					Query(gomock.Any(), getFieldProduct, testProductIDs, testRestaurantID.String()).
 					Return(nil, fmt.Errorf("db error"))
 			},
 			expectedResult: models.Cart{},
 			expectError:    true,
 		},
+		{
+			name: "Restaurant deleted",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					Query(gomock.Any(), getFieldProduct, testProductIDs, testRestaurantID.String()).
+					Return(pgxpoolmock.NewRows(productColumns).ToPgxRows(), nil)
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), getRestaurantName, testRestaurantID.String()).
+					Return(errRow{pgx.ErrNoRows})
+			},
+			expectError: true,
+			expectedErr: cart.ErrRestaurantNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,6 +111,9 @@ func TestGetCartItem(t *testing.T) {
 
 			if tt.expectError {
 				assert.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedResult, result)
@@ -103,6 +122,180 @@ func TestGetCartItem(t *testing.T) {
 	}
 }
 
+// poolTx — транзакция поверх мока пула: запросы внутри BeginFunc уходят в тот же мок.
+type poolTx struct {
+	pgx.Tx
+	pool *pgxpoolmock.MockPgxPool
+}
+
+func (tx poolTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.pool.Exec(ctx, sql, args...)
+}
+
+func (tx poolTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.pool.QueryRow(ctx, sql, args...)
+}
+
+func expectTx(mockPool *pgxpoolmock.MockPgxPool) {
+	mockPool.EXPECT().BeginFunc(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, f func(pgx.Tx) error) error {
+			return f(poolTx{pool: mockPool})
+		})
+}
+
+// errRow — строка результата, Scan которой сразу возвращает ошибку (например, pgx.ErrNoRows).
+type errRow struct{ err error }
+
+func (r errRow) Scan(...interface{}) error { return r.err }
+
+func TestGetProductRestaurant(t *testing.T) {
+	productID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		mock    func(mockPool *pgxpoolmock.MockPgxPool)
+		want    uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				row := pgxpoolmock.NewRows([]string{"restaurant_id"}).AddRow(restaurantID).ToPgxRows()
+				row.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), getProductRestaurant, productID).Return(row)
+			},
+			want: restaurantID,
+		},
+		{
+			name: "Not found",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), getProductRestaurant, productID).Return(errRow{pgx.ErrNoRows})
+			},
+			want:    uuid.Nil,
+			wantErr: cart.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.mock(mockPool)
+
+			repo := &RestaurantRepository{db: mockPool}
+			got, err := repo.GetProductRestaurant(context.Background(), productID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetWorkingMode(t *testing.T) {
+	restaurantID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		mock    func(mockPool *pgxpoolmock.MockPgxPool)
+		want    models.WorkingMode
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				row := pgxpoolmock.NewRows([]string{"working_mode_from", "working_mode_to"}).AddRow(6, 3).ToPgxRows()
+				row.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), getWorkingMode, restaurantID).Return(row)
+			},
+			want: models.WorkingMode{From: 6, To: 3},
+		},
+		{
+			name: "Not found",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), getWorkingMode, restaurantID).Return(errRow{pgx.ErrNoRows})
+			},
+			wantErr: cart.ErrRestaurantNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.mock(mockPool)
+
+			repo := &RestaurantRepository{db: mockPool}
+			got, err := repo.GetWorkingMode(context.Background(), restaurantID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetDeliveryEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	restaurantID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	row := pgxpoolmock.NewRows([]string{"delivery_time_from", "delivery_time_to", "queue"}).AddRow(30, 40, 3).ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getDeliveryEstimate, restaurantID, cart.KitchenQueueStatuses).Return(row)
+
+	estimate, err := repo.GetDeliveryEstimate(context.Background(), restaurantID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 30, To: 40}, QueueLength: 3}, estimate)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getDeliveryEstimate, restaurantID, cart.KitchenQueueStatuses).
+		Return(errRow{pgx.ErrNoRows})
+	_, err = repo.GetDeliveryEstimate(context.Background(), restaurantID)
+	assert.ErrorIs(t, err, cart.ErrRestaurantNotFound)
+}
+
+func TestGetOrderEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	deliverAt := time.Date(2025, 5, 10, 19, 0, 0, 0, time.UTC)
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	row := pgxpoolmock.NewRows([]string{"delivery_time_from", "delivery_time_to", "deliver_at", "queue"}).
+		AddRow(50, 60, &deliverAt, 1).ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getOrderEstimate, orderID, cart.KitchenQueueStatuses).Return(row)
+
+	estimate, err := repo.GetOrderEstimate(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeliveryEstimate{
+		DeliveryTime: models.DeliveryTime{From: 50, To: 60},
+		QueueLength:  1,
+		DeliverAt:    &deliverAt,
+	}, estimate)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getOrderEstimate, orderID, cart.KitchenQueueStatuses).
+		Return(errRow{pgx.ErrNoRows})
+	_, err = repo.GetOrderEstimate(context.Background(), orderID)
+	assert.ErrorIs(t, err, cart.ErrOrderNotFound)
+}
+
 func TestSaveOrder(t *testing.T) {
 	testOrderID := uuid.NewV4()
 	testUserLogin := "test_user"
@@ -141,6 +334,12 @@ func TestSaveOrder(t *testing.T) {
 		LeaveAtDoor:       true,
 		CreatedAt:         time.Now(),
 		FinalPrice:        1199.47,
+		PaymentID:         "fake_payment",
+		PriceBreakdown: models.PriceBreakdown{
+			Subtotal:    1099.47,
+			DeliveryFee: 100,
+			Total:       1199.47,
+		},
 	}
 
 	tests := []struct {
@@ -157,6 +356,7 @@ func TestSaveOrder(t *testing.T) {
 				mockPool.EXPECT().
 					QueryRow(gomock.Any(), `SELECT id FROM users WHERE login = $1`, testUserLogin).
 					Return(userRow)
+				expectTx(mockPool)
 
 				mockPool.EXPECT().
 					Exec(gomock.Any(), insertOrder,
@@ -164,6 +364,15 @@ func TestSaveOrder(t *testing.T) {
 						gomock.Any(),
 						testOrder.ApartmentOrOffice, testOrder.Intercom, testOrder.Entrance, testOrder.Floor,
 						testOrder.CourierComment, testOrder.LeaveAtDoor, testOrder.CreatedAt, testOrder.FinalPrice,
+						testOrder.PriceBreakdown.Subtotal, testOrder.PriceBreakdown.DeliveryFee,
+						testOrder.PriceBreakdown.ServiceFee, testOrder.PriceBreakdown.Discount,
+						testOrder.PaymentID, testOrder.PromoCode, testOrder.DeliverAt,
+						[]string{testOrder.OrderProducts.CartItems[0].Id.String(), testOrder.OrderProducts.CartItems[1].Id.String()},
+						[]string{"Test Burger", "Fries"},
+						[]float64{499.99, 199.49},
+						[]int{2, 1},
+						[]int{250, 150},
+						testOrder.ETA, testOrder.PriceBreakdown.Tip,
 					).
 					Return(nil, nil)
 			},
@@ -178,13 +387,18 @@ func TestSaveOrder(t *testing.T) {
 				mockPool.EXPECT().
 					QueryRow(gomock.Any(), `SELECT id FROM users WHERE login = $1`, testUserLogin).
 					Return(userRow)
+				expectTx(mockPool)
 
 				mockPool.EXPECT().
 					Exec(gomock.Any(), insertOrder,
 						testOrder.ID, testUserID, testOrder.Status, testOrder.Address,
 						gomock.Any(), testOrder.ApartmentOrOffice, testOrder.Intercom,
 						testOrder.Entrance, testOrder.Floor, testOrder.CourierComment,
-						testOrder.LeaveAtDoor, testOrder.CreatedAt, testOrder.FinalPrice).
+						testOrder.LeaveAtDoor, testOrder.CreatedAt, testOrder.FinalPrice,
+						testOrder.PriceBreakdown.Subtotal, testOrder.PriceBreakdown.DeliveryFee,
+						testOrder.PriceBreakdown.ServiceFee, testOrder.PriceBreakdown.Discount,
+						testOrder.PaymentID, testOrder.PromoCode, testOrder.DeliverAt,
+						gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), testOrder.ETA, testOrder.PriceBreakdown.Tip).
 					Return(nil, errors.New("insert error"))
 			},
 			expectError: true,
@@ -211,6 +425,178 @@ func TestSaveOrder(t *testing.T) {
 	}
 }
 
+func TestSaveOrderWithPromo(t *testing.T) {
+	testUserLogin := "test_user"
+	testUserID := uuid.NewV4()
+	promoID := uuid.NewV4()
+	testOrder := models.Order{
+		ID:          uuid.NewV4(),
+		Status:      "created",
+		Address:     "123 Test St",
+		CreatedAt:   time.Now(),
+		FinalPrice:  800,
+		PromoCode:   "WELCOME",
+		PromoCodeID: promoID,
+		PriceBreakdown: models.PriceBreakdown{
+			Subtotal: 1000,
+			Discount: 200,
+			Total:    800,
+		},
+	}
+
+	expectUser := func(mockPool *pgxpoolmock.MockPgxPool) {
+		userRow := pgxpoolmock.NewRows([]string{"id"}).AddRow(testUserID).ToPgxRows()
+		userRow.Next()
+		mockPool.EXPECT().
+			QueryRow(gomock.Any(), `SELECT id FROM users WHERE login = $1`, testUserLogin).
+			Return(userRow)
+		expectTx(mockPool)
+		mockPool.EXPECT().Exec(gomock.Any(), lockPromoCode, promoID).Return(pgconn.CommandTag("SELECT 1"), nil)
+	}
+	insertArgs := []interface{}{
+		testOrder.ID, testUserID, testOrder.Status, testOrder.Address, gomock.Any(),
+		testOrder.ApartmentOrOffice, testOrder.Intercom, testOrder.Entrance, testOrder.Floor,
+		testOrder.CourierComment, testOrder.LeaveAtDoor, testOrder.CreatedAt, testOrder.FinalPrice,
+		testOrder.PriceBreakdown.Subtotal, testOrder.PriceBreakdown.DeliveryFee,
+		testOrder.PriceBreakdown.ServiceFee, testOrder.PriceBreakdown.Discount,
+		testOrder.PaymentID, testOrder.PromoCode, testOrder.DeliverAt,
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), testOrder.ETA, testOrder.PriceBreakdown.Tip}
+
+	tests := []struct {
+		name    string
+		mock    func(mockPool *pgxpoolmock.MockPgxPool)
+		wantErr error
+	}{
+		{
+			name: "Redeemed",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				expectUser(mockPool)
+				mockPool.EXPECT().Exec(gomock.Any(), redeemPromoCode, promoID, testUserID, testOrder.ID).
+					Return(pgconn.CommandTag("INSERT 0 1"), nil)
+				mockPool.EXPECT().Exec(gomock.Any(), insertOrder, insertArgs...).Return(pgconn.CommandTag("INSERT 0 1"), nil)
+			},
+		},
+		{
+			name: "Limit reached concurrently",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				expectUser(mockPool)
+				mockPool.EXPECT().Exec(gomock.Any(), redeemPromoCode, promoID, testUserID, testOrder.ID).
+					Return(pgconn.CommandTag("INSERT 0 0"), nil)
+			},
+			wantErr: cart.ErrPromoExhausted,
+		},
+		{
+			name: "Insert fails rolls back promo code",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				expectUser(mockPool)
+				mockPool.EXPECT().Exec(gomock.Any(), redeemPromoCode, promoID, testUserID, testOrder.ID).
+					Return(pgconn.CommandTag("INSERT 0 1"), nil)
+				mockPool.EXPECT().Exec(gomock.Any(), insertOrder, insertArgs...).Return(nil, errors.New("insert error"))
+			},
+			wantErr: errors.New("insert error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.mock(mockPool)
+
+			repo := &RestaurantRepository{db: mockPool}
+			err := repo.Save(context.Background(), testOrder, testUserLogin)
+
+			switch {
+			case tt.wantErr == nil:
+				assert.NoError(t, err)
+			case errors.Is(tt.wantErr, cart.ErrPromoExhausted):
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetPromoCode(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	tagID := uuid.NewV4()
+	endsAt := time.Now().Add(24 * time.Hour)
+	promo := models.PromoCode{
+		ID:               uuid.NewV4(),
+		Code:             "VEGAN15",
+		Type:             cart.PromoPercent,
+		Value:            15,
+		MinSubtotal:      500,
+		MaxUsesPerUser:   1,
+		EndsAt:           &endsAt,
+		TagID:            tagID,
+		RestaurantHasTag: true,
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(mockPool *pgxpoolmock.MockPgxPool)
+		want    models.PromoCode
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				row := pgxpoolmock.NewRows([]string{"id", "code", "type", "value", "first_order_only", "min_subtotal",
+					"max_uses", "max_uses_per_user", "starts_at", "ends_at", "restaurant_id", "tag_id", "has_tag"}).
+					AddRow(promo.ID, promo.Code, promo.Type, promo.Value, false, promo.MinSubtotal,
+						0, 1, (*time.Time)(nil), &endsAt, uuid.Nil, tagID, true).ToPgxRows()
+				row.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), getPromoCode, "VEGAN15", restaurantID).Return(row)
+			},
+			want: promo,
+		},
+		{
+			name: "Not found",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), getPromoCode, "VEGAN15", restaurantID).Return(errRow{pgx.ErrNoRows})
+			},
+			wantErr: cart.ErrPromoNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.mock(mockPool)
+
+			repo := &RestaurantRepository{db: mockPool}
+			got, err := repo.GetPromoCode(context.Background(), "VEGAN15", restaurantID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetPromoUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promoID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	row := pgxpoolmock.NewRows([]string{"total", "by_user", "user_orders"}).AddRow(10, 1, 3).ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getPromoUsage, promoID, "test_user").Return(row)
+
+	repo := &RestaurantRepository{db: mockPool}
+	usage, err := repo.GetPromoUsage(context.Background(), promoID, "test_user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PromoUsage{Total: 10, ByUser: 1, UserOrders: 3}, usage)
+}
+
 func TestGetOrders(t *testing.T) {
     testUserID := uuid.NewV4()
     testOrderID := uuid.NewV4()
@@ -232,7 +618,8 @@ func TestGetOrders(t *testing.T) {
             },
         },
     }
-    testCartJSON, _ := json.Marshal(testCart)
+    itemColumns := []string{"order_id", "product_id", "name", "price", "image_url", "weight", "quantity"}
+    item := testCart.CartItems[0]
     
     testOrder := models.Order{
         ID:            testOrderID,
@@ -247,20 +634,31 @@ func TestGetOrders(t *testing.T) {
         CourierComment: "Call before arrival",
         LeaveAtDoor:   false,
         FinalPrice:    999.99,
+        PriceBreakdown: models.PriceBreakdown{Subtotal: 999.99, Total: 999.99},
         CreatedAt:     testTime,
     }
+    deliverAt := testTime.Add(3 * time.Hour)
+    testOrder.DeliverAt = &deliverAt
+    testOrder.ETA = &deliverAt
     
     columns := []string{
-        "id", "user_id", "status", "address_id", "order_products",
+        "id", "user_id", "status", "address_id", "restaurant_id", "restaurant_name",
         "apartment_or_office", "intercom", "entrance", "floor", 
-        "courier_comment", "leave_at_door", "final_price", "created_at",
+        "courier_comment", "leave_at_door", "final_price",
+        "subtotal", "delivery_fee", "service_fee", "discount", "tip", "deliver_at", "eta", "created_at",
     }
     
+    defaultArgs := []interface{}{testUserID, []string(nil), "", (*time.Time)(nil), (*time.Time)(nil),
+        0.0, 0.0, (*time.Time)(nil), "", 10}
+    restaurantID := uuid.NewV4()
+    cursor := models.OrderCursor{CreatedAt: testTime.Add(-time.Hour), ID: uuid.NewV4()}
+    from := testTime.Add(-24 * time.Hour)
+
     tests := []struct {
         name           string
         userID        uuid.UUID
         count         int
-        offset        int
+        filter        models.OrderFilter
         repoMocker    func(*pgxpoolmock.MockPgxPool)
         expectedResult []models.Order
         expectError   bool
@@ -269,7 +667,6 @@ func TestGetOrders(t *testing.T) {
             name:    "Success - single order",
             userID:  testUserID,
             count:   10,
-            offset:  0,
             repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
                 rows := pgxpoolmock.NewRows(columns).
                     AddRow(
@@ -277,7 +674,8 @@ func TestGetOrders(t *testing.T) {
                         testOrder.UserID,
                         testOrder.Status,
                         testOrder.Address,
-                        string(testCartJSON),
+                        testCart.Id,
+                        testCart.Name,
                         testOrder.ApartmentOrOffice,
                         testOrder.Intercom,
                         testOrder.Entrance,
@@ -285,34 +683,69 @@ func TestGetOrders(t *testing.T) {
                         testOrder.CourierComment,
                         testOrder.LeaveAtDoor,
                         testOrder.FinalPrice,
+                        testOrder.PriceBreakdown.Subtotal,
+                        testOrder.PriceBreakdown.DeliveryFee,
+                        testOrder.PriceBreakdown.ServiceFee,
+                        testOrder.PriceBreakdown.Discount,
+                        testOrder.PriceBreakdown.Tip,
+                        testOrder.DeliverAt,
+                        testOrder.ETA,
                         testTime,
                     ).ToPgxRows()
                 
                 mockPool.EXPECT().
-                    Query(gomock.Any(), getAllOrders, testUserID, 10, 0).
+                    Query(gomock.Any(), getOrdersNewestFirst, defaultArgs...).
                     Return(rows, nil)
+
+                itemRows := pgxpoolmock.NewRows(itemColumns).
+                    AddRow(testOrderID, item.Id, item.Name, item.Price, item.ImageURL, item.Weight, item.Amount).
+                    ToPgxRows()
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrderItems, []string{testOrderID.String()}).
+                    Return(itemRows, nil)
             },
             expectedResult: []models.Order{testOrder},
             expectError:   false,
         },
+        {
+            name:    "Filters, cursor and oldest first",
+            userID:  testUserID,
+            count:   5,
+            filter: models.OrderFilter{
+                Statuses:     []string{"delivered", "cancelled"},
+                RestaurantID: restaurantID,
+                From:         &from,
+                MinTotal:     100,
+                MaxTotal:     2000,
+                OldestFirst:  true,
+                After:        &cursor,
+            },
+            repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrdersOldestFirst, testUserID, []string{"delivered", "cancelled"},
+                        restaurantID.String(), &from, (*time.Time)(nil), 100.0, 2000.0,
+                        &cursor.CreatedAt, cursor.ID.String(), 5).
+                    Return(pgxpoolmock.NewRows(columns).ToPgxRows(), nil)
+            },
+            expectedResult: nil,
+            expectError:   false,
+        },
         {
             name:    "Error - database query fails",
             userID:  testUserID,
             count:   10,
-            offset:  0,
             repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
                 mockPool.EXPECT().
-                    Query(gomock.Any(), getAllOrders, testUserID, 10, 0).
+                    Query(gomock.Any(), getOrdersNewestFirst, defaultArgs...).
                     Return(nil, fmt.Errorf("database error"))
             },
             expectedResult: nil,
             expectError:   true,
         },
         {
-            name:    "Error - invalid JSON in order_products",
+            name:    "Error - order items query fails",
             userID:  testUserID,
             count:   10,
-            offset:  0,
             repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
                 rows := pgxpoolmock.NewRows(columns).
                     AddRow(
@@ -320,7 +753,8 @@ func TestGetOrders(t *testing.T) {
                         testOrder.UserID,
                         testOrder.Status,
                         testOrder.Address,
-                        "invalid json",
+                        testCart.Id,
+                        testCart.Name,
                         testOrder.ApartmentOrOffice,
                         testOrder.Intercom,
                         testOrder.Entrance,
@@ -328,12 +762,22 @@ func TestGetOrders(t *testing.T) {
                         testOrder.CourierComment,
                         testOrder.LeaveAtDoor,
                         testOrder.FinalPrice,
+                        testOrder.PriceBreakdown.Subtotal,
+                        testOrder.PriceBreakdown.DeliveryFee,
+                        testOrder.PriceBreakdown.ServiceFee,
+                        testOrder.PriceBreakdown.Discount,
+                        testOrder.PriceBreakdown.Tip,
+                        testOrder.DeliverAt,
+                        testOrder.ETA,
                         testTime,
                     ).ToPgxRows()
                 
                 mockPool.EXPECT().
-                    Query(gomock.Any(), getAllOrders, testUserID, 10, 0).
+                    Query(gomock.Any(), getOrdersNewestFirst, defaultArgs...).
                     Return(rows, nil)
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrderItems, []string{testOrderID.String()}).
+                    Return(nil, fmt.Errorf("database error"))
             },
             expectedResult: nil,
             expectError:   true,
@@ -350,7 +794,7 @@ func TestGetOrders(t *testing.T) {
             
             repo := &RestaurantRepository{db: mockPool}
             
-            orders, err := repo.GetOrders(context.Background(), tt.userID, tt.count, tt.offset)
+            orders, err := repo.GetOrders(context.Background(), tt.userID, tt.filter, tt.count)
             
             if tt.expectError {
                 assert.Error(t, err)
@@ -379,6 +823,7 @@ func TestGetOrderById(t *testing.T) {
     testOrderID := uuid.NewV4()
     testAddressID := "test_address_123"
     testTime := time.Now().UTC()
+    eta := testTime.Add(45 * time.Minute)
     
     // Тестовые данные для корзины
     testCart := models.Cart{
@@ -395,7 +840,8 @@ func TestGetOrderById(t *testing.T) {
             },
         },
     }
-    testCartJSON, _ := json.Marshal(testCart)
+    itemColumns := []string{"order_id", "product_id", "name", "price", "image_url", "weight", "quantity"}
+    item := testCart.CartItems[0]
     
     testOrder := models.Order{
         ID:            testOrderID,
@@ -410,13 +856,21 @@ func TestGetOrderById(t *testing.T) {
         CourierComment: "Call before arrival",
         LeaveAtDoor:   false,
         FinalPrice:    999.99,
+        PaymentID:     "fake_payment",
+        PromoCode:     "WELCOME",
+        PriceBreakdown: models.PriceBreakdown{Subtotal: 949.99, Tip: 50, Total: 999.99},
         CreatedAt:     testTime,
+        Timeline: []models.OrderStatusEvent{
+            {Status: "created", Actor: "user", ETA: &eta, CreatedAt: testTime},
+        },
+        ETA:           &eta,
     }
     
     columns := []string{
-        "id", "user_id", "status", "address_id", "order_products",
+        "id", "user_id", "status", "address_id", "restaurant_id", "restaurant_name",
         "apartment_or_office", "intercom", "entrance", "floor", 
-        "courier_comment", "leave_at_door", "final_price", "created_at",
+        "courier_comment", "leave_at_door", "final_price",
+        "subtotal", "delivery_fee", "service_fee", "discount", "tip", "payment_id", "promo_code", "deliver_at", "eta", "created_at",
     }
 
     tests := []struct {
@@ -439,7 +893,8 @@ func TestGetOrderById(t *testing.T) {
                         testOrder.UserID,
                         testOrder.Status,
                         testOrder.Address,
-                        string(testCartJSON),
+                        testCart.Id,
+                        testCart.Name,
                         testOrder.ApartmentOrOffice,
                         testOrder.Intercom,
                         testOrder.Entrance,
@@ -447,6 +902,15 @@ func TestGetOrderById(t *testing.T) {
                         testOrder.CourierComment,
                         testOrder.LeaveAtDoor,
                         testOrder.FinalPrice,
+                        testOrder.PriceBreakdown.Subtotal,
+                        testOrder.PriceBreakdown.DeliveryFee,
+                        testOrder.PriceBreakdown.ServiceFee,
+                        testOrder.PriceBreakdown.Discount,
+                        testOrder.PriceBreakdown.Tip,
+                        testOrder.PaymentID,
+                        testOrder.PromoCode,
+                        testOrder.DeliverAt,
+                        testOrder.ETA,
                         testTime,
                     ).ToPgxRows()
                 row.Next()
@@ -454,12 +918,26 @@ func TestGetOrderById(t *testing.T) {
                 mockPool.EXPECT().
                     QueryRow(gomock.Any(), getOrderById, testOrderID, testUserID).
                     Return(row)
+
+                itemRows := pgxpoolmock.NewRows(itemColumns).
+                    AddRow(testOrderID, item.Id, item.Name, item.Price, item.ImageURL, item.Weight, item.Amount).
+                    ToPgxRows()
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrderItems, []string{testOrderID.String()}).
+                    Return(itemRows, nil)
+
+                timelineRows := pgxpoolmock.NewRows([]string{"status", "actor", "reason", "eta", "created_at"}).
+                    AddRow("created", "user", "", &eta, testTime).
+                    ToPgxRows()
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrderTimeline, testOrderID).
+                    Return(timelineRows, nil)
             },
             expectedResult: testOrder,
             expectError:   false,
         },
         {
-            name:     "Error - invalid JSON",
+            name:     "Error - order items query fails",
             orderID:  testOrderID,
             userID:   testUserID,
             repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
@@ -469,7 +947,8 @@ func TestGetOrderById(t *testing.T) {
                         testOrder.UserID,
                         testOrder.Status,
                         testOrder.Address,
-                        "invalid json",
+                        testCart.Id,
+                        testCart.Name,
                         testOrder.ApartmentOrOffice,
                         testOrder.Intercom,
                         testOrder.Entrance,
@@ -477,6 +956,15 @@ func TestGetOrderById(t *testing.T) {
                         testOrder.CourierComment,
                         testOrder.LeaveAtDoor,
                         testOrder.FinalPrice,
+                        testOrder.PriceBreakdown.Subtotal,
+                        testOrder.PriceBreakdown.DeliveryFee,
+                        testOrder.PriceBreakdown.ServiceFee,
+                        testOrder.PriceBreakdown.Discount,
+                        testOrder.PriceBreakdown.Tip,
+                        testOrder.PaymentID,
+                        testOrder.PromoCode,
+                        testOrder.DeliverAt,
+                        testOrder.ETA,
                         testTime,
                     ).ToPgxRows()
                 row.Next()
@@ -484,6 +972,9 @@ func TestGetOrderById(t *testing.T) {
                 mockPool.EXPECT().
                     QueryRow(gomock.Any(), getOrderById, testOrderID, testUserID).
                     Return(row)
+                mockPool.EXPECT().
+                    Query(gomock.Any(), getOrderItems, []string{testOrderID.String()}).
+                    Return(nil, fmt.Errorf("database error"))
             },
             expectedResult: models.Order{},
             expectError:   true,
@@ -521,4 +1012,304 @@ func TestGetOrderById(t *testing.T) {
             }
         })
     }
-}
\ No newline at end of file
+}
+func TestUpdateOrderStatus(t *testing.T) {
+	testOrderID := uuid.NewV4()
+	event := models.OrderStatusEvent{
+		Status:    cart.StatusCooking,
+		Actor:     cart.ActorRestaurant,
+		Reason:    "",
+		CreatedAt: time.Now(),
+	}
+	eta := event.CreatedAt.Add(40 * time.Minute)
+	event.ETA = &eta
+
+	tests := []struct {
+		name    string
+		mock    func(mockPool *pgxpoolmock.MockPgxPool)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					Exec(gomock.Any(), updateOrderStatus, cart.StatusCooking, testOrderID, cart.StatusPaid,
+						event.Actor, event.Reason, event.CreatedAt, event.ETA).
+					Return(pgconn.CommandTag("UPDATE 1"), nil)
+			},
+		},
+		{
+			name: "Status changed concurrently",
+			mock: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					Exec(gomock.Any(), updateOrderStatus, cart.StatusCooking, testOrderID, cart.StatusPaid,
+						event.Actor, event.Reason, event.CreatedAt, event.ETA).
+					Return(pgconn.CommandTag("UPDATE 0"), nil)
+			},
+			wantErr: cart.ErrStatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.mock(mockPool)
+
+			repo := &RestaurantRepository{db: mockPool}
+			err := repo.UpdateOrderStatus(context.Background(), testOrderID, cart.StatusPaid, event)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderTip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	mockPool.EXPECT().Exec(gomock.Any(), updateOrderTip, orderID, 50.0, 120.0).Return(pgconn.CommandTag("UPDATE 1"), nil)
+	assert.NoError(t, repo.UpdateOrderTip(context.Background(), orderID, 50, 120))
+
+	mockPool.EXPECT().Exec(gomock.Any(), updateOrderTip, orderID, 50.0, 120.0).Return(pgconn.CommandTag("UPDATE 0"), nil)
+	assert.ErrorIs(t, repo.UpdateOrderTip(context.Background(), orderID, 50, 120), cart.ErrStatusConflict)
+}
+
+func TestTipPayments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+	tip := models.TipPayment{PaymentID: "fake_tip", OrderID: orderID, Amount: 100}
+
+	mockPool.EXPECT().Exec(gomock.Any(), insertTipPayment, "fake_tip", orderID, 100.0).Return(pgconn.CommandTag("INSERT 0 1"), nil)
+	assert.NoError(t, repo.SaveTipPayment(context.Background(), tip))
+
+	rows := pgxpoolmock.NewRows([]string{"payment_id", "order_id", "amount"}).AddRow("fake_tip", orderID, 100.0).ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), getTipPayments, orderID).Return(rows, nil)
+	tips, err := repo.GetTipPayments(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TipPayment{tip}, tips)
+
+	row := pgxpoolmock.NewRows([]string{"exists"}).AddRow(true).ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), isTipPayment, "fake_tip").Return(row)
+	isTip, err := repo.IsTipPayment(context.Background(), "fake_tip")
+	assert.NoError(t, err)
+	assert.True(t, isTip)
+}
+
+func TestGetOrderForPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testOrderID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	row := pgxpoolmock.NewRows([]string{"id", "status", "final_price", "payment_id"}).
+		AddRow(testOrderID, cart.StatusCreated, 500.0, "").
+		ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getOrderPayment, testOrderID).Return(row)
+
+	repo := &RestaurantRepository{db: mockPool}
+	order, err := repo.GetOrderForPayment(context.Background(), testOrderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.Order{ID: testOrderID, Status: cart.StatusCreated, FinalPrice: 500}, order)
+}
+
+func TestGetDueStatusTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transition := models.StatusTransition{
+		ID:         uuid.NewV4(),
+		OrderID:    uuid.NewV4(),
+		FromStatus: cart.StatusCooking,
+		ToStatus:   cart.StatusInDelivery,
+		RunAt:      time.Now().Truncate(time.Second),
+	}
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	rows := pgxpoolmock.NewRows([]string{"id", "order_id", "from_status", "to_status", "run_at"}).
+		AddRow(transition.ID, transition.OrderID, transition.FromStatus, transition.ToStatus, transition.RunAt).
+		ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), getDueStatusTransitions, 10).Return(rows, nil)
+
+	repo := &RestaurantRepository{db: mockPool}
+	got, err := repo.GetDueStatusTransitions(context.Background(), 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.StatusTransition{transition}, got)
+}
+
+func TestGetStaffRestaurant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	staffID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	row := pgxpoolmock.NewRows([]string{"restaurant_id"}).AddRow(restaurantID).ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getStaffRestaurant, staffID).Return(row)
+
+	got, err := repo.GetStaffRestaurant(context.Background(), staffID)
+	assert.NoError(t, err)
+	assert.Equal(t, restaurantID, got)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getStaffRestaurant, staffID).Return(errRow{pgx.ErrNoRows})
+
+	_, err = repo.GetStaffRestaurant(context.Background(), staffID)
+	assert.ErrorIs(t, err, cart.ErrNotRestaurantStaff)
+}
+
+func TestGetRestaurantOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	userID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	deliverAt := time.Date(2025, 5, 10, 19, 0, 0, 0, time.UTC)
+	row := pgxpoolmock.NewRows([]string{"id", "user_id", "status", "payment_id", "deliver_at"}).
+		AddRow(orderID, userID.String(), cart.StatusPaid, "pay_1", &deliverAt).
+		ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getRestaurantOrder, orderID, restaurantID).Return(row)
+
+	order, err := repo.GetRestaurantOrder(context.Background(), orderID, restaurantID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Order{ID: orderID, UserID: userID.String(), Status: cart.StatusPaid, PaymentID: "pay_1",
+		DeliverAt: &deliverAt}, order)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getRestaurantOrder, orderID, restaurantID).Return(errRow{pgx.ErrNoRows})
+
+	_, err = repo.GetRestaurantOrder(context.Background(), orderID, restaurantID)
+	assert.ErrorIs(t, err, cart.ErrOrderNotFound)
+}
+
+func TestGetSimulatedKitchenOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	orderID := uuid.NewV4()
+	updatedAt := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	statuses := []string{cart.StatusPaid, cart.StatusCooking}
+	rows := pgxpoolmock.NewRows([]string{"id", "status", "deliver_at", "delivery_time_from", "delivery_time_to", "updated_at"}).
+		AddRow(orderID, cart.StatusCooking, (*time.Time)(nil), 30, 45, updatedAt).
+		ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), getSimulatedKitchenOrders, statuses, 10).Return(rows, nil)
+
+	orders, err := repo.GetSimulatedKitchenOrders(context.Background(), statuses, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.KitchenOrder{{
+		OrderID:      orderID,
+		Status:       cart.StatusCooking,
+		DeliveryTime: models.DeliveryTime{From: 30, To: 45},
+		UpdatedAt:    updatedAt,
+	}}, orders)
+}
+
+func TestGetCourierByUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.NewV4()
+	courierID := uuid.NewV4()
+	lat, lon := 55.75, 37.62
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	row := pgxpoolmock.NewRows([]string{"id", "name", "available", "latitude", "longitude", "max_orders", "active"}).
+		AddRow(courierID, "<b>Иван</b>", true, &lat, &lon, 2, 1).
+		ToPgxRows()
+	row.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getCourierByUser, userID).Return(row)
+
+	courier, err := repo.GetCourierByUser(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Courier{ID: courierID, Name: "&lt;b&gt;Иван&lt;/b&gt;", Available: true,
+		Latitude: &lat, Longitude: &lon, ActiveOrders: 1, MaxOrders: 2}, courier)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getCourierByUser, userID).Return(errRow{pgx.ErrNoRows})
+
+	_, err = repo.GetCourierByUser(context.Background(), userID)
+	assert.ErrorIs(t, err, cart.ErrNotCourier)
+}
+
+func TestAssignCourier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	courierID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	mockPool.EXPECT().Exec(gomock.Any(), assignCourier, orderID, courierID, cart.AssignmentOffered).
+		Return(pgconn.CommandTag("INSERT 0 1"), nil)
+	assert.NoError(t, repo.AssignCourier(context.Background(), orderID, courierID))
+
+	mockPool.EXPECT().Exec(gomock.Any(), assignCourier, orderID, courierID, cart.AssignmentOffered).
+		Return(pgconn.CommandTag("INSERT 0 0"), nil)
+	assert.ErrorIs(t, repo.AssignCourier(context.Background(), orderID, courierID), cart.ErrStatusConflict)
+}
+
+func TestUpdateAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	courierID := uuid.NewV4()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	mockPool.EXPECT().Exec(gomock.Any(), updateAssignment, orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted).
+		Return(pgconn.CommandTag("UPDATE 1"), nil)
+	assert.NoError(t, repo.UpdateAssignment(context.Background(), orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted))
+
+	mockPool.EXPECT().Exec(gomock.Any(), updateAssignment, orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted).
+		Return(pgconn.CommandTag("UPDATE 0"), nil)
+	err := repo.UpdateAssignment(context.Background(), orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted)
+	assert.ErrorIs(t, err, cart.ErrStatusConflict)
+}
+
+func TestGetUnassignedOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	lat, lon := 55.75, 37.62
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := &RestaurantRepository{db: mockPool}
+
+	rows := pgxpoolmock.NewRows([]string{"id", "latitude", "longitude"}).
+		AddRow(orderID, &lat, &lon).
+		ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), getUnassignedOrders, cart.StatusReadyForPickup, 10).Return(rows, nil)
+
+	orders, err := repo.GetUnassignedOrders(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DispatchOrder{{OrderID: orderID, Latitude: &lat, Longitude: &lon}}, orders)
+}
@@ -4,19 +4,24 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/db"
 	"github.com/redis/go-redis/v9"
 )
 
+const maxItemQuantity = 999
+
 type CartRepository struct {
 	redisClient *redis.Client
+	ttl         time.Duration
 }
 
 func NewCartRepository() (*CartRepository, error) {
 	redisClient, err := dbUtils.InitRedis()
-	return &CartRepository{redisClient: redisClient}, err
+	return &CartRepository{redisClient: redisClient, ttl: cart.CartTTLFromEnv()}, err
 }
 
 func (r *CartRepository) GetCart(ctx context.Context, userID string) (map[string]int, string, error) {
@@ -52,7 +57,9 @@ func (r *CartRepository) GetCart(ctx context.Context, userID string) (map[string
 	return cart, restaurantID, nil
 }
 
-func (r *CartRepository) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantID string, quantity int) error {
+// UpdateItemQuantity меняет количество товара в корзине. Корзина содержит товары одного ресторана:
+// добавление товара из другого ресторана возвращает cart.ErrRestaurantConflict, если не передан replace.
+func (r *CartRepository) UpdateItemQuantity(ctx context.Context, userID, productID, restaurantID string, quantity int, replace bool) error {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("user_id", userID), slog.String("product_id", productID), slog.String("restaurant_id", restaurantID), slog.Int("quantity", quantity))
 
 	key := "cart:" + userID
@@ -63,15 +70,17 @@ func (r *CartRepository) UpdateItemQuantity(ctx context.Context, userID, product
 		return err
 	}
 
-	if currentRestaurantID != "" && currentRestaurantID != restaurantID {
-		if err := r.redisClient.Del(ctx, key).Err(); err != nil {
-			logger.Error("Ошибка при удалении ключа Redis", slog.String("error", err.Error()))
-			return err
-		}
-	}
+	otherRestaurant := currentRestaurantID != "" && currentRestaurantID != restaurantID
 
 	if quantity <= 0 {
-		err := r.redisClient.HDel(ctx, key, productID).Err()
+		if otherRestaurant {
+			return nil
+		}
+
+		pipe := r.redisClient.TxPipeline()
+		pipe.HDel(ctx, key, productID)
+		pipe.Expire(ctx, key, r.ttl)
+		_, err := pipe.Exec(ctx)
 		if err != nil {
 			logger.Error("Ошибка при удалении товара из корзины", slog.String("error", err.Error()))
 			return err
@@ -91,14 +100,23 @@ func (r *CartRepository) UpdateItemQuantity(ctx context.Context, userID, product
 		return nil
 	}
 
-	if quantity > 999 {
+	if quantity > maxItemQuantity {
 		logger.Warn("Превышен лимит количества товара", slog.Int("quantity", quantity))
 		return fmt.Errorf("товар уже в корзине")
 	}
 
+	if otherRestaurant && !replace {
+		logger.Warn("В корзине товары другого ресторана", slog.String("current_restaurant_id", currentRestaurantID))
+		return cart.ErrRestaurantConflict
+	}
+
 	pipe := r.redisClient.TxPipeline()
+	if otherRestaurant {
+		pipe.Del(ctx, key)
+	}
 	pipe.HSet(ctx, key, productID, quantity)
 	pipe.HSet(ctx, key, "restaurant_id", restaurantID)
+	pipe.Expire(ctx, key, r.ttl)
 
 	_, err = pipe.Exec(ctx)
 	if err != nil {
@@ -109,6 +127,93 @@ func (r *CartRepository) UpdateItemQuantity(ctx context.Context, userID, product
 	return err
 }
 
+// MergeCart переносит товары из корзины fromUserID в корзину toUserID и удаляет исходную.
+// Правила те же, что у UpdateItemQuantity: корзина другого ресторана заменяется только при replace,
+// иначе возвращается cart.ErrRestaurantConflict и обе корзины остаются нетронутыми.
+func (r *CartRepository) MergeCart(ctx context.Context, fromUserID, toUserID string, replace bool) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("from", fromUserID), slog.String("to", toUserID))
+
+	fromKey := "cart:" + fromUserID
+	toKey := "cart:" + toUserID
+
+	items, restaurantID, err := r.GetCart(ctx, fromUserID)
+	if err != nil {
+		return err
+	}
+	if restaurantID == "" || len(items) == 0 {
+		logger.Info("Переносить нечего")
+		return r.redisClient.Del(ctx, fromKey).Err()
+	}
+
+	current, currentRestaurantID, err := r.GetCart(ctx, toUserID)
+	if err != nil {
+		return err
+	}
+
+	otherRestaurant := currentRestaurantID != "" && currentRestaurantID != restaurantID
+	if otherRestaurant && !replace {
+		logger.Warn("В корзине товары другого ресторана", slog.String("current_restaurant_id", currentRestaurantID))
+		return cart.ErrRestaurantConflict
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	if otherRestaurant {
+		pipe.Del(ctx, toKey)
+		current = nil
+	}
+	for productID, quantity := range items {
+		pipe.HSet(ctx, toKey, productID, min(current[productID]+quantity, maxItemQuantity))
+	}
+	pipe.HSet(ctx, toKey, "restaurant_id", restaurantID)
+	pipe.Expire(ctx, toKey, r.ttl)
+	pipe.Del(ctx, fromKey)
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		logger.Error("Ошибка при выполнении транзакции Redis", slog.String("error", err.Error()))
+	} else {
+		logger.Info("Корзина перенесена", slog.Int("items", len(items)))
+	}
+	return err
+}
+
+// FillCart кладёт в корзину сразу несколько товаров одного ресторана, заменяя их количество.
+func (r *CartRepository) FillCart(ctx context.Context, userID, restaurantID string, quantities map[string]int, replace bool) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("user_id", userID), slog.String("restaurant_id", restaurantID))
+
+	key := "cart:" + userID
+
+	currentRestaurantID, err := r.redisClient.HGet(ctx, key, "restaurant_id").Result()
+	if err != nil && err != redis.Nil {
+		logger.Error("Ошибка при получении restaurant_id из Redis", slog.String("error", err.Error()))
+		return err
+	}
+
+	otherRestaurant := currentRestaurantID != "" && currentRestaurantID != restaurantID
+	if otherRestaurant && !replace {
+		logger.Warn("В корзине товары другого ресторана", slog.String("current_restaurant_id", currentRestaurantID))
+		return cart.ErrRestaurantConflict
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	if otherRestaurant {
+		pipe.Del(ctx, key)
+	}
+	for productID, quantity := range quantities {
+		pipe.HSet(ctx, key, productID, min(quantity, maxItemQuantity))
+	}
+	pipe.HSet(ctx, key, "restaurant_id", restaurantID)
+	pipe.Expire(ctx, key, r.ttl)
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		logger.Error("Ошибка при выполнении транзакции Redis", slog.String("error", err.Error()))
+	} else {
+		logger.Info("Корзина заполнена", slog.Int("items", len(quantities)))
+	}
+	return err
+}
+
 func (r *CartRepository) ClearCart(ctx context.Context, userID string) error {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("user_id", userID))
 
@@ -0,0 +1,8 @@
+package cart
+
+import "errors"
+
+var (
+	ErrTipNotEditable  = errors.New("чаевые можно изменить только после вручения заказа")
+	ErrTipWindowClosed = errors.New("время на изменение чаевых истекло")
+)
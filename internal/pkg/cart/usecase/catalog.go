@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/satori/uuid"
+)
+
+const (
+	defaultProductCacheTTL = 5 * time.Minute
+	maxProductCacheSize    = 10000
+)
+
+type cachedProduct struct {
+	restaurantID uuid.UUID
+	expiresAt    time.Time
+}
+
+// productCache помнит, какому ресторану принадлежит товар, чтобы не ходить в Postgres
+// на каждое изменение корзины. Кэшируются только найденные товары.
+type productCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[uuid.UUID]cachedProduct
+}
+
+func newProductCache(ttl time.Duration) *productCache {
+	return &productCache{ttl: ttl, entries: make(map[uuid.UUID]cachedProduct)}
+}
+
+func productCacheTTLFromEnv() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("CART_PRODUCT_CACHE_TTL"))
+	if err != nil || ttl < 0 {
+		return defaultProductCacheTTL
+	}
+	return ttl
+}
+
+func (c *productCache) get(productID uuid.UUID) (uuid.UUID, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[productID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return uuid.Nil, false
+	}
+	return entry.restaurantID, true
+}
+
+func (c *productCache) put(productID, restaurantID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxProductCacheSize {
+		c.entries = make(map[uuid.UUID]cachedProduct)
+	}
+	c.entries[productID] = cachedProduct{restaurantID: restaurantID, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// checkProduct проверяет, что товар есть в каталоге и продаётся в указанном ресторане.
+func (uc *CartUsecase) checkProduct(ctx context.Context, productID, restaurantID string) error {
+	productUUID, err := uuid.FromString(productID)
+	if err != nil {
+		return fmt.Errorf("%w: некорректный id товара", cart.ErrUnknownProduct)
+	}
+	restaurantUUID, err := uuid.FromString(restaurantID)
+	if err != nil {
+		return fmt.Errorf("%w: некорректный id ресторана", cart.ErrUnknownProduct)
+	}
+
+	owner, ok := uc.products.get(productUUID)
+	if !ok {
+		owner, err = uc.restaurantRepo.GetProductRestaurant(ctx, productUUID)
+		if err != nil {
+			return err
+		}
+		uc.products.put(productUUID, owner)
+	}
+
+	if owner != restaurantUUID {
+		return fmt.Errorf("%w: %s", cart.ErrUnknownProduct, productID)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// SetCourierAvailability выводит курьера на линию или снимает с неё и обновляет его координаты.
+func (u *CartUsecase) SetCourierAvailability(ctx context.Context, userID uuid.UUID, req models.CourierAvailabilityReq) (models.Courier, error) {
+	courier, err := u.couriers.GetCourierByUser(ctx, userID)
+	if err != nil {
+		return models.Courier{}, err
+	}
+	return u.couriers.SetCourierAvailability(ctx, courier.ID, req)
+}
+
+// GetCourierOrders возвращает заказы, которые назначены курьеру и ещё не вручены.
+func (u *CartUsecase) GetCourierOrders(ctx context.Context, userID uuid.UUID) ([]models.Order, error) {
+	courier, err := u.couriers.GetCourierByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := u.couriers.GetCourierOrders(ctx, courier.ID)
+	if err != nil {
+		return nil, err
+	}
+	if orders == nil {
+		orders = []models.Order{}
+	}
+	return orders, nil
+}
+
+// CourierOrderAction выполняет действие курьера над назначенным ему заказом.
+func (u *CartUsecase) CourierOrderAction(ctx context.Context, userID, orderID uuid.UUID, action string) (models.Order, error) {
+	if !cart.IsCourierAction(action) {
+		return models.Order{}, cart.ErrUnknownCourierAction
+	}
+
+	courier, err := u.couriers.GetCourierByUser(ctx, userID)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	assignment, err := u.applyCourierAction(ctx, courier.ID, orderID, action)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	customerID, err := uuid.FromString(assignment.UserID)
+	if err != nil {
+		return models.Order{}, err
+	}
+	return u.restaurantRepo.GetOrderById(ctx, orderID, customerID)
+}
+
+// applyCourierAction проводит назначение по этапам offered → accepted → picked_up → delivered.
+// Забрать и вручить заказ можно повторно: если статус заказа уже сменился, а этап назначения
+// нет (например, сервис упал между запросами), повтор просто догоняет назначение.
+func (u *CartUsecase) applyCourierAction(ctx context.Context, courierID, orderID uuid.UUID, action string) (models.CourierAssignment, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()),
+		slog.String("orderID", orderID.String()), slog.String("action", action))
+
+	assignment, err := u.couriers.GetAssignment(ctx, orderID, courierID)
+	if err != nil {
+		return models.CourierAssignment{}, err
+	}
+
+	invalid := fmt.Errorf("%w: %s в статусе %s", cart.ErrInvalidAssignment, action, assignment.Status)
+	switch action {
+	case cart.CourierActionAccept:
+		if assignment.Status != cart.AssignmentOffered {
+			return models.CourierAssignment{}, invalid
+		}
+		err = u.couriers.UpdateAssignment(ctx, orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted)
+
+	case cart.CourierActionDecline:
+		if assignment.Status != cart.AssignmentOffered && assignment.Status != cart.AssignmentAccepted {
+			return models.CourierAssignment{}, invalid
+		}
+		err = u.couriers.DeleteAssignment(ctx, orderID, courierID)
+
+	case cart.CourierActionPickup:
+		if assignment.Status != cart.AssignmentAccepted {
+			return models.CourierAssignment{}, invalid
+		}
+		if err := u.advanceCourierOrder(ctx, orderID, assignment.OrderStatus, cart.StatusReadyForPickup, cart.StatusInDelivery); err != nil {
+			return models.CourierAssignment{}, err
+		}
+		err = u.couriers.UpdateAssignment(ctx, orderID, courierID, cart.AssignmentAccepted, cart.AssignmentPickedUp)
+
+	case cart.CourierActionDeliver:
+		if assignment.Status != cart.AssignmentPickedUp {
+			return models.CourierAssignment{}, invalid
+		}
+		if err := u.advanceCourierOrder(ctx, orderID, assignment.OrderStatus, cart.StatusInDelivery, cart.StatusDelivered); err != nil {
+			return models.CourierAssignment{}, err
+		}
+		err = u.couriers.UpdateAssignment(ctx, orderID, courierID, cart.AssignmentPickedUp, cart.AssignmentDelivered)
+
+	default:
+		return models.CourierAssignment{}, cart.ErrUnknownCourierAction
+	}
+	if err != nil {
+		logger.Error("не удалось обновить назначение", slog.String("error", err.Error()))
+		return models.CourierAssignment{}, err
+	}
+
+	logger.Info("действие курьера выполнено")
+	return assignment, nil
+}
+
+// advanceCourierOrder переводит заказ from → to от имени курьера; если заказ уже в to, ничего не делает.
+func (u *CartUsecase) advanceCourierOrder(ctx context.Context, orderID uuid.UUID, current, from, to string) error {
+	if current == to {
+		return nil
+	}
+	if current != from {
+		return fmt.Errorf("%w: %s -> %s", cart.ErrInvalidTransition, current, to)
+	}
+	return u.transitOrderStatus(ctx, orderID, from, newStatusEvent(to, cart.ActorCourier, ""))
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+)
+
+const (
+	dispatchInterval    = 5 * time.Second
+	dispatchBatchSize   = 50
+	courierOfferTimeout = 2 * time.Minute
+	earthRadiusKm       = 6371.0
+)
+
+// courierSimulationFromEnv включает курьеров-симуляторов (COURIER_SIMULATION, по умолчанию выключено).
+// Сами курьеры создаются только на локальном стенде фикстурой build/sql/fixtures/dev_couriers.sql.
+func courierSimulationFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("COURIER_SIMULATION"))
+	return err == nil && enabled
+}
+
+// RunDispatcher раздаёт готовые заказы курьерам, пока не отменён ctx. Если включена
+// симуляция (COURIER_SIMULATION), на том же тике действуют и курьеры-симуляторы.
+func (u *CartUsecase) RunDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		u.dispatchOrders(ctx)
+		if u.simulateFleet {
+			u.driveSimulatedCouriers(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *CartUsecase) dispatchOrders(ctx context.Context) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	released, err := u.couriers.ReleaseExpiredOffers(ctx, u.now().Add(-courierOfferTimeout))
+	if err != nil {
+		logger.Error("не удалось снять просроченные назначения", slog.String("error", err.Error()))
+	} else if released > 0 {
+		logger.Info("курьеры не ответили на назначения, заказы вернулись в очередь", slog.Int64("count", released))
+	}
+
+	orders, err := u.couriers.GetUnassignedOrders(ctx, dispatchBatchSize)
+	if err != nil {
+		logger.Error("не удалось получить заказы без курьера", slog.String("error", err.Error()))
+		return
+	}
+	if len(orders) == 0 {
+		return
+	}
+
+	couriers, err := u.couriers.GetAvailableCouriers(ctx, u.simulateFleet)
+	if err != nil {
+		logger.Error("не удалось получить свободных курьеров", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, order := range orders {
+		i := pickCourier(order, couriers)
+		if i < 0 {
+			logger.Info("нет свободных курьеров", slog.Int("waiting", len(orders)))
+			return
+		}
+		if err := u.couriers.AssignCourier(ctx, order.OrderID, couriers[i].ID); err != nil {
+			logger.Warn("не удалось назначить курьера", slog.String("orderID", order.OrderID.String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		couriers[i].ActiveOrders++
+		logger.Info("заказ предложен курьеру", slog.String("orderID", order.OrderID.String()),
+			slog.String("courierID", couriers[i].ID.String()))
+	}
+}
+
+// pickCourier возвращает индекс ближайшего к ресторану курьера, у которого есть свободное место.
+// При равном расстоянии (в том числе когда координаты неизвестны) выбирается наименее загруженный.
+// Если подходящих курьеров нет, возвращается -1.
+func pickCourier(order models.DispatchOrder, couriers []models.Courier) int {
+	best, bestDistance := -1, math.Inf(1)
+	for i, courier := range couriers {
+		if courier.ActiveOrders >= courier.MaxOrders {
+			continue
+		}
+		distance := distanceKm(order.Latitude, order.Longitude, courier.Latitude, courier.Longitude)
+		if best < 0 || distance < bestDistance ||
+			(distance == bestDistance && courier.ActiveOrders < couriers[best].ActiveOrders) {
+			best, bestDistance = i, distance
+		}
+	}
+	return best
+}
+
+// distanceKm — расстояние по поверхности Земли; если хотя бы одна точка неизвестна, +Inf.
+func distanceKm(lat1, lon1, lat2, lon2 *float64) float64 {
+	if lat1 == nil || lon1 == nil || lat2 == nil || lon2 == nil {
+		return math.Inf(1)
+	}
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(*lat2 - *lat1)
+	dLon := toRad(*lon2 - *lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(*lat1))*math.Cos(toRad(*lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func (u *CartUsecase) driveSimulatedCouriers(ctx context.Context) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	assignments, err := u.couriers.GetSimulatedAssignments(ctx)
+	if err != nil {
+		logger.Error("не удалось получить назначения симулятора", slog.String("error", err.Error()))
+		return
+	}
+
+	now := u.now()
+	for _, assignment := range assignments {
+		action := simulatedAction(assignment, now)
+		if action == "" {
+			continue
+		}
+		if _, err := u.applyCourierAction(ctx, assignment.CourierID, assignment.OrderID, action); err != nil {
+			logger.Warn("курьер-симулятор не смог выполнить действие", slog.String("orderID", assignment.OrderID.String()),
+				slog.String("action", action), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// simulatedAction повторяет прежнее поведение стенда: курьер сразу принимает заказ,
+// забирает его через expectedPickupTime и вручает ещё через expectedDeliveryTime.
+func simulatedAction(assignment models.CourierAssignment, now time.Time) string {
+	waited := now.Sub(assignment.UpdatedAt)
+	switch assignment.Status {
+	case cart.AssignmentOffered:
+		return cart.CourierActionAccept
+	case cart.AssignmentAccepted:
+		if waited >= expectedPickupTime {
+			return cart.CourierActionPickup
+		}
+	case cart.AssignmentPickedUp:
+		if waited >= expectedDeliveryTime {
+			return cart.CourierActionDeliver
+		}
+	}
+	return ""
+}
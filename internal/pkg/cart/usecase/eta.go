@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/satori/uuid"
+)
+
+// etaQueueDelayPerOrder — насколько каждый заказ, ожидающий на кухне, отодвигает следующий.
+const etaQueueDelayPerOrder = 5 * time.Minute
+
+// etaRemainingShare — доля окна доставки ресторана, которая остаётся до вручения заказа после
+// входа в статус. Окно (delivery_time_from..to) покрывает готовку, ожидание курьера и дорогу.
+var etaRemainingShare = map[string]float64{
+	cart.StatusCreated:        1,
+	cart.StatusPaid:           1,
+	cart.StatusAccepted:       1,
+	cart.StatusScheduled:      1,
+	cart.StatusCooking:        1,
+	cart.StatusReadyForPickup: 0.5,
+	cart.StatusInDelivery:     0.3,
+}
+
+// deliveryWindow — середина окна доставки ресторана: столько, по оценке estimateETA, проходит
+// от начала готовки до вручения заказа.
+func deliveryWindow(delivery models.DeliveryTime) time.Duration {
+	return time.Duration(delivery.From+delivery.To) * time.Minute / 2
+}
+
+// estimateETA возвращает ожидаемое время вручения заказа, вошедшего в status в момент now.
+// Пока заказ не начали готовить, к середине окна доставки добавляется очередь кухни; заказ
+// с выбранным временем доставки не приедет раньше него. У отменённых заказов ETA нет.
+func estimateETA(status string, estimate models.DeliveryEstimate, now time.Time) *time.Time {
+	if status == cart.StatusDelivered {
+		return &now
+	}
+	share, ok := etaRemainingShare[status]
+	if !ok {
+		return nil
+	}
+
+	eta := now.Add(time.Duration(float64(deliveryWindow(estimate.DeliveryTime)) * share))
+	switch status {
+	case cart.StatusCreated, cart.StatusPaid, cart.StatusAccepted:
+		eta = eta.Add(time.Duration(estimate.QueueLength) * etaQueueDelayPerOrder)
+	}
+	if estimate.DeliverAt != nil && estimate.DeliverAt.After(eta) {
+		eta = *estimate.DeliverAt
+	}
+	return &eta
+}
+
+// orderETA пересчитывает ETA заказа для события смены статуса.
+func (u *CartUsecase) orderETA(ctx context.Context, orderID uuid.UUID, event models.OrderStatusEvent) (*time.Time, error) {
+	if _, ok := etaRemainingShare[event.Status]; !ok {
+		return estimateETA(event.Status, models.DeliveryEstimate{}, event.CreatedAt), nil
+	}
+
+	estimate, err := u.restaurantRepo.GetOrderEstimate(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return estimateETA(event.Status, estimate, event.CreatedAt), nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/satori/uuid"
+)
+
+const (
+	defaultOrdersPageSize = 15
+	maxOrdersPageSize     = 50
+)
+
+// GetOrders возвращает страницу истории заказов. Курсор следующей страницы выдаётся,
+// только если после неё ещё остались заказы.
+func (u *CartUsecase) GetOrders(ctx context.Context, user_id uuid.UUID, filter models.OrderFilter, cursor string, count int) (models.OrderPage, error) {
+	if err := validateOrderFilter(filter); err != nil {
+		return models.OrderPage{}, err
+	}
+	if count <= 0 {
+		count = defaultOrdersPageSize
+	}
+	if count > maxOrdersPageSize {
+		count = maxOrdersPageSize
+	}
+	if cursor != "" {
+		after, err := cart.DecodeOrderCursor(cursor, filter)
+		if err != nil {
+			return models.OrderPage{}, err
+		}
+		filter.After = &after
+	}
+
+	orders, err := u.restaurantRepo.GetOrders(ctx, user_id, filter, count+1)
+	if err != nil {
+		return models.OrderPage{}, err
+	}
+
+	page := models.OrderPage{Orders: orders}
+	if len(orders) > count {
+		page.Orders = orders[:count]
+		last := page.Orders[count-1]
+		page.NextCursor = cart.EncodeOrderCursor(models.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}, filter)
+	}
+	if page.Orders == nil {
+		page.Orders = []models.Order{}
+	}
+	return page, nil
+}
+
+func validateOrderFilter(filter models.OrderFilter) error {
+	for _, status := range filter.Statuses {
+		if !cart.IsKnownStatus(status) {
+			return fmt.Errorf("%w: неизвестный статус %q", cart.ErrInvalidOrderFilter, status)
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: начало периода должно быть раньше конца", cart.ErrInvalidOrderFilter)
+	}
+	if filter.MinTotal < 0 || filter.MaxTotal < 0 {
+		return fmt.Errorf("%w: сумма заказа не может быть отрицательной", cart.ErrInvalidOrderFilter)
+	}
+	if filter.MaxTotal > 0 && filter.MinTotal > filter.MaxTotal {
+		return fmt.Errorf("%w: минимальная сумма больше максимальной", cart.ErrInvalidOrderFilter)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+const (
+	statusWorkerInterval  = 5 * time.Second
+	statusWorkerBatchSize = 100
+)
+
+func newStatusEvent(status, actor, reason string) models.OrderStatusEvent {
+	return models.OrderStatusEvent{
+		Status:    status,
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (u *CartUsecase) changeOrderStatus(ctx context.Context, orderID uuid.UUID, event models.OrderStatusEvent) error {
+	from, err := u.restaurantRepo.GetOrderStatus(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return u.transitOrderStatus(ctx, orderID, from, event)
+}
+
+func (u *CartUsecase) transitOrderStatus(ctx context.Context, orderID uuid.UUID, from string, event models.OrderStatusEvent) error {
+	to := event.Status
+	if !cart.CanTransition(from, to) {
+		return fmt.Errorf("%w: %s -> %s", cart.ErrInvalidTransition, from, to)
+	}
+
+	// Следующий шаг планируется до смены статуса: если сервис упадёт между этими
+	// запросами, задача просто не совпадёт со статусом заказа и будет отброшена.
+	transition, ok, err := u.nextTransition(ctx, orderID, to)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := u.restaurantRepo.ScheduleStatusTransition(ctx, transition); err != nil {
+			return err
+		}
+	}
+
+	if event.ETA, err = u.orderETA(ctx, orderID, event); err != nil {
+		return err
+	}
+
+	if err := u.restaurantRepo.UpdateOrderStatus(ctx, orderID, from, event); err != nil {
+		return err
+	}
+
+	u.watchers.publish(orderID, event)
+	return nil
+}
+
+// nextTransition возвращает автоматический шаг после входа заказа в статус. Остальные шаги
+// выполняют ресторан и курьеры, поэтому он единственный: принятый заказ с выбранным временем
+// доставки сразу уходит в "scheduled" и ждёт, пока ресторан начнёт готовить.
+func (u *CartUsecase) nextTransition(ctx context.Context, orderID uuid.UUID, status string) (models.StatusTransition, bool, error) {
+	if status != cart.StatusAccepted {
+		return models.StatusTransition{}, false, nil
+	}
+
+	estimate, err := u.restaurantRepo.GetOrderEstimate(ctx, orderID)
+	if err != nil {
+		return models.StatusTransition{}, false, err
+	}
+	now := time.Now()
+	if canStartCooking(estimate.DeliverAt, estimate.DeliveryTime, now) {
+		return models.StatusTransition{}, false, nil
+	}
+
+	return models.StatusTransition{
+		ID:         uuid.NewV4(),
+		OrderID:    orderID,
+		FromStatus: status,
+		ToStatus:   cart.StatusScheduled,
+		RunAt:      now,
+	}, true, nil
+}
+
+// RunStatusWorker выполняет запланированные смены статусов, пока не отменён ctx.
+// Задачи хранятся в БД, поэтому после перезапуска сервиса обработка продолжается. Если включена
+// симуляция (RESTAURANT_SIMULATION), на том же тике работают рестораны без персонала.
+func (u *CartUsecase) RunStatusWorker(ctx context.Context) {
+	ticker := time.NewTicker(statusWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		u.processDueTransitions(ctx)
+		if u.simulateKitchens {
+			u.driveSimulatedKitchens(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *CartUsecase) processDueTransitions(ctx context.Context) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	transitions, err := u.restaurantRepo.GetDueStatusTransitions(ctx, statusWorkerBatchSize)
+	if err != nil {
+		logger.Error("не удалось получить запланированные смены статуса", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, transition := range transitions {
+		event := newStatusEvent(transition.ToStatus, cart.ActorSystem, "")
+		err := u.transitOrderStatus(ctx, transition.OrderID, transition.FromStatus, event)
+		if err != nil && !errors.Is(err, cart.ErrStatusConflict) && !errors.Is(err, cart.ErrInvalidTransition) {
+			logger.Error("не удалось сменить статус заказа, повторим позже",
+				slog.String("orderID", transition.OrderID.String()), slog.String("error", err.Error()))
+			continue
+		}
+		if err != nil {
+			logger.Info("запланированная смена статуса устарела",
+				slog.String("orderID", transition.OrderID.String()), slog.String("error", err.Error()))
+		}
+
+		if err := u.restaurantRepo.DeleteStatusTransition(ctx, transition.ID); err != nil {
+			logger.Error("не удалось удалить выполненную задачу", slog.String("error", err.Error()))
+		}
+	}
+}
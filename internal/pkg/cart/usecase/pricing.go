@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+)
+
+const priceTolerance = 0.01
+
+type pricingConfig struct {
+	deliveryFee       float64
+	freeDeliveryFrom  float64
+	serviceFeePercent float64
+}
+
+func pricingConfigFromEnv() pricingConfig {
+	return pricingConfig{
+		deliveryFee:       floatFromEnv("DELIVERY_FEE", 0),
+		freeDeliveryFrom:  floatFromEnv("FREE_DELIVERY_FROM", 0),
+		serviceFeePercent: floatFromEnv("SERVICE_FEE_PERCENT", 0),
+	}
+}
+
+func floatFromEnv(name string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(name), 64)
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
+
+func (c pricingConfig) calculate(items []models.CartItem) models.PriceBreakdown {
+	var breakdown models.PriceBreakdown
+	for _, item := range items {
+		breakdown.Subtotal += item.Price * float64(item.Amount)
+	}
+	breakdown.Subtotal = roundPrice(breakdown.Subtotal)
+
+	if c.freeDeliveryFrom == 0 || breakdown.Subtotal < c.freeDeliveryFrom {
+		breakdown.DeliveryFee = roundPrice(c.deliveryFee)
+	}
+	breakdown.ServiceFee = roundPrice(breakdown.Subtotal * c.serviceFeePercent / 100)
+
+	breakdown.Total = breakdownTotal(breakdown)
+	return breakdown
+}
+
+// applyDiscount пересчитывает итог со скидкой промокода; скидка не превышает стоимость товаров.
+func applyDiscount(breakdown models.PriceBreakdown, promo models.PromoCode) models.PriceBreakdown {
+	switch promo.Type {
+	case cart.PromoPercent:
+		breakdown.Discount = breakdown.Subtotal * promo.Value / 100
+	case cart.PromoFixed:
+		breakdown.Discount = promo.Value
+	case cart.PromoFreeDelivery:
+		breakdown.Discount = breakdown.DeliveryFee
+	}
+	if promo.Type != cart.PromoFreeDelivery {
+		breakdown.Discount = math.Min(breakdown.Discount, breakdown.Subtotal)
+	}
+	breakdown.Discount = roundPrice(breakdown.Discount)
+
+	breakdown.Total = breakdownTotal(breakdown)
+	return breakdown
+}
+
+// applyTip добавляет чаевые отдельной строкой; промокод на них не действует.
+func applyTip(breakdown models.PriceBreakdown, tip float64) models.PriceBreakdown {
+	breakdown.Tip = roundPrice(tip)
+	breakdown.Total = breakdownTotal(breakdown)
+	return breakdown
+}
+
+// tipFor переводит чаевые из запроса в сумму; процент считается от стоимости товаров.
+func tipFor(subtotal, amount, percent float64) float64 {
+	if percent > 0 {
+		return roundPrice(subtotal * percent / 100)
+	}
+	return roundPrice(amount)
+}
+
+func breakdownTotal(breakdown models.PriceBreakdown) float64 {
+	return roundPrice(breakdown.Subtotal + breakdown.DeliveryFee + breakdown.ServiceFee - breakdown.Discount + breakdown.Tip)
+}
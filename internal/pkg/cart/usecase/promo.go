@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// PreviewPromo показывает, как изменится стоимость корзины с промокодом, ничего не списывая.
+func (u *CartUsecase) PreviewPromo(ctx context.Context, login, code string, clientCart models.Cart) (models.PromoPreview, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	orderCart, breakdown, err := u.priceCart(ctx, clientCart)
+	if err != nil {
+		logger.Error("не удалось рассчитать стоимость корзины", slog.String("error", err.Error()))
+		return models.PromoPreview{}, err
+	}
+
+	promo, err := u.checkPromo(ctx, login, code, orderCart, breakdown)
+	if err != nil {
+		logger.Warn("промокод не применён", slog.String("code", code), slog.String("error", err.Error()))
+		return models.PromoPreview{}, err
+	}
+
+	return models.PromoPreview{
+		PromoCode:      promo.Code,
+		Cart:           orderCart,
+		PriceBreakdown: applyDiscount(breakdown, promo),
+	}, nil
+}
+
+// checkPromo находит промокод и проверяет все его условия для пользователя и корзины.
+func (u *CartUsecase) checkPromo(ctx context.Context, login, code string, orderCart models.Cart, breakdown models.PriceBreakdown) (models.PromoCode, error) {
+	promo, err := u.restaurantRepo.GetPromoCode(ctx, cart.NormalizePromoCode(code), orderCart.Id)
+	if err != nil {
+		return models.PromoCode{}, err
+	}
+
+	now := time.Now()
+	if (promo.StartsAt != nil && now.Before(*promo.StartsAt)) || (promo.EndsAt != nil && !now.Before(*promo.EndsAt)) {
+		return models.PromoCode{}, cart.ErrPromoInactive
+	}
+	if (promo.RestaurantID != uuid.Nil && promo.RestaurantID != orderCart.Id) || (promo.TagID != uuid.Nil && !promo.RestaurantHasTag) {
+		return models.PromoCode{}, cart.ErrPromoNotApplicable
+	}
+	if breakdown.Subtotal < promo.MinSubtotal {
+		return models.PromoCode{}, fmt.Errorf("%w: от %.2f", cart.ErrPromoMinSubtotal, promo.MinSubtotal)
+	}
+
+	usage, err := u.restaurantRepo.GetPromoUsage(ctx, promo.ID, login)
+	if err != nil {
+		return models.PromoCode{}, err
+	}
+	if (promo.MaxUses > 0 && usage.Total >= promo.MaxUses) || (promo.MaxUsesPerUser > 0 && usage.ByUser >= promo.MaxUsesPerUser) {
+		return models.PromoCode{}, cart.ErrPromoExhausted
+	}
+	if promo.FirstOrderOnly && usage.UserOrders > 0 {
+		return models.PromoCode{}, cart.ErrPromoFirstOrder
+	}
+
+	return promo, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// Reorder собирает корзину из прошлого заказа по текущему меню ресторана. Товары, которых
+// больше нет, в корзину не попадают, а товары с новой ценой попадают в отчёт о переоценке.
+func (u *CartUsecase) Reorder(ctx context.Context, orderID, userID uuid.UUID, login string, replace bool) (models.ReorderResult, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("orderID", orderID.String()))
+
+	order, err := u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+	if err != nil {
+		logger.Error("не удалось получить заказ", slog.String("error", err.Error()))
+		return models.ReorderResult{}, err
+	}
+
+	restaurantID := order.OrderProducts.Id.String()
+	productIDs := make([]string, 0, len(order.OrderProducts.CartItems))
+	productAmounts := make(map[string]int, len(order.OrderProducts.CartItems))
+	for _, item := range order.OrderProducts.CartItems {
+		id := item.Id.String()
+		if _, ok := productAmounts[id]; !ok {
+			productIDs = append(productIDs, id)
+		}
+		productAmounts[id] += item.Amount
+	}
+
+	// Если ресторан удалён, меню пустое: все товары заказа попадут в Dropped.
+	menu, err := u.restaurantRepo.GetCartItem(ctx, productIDs, productAmounts, restaurantID)
+	if errors.Is(err, cart.ErrRestaurantNotFound) {
+		logger.Warn("ресторан заказа больше не существует")
+		menu, err = models.Cart{}, nil
+	}
+	if err != nil {
+		logger.Error("не удалось получить текущее меню", slog.String("error", err.Error()))
+		return models.ReorderResult{}, err
+	}
+	current := make(map[uuid.UUID]models.CartItem, len(menu.CartItems))
+	for _, item := range menu.CartItems {
+		current[item.Id] = item
+	}
+
+	result := models.ReorderResult{Dropped: []models.ReorderItem{}, Repriced: []models.ReorderItem{}}
+	quantities := make(map[string]int, len(productIDs))
+	for _, item := range order.OrderProducts.CartItems {
+		reordered := models.ReorderItem{Id: item.Id, Name: item.Name, Amount: item.Amount, OldPrice: item.Price}
+
+		now, ok := current[item.Id]
+		if !ok {
+			result.Dropped = append(result.Dropped, reordered)
+			continue
+		}
+		if math.Abs(now.Price-item.Price) > priceTolerance {
+			reordered.NewPrice = now.Price
+			result.Repriced = append(result.Repriced, reordered)
+		}
+		quantities[item.Id.String()] += item.Amount
+	}
+
+	if len(quantities) == 0 {
+		logger.Info("ни одного товара из заказа нет в меню, корзина не изменена")
+		return result, nil
+	}
+
+	if err := u.cartRepo.FillCart(ctx, login, restaurantID, quantities, replace); err != nil {
+		logger.Warn("не удалось заполнить корзину", slog.String("error", err.Error()))
+		return models.ReorderResult{}, err
+	}
+
+	result.Cart, err, _ = u.GetCart(ctx, login)
+	if err != nil {
+		return models.ReorderResult{}, err
+	}
+
+	logger.Info("заказ повторён", slog.Int("dropped", len(result.Dropped)), slog.Int("repriced", len(result.Repriced)))
+	return result, nil
+}
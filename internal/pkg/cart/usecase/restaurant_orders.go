@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// GetRestaurantOrders возвращает необработанные заказы ресторана, в котором работает сотрудник.
+func (u *CartUsecase) GetRestaurantOrders(ctx context.Context, staffID uuid.UUID) ([]models.Order, error) {
+	restaurantID, err := u.restaurantRepo.GetStaffRestaurant(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := u.restaurantRepo.GetRestaurantOrders(ctx, restaurantID, cart.RestaurantActiveStatuses)
+	if err != nil {
+		return nil, err
+	}
+	if orders == nil {
+		orders = []models.Order{}
+	}
+	return orders, nil
+}
+
+// SetRestaurantOrderStatus выполняет действие персонала ресторана над заказом: принять, отклонить,
+// начать готовить или отдать курьеру. Заказы чужих ресторанов для сотрудника не существуют.
+func (u *CartUsecase) SetRestaurantOrderStatus(ctx context.Context, staffID, orderID uuid.UUID, status, reason string) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()),
+		slog.String("orderID", orderID.String()), slog.String("status", status))
+
+	restaurantID, err := u.restaurantRepo.GetStaffRestaurant(ctx, staffID)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	order, err := u.restaurantRepo.GetRestaurantOrder(ctx, orderID, restaurantID)
+	if err != nil {
+		return models.Order{}, err
+	}
+	if !cart.CanRestaurantTransition(order.Status, status) {
+		logger.Warn("недопустимое действие ресторана", slog.String("from", order.Status))
+		return models.Order{}, fmt.Errorf("%w: %s -> %s", cart.ErrInvalidTransition, order.Status, status)
+	}
+	if status == cart.StatusCooking && order.DeliverAt != nil {
+		estimate, err := u.restaurantRepo.GetOrderEstimate(ctx, orderID)
+		if err != nil {
+			return models.Order{}, err
+		}
+		if !canStartCooking(order.DeliverAt, estimate.DeliveryTime, u.now()) {
+			logger.Warn("запланированный заказ рано начинать готовить")
+			return models.Order{}, fmt.Errorf("%w: заказ запланирован, готовить можно с %s", cart.ErrInvalidTransition,
+				cookingStartsAt(*order.DeliverAt, estimate.DeliveryTime).In(u.location).Format("02.01 в 15:04"))
+		}
+	}
+
+	event := newStatusEvent(status, cart.ActorRestaurant, reason)
+	if err := u.transitOrderStatus(ctx, orderID, order.Status, event); err != nil {
+		logger.Error("не удалось сменить статус заказа", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	if status == cart.StatusCancelled {
+		if err := u.refundOrder(ctx, orderID, order.PaymentID); err != nil {
+			logger.Error("не удалось вернуть платёж", slog.String("error", err.Error()))
+			return models.Order{}, err
+		}
+	}
+
+	userID, err := uuid.FromString(order.UserID)
+	if err != nil {
+		return models.Order{}, err
+	}
+	logger.Info("статус заказа изменён рестораном")
+	return u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+}
+
+const simulatedKitchenBatchSize = 50
+
+// simulatedKitchenStatuses — статусы, из которых ресторан-симулятор переводит заказ дальше.
+var simulatedKitchenStatuses = []string{cart.StatusPaid, cart.StatusAccepted, cart.StatusScheduled, cart.StatusCooking}
+
+func restaurantSimulationFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("RESTAURANT_SIMULATION"))
+	return err == nil && enabled
+}
+
+// driveSimulatedKitchens ведёт заказы ресторанов, у которых нет персонала, так же, как это
+// делал бы сотрудник: принимает, начинает готовить и через expectedCookingTime отдаёт курьеру.
+func (u *CartUsecase) driveSimulatedKitchens(ctx context.Context) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	orders, err := u.restaurantRepo.GetSimulatedKitchenOrders(ctx, simulatedKitchenStatuses, simulatedKitchenBatchSize)
+	if err != nil {
+		logger.Error("не удалось получить заказы ресторанов-симуляторов", slog.String("error", err.Error()))
+		return
+	}
+
+	now := u.now()
+	for _, order := range orders {
+		status := simulatedKitchenStatus(order, now)
+		if status == "" {
+			continue
+		}
+		event := newStatusEvent(status, cart.ActorRestaurant, "")
+		if err := u.transitOrderStatus(ctx, order.OrderID, order.Status, event); err != nil {
+			logger.Warn("ресторан-симулятор не смог сменить статус заказа", slog.String("orderID", order.OrderID.String()),
+				slog.String("status", status), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// simulatedKitchenStatus возвращает следующий статус заказа ресторана-симулятора или "",
+// если пока ждать: запланированный заказ не готовится раньше cookingStartsAt.
+func simulatedKitchenStatus(order models.KitchenOrder, now time.Time) string {
+	switch order.Status {
+	case cart.StatusPaid:
+		return cart.StatusAccepted
+	case cart.StatusAccepted, cart.StatusScheduled:
+		if canStartCooking(order.DeliverAt, order.DeliveryTime, now) {
+			return cart.StatusCooking
+		}
+	case cart.StatusCooking:
+		if now.Sub(order.UpdatedAt) >= expectedCookingTime {
+			return cart.StatusReadyForPickup
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+)
+
+const (
+	defaultMinLeadTime = time.Hour
+	maxScheduleAhead   = 7 * 24 * time.Hour
+	// Столько готовят рестораны-симуляторы и ждут курьеры-симуляторы, см. simulatedKitchenStatus
+	// и simulatedAction.
+	expectedCookingTime  = 60 * time.Second
+	expectedPickupTime   = 30 * time.Second
+	expectedDeliveryTime = 20 * time.Second
+)
+
+func minLeadTimeFromEnv() time.Duration {
+	lead, err := time.ParseDuration(os.Getenv("ORDER_MIN_LEAD_TIME"))
+	if err != nil || lead < 0 {
+		return defaultMinLeadTime
+	}
+	return lead
+}
+
+// cookingStartsAt возвращает момент, когда ресторан должен начать готовить, чтобы успеть к deliverAt.
+// Запас берётся из окна доставки ресторана так же, как в estimateETA: заказ, начатый в этот момент,
+// по оценке сервиса приедет ровно к deliverAt.
+func cookingStartsAt(deliverAt time.Time, delivery models.DeliveryTime) time.Time {
+	return deliverAt.Add(-deliveryWindow(delivery))
+}
+
+// canStartCooking сообщает, можно ли уже готовить заказ: запланированный ждёт cookingStartsAt.
+func canStartCooking(deliverAt *time.Time, delivery models.DeliveryTime, now time.Time) bool {
+	return deliverAt == nil || !cookingStartsAt(*deliverAt, delivery).After(now)
+}
+
+func (u *CartUsecase) checkDeliverAt(deliverAt, now time.Time) error {
+	if deliverAt.Before(now.Add(u.minLeadTime)) {
+		return fmt.Errorf("%w: заказ можно запланировать не раньше чем через %d мин",
+			cart.ErrInvalidDeliverAt, int(u.minLeadTime.Minutes()))
+	}
+	if deliverAt.After(now.Add(maxScheduleAhead)) {
+		return fmt.Errorf("%w: заказ можно запланировать не позже чем через %d дней",
+			cart.ErrInvalidDeliverAt, int(maxScheduleAhead.Hours()/24))
+	}
+	return nil
+}
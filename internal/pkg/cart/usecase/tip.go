@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/satori/uuid"
+)
+
+// tipEditWindow — сколько времени после вручения заказа можно изменить чаевые.
+const tipEditWindow = 24 * time.Hour
+
+// UpdateTip меняет чаевые доставленного заказа. Прибавка к чаевым списывается отдельным
+// платежом, уменьшение возвращается сначала из таких платежей, затем из платежа заказа.
+func (u *CartUsecase) UpdateTip(ctx context.Context, orderID, userID uuid.UUID, req models.TipReq) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("orderID", orderID.String()))
+
+	order, err := u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+	if err != nil {
+		logger.Error("не удалось получить заказ", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	deliveredAt, ok := deliveredAt(order)
+	if !ok {
+		return models.Order{}, fmt.Errorf("%w: заказ в статусе %s", cart.ErrTipNotEditable, order.Status)
+	}
+	if u.now().After(deliveredAt.Add(tipEditWindow)) {
+		return models.Order{}, cart.ErrTipWindowClosed
+	}
+
+	oldTip := order.PriceBreakdown.Tip
+	newTip := tipFor(order.PriceBreakdown.Subtotal, req.Amount, req.Percent)
+	if newTip == oldTip {
+		return order, nil
+	}
+
+	if err := u.restaurantRepo.UpdateOrderTip(ctx, orderID, oldTip, newTip); err != nil {
+		logger.Error("не удалось сохранить чаевые", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	if err := u.settleTip(ctx, order, roundPrice(newTip-oldTip)); err != nil {
+		logger.Error("не удалось провести оплату чаевых", slog.String("error", err.Error()))
+		if revertErr := u.restaurantRepo.UpdateOrderTip(ctx, orderID, newTip, oldTip); revertErr != nil {
+			logger.Error("не удалось вернуть прежние чаевые", slog.String("error", revertErr.Error()))
+		}
+		return models.Order{}, err
+	}
+
+	logger.Info("чаевые изменены", slog.Float64("from", oldTip), slog.Float64("to", newTip))
+	return u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+}
+
+// settleTip списывает прибавку к чаевым или возвращает разницу, если чаевые уменьшили.
+func (u *CartUsecase) settleTip(ctx context.Context, order models.Order, delta float64) error {
+	if delta < 0 {
+		return u.refundTip(ctx, order, -delta)
+	}
+
+	intent, err := u.payments.CreateIntent(ctx, order.ID, delta)
+	if err != nil {
+		return err
+	}
+	// Платёж записывается до списания, чтобы уведомление провайдера о нём не приняли за оплату заказа.
+	tip := models.TipPayment{PaymentID: intent.ID, OrderID: order.ID, Amount: delta}
+	if err := u.restaurantRepo.SaveTipPayment(ctx, tip); err != nil {
+		return err
+	}
+	_, err = u.payments.Capture(ctx, intent.ID)
+	return err
+}
+
+// refundTip возвращает amount сначала из доплат чаевых, начиная с последней, а остаток —
+// из платежа заказа, в который вошли чаевые, указанные при оформлении.
+func (u *CartUsecase) refundTip(ctx context.Context, order models.Order, amount float64) error {
+	tips, err := u.restaurantRepo.GetTipPayments(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, tip := range tips {
+		if amount <= priceTolerance {
+			return nil
+		}
+		intent, err := u.payments.GetStatus(ctx, tip.PaymentID)
+		if err != nil {
+			return err
+		}
+		refundable := roundPrice(intent.Amount - intent.RefundedAmount)
+		if intent.Status != payment.IntentCaptured || refundable <= 0 {
+			continue
+		}
+		part := math.Min(amount, refundable)
+		if _, err := u.payments.Refund(ctx, tip.PaymentID, part); err != nil {
+			return err
+		}
+		amount = roundPrice(amount - part)
+	}
+
+	if amount <= priceTolerance || order.PaymentID == "" {
+		return nil
+	}
+	_, err = u.payments.Refund(ctx, order.PaymentID, amount)
+	return err
+}
+
+// deliveredAt возвращает момент вручения заказа по его истории.
+func deliveredAt(order models.Order) (time.Time, bool) {
+	if order.Status != cart.StatusDelivered {
+		return time.Time{}, false
+	}
+	for i := len(order.Timeline) - 1; i >= 0; i-- {
+		if order.Timeline[i].Status == cart.StatusDelivered {
+			return order.Timeline[i].CreatedAt, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -2,25 +2,51 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"log/slog"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/workhours"
 	"github.com/satori/uuid"
 )
 
 type CartUsecase struct {
 	cartRepo       cart.CartRepo
 	restaurantRepo cart.RestaurantRepo
+	couriers       cart.CourierRepo
+	payments       payment.PaymentProvider
+	pricing        pricingConfig
+	watchers       *orderWatchers
+	products       *productCache
+	location       *time.Location
+	minLeadTime    time.Duration
+	now            func() time.Time
+	simulateFleet  bool
+	// simulateKitchens включает рестораны-симуляторы, см. driveSimulatedKitchens.
+	simulateKitchens bool
 }
 
-func NewCartUsecase(cartRepo cart.CartRepo, restaurantRepo cart.RestaurantRepo) *CartUsecase {
+func NewCartUsecase(cartRepo cart.CartRepo, restaurantRepo cart.RestaurantRepo, couriers cart.CourierRepo, payments payment.PaymentProvider) *CartUsecase {
 	return &CartUsecase{
-		cartRepo:       cartRepo,
-		restaurantRepo: restaurantRepo,
+		cartRepo:         cartRepo,
+		restaurantRepo:   restaurantRepo,
+		couriers:         couriers,
+		payments:         payments,
+		pricing:          pricingConfigFromEnv(),
+		watchers:         newOrderWatchers(),
+		products:         newProductCache(productCacheTTLFromEnv()),
+		location:         workhours.LocationFromEnv(),
+		minLeadTime:      minLeadTimeFromEnv(),
+		now:              time.Now,
+		simulateFleet:    courierSimulationFromEnv(),
+		simulateKitchens: restaurantSimulationFromEnv(),
 	}
 }
 
@@ -53,9 +79,17 @@ func (uc *CartUsecase) GetCart(ctx context.Context, login string) (models.Cart,
 	return items, nil, true
 }
 
-func (uc *CartUsecase) UpdateItemQuantity(ctx context.Context, login, productID string, restaurantId string, quantity int) error {
+func (uc *CartUsecase) UpdateItemQuantity(ctx context.Context, login, productID string, restaurantId string, quantity int, replace bool) error {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-	err := uc.cartRepo.UpdateItemQuantity(ctx, login, productID, restaurantId, quantity)
+
+	if quantity > 0 {
+		if err := uc.checkProduct(ctx, productID, restaurantId); err != nil {
+			logger.Warn("товар не прошёл проверку по каталогу", slog.String("productID", productID), slog.String("error", err.Error()))
+			return err
+		}
+	}
+
+	err := uc.cartRepo.UpdateItemQuantity(ctx, login, productID, restaurantId, quantity, replace)
 	if err != nil {
 		logger.Error("не удалось обновить количество", slog.String("error", err.Error()))
 	} else {
@@ -75,29 +109,116 @@ func (uc *CartUsecase) ClearCart(ctx context.Context, login string) error {
 	return err
 }
 
-func (u *CartUsecase) CreateOrder(ctx context.Context, userID string, req models.OrderInReq, cart models.Cart) (models.Order, error) {
+// MergeGuestCart переносит гостевую корзину в корзину пользователя после входа.
+func (uc *CartUsecase) MergeGuestCart(ctx context.Context, guestID, login string, replace bool) error {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
+	if !cart.IsGuestOwner(guestID) || login == "" || cart.IsGuestOwner(login) {
+		logger.Warn("некорректные владельцы корзин", slog.String("guest", guestID), slog.String("login", login))
+		return cart.ErrInvalidCartOwner
+	}
+
+	err := uc.cartRepo.MergeCart(ctx, guestID, login, replace)
+	if err != nil {
+		logger.Error("не удалось перенести гостевую корзину", slog.String("error", err.Error()))
+	} else {
+		logger.Info("гостевая корзина перенесена")
+	}
+	return err
+}
+
+func (u *CartUsecase) CreateOrder(ctx context.Context, userID string, req models.OrderInReq, clientCart models.Cart) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	orderCart, breakdown, err := u.priceCart(ctx, clientCart)
+	if err != nil {
+		logger.Error("не удалось рассчитать стоимость заказа", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	now := u.now()
+	if req.DeliverAt != nil {
+		if err := u.checkDeliverAt(*req.DeliverAt, now); err != nil {
+			logger.Warn("некорректное время доставки", slog.String("error", err.Error()))
+			return models.Order{}, err
+		}
+	}
+
+	estimate, err := u.restaurantRepo.GetDeliveryEstimate(ctx, orderCart.Id)
+	if err != nil {
+		logger.Error("не удалось оценить время доставки", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+	estimate.DeliverAt = req.DeliverAt
+	if req.DeliverAt != nil && cookingStartsAt(*req.DeliverAt, estimate.DeliveryTime).Before(now) {
+		logger.Warn("ресторан не успеет к выбранному времени")
+		return models.Order{}, fmt.Errorf("%w: ресторан доставляет заказ примерно за %d мин",
+			cart.ErrInvalidDeliverAt, int(deliveryWindow(estimate.DeliveryTime).Minutes()))
+	}
+
+	if err := u.checkWorkingHours(ctx, orderCart.Id, now, req.DeliverAt, estimate.DeliveryTime); err != nil {
+		logger.Warn("ресторан не принимает заказы", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	var promo models.PromoCode
+	if req.PromoCode != "" {
+		promo, err = u.checkPromo(ctx, userID, req.PromoCode, orderCart, breakdown)
+		if err != nil {
+			logger.Warn("промокод не применён", slog.String("code", req.PromoCode), slog.String("error", err.Error()))
+			return models.Order{}, err
+		}
+		breakdown = applyDiscount(breakdown, promo)
+	}
+	breakdown = applyTip(breakdown, tipFor(breakdown.Subtotal, req.TipAmount, req.TipPercent))
+
+	if math.Abs(breakdown.Total-req.FinalPrice) > priceTolerance {
+		logger.Warn("итоговая сумма клиента не совпадает с расчётной",
+			slog.Float64("client", req.FinalPrice), slog.Float64("server", breakdown.Total))
+		return models.Order{}, fmt.Errorf("%w: ожидалось %.2f", cart.ErrPriceMismatch, breakdown.Total)
+	}
+
 	order := models.Order{
 		ID:                uuid.NewV4(),
 		UserID:            userID,
-		Status:            req.Status,
+		Status:            cart.StatusCreated,
 		Address:           req.Address,
-		OrderProducts:     cart,
+		OrderProducts:     orderCart,
 		ApartmentOrOffice: req.ApartmentOrOffice,
 		Intercom:          req.Intercom,
 		Entrance:          req.Entrance,
 		Floor:             req.Floor,
 		CourierComment:    req.CourierComment,
 		LeaveAtDoor:       req.LeaveAtDoor,
-		CreatedAt:         time.Now(),
-		FinalPrice:        req.FinalPrice,
+		CreatedAt:         now,
+		FinalPrice:        breakdown.Total,
+		PriceBreakdown:    breakdown,
+		PromoCode:         promo.Code,
+		PromoCodeID:       promo.ID,
+		DeliverAt:         req.DeliverAt,
+		ETA:               estimateETA(cart.StatusCreated, estimate, now),
 	}
+	order.Timeline = []models.OrderStatusEvent{{
+		Status:    order.Status,
+		Actor:     cart.ActorUser,
+		ETA:       order.ETA,
+		CreatedAt: order.CreatedAt,
+	}}
 
-	order.Sanitize()
+	intent, err := u.payments.CreateIntent(ctx, order.ID, order.FinalPrice)
+	if err != nil {
+		logger.Error("не удалось создать платёж", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+	order.PaymentID = intent.ID
+	order.PaymentURL = intent.ConfirmationURL
 
 	if err := u.restaurantRepo.Save(ctx, order, userID); err != nil {
 		logger.Error("не удалось сохранить заказ", slog.String("error", err.Error()))
+		// Заказа нет — платёж к нему оплачивать нельзя
+		if _, cancelErr := u.payments.Cancel(ctx, intent.ID); cancelErr != nil {
+			logger.Error("не удалось отменить платёж", slog.String("paymentID", intent.ID), slog.String("error", cancelErr.Error()))
+		}
 		return models.Order{}, err
 	}
 
@@ -105,53 +226,204 @@ func (u *CartUsecase) CreateOrder(ctx context.Context, userID string, req models
 	return order, nil
 }
 
-func (u *CartUsecase) GetOrders(ctx context.Context, user_id uuid.UUID, count, offset int) ([]models.Order, error) {
-	return u.restaurantRepo.GetOrders(ctx, user_id, count, offset)
+// checkWorkingHours не даёт оформить заказ, который ресторан не успеет выполнить в часы
+// работы. Окно доставки — delivery_time_to минут: заказ должен попасть к клиенту до
+// закрытия, а запланированный ещё и начать готовиться не раньше открытия.
+func (u *CartUsecase) checkWorkingHours(ctx context.Context, restaurantID uuid.UUID, now time.Time, deliverAt *time.Time, delivery models.DeliveryTime) error {
+	mode, err := u.restaurantRepo.GetWorkingMode(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	window := time.Duration(delivery.To) * time.Minute
+
+	if deliverAt != nil {
+		// Готовить начинают в cookingStartsAt — внутри этого окна, поэтому открытым ресторан
+		// должен быть всё окно перед deliverAt.
+		start := deliverAt.Add(-window)
+		if workhours.IsOpenFor(mode, start, deliverAt.Sub(start), u.location) {
+			return nil
+		}
+		firstDelivery := time.Date(0, 1, 1, mode.From, 0, 0, 0, time.UTC).Add(window)
+		return fmt.Errorf("%w: ресторан доставляет заказы с %s до %02d:00",
+			cart.ErrInvalidDeliverAt, firstDelivery.Format("15:04"), mode.To%24)
+	}
+
+	if !workhours.IsOpen(mode, now, u.location) {
+		opensAt := workhours.OpensAt(mode, now, u.location)
+		return fmt.Errorf("%w, заказы принимаются с %02d:00 до %02d:00, откроется %s",
+			cart.ErrRestaurantClosed, mode.From, mode.To%24, opensAt.Format("02.01 в 15:04"))
+	}
+	if !workhours.IsOpenFor(mode, now, window, u.location) {
+		return fmt.Errorf("%w: ресторан закрывается в %02d:00, а доставка занимает до %d мин",
+			cart.ErrOutsideDeliveryWindow, mode.To%24, delivery.To)
+	}
+	return nil
+}
+
+// priceCart перечитывает цены товаров из меню ресторана, не доверяя данным клиента.
+func (u *CartUsecase) priceCart(ctx context.Context, clientCart models.Cart) (models.Cart, models.PriceBreakdown, error) {
+	if len(clientCart.CartItems) == 0 {
+		return models.Cart{}, models.PriceBreakdown{}, cart.ErrEmptyCart
+	}
+
+	productIDs := make([]string, 0, len(clientCart.CartItems))
+	productAmounts := make(map[string]int, len(clientCart.CartItems))
+	for _, item := range clientCart.CartItems {
+		if item.Amount <= 0 {
+			return models.Cart{}, models.PriceBreakdown{}, fmt.Errorf("%w: %s", cart.ErrUnknownProduct, item.Id)
+		}
+		id := item.Id.String()
+		if _, ok := productAmounts[id]; !ok {
+			productIDs = append(productIDs, id)
+		}
+		productAmounts[id] += item.Amount
+	}
+
+	priced, err := u.restaurantRepo.GetCartItem(ctx, productIDs, productAmounts, clientCart.Id.String())
+	if err != nil {
+		return models.Cart{}, models.PriceBreakdown{}, err
+	}
+	if len(priced.CartItems) != len(productIDs) {
+		return models.Cart{}, models.PriceBreakdown{}, cart.ErrUnknownProduct
+	}
+
+	return priced, u.pricing.calculate(priced.CartItems), nil
 }
 
 func (u *CartUsecase) GetOrderById(ctx context.Context, order_id, user_id uuid.UUID) (models.Order, error) {
 	return u.restaurantRepo.GetOrderById(ctx, order_id, user_id)
 }
 
-func (u *CartUsecase) UpdateOrderStatus(ctx context.Context, order_id uuid.UUID) error {
-	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+// ConfirmPayment списывает платёж по уведомлению провайдера и только после этого
+// переводит заказ в статус "paid". Повторное уведомление о том же платеже ничего не меняет.
+func (u *CartUsecase) ConfirmPayment(ctx context.Context, orderID uuid.UUID, paymentID string, amount float64) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("paymentID", paymentID))
 
-	status := "paid"
-	err := u.restaurantRepo.UpdateOrderStatus(ctx, order_id, status)
-	if err != nil{
-		logger.Error(err.Error())
+	order, err := u.restaurantRepo.GetOrderForPayment(ctx, orderID)
+	if err != nil {
+		logger.Error("не удалось получить заказ", slog.String("error", err.Error()))
 		return err
 	}
 
-	go u.scheduleDeliveryStatusUpdate(order_id)
+	if order.PaymentID != paymentID {
+		// Доплаты чаевых списываются сразу, уведомления о них заказ не меняют.
+		isTip, err := u.restaurantRepo.IsTipPayment(ctx, paymentID)
+		if err != nil {
+			logger.Error("не удалось проверить платёж", slog.String("error", err.Error()))
+			return err
+		}
+		if isTip {
+			logger.Info("уведомление о доплате чаевых, заказ не меняется")
+			return nil
+		}
+		logger.Warn("заказ привязан к другому платежу", slog.String("attached", order.PaymentID))
+		return cart.ErrPaymentConflict
+	}
+
+	if math.Abs(order.FinalPrice-amount) > priceTolerance {
+		logger.Warn("сумма платежа не совпадает с суммой заказа",
+			slog.Float64("amount", amount), slog.Float64("finalPrice", order.FinalPrice))
+		return fmt.Errorf("%w: ожидалось %.2f", cart.ErrPaymentAmountMismatch, order.FinalPrice)
+	}
+	if order.Status != cart.StatusCreated {
+		// Платёж, который уже был списан, означает повторную доставку того же уведомления.
+		if intent, err := u.payments.GetStatus(ctx, paymentID); err == nil && intent.Status != payment.IntentPending {
+			logger.Info("повторное уведомление об оплате, заказ уже обработан")
+			return nil
+		}
+		logger.Warn("заказ нельзя оплатить в текущем статусе", slog.String("status", order.Status))
+		return fmt.Errorf("%w: %s -> %s", cart.ErrInvalidTransition, order.Status, cart.StatusPaid)
+	}
+
+	intent, err := u.payments.Capture(ctx, paymentID)
+	if err != nil {
+		logger.Error("не удалось списать платёж", slog.String("error", err.Error()))
+		return err
+	}
+	if math.Abs(intent.Amount-order.FinalPrice) > priceTolerance {
+		logger.Error("сумма списания не совпадает с суммой заказа", slog.Float64("captured", intent.Amount))
+		return fmt.Errorf("%w: списано %.2f", cart.ErrPaymentAmountMismatch, intent.Amount)
+	}
+
+	event := newStatusEvent(cart.StatusPaid, cart.ActorSystem, "платёж "+paymentID)
+	err = u.transitOrderStatus(ctx, orderID, order.Status, event)
+	if errors.Is(err, cart.ErrStatusConflict) {
+		status, statusErr := u.restaurantRepo.GetOrderStatus(ctx, orderID)
+		if statusErr == nil && status == cart.StatusCancelled {
+			// Заказ отменили, пока шло списание: деньги нужно вернуть.
+			logger.Warn("заказ отменён во время списания, возвращаем платёж")
+			if err := u.refundOrder(ctx, orderID, paymentID); err != nil {
+				logger.Error("не удалось вернуть платёж", slog.String("error", err.Error()))
+				return err
+			}
+			return fmt.Errorf("%w: заказ отменён", cart.ErrInvalidTransition)
+		}
+		// Параллельное уведомление о том же платеже уже перевело заказ в "paid".
+		if statusErr == nil && status != cart.StatusCreated {
+			logger.Info("заказ оплачен параллельным уведомлением")
+			return nil
+		}
+	}
+	if err != nil {
+		logger.Error("не удалось отметить заказ оплаченным", slog.String("error", err.Error()))
+		return err
+	}
 
-	logger.Info("success")
+	logger.Info("оплата заказа подтверждена")
 	return nil
 }
 
-func (u *CartUsecase) scheduleDeliveryStatusUpdate(orderID uuid.UUID) {
-    ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
-    defer cancel()
-	
-	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+// CancelOrder отменяет заказ пользователя до передачи курьеру и возвращает деньги, если он был оплачен.
+// Повторная отмена уже отменённого заказа повторяет возврат, если в прошлый раз он не прошёл.
+func (u *CartUsecase) CancelOrder(ctx context.Context, orderID, userID uuid.UUID, reason string) (models.Order, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()), slog.String("orderID", orderID.String()))
+
+	order, err := u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+	if err != nil {
+		logger.Error("не удалось получить заказ", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	if order.Status != cart.StatusCancelled {
+		if !cart.CanTransition(order.Status, cart.StatusCancelled) {
+			logger.Warn("заказ нельзя отменить в текущем статусе", slog.String("status", order.Status))
+			return models.Order{}, fmt.Errorf("%w: заказ в статусе %s нельзя отменить", cart.ErrInvalidTransition, order.Status)
+		}
+
+		event := newStatusEvent(cart.StatusCancelled, cart.ActorUser, reason)
+		if err := u.transitOrderStatus(ctx, orderID, order.Status, event); err != nil {
+			logger.Error("не удалось отменить заказ", slog.String("error", err.Error()))
+			return models.Order{}, err
+		}
+	}
+
+	if err := u.refundOrder(ctx, orderID, order.PaymentID); err != nil {
+		logger.Error("не удалось вернуть платёж", slog.String("error", err.Error()))
+		return models.Order{}, err
+	}
+
+	logger.Info("заказ отменён")
+	return u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+}
+
+// refundOrder возвращает списанный платёж отменённого заказа и переводит заказ в "refunded".
+func (u *CartUsecase) refundOrder(ctx context.Context, orderID uuid.UUID, paymentID string) error {
+	if paymentID == "" {
+		return nil
+	}
+
+	intent, err := u.payments.GetStatus(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+	if intent.Status != payment.IntentCaptured {
+		return nil
+	}
+
+	if _, err := u.payments.Refund(ctx, paymentID, roundPrice(intent.Amount-intent.RefundedAmount)); err != nil {
+		return err
+	}
 
-    time.Sleep(60 * time.Second)
-
-    err := u.restaurantRepo.UpdateOrderStatus(ctx, orderID, "in_delivery")
-    if err != nil {
-        logger.Error("Failed to update delivery status",
-            slog.String("error", err.Error()))
-        return
-    }
-
-    logger.Info("Order status updated to 'in_delivery'")
-
-	time.Sleep(20 * time.Second)
-    err = u.restaurantRepo.UpdateOrderStatus(ctx, orderID, "delivered")
-    if err != nil {
-        logger.Error("Failed to update to 'delivered'",
-            slog.String("error", err.Error()))
-        return
-    }
-    logger.Info("Order status updated to 'delivered'")
+	event := newStatusEvent(cart.StatusRefunded, cart.ActorSystem, "возврат платежа "+paymentID)
+	return u.transitOrderStatus(ctx, orderID, cart.StatusCancelled, event)
 }
@@ -5,15 +5,22 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart/mocks"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	fakePayment "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment/fake"
 	"github.com/golang/mock/gomock"
 	"github.com/satori/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestUpdateItemQuantity(t *testing.T) {
+	productID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+
 	type args struct {
 		userID       string
 		productID    string
@@ -23,19 +30,20 @@ func TestUpdateItemQuantity(t *testing.T) {
 	tests := []struct {
 		name       string
 		args       args
-		repoMocker func(*mocks.MockCartRepo)
+		repoMocker func(*mocks.MockCartRepo, *mocks.MockRestaurantRepo)
 		wantErr    error
 	}{
 		{
 			name: "Success",
 			args: args{
 				userID:       "user123",
-				productID:    "product456",
-				restaurantID: "restaurant789",
+				productID:    productID.String(),
+				restaurantID: restaurantID.String(),
 				quantity:     3,
 			},
-			repoMocker: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", "product456", "restaurant789", 3).Return(nil).Times(1)
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {
+				catalog.EXPECT().GetProductRestaurant(gomock.Any(), productID).Return(restaurantID, nil).Times(1)
+				repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", productID.String(), restaurantID.String(), 3, false).Return(nil).Times(1)
 			},
 			wantErr: nil,
 		},
@@ -43,15 +51,66 @@ func TestUpdateItemQuantity(t *testing.T) {
 			name: "Update quantity failure",
 			args: args{
 				userID:       "user123",
-				productID:    "product456",
-				restaurantID: "restaurant789",
+				productID:    productID.String(),
+				restaurantID: restaurantID.String(),
 				quantity:     3,
 			},
-			repoMocker: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", "product456", "restaurant789", 3).Return(errors.New("update error")).Times(1)
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {
+				catalog.EXPECT().GetProductRestaurant(gomock.Any(), productID).Return(restaurantID, nil).Times(1)
+				repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", productID.String(), restaurantID.String(), 3, false).Return(errors.New("update error")).Times(1)
 			},
 			wantErr: errors.New("update error"),
 		},
+		{
+			name: "Product does not exist",
+			args: args{
+				userID:       "user123",
+				productID:    productID.String(),
+				restaurantID: restaurantID.String(),
+				quantity:     1,
+			},
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {
+				catalog.EXPECT().GetProductRestaurant(gomock.Any(), productID).Return(uuid.Nil, cart.ErrProductNotFound).Times(1)
+			},
+			wantErr: cart.ErrProductNotFound,
+		},
+		{
+			name: "Product of another restaurant",
+			args: args{
+				userID:       "user123",
+				productID:    productID.String(),
+				restaurantID: restaurantID.String(),
+				quantity:     1,
+			},
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {
+				catalog.EXPECT().GetProductRestaurant(gomock.Any(), productID).Return(uuid.NewV4(), nil).Times(1)
+			},
+			wantErr: cart.ErrUnknownProduct,
+		},
+		{
+			name: "Malformed product id",
+			args: args{
+				userID:       "user123",
+				productID:    "product456",
+				restaurantID: restaurantID.String(),
+				quantity:     1,
+			},
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {},
+			wantErr:    cart.ErrUnknownProduct,
+		},
+		{
+			name: "Removal skips catalog check",
+			args: args{
+				userID:       "user123",
+				productID:    "product456",
+				restaurantID: restaurantID.String(),
+				quantity:     0,
+			},
+			repoMocker: func(repo *mocks.MockCartRepo, catalog *mocks.MockRestaurantRepo) {
+				repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", "product456", restaurantID.String(), 0, false).Return(nil).Times(1)
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -60,19 +119,40 @@ func TestUpdateItemQuantity(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockCartRepo(ctrl)
-			uc := NewCartUsecase(repo, nil)
+			catalog := mocks.NewMockRestaurantRepo(ctrl)
+			uc := NewCartUsecase(repo, catalog, nil, nil)
 
-			tt.repoMocker(repo)
+			tt.repoMocker(repo, catalog)
 
-			err := uc.UpdateItemQuantity(context.Background(), tt.args.userID, tt.args.productID, tt.args.restaurantID, tt.args.quantity)
+			err := uc.UpdateItemQuantity(context.Background(), tt.args.userID, tt.args.productID, tt.args.restaurantID, tt.args.quantity, false)
 
-			if err != nil && err.Error() != tt.wantErr.Error() {
-				t.Errorf("UpdateItemQuantity() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
 }
 
+func TestUpdateItemQuantityCachesCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+
+	repo := mocks.NewMockCartRepo(ctrl)
+	catalog := mocks.NewMockRestaurantRepo(ctrl)
+	catalog.EXPECT().GetProductRestaurant(gomock.Any(), productID).Return(restaurantID, nil).Times(1)
+	repo.EXPECT().UpdateItemQuantity(gomock.Any(), "user123", productID.String(), restaurantID.String(), gomock.Any(), false).Return(nil).Times(2)
+
+	uc := NewCartUsecase(repo, catalog, nil, nil)
+	assert.NoError(t, uc.UpdateItemQuantity(context.Background(), "user123", productID.String(), restaurantID.String(), 1, false))
+	assert.NoError(t, uc.UpdateItemQuantity(context.Background(), "user123", productID.String(), restaurantID.String(), 2, false))
+}
+
 func TestClearCart(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -104,7 +184,7 @@ func TestClearCart(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockCartRepo(ctrl)
-			uc := NewCartUsecase(repo, nil)
+			uc := NewCartUsecase(repo, nil, nil, nil)
 
 			tt.repoMocker(repo)
 
@@ -117,55 +197,201 @@ func TestClearCart(t *testing.T) {
 	}
 }
 
+func TestMergeGuestCart(t *testing.T) {
+	guest := cart.GuestOwner(uuid.NewV4())
+
+	tests := []struct {
+		name       string
+		guestID    string
+		login      string
+		replace    bool
+		repoMocker func(*mocks.MockCartRepo)
+		wantErr    error
+	}{
+		{
+			name:    "Success",
+			guestID: guest,
+			login:   "user123",
+			repoMocker: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().MergeCart(gomock.Any(), guest, "user123", false).Return(nil)
+			},
+		},
+		{
+			name:    "Replace account cart",
+			guestID: guest,
+			login:   "user123",
+			replace: true,
+			repoMocker: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().MergeCart(gomock.Any(), guest, "user123", true).Return(nil)
+			},
+		},
+		{
+			name:    "Other restaurant",
+			guestID: guest,
+			login:   "user123",
+			repoMocker: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().MergeCart(gomock.Any(), guest, "user123", false).Return(cart.ErrRestaurantConflict)
+			},
+			wantErr: cart.ErrRestaurantConflict,
+		},
+		{
+			name:       "Source is not a guest",
+			guestID:    "user456",
+			login:      "user123",
+			repoMocker: func(repo *mocks.MockCartRepo) {},
+			wantErr:    cart.ErrInvalidCartOwner,
+		},
+		{
+			name:       "Target is a guest",
+			guestID:    guest,
+			login:      cart.GuestOwner(uuid.NewV4()),
+			repoMocker: func(repo *mocks.MockCartRepo) {},
+			wantErr:    cart.ErrInvalidCartOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockCartRepo(ctrl)
+			uc := NewCartUsecase(repo, nil, nil, nil)
+
+			tt.repoMocker(repo)
+
+			err := uc.MergeGuestCart(context.Background(), tt.guestID, tt.login, tt.replace)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestCreateOrder(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+
+	clientCart := models.Cart{
+		Id:   restaurantID,
+		Name: "Test Cart",
+		CartItems: []models.CartItem{
+			{Id: productID, Name: "Product 1", Price: 1, Amount: 2},
+		},
+	}
+	pricedCart := models.Cart{
+		Id:   restaurantID,
+		Name: "Test Cart",
+		CartItems: []models.CartItem{
+			{Id: productID, Name: "Product 1", Price: 50.25, Amount: 2},
+		},
+	}
+
 	type args struct {
 		userID string
 		req    models.OrderInReq
 		cart   models.Cart
 	}
+	var unsavedPaymentID string
 	tests := []struct {
-		name       string
-		args       args
-		repoMocker func(*mocks.MockRestaurantRepo)
-		wantErr    error
+		name                string
+		args                args
+		repoMocker          func(*mocks.MockRestaurantRepo)
+		wantPrice           float64
+		wantErr             error
+		wantCancelledIntent bool
 	}{
 		{
 			name: "Success",
 			args: args{
 				userID: "user123",
 				req: models.OrderInReq{
-					Status:     "new",
 					Address:    "123 Street",
 					FinalPrice: 100.50,
 				},
-				cart: models.Cart{
-					Id:   uuid.NewV4(),
-					Name: "Test Cart",
-				},
+				cart: clientCart,
 			},
 			repoMocker: func(repo *mocks.MockRestaurantRepo) {
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").Return(nil).Times(1)
+				repo.EXPECT().GetCartItem(gomock.Any(), []string{productID.String()},
+					map[string]int{productID.String(): 2}, restaurantID.String()).Return(pricedCart, nil).Times(1)
+				repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{}, nil)
+				repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).
+					Return(models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 30, To: 40}}, nil)
+				repo.EXPECT().
+					Save(gomock.Any(), gomock.Any(), "user123").
+					DoAndReturn(func(_ context.Context, order models.Order, _ string) error {
+						assert.NotEmpty(t, order.PaymentID)
+						if assert.NotNil(t, order.ETA) {
+							assert.Equal(t, order.CreatedAt.Add(35*time.Minute), *order.ETA)
+							assert.Equal(t, order.ETA, order.Timeline[0].ETA)
+						}
+						return nil
+					}).
+					Times(1)
 			},
-			wantErr: nil,
+			wantPrice: 100.50,
+			wantErr:   nil,
 		},
 		{
 			name: "Save order failure",
 			args: args{
 				userID: "user123",
 				req: models.OrderInReq{
-					Status:     "new",
 					Address:    "123 Street",
 					FinalPrice: 100.50,
 				},
-				cart: models.Cart{
-					Id:   uuid.NewV4(),
-					Name: "Test Cart",
+				cart: clientCart,
+			},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil).Times(1)
+				repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{}, nil)
+				repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).Return(models.DeliveryEstimate{}, nil)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").
+					DoAndReturn(func(_ context.Context, order models.Order, _ string) error {
+						unsavedPaymentID = order.PaymentID
+						return errors.New("save error")
+					}).Times(1)
+			},
+			wantErr:             errors.New("save error"),
+			wantCancelledIntent: true,
+		},
+		{
+			name: "Client total mismatch",
+			args: args{
+				userID: "user123",
+				req: models.OrderInReq{
+					Address:    "123 Street",
+					FinalPrice: 2,
 				},
+				cart: clientCart,
+			},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil).Times(1)
+				repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).Return(models.DeliveryEstimate{}, nil)
+				repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{}, nil)
+			},
+			wantErr: cart.ErrPriceMismatch,
+		},
+		{
+			name: "Product missing from menu",
+			args: args{
+				userID: "user123",
+				req:    models.OrderInReq{FinalPrice: 100.50},
+				cart:   clientCart,
 			},
 			repoMocker: func(repo *mocks.MockRestaurantRepo) {
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").Return(errors.New("save error")).Times(1)
+				repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.Cart{Id: restaurantID}, nil).Times(1)
+			},
+			wantErr: cart.ErrUnknownProduct,
+		},
+		{
+			name: "Empty cart",
+			args: args{
+				userID: "user123",
+				req:    models.OrderInReq{FinalPrice: 100.50},
+				cart:   models.Cart{Id: restaurantID},
 			},
-			wantErr: errors.New("save error"),
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {},
+			wantErr:    cart.ErrEmptyCart,
 		},
 	}
 
@@ -175,156 +401,77 @@ func TestCreateOrder(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockRestaurantRepo(ctrl)
-			uc := NewCartUsecase(nil, repo)
+			payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+			uc := NewCartUsecase(nil, repo, nil, payments)
 
 			tt.repoMocker(repo)
 
-			_, err := uc.CreateOrder(context.Background(), tt.args.userID, tt.args.req, tt.args.cart)
+			order, err := uc.CreateOrder(context.Background(), tt.args.userID, tt.args.req, tt.args.cart)
 
-			if err != nil && err.Error() != tt.wantErr.Error() {
-				t.Errorf("CreateOrder() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+				if tt.wantCancelledIntent {
+					intent, err := payments.GetStatus(context.Background(), unsavedPaymentID)
+					assert.NoError(t, err)
+					assert.Equal(t, payment.IntentCancelled, intent.Status, "платёж несохранённого заказа отменяется")
+				}
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPrice, order.FinalPrice)
+			assert.Equal(t, tt.wantPrice, order.PriceBreakdown.Subtotal)
+			assert.Equal(t, pricedCart, order.OrderProducts)
+			assert.NotEmpty(t, order.PaymentURL)
+
+			intent, err := payments.GetStatus(context.Background(), order.PaymentID)
+			assert.NoError(t, err)
+			assert.Equal(t, payment.IntentPending, intent.Status)
+			assert.Equal(t, order.FinalPrice, intent.Amount)
 		})
 	}
 }
 
-func TestGetCart(t *testing.T) {
-	restaurantId := uuid.NewV4()
-	product1Id := uuid.NewV4()
-	product2Id := uuid.NewV4()
-	type args struct {
-		login string
-	}
+func TestCreateOrderWorkingHours(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	clientCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Amount: 1}}}
+	pricedCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Price: 500, Amount: 1}}}
+	msk := time.FixedZone("MSK", 3*60*60)
+
 	tests := []struct {
-		name               string
-		args               args
-		cartRepoMock       func(*mocks.MockCartRepo)
-		restaurantRepoMock func(*mocks.MockRestaurantRepo)
-		want               models.Cart
-		wantErr            error
-		wantBool           bool
+		name    string
+		mode    models.WorkingMode
+		now     time.Time
+		wantErr error
 	}{
 		{
-			name: "Success",
-			args: args{login: "user123"},
-			cartRepoMock: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().
-					GetCart(gomock.Any(), "user123").
-					Return(
-						map[string]int{"product1": 2, "product2": 1},
-						"restaurant123",
-						nil,
-					).Times(1)
-			},
-			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {
-				repo.EXPECT().
-					GetCartItem(
-						gomock.Any(),
-						[]string{"product1", "product2"},
-						map[string]int{"product1": 2, "product2": 1},
-						"restaurant123",
-					).
-					Return(models.Cart{
-						Id:   restaurantId,
-						Name: "Test Restaurant",
-						CartItems: []models.CartItem{
-							{
-								Id:       product1Id,
-								Name:     "Product 1",
-								Price:    100,
-								ImageURL: "image1.jpg",
-								Weight:   200,
-								Amount:   2,
-							},
-							{
-								Id:       product2Id,
-								Name:     "Product 2",
-								Price:    200,
-								ImageURL: "image2.jpg",
-								Weight:   300,
-								Amount:   1,
-							},
-						},
-					}, nil).Times(1)
-			},
-			want: models.Cart{
-				Id:   restaurantId,
-				Name: "Test Restaurant",
-				CartItems: []models.CartItem{
-					{
-						Id:       product1Id,
-						Name:     "Product 1",
-						Price:    100,
-						ImageURL: "image1.jpg",
-						Weight:   200,
-						Amount:   2,
-					},
-					{
-						Id:       product2Id,
-						Name:     "Product 2",
-						Price:    200,
-						ImageURL: "image2.jpg",
-						Weight:   300,
-						Amount:   1,
-					},
-				},
-			},
-			wantErr:  nil,
-			wantBool: true,
+			name: "Open after midnight",
+			mode: models.WorkingMode{From: 6, To: 3},
+			now:  time.Date(2025, 5, 10, 2, 30, 0, 0, msk),
 		},
 		{
-			name: "Empty cart",
-			args: args{login: "user123"},
-			cartRepoMock: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().
-					GetCart(gomock.Any(), "user123").
-					Return(nil, "", nil).
-					Times(1)
-			},
-			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {},
-			want:               models.Cart{},
-			wantErr:            nil,
-			wantBool:           false,
+			name:    "Closed before opening",
+			mode:    models.WorkingMode{From: 6, To: 3},
+			now:     time.Date(2025, 5, 10, 4, 0, 0, 0, msk),
+			wantErr: cart.ErrRestaurantClosed,
 		},
 		{
-			name: "Get cart error",
-			args: args{login: "user123"},
-			cartRepoMock: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().
-					GetCart(gomock.Any(), "user123").
-					Return(nil, "", errors.New("cart error")).
-					Times(1)
-			},
-			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {},
-			want:               models.Cart{},
-			wantErr:            errors.New("cart error"),
-			wantBool:           false,
+			name:    "Closed at midnight",
+			mode:    models.WorkingMode{From: 11, To: 0},
+			now:     time.Date(2025, 5, 10, 0, 0, 0, 0, msk),
+			wantErr: cart.ErrRestaurantClosed,
 		},
 		{
-			name: "Get cart items error",
-			args: args{login: "user123"},
-			cartRepoMock: func(repo *mocks.MockCartRepo) {
-				repo.EXPECT().
-					GetCart(gomock.Any(), "user123").
-					Return(
-						map[string]int{"product1": 2},
-						"restaurant123",
-						nil,
-					).Times(1)
-			},
-			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {
-				repo.EXPECT().
-					GetCartItem(
-						gomock.Any(),
-						[]string{"product1"},
-						map[string]int{"product1": 2},
-						"restaurant123",
-					).
-					Return(models.Cart{}, errors.New("items error")).Times(1)
-			},
-			want:     models.Cart{},
-			wantErr:  errors.New("items error"),
-			wantBool: false,
+			name:    "Closes before delivery",
+			mode:    models.WorkingMode{From: 11, To: 23},
+			now:     time.Date(2025, 5, 10, 22, 50, 0, 0, msk),
+			wantErr: cart.ErrOutsideDeliveryWindow,
+		},
+		{
+			name: "Delivered right before closing",
+			mode: models.WorkingMode{From: 11, To: 23},
+			now:  time.Date(2025, 5, 10, 22, 40, 0, 0, msk),
 		},
 	}
 
@@ -333,60 +480,1339 @@ func TestGetCart(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			cartRepo := mocks.NewMockCartRepo(ctrl)
-			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
-			uc := NewCartUsecase(cartRepo, restaurantRepo)
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil)
+			repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).
+				Return(models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 10, To: 20}}, nil)
+			repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(tt.mode, nil)
+			if tt.wantErr == nil {
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").Return(nil)
+			}
 
-			tt.cartRepoMock(cartRepo)
-			tt.restaurantRepoMock(restaurantRepo)
+			uc := NewCartUsecase(nil, repo, nil, fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore()))
+			uc.location = msk
+			uc.now = func() time.Time { return tt.now }
+
+			order, err := uc.CreateOrder(context.Background(), "user123", models.OrderInReq{FinalPrice: 500}, clientCart)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.now, order.CreatedAt)
+		})
+	}
+}
+
+func TestCreateOrderScheduled(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	clientCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Amount: 1}}}
+	pricedCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Price: 500, Amount: 1}}}
+	msk := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2025, 5, 10, 4, 0, 0, 0, msk)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		deliverAt *time.Time
+		wantErr   error
+	}{
+		{
+			name:      "Dinner ordered while restaurant is closed",
+			deliverAt: at(time.Date(2025, 5, 10, 19, 0, 0, 0, msk)),
+		},
+		{
+			name:      "Too soon",
+			deliverAt: at(now.Add(20 * time.Minute)),
+			wantErr:   cart.ErrInvalidDeliverAt,
+		},
+		{
+			name:      "Restaurant cannot deliver that soon",
+			deliverAt: at(now.Add(40 * time.Minute)),
+			wantErr:   cart.ErrInvalidDeliverAt,
+		},
+		{
+			name:      "Too far ahead",
+			deliverAt: at(now.Add(8 * 24 * time.Hour)),
+			wantErr:   cart.ErrInvalidDeliverAt,
+		},
+		{
+			name:      "Outside working hours",
+			deliverAt: at(time.Date(2025, 5, 10, 23, 30, 0, 0, msk)),
+			wantErr:   cart.ErrInvalidDeliverAt,
+		},
+		{
+			name:      "Before the first delivery window",
+			deliverAt: at(time.Date(2025, 5, 10, 11, 30, 0, 0, msk)),
+			wantErr:   cart.ErrInvalidDeliverAt,
+		},
+		{
+			name:      "First delivery window",
+			deliverAt: at(time.Date(2025, 5, 10, 12, 0, 0, 0, msk)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil)
+			repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).
+				Return(models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 40, To: 60}}, nil).MaxTimes(1)
+			repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{From: 11, To: 23}, nil).MaxTimes(1)
+			if tt.wantErr == nil {
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").Return(nil)
+			}
+
+			uc := NewCartUsecase(nil, repo, nil, fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore()))
+			uc.location = msk
+			uc.minLeadTime = 30 * time.Minute
+			uc.now = func() time.Time { return now }
+
+			order, err := uc.CreateOrder(context.Background(), "user123",
+				models.OrderInReq{FinalPrice: 500, DeliverAt: tt.deliverAt}, clientCart)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.deliverAt, order.DeliverAt)
+			assert.Equal(t, tt.deliverAt, order.ETA)
+			assert.Equal(t, cart.StatusCreated, order.Status)
+		})
+	}
+}
+
+func TestNextTransition(t *testing.T) {
+	orderID := uuid.NewV4()
+	delivery := models.DeliveryTime{From: 30, To: 45}
+	later := time.Now().Add(3 * time.Hour)
+	soon := time.Now().Add(deliveryWindow(delivery) / 2)
+
+	tests := []struct {
+		name          string
+		status        string
+		checksDeliver bool
+		deliverAt     *time.Time
+		wantOK        bool
+		wantTo        string
+		wantRunAt     time.Time
+	}{
+		{name: "Paid waits for the restaurant", status: cart.StatusPaid},
+		{name: "Accepted without schedule", status: cart.StatusAccepted, checksDeliver: true},
+		{name: "Accepted for later", status: cart.StatusAccepted, checksDeliver: true, deliverAt: &later,
+			wantOK: true, wantTo: cart.StatusScheduled, wantRunAt: time.Now()},
+		{name: "Accepted too late to wait", status: cart.StatusAccepted, checksDeliver: true, deliverAt: &soon},
+		{name: "Ready for pickup waits for a courier", status: cart.StatusReadyForPickup},
+		{name: "In delivery waits for the courier", status: cart.StatusInDelivery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			if tt.checksDeliver {
+				repo.EXPECT().GetOrderEstimate(gomock.Any(), orderID).
+					Return(models.DeliveryEstimate{DeliveryTime: delivery, DeliverAt: tt.deliverAt}, nil)
+			}
+			uc := &CartUsecase{restaurantRepo: repo}
+
+			transition, ok, err := uc.nextTransition(context.Background(), orderID, tt.status)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			assert.Equal(t, tt.status, transition.FromStatus)
+			assert.Equal(t, tt.wantTo, transition.ToStatus)
+			assert.WithinDuration(t, tt.wantRunAt, transition.RunAt, time.Second)
+		})
+	}
+}
+
+func TestReorder(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	keptID, repricedID, droppedID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	pastOrder := models.Order{
+		ID: orderID,
+		OrderProducts: models.Cart{
+			Id: restaurantID,
+			CartItems: []models.CartItem{
+				{Id: keptID, Name: "Хачапури", Price: 450, Amount: 1},
+				{Id: repricedID, Name: "Хинкали", Price: 90, Amount: 5},
+				{Id: droppedID, Name: "Лобио", Price: 300, Amount: 2},
+			},
+		},
+	}
+	menu := models.Cart{
+		Id: restaurantID,
+		CartItems: []models.CartItem{
+			{Id: keptID, Name: "Хачапури", Price: 450, Amount: 1},
+			{Id: repricedID, Name: "Хинкали", Price: 110, Amount: 5},
+		},
+	}
+	wantQuantities := map[string]int{keptID.String(): 1, repricedID.String(): 5}
+
+	tests := []struct {
+		name         string
+		replace      bool
+		mockSetup    func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo)
+		wantDropped  int
+		wantRepriced int
+		wantErr      error
+	}{
+		{
+			name: "Dropped and repriced items",
+			mockSetup: func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(pastOrder, nil)
+				restaurantRepo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), restaurantID.String()).Return(menu, nil)
+				cartRepo.EXPECT().FillCart(gomock.Any(), "user123", restaurantID.String(), wantQuantities, false).Return(nil)
+				cartRepo.EXPECT().GetCart(gomock.Any(), "user123").Return(wantQuantities, restaurantID.String(), nil)
+				restaurantRepo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), restaurantID.String()).Return(menu, nil)
+			},
+			wantDropped:  1,
+			wantRepriced: 1,
+		},
+		{
+			name: "Cart of another restaurant",
+			mockSetup: func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(pastOrder, nil)
+				restaurantRepo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(menu, nil)
+				cartRepo.EXPECT().FillCart(gomock.Any(), "user123", restaurantID.String(), wantQuantities, false).
+					Return(cart.ErrRestaurantConflict)
+			},
+			wantErr: cart.ErrRestaurantConflict,
+		},
+		{
+			name: "Nothing left in the menu",
+			mockSetup: func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(pastOrder, nil)
+				restaurantRepo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.Cart{Id: restaurantID}, nil)
+			},
+			wantDropped: 3,
+		},
+		{
+			name: "Restaurant was deleted",
+			mockSetup: func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(pastOrder, nil)
+				restaurantRepo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.Cart{}, cart.ErrRestaurantNotFound)
+			},
+			wantDropped: 3,
+		},
+		{
+			name: "Order not found",
+			mockSetup: func(cartRepo *mocks.MockCartRepo, restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{}, cart.ErrOrderNotFound)
+			},
+			wantErr: cart.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cartRepo := mocks.NewMockCartRepo(ctrl)
+			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+			tt.mockSetup(cartRepo, restaurantRepo)
+			uc := NewCartUsecase(cartRepo, restaurantRepo, nil, nil)
+
+			result, err := uc.Reorder(context.Background(), orderID, userID, "user123", tt.replace)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, result.Dropped, tt.wantDropped)
+			assert.Len(t, result.Repriced, tt.wantRepriced)
+			if tt.wantRepriced > 0 {
+				assert.Equal(t, 90.0, result.Repriced[0].OldPrice)
+				assert.Equal(t, 110.0, result.Repriced[0].NewPrice)
+			}
+		})
+	}
+}
+
+func TestGetOrders(t *testing.T) {
+	userID := uuid.NewV4()
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	orders := []models.Order{
+		{ID: uuid.NewV4(), CreatedAt: now},
+		{ID: uuid.NewV4(), CreatedAt: now.Add(-time.Hour)},
+		{ID: uuid.NewV4(), CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	cursor := cart.EncodeOrderCursor(models.OrderCursor{CreatedAt: orders[1].CreatedAt, ID: orders[1].ID}, models.OrderFilter{})
+
+	tests := []struct {
+		name       string
+		filter     models.OrderFilter
+		cursor     string
+		count      int
+		mockSetup  func(restaurantRepo *mocks.MockRestaurantRepo)
+		wantOrders int
+		wantCursor string
+		wantErr    error
+	}{
+		{
+			name:  "First page has next cursor",
+			count: 2,
+			mockSetup: func(restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrders(gomock.Any(), userID, models.OrderFilter{}, 3).Return(orders, nil)
+			},
+			wantOrders: 2,
+			wantCursor: cursor,
+		},
+		{
+			name:   "Last page",
+			cursor: cursor,
+			count:  2,
+			mockSetup: func(restaurantRepo *mocks.MockRestaurantRepo) {
+				after := models.OrderCursor{CreatedAt: orders[1].CreatedAt, ID: orders[1].ID}
+				restaurantRepo.EXPECT().GetOrders(gomock.Any(), userID, models.OrderFilter{After: &after}, 3).
+					Return(orders[2:], nil)
+			},
+			wantOrders: 1,
+		},
+		{
+			name: "Default page size",
+			mockSetup: func(restaurantRepo *mocks.MockRestaurantRepo) {
+				restaurantRepo.EXPECT().GetOrders(gomock.Any(), userID, models.OrderFilter{}, defaultOrdersPageSize+1).
+					Return(nil, nil)
+			},
+		},
+		{
+			name:    "Unknown status",
+			filter:  models.OrderFilter{Statuses: []string{"lost"}},
+			wantErr: cart.ErrInvalidOrderFilter,
+		},
+		{
+			name:    "Min total above max total",
+			filter:  models.OrderFilter{MinTotal: 1000, MaxTotal: 500},
+			wantErr: cart.ErrInvalidOrderFilter,
+		},
+		{
+			name:    "Broken cursor",
+			cursor:  "not-a-cursor",
+			wantErr: cart.ErrInvalidOrderCursor,
+		},
+		{
+			name:    "Cursor issued for another sort",
+			filter:  models.OrderFilter{OldestFirst: true},
+			cursor:  cursor,
+			wantErr: cart.ErrInvalidOrderCursor,
+		},
+		{
+			name:    "Cursor issued for other filters",
+			filter:  models.OrderFilter{Statuses: []string{cart.StatusDelivered}},
+			cursor:  cursor,
+			wantErr: cart.ErrInvalidOrderCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(restaurantRepo)
+			}
+			uc := NewCartUsecase(nil, restaurantRepo, nil, nil)
+
+			page, err := uc.GetOrders(context.Background(), userID, tt.filter, tt.cursor, tt.count)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, page.Orders, tt.wantOrders)
+			assert.Equal(t, tt.wantCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestPricingCalculate(t *testing.T) {
+	items := []models.CartItem{
+		{Price: 100, Amount: 3},
+		{Price: 49.99, Amount: 1},
+	}
+
+	tests := []struct {
+		name   string
+		config pricingConfig
+		want   models.PriceBreakdown
+	}{
+		{
+			name:   "No fees",
+			config: pricingConfig{},
+			want:   models.PriceBreakdown{Subtotal: 349.99, Total: 349.99},
+		},
+		{
+			name:   "Delivery and service fee",
+			config: pricingConfig{deliveryFee: 99, serviceFeePercent: 5},
+			want:   models.PriceBreakdown{Subtotal: 349.99, DeliveryFee: 99, ServiceFee: 17.5, Total: 466.49},
+		},
+		{
+			name:   "Free delivery threshold reached",
+			config: pricingConfig{deliveryFee: 99, freeDeliveryFrom: 300},
+			want:   models.PriceBreakdown{Subtotal: 349.99, Total: 349.99},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.calculate(items))
+		})
+	}
+}
+
+func TestApplyDiscount(t *testing.T) {
+	base := models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, ServiceFee: 50, Total: 1200}
+
+	tests := []struct {
+		name  string
+		promo models.PromoCode
+		want  models.PriceBreakdown
+	}{
+		{
+			name:  "Percent",
+			promo: models.PromoCode{Type: cart.PromoPercent, Value: 15},
+			want:  models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, ServiceFee: 50, Discount: 150, Total: 1050},
+		},
+		{
+			name:  "Fixed",
+			promo: models.PromoCode{Type: cart.PromoFixed, Value: 300},
+			want:  models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, ServiceFee: 50, Discount: 300, Total: 900},
+		},
+		{
+			name:  "Fixed larger than subtotal",
+			promo: models.PromoCode{Type: cart.PromoFixed, Value: 5000},
+			want:  models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, ServiceFee: 50, Discount: 1000, Total: 200},
+		},
+		{
+			name:  "Free delivery",
+			promo: models.PromoCode{Type: cart.PromoFreeDelivery},
+			want:  models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, ServiceFee: 50, Discount: 150, Total: 1050},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, applyDiscount(base, tt.promo))
+		})
+	}
+}
+
+func TestApplyTip(t *testing.T) {
+	base := models.PriceBreakdown{Subtotal: 1000, DeliveryFee: 150, Discount: 100, Total: 1050}
+
+	assert.Equal(t, 120.0, tipFor(base.Subtotal, 120, 0))
+	assert.Equal(t, 100.0, tipFor(base.Subtotal, 0, 10))
+	assert.Equal(t, 0.0, tipFor(base.Subtotal, 0, 0))
+
+	withTip := applyTip(base, tipFor(base.Subtotal, 0, 10))
+	assert.Equal(t, 100.0, withTip.Tip)
+	assert.Equal(t, 1150.0, withTip.Total)
+	assert.Equal(t, 100.0, withTip.Discount)
+}
+
+func TestPreviewPromo(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	promoID := uuid.NewV4()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	clientCart := models.Cart{
+		Id:        restaurantID,
+		CartItems: []models.CartItem{{Id: productID, Amount: 2}},
+	}
+	pricedCart := models.Cart{
+		Id:        restaurantID,
+		Name:      "Test Cart",
+		CartItems: []models.CartItem{{Id: productID, Name: "Product 1", Price: 500, Amount: 2}},
+	}
+	percent := models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20}
+
+	tests := []struct {
+		name      string
+		promo     models.PromoCode
+		promoErr  error
+		usage     *models.PromoUsage
+		wantTotal float64
+		wantErr   error
+	}{
+		{
+			name:      "Applied",
+			promo:     percent,
+			usage:     &models.PromoUsage{},
+			wantTotal: 800,
+		},
+		{
+			name:     "Unknown code",
+			promoErr: cart.ErrPromoNotFound,
+			wantErr:  cart.ErrPromoNotFound,
+		},
+		{
+			name:    "Not started",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, StartsAt: &future},
+			wantErr: cart.ErrPromoInactive,
+		},
+		{
+			name:    "Expired",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, EndsAt: &past},
+			wantErr: cart.ErrPromoInactive,
+		},
+		{
+			name:    "Other restaurant",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, RestaurantID: uuid.NewV4()},
+			wantErr: cart.ErrPromoNotApplicable,
+		},
+		{
+			name:    "Restaurant without tag",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, TagID: uuid.NewV4()},
+			wantErr: cart.ErrPromoNotApplicable,
+		},
+		{
+			name:    "Subtotal too small",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, MinSubtotal: 1500},
+			wantErr: cart.ErrPromoMinSubtotal,
+		},
+		{
+			name:    "Global cap reached",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, MaxUses: 100},
+			usage:   &models.PromoUsage{Total: 100},
+			wantErr: cart.ErrPromoExhausted,
+		},
+		{
+			name:    "Per-user cap reached",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, MaxUsesPerUser: 1},
+			usage:   &models.PromoUsage{Total: 5, ByUser: 1},
+			wantErr: cart.ErrPromoExhausted,
+		},
+		{
+			name:    "Not the first order",
+			promo:   models.PromoCode{ID: promoID, Code: "WELCOME", Type: cart.PromoPercent, Value: 20, FirstOrderOnly: true},
+			usage:   &models.PromoUsage{UserOrders: 2},
+			wantErr: cart.ErrPromoFirstOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), restaurantID.String()).Return(pricedCart, nil)
+			repo.EXPECT().GetPromoCode(gomock.Any(), "WELCOME", restaurantID).Return(tt.promo, tt.promoErr)
+			if tt.usage != nil {
+				repo.EXPECT().GetPromoUsage(gomock.Any(), promoID, "user123").Return(*tt.usage, nil)
+			}
+
+			uc := NewCartUsecase(nil, repo, nil, nil)
+			preview, err := uc.PreviewPromo(context.Background(), "user123", " welcome ", clientCart)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "WELCOME", preview.PromoCode)
+			assert.Equal(t, pricedCart, preview.Cart)
+			assert.Equal(t, tt.wantTotal, preview.PriceBreakdown.Total)
+		})
+	}
+}
+
+func TestCreateOrderWithPromo(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	promo := models.PromoCode{ID: uuid.NewV4(), Code: "MINUS300", Type: cart.PromoFixed, Value: 300}
+	clientCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Amount: 2}}}
+	pricedCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Price: 500, Amount: 2}}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil).Times(2)
+	repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{}, nil).Times(2)
+	repo.EXPECT().GetPromoCode(gomock.Any(), "MINUS300", restaurantID).Return(promo, nil).Times(2)
+	repo.EXPECT().GetPromoUsage(gomock.Any(), promo.ID, "user123").Return(models.PromoUsage{}, nil).Times(2)
+	repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).Return(models.DeliveryEstimate{}, nil).Times(2)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").
+		DoAndReturn(func(_ context.Context, order models.Order, _ string) error {
+			assert.Equal(t, promo.ID, order.PromoCodeID)
+			assert.Equal(t, "MINUS300", order.PromoCode)
+			return nil
+		})
+
+	uc := NewCartUsecase(nil, repo, nil, fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore()))
+
+	_, err := uc.CreateOrder(context.Background(), "user123", models.OrderInReq{FinalPrice: 1000, PromoCode: "MINUS300"}, clientCart)
+	assert.ErrorIs(t, err, cart.ErrPriceMismatch)
+
+	order, err := uc.CreateOrder(context.Background(), "user123", models.OrderInReq{FinalPrice: 700, PromoCode: "MINUS300"}, clientCart)
+	assert.NoError(t, err)
+	assert.Equal(t, 700.0, order.FinalPrice)
+	assert.Equal(t, 300.0, order.PriceBreakdown.Discount)
+}
+
+func TestCreateOrderWithTip(t *testing.T) {
+	restaurantID := uuid.NewV4()
+	productID := uuid.NewV4()
+	clientCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Amount: 2}}}
+	pricedCart := models.Cart{Id: restaurantID, CartItems: []models.CartItem{{Id: productID, Price: 500, Amount: 2}}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetCartItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(pricedCart, nil)
+	repo.EXPECT().GetWorkingMode(gomock.Any(), restaurantID).Return(models.WorkingMode{}, nil)
+	repo.EXPECT().GetDeliveryEstimate(gomock.Any(), restaurantID).Return(models.DeliveryEstimate{}, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any(), "user123").Return(nil)
+
+	payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+	uc := NewCartUsecase(nil, repo, nil, payments)
+
+	order, err := uc.CreateOrder(context.Background(), "user123", models.OrderInReq{FinalPrice: 1100, TipPercent: 10}, clientCart)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, order.PriceBreakdown.Tip)
+	assert.Equal(t, 1100.0, order.FinalPrice)
+
+	intent, err := payments.GetStatus(context.Background(), order.PaymentID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1100.0, intent.Amount)
+}
+
+func TestGetCart(t *testing.T) {
+	restaurantId := uuid.NewV4()
+	product1Id := uuid.NewV4()
+	product2Id := uuid.NewV4()
+	type args struct {
+		login string
+	}
+	tests := []struct {
+		name               string
+		args               args
+		cartRepoMock       func(*mocks.MockCartRepo)
+		restaurantRepoMock func(*mocks.MockRestaurantRepo)
+		want               models.Cart
+		wantErr            error
+		wantBool           bool
+	}{
+		{
+			name: "Success",
+			args: args{login: "user123"},
+			cartRepoMock: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().
+					GetCart(gomock.Any(), "user123").
+					Return(
+						map[string]int{"product1": 2, "product2": 1},
+						"restaurant123",
+						nil,
+					).Times(1)
+			},
+			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().
+					GetCartItem(
+						gomock.Any(),
+						[]string{"product1", "product2"},
+						map[string]int{"product1": 2, "product2": 1},
+						"restaurant123",
+					).
+					Return(models.Cart{
+						Id:   restaurantId,
+						Name: "Test Restaurant",
+						CartItems: []models.CartItem{
+							{
+								Id:       product1Id,
+								Name:     "Product 1",
+								Price:    100,
+								ImageURL: "image1.jpg",
+								Weight:   200,
+								Amount:   2,
+							},
+							{
+								Id:       product2Id,
+								Name:     "Product 2",
+								Price:    200,
+								ImageURL: "image2.jpg",
+								Weight:   300,
+								Amount:   1,
+							},
+						},
+					}, nil).Times(1)
+			},
+			want: models.Cart{
+				Id:   restaurantId,
+				Name: "Test Restaurant",
+				CartItems: []models.CartItem{
+					{
+						Id:       product1Id,
+						Name:     "Product 1",
+						Price:    100,
+						ImageURL: "image1.jpg",
+						Weight:   200,
+						Amount:   2,
+					},
+					{
+						Id:       product2Id,
+						Name:     "Product 2",
+						Price:    200,
+						ImageURL: "image2.jpg",
+						Weight:   300,
+						Amount:   1,
+					},
+				},
+			},
+			wantErr:  nil,
+			wantBool: true,
+		},
+		{
+			name: "Empty cart",
+			args: args{login: "user123"},
+			cartRepoMock: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().
+					GetCart(gomock.Any(), "user123").
+					Return(nil, "", nil).
+					Times(1)
+			},
+			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {},
+			want:               models.Cart{},
+			wantErr:            nil,
+			wantBool:           false,
+		},
+		{
+			name: "Get cart error",
+			args: args{login: "user123"},
+			cartRepoMock: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().
+					GetCart(gomock.Any(), "user123").
+					Return(nil, "", errors.New("cart error")).
+					Times(1)
+			},
+			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {},
+			want:               models.Cart{},
+			wantErr:            errors.New("cart error"),
+			wantBool:           false,
+		},
+		{
+			name: "Get cart items error",
+			args: args{login: "user123"},
+			cartRepoMock: func(repo *mocks.MockCartRepo) {
+				repo.EXPECT().
+					GetCart(gomock.Any(), "user123").
+					Return(
+						map[string]int{"product1": 2},
+						"restaurant123",
+						nil,
+					).Times(1)
+			},
+			restaurantRepoMock: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().
+					GetCartItem(
+						gomock.Any(),
+						[]string{"product1"},
+						map[string]int{"product1": 2},
+						"restaurant123",
+					).
+					Return(models.Cart{}, errors.New("items error")).Times(1)
+			},
+			want:     models.Cart{},
+			wantErr:  errors.New("items error"),
+			wantBool: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cartRepo := mocks.NewMockCartRepo(ctrl)
+			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+			uc := NewCartUsecase(cartRepo, restaurantRepo, nil, nil)
+
+			tt.cartRepoMock(cartRepo)
+			tt.restaurantRepoMock(restaurantRepo)
 
 			got, err, ok := uc.GetCart(context.Background(), tt.args.login)
 
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetCart() got = %v, want %v", got, tt.want)
-			}
-			if (err != nil && tt.wantErr == nil) ||
-				(err == nil && tt.wantErr != nil) ||
-				(err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error()) {
-				t.Errorf("GetCart() error = %v, wantErr %v", err, tt.wantErr)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCart() got = %v, want %v", got, tt.want)
+			}
+			if (err != nil && tt.wantErr == nil) ||
+				(err == nil && tt.wantErr != nil) ||
+				(err != nil && tt.wantErr != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("GetCart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantBool {
+				t.Errorf("GetCart() ok = %v, want %v", ok, tt.wantBool)
+			}
+		})
+	}
+}
+
+func TestConfirmPayment(t *testing.T) {
+	testOrderID := uuid.NewV4()
+
+	tests := []struct {
+		name          string
+		status        string
+		captured      bool
+		otherPayment  bool
+		amount        float64
+		repoMocker    func(*mocks.MockRestaurantRepo)
+		wantIntent    string
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			status: cart.StatusCreated,
+			amount: 500,
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), testOrderID, cart.StatusCreated, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+						assert.Equal(t, cart.StatusPaid, event.Status)
+						assert.Equal(t, cart.ActorSystem, event.Actor)
+						return nil
+					}).
+					Times(1)
+			},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: nil,
+		},
+		{
+			name:          "Replay of the same payment",
+			status:        cart.StatusCooking,
+			captured:      true,
+			amount:        500,
+			repoMocker:    func(repo *mocks.MockRestaurantRepo) {},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: nil,
+		},
+		{
+			name:   "Concurrent delivery of the same payment",
+			status: cart.StatusCreated,
+			amount: 500,
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), testOrderID, cart.StatusCreated, gomock.Any()).
+					Return(cart.ErrStatusConflict).
+					Times(1)
+				repo.EXPECT().GetOrderStatus(gomock.Any(), testOrderID).Return(cart.StatusPaid, nil).Times(1)
+			},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: nil,
+		},
+		{
+			name:   "Order cancelled while capturing",
+			status: cart.StatusCreated,
+			amount: 500,
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), testOrderID, cart.StatusCreated, gomock.Any()).
+					Return(cart.ErrStatusConflict).
+					Times(1)
+				repo.EXPECT().GetOrderStatus(gomock.Any(), testOrderID).Return(cart.StatusCancelled, nil).Times(1)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), testOrderID, cart.StatusCancelled, gomock.Any()).Return(nil).Times(1)
+			},
+			wantIntent:    payment.IntentRefunded,
+			expectedError: cart.ErrInvalidTransition,
+		},
+		{
+			name:          "Amount mismatch",
+			status:        cart.StatusCreated,
+			amount:        499,
+			repoMocker:    func(repo *mocks.MockRestaurantRepo) {},
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrPaymentAmountMismatch,
+		},
+		{
+			name:         "Payment of another order",
+			status:       cart.StatusCreated,
+			otherPayment: true,
+			amount:       500,
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().IsTipPayment(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrPaymentConflict,
+		},
+		{
+			name:         "Tip payment notification",
+			status:       cart.StatusDelivered,
+			otherPayment: true,
+			amount:       100,
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().IsTipPayment(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantIntent: payment.IntentPending,
+		},
+		{
+			name:          "Cancelled order",
+			status:        cart.StatusCancelled,
+			amount:        500,
+			repoMocker:    func(repo *mocks.MockRestaurantRepo) {},
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+			intent, err := payments.CreateIntent(ctx, testOrderID, 500)
+			assert.NoError(t, err)
+			if tt.captured {
+				_, err = payments.Capture(ctx, intent.ID)
+				assert.NoError(t, err)
+			}
+
+			order := models.Order{ID: testOrderID, Status: tt.status, FinalPrice: 500, PaymentID: intent.ID}
+			if tt.otherPayment {
+				order.PaymentID = "fake_other"
+			}
+
+			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+			restaurantRepo.EXPECT().GetOrderForPayment(gomock.Any(), testOrderID).Return(order, nil).Times(1)
+			restaurantRepo.EXPECT().GetOrderEstimate(gomock.Any(), testOrderID).Return(models.DeliveryEstimate{}, nil).AnyTimes()
+			tt.repoMocker(restaurantRepo)
+
+			uc := &CartUsecase{
+				restaurantRepo: restaurantRepo,
+				payments:       payments,
+			}
+
+			err = uc.ConfirmPayment(ctx, testOrderID, intent.ID, tt.amount)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			intent, err = payments.GetStatus(ctx, intent.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIntent, intent.Status)
+		})
+	}
+}
+
+func TestProcessDueTransitions(t *testing.T) {
+	orderID := uuid.NewV4()
+	jobID := uuid.NewV4()
+	job := models.StatusTransition{
+		ID:         jobID,
+		OrderID:    orderID,
+		FromStatus: cart.StatusInDelivery,
+		ToStatus:   cart.StatusDelivered,
+	}
+
+	tests := []struct {
+		name       string
+		repoMocker func(*mocks.MockRestaurantRepo)
+	}{
+		{
+			name: "Applies due transition",
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().GetDueStatusTransitions(gomock.Any(), statusWorkerBatchSize).
+					Return([]models.StatusTransition{job}, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusInDelivery, gomock.Any()).Return(nil)
+				repo.EXPECT().DeleteStatusTransition(gomock.Any(), jobID).Return(nil)
+			},
+		},
+		{
+			name: "Drops stale transition",
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().GetDueStatusTransitions(gomock.Any(), statusWorkerBatchSize).
+					Return([]models.StatusTransition{job}, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusInDelivery, gomock.Any()).
+					Return(cart.ErrStatusConflict)
+				repo.EXPECT().DeleteStatusTransition(gomock.Any(), jobID).Return(nil)
+			},
+		},
+		{
+			name: "Keeps transition on transient error",
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().GetDueStatusTransitions(gomock.Any(), statusWorkerBatchSize).
+					Return([]models.StatusTransition{job}, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusInDelivery, gomock.Any()).
+					Return(errors.New("connection reset"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+			uc := &CartUsecase{restaurantRepo: restaurantRepo}
+
+			tt.repoMocker(restaurantRepo)
+
+			uc.processDueTransitions(context.Background())
+		})
+	}
+}
+
+func TestWatchOrder(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+
+	t.Run("Streams updates until delivered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+		uc := NewCartUsecase(nil, restaurantRepo, nil, nil)
+
+		restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{
+			ID:     orderID,
+			Status: cart.StatusInDelivery,
+			Timeline: []models.OrderStatusEvent{
+				{Status: cart.StatusCreated, Actor: cart.ActorUser},
+				{Status: cart.StatusInDelivery, Actor: cart.ActorSystem},
+			},
+		}, nil)
+
+		updates, err := uc.WatchOrder(context.Background(), orderID, userID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, cart.StatusInDelivery, (<-updates).Status)
+
+		uc.watchers.publish(orderID, models.OrderStatusEvent{Status: cart.StatusDelivered, Actor: cart.ActorSystem})
+		assert.Equal(t, cart.StatusDelivered, (<-updates).Status)
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+
+	t.Run("Closes on cancellation before payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+		uc := NewCartUsecase(nil, restaurantRepo, nil, nil)
+
+		restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{
+			ID:       orderID,
+			Status:   cart.StatusCreated,
+			Timeline: []models.OrderStatusEvent{{Status: cart.StatusCreated, Actor: cart.ActorUser}},
+		}, nil)
+
+		updates, err := uc.WatchOrder(context.Background(), orderID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, cart.StatusCreated, (<-updates).Status)
+
+		uc.watchers.publish(orderID, models.OrderStatusEvent{Status: cart.StatusCancelled, Actor: cart.ActorUser})
+		assert.Equal(t, cart.StatusCancelled, (<-updates).Status)
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+
+	t.Run("Waits for refund after cancelling a paid order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+		uc := NewCartUsecase(nil, restaurantRepo, nil, nil)
+
+		restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{
+			ID:     orderID,
+			Status: cart.StatusCancelled,
+			Timeline: []models.OrderStatusEvent{
+				{Status: cart.StatusCreated, Actor: cart.ActorUser},
+				{Status: cart.StatusPaid, Actor: cart.ActorSystem},
+				{Status: cart.StatusCancelled, Actor: cart.ActorUser},
+			},
+		}, nil)
+
+		updates, err := uc.WatchOrder(context.Background(), orderID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, cart.StatusCancelled, (<-updates).Status)
+
+		uc.watchers.publish(orderID, models.OrderStatusEvent{Status: cart.StatusRefunded, Actor: cart.ActorSystem})
+		assert.Equal(t, cart.StatusRefunded, (<-updates).Status)
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+
+	t.Run("Unknown order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
+		uc := NewCartUsecase(nil, restaurantRepo, nil, nil)
+
+		restaurantRepo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{}, cart.ErrOrderNotFound)
+
+		_, err := uc.WatchOrder(context.Background(), orderID, userID)
+		assert.ErrorIs(t, err, cart.ErrOrderNotFound)
+		assert.Empty(t, uc.watchers.subs)
+	})
+}
+
+func TestCancelOrder(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name          string
+		status        string
+		captured      bool
+		repoMocker    func(repo *mocks.MockRestaurantRepo, order models.Order)
+		wantIntent    string
+		expectedError error
+	}{
+		{
+			name:   "Unpaid order",
+			status: cart.StatusCreated,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCreated, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+						assert.Equal(t, cart.StatusCancelled, event.Status)
+						assert.Equal(t, cart.ActorUser, event.Actor)
+						assert.Equal(t, "передумал", event.Reason)
+						return nil
+					}).
+					Times(1)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+			},
+			wantIntent: payment.IntentPending,
+		},
+		{
+			name:     "Paid order is refunded",
+			status:   cart.StatusCooking,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCooking, gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCancelled, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+						assert.Equal(t, cart.StatusRefunded, event.Status)
+						assert.Equal(t, cart.ActorSystem, event.Actor)
+						return nil
+					}).
+					Times(1)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+			},
+			wantIntent: payment.IntentRefunded,
+		},
+		{
+			name:     "Retry refund of a cancelled order",
+			status:   cart.StatusCancelled,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCancelled, gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+			},
+			wantIntent: payment.IntentRefunded,
+		},
+		{
+			name:     "Order already in delivery",
+			status:   cart.StatusInDelivery,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil).Times(1)
+			},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: cart.ErrInvalidTransition,
+		},
+		{
+			name:   "Order of another user",
+			status: cart.StatusCreated,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{}, cart.ErrOrderNotFound).Times(1)
+			},
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+			intent, err := payments.CreateIntent(ctx, orderID, 500)
+			assert.NoError(t, err)
+			if tt.captured {
+				_, err = payments.Capture(ctx, intent.ID)
+				assert.NoError(t, err)
 			}
-			if ok != tt.wantBool {
-				t.Errorf("GetCart() ok = %v, want %v", ok, tt.wantBool)
+
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			tt.repoMocker(repo, models.Order{ID: orderID, Status: tt.status, FinalPrice: 500, PaymentID: intent.ID})
+
+			uc := &CartUsecase{restaurantRepo: repo, payments: payments}
+			_, err = uc.CancelOrder(ctx, orderID, userID, "передумал")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
 			}
+
+			intent, err = payments.GetStatus(ctx, intent.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIntent, intent.Status)
 		})
 	}
 }
 
-func TestUpdateOrderStatus(t *testing.T) {
-	testOrderID := uuid.NewV4()
+func TestSetRestaurantOrderStatus(t *testing.T) {
+	staffID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+	at := func(d time.Duration) *time.Time { t := time.Now().Add(d); return &t }
+	estimate := models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 30, To: 45}}
 
 	tests := []struct {
 		name          string
-		orderID       uuid.UUID
-		repoMocker    func(*mocks.MockRestaurantRepo)
+		status        string
+		to            string
+		deliverAt     *time.Time
+		captured      bool
+		repoMocker    func(repo *mocks.MockRestaurantRepo, order models.Order)
+		wantIntent    string
 		expectedError error
 	}{
 		{
-			name:    "Success",
-			orderID: testOrderID,
-			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+			name:   "Accept paid order",
+			status: cart.StatusPaid,
+			to:     cart.StatusAccepted,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
 				repo.EXPECT().
-					UpdateOrderStatus(gomock.Any(), testOrderID, "paid").
-					Return(nil).
-					Times(1)
+					UpdateOrderStatus(gomock.Any(), orderID, cart.StatusPaid, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+						assert.Equal(t, cart.StatusAccepted, event.Status)
+						assert.Equal(t, cart.ActorRestaurant, event.Actor)
+						return nil
+					})
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil)
 			},
-			expectedError: nil,
+			wantIntent: payment.IntentPending,
 		},
 		{
-			name:    "Update status error",
-			orderID: testOrderID,
-			repoMocker: func(repo *mocks.MockRestaurantRepo) {
-				repo.EXPECT().
-					UpdateOrderStatus(gomock.Any(), testOrderID, "paid").
-					Return(errors.New("database error")).
-					Times(1)
+			name:     "Reject refunds the payment",
+			status:   cart.StatusPaid,
+			to:       cart.StatusCancelled,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusPaid, gomock.Any()).Return(nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCancelled, gomock.Any()).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil)
+			},
+			wantIntent: payment.IntentRefunded,
+		},
+		{
+			name:     "Ready for pickup waits for the dispatcher",
+			status:   cart.StatusCooking,
+			to:       cart.StatusReadyForPickup,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCooking, gomock.Any()).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil)
+			},
+			wantIntent: payment.IntentCaptured,
+		},
+		{
+			name:     "Cannot reject while cooking",
+			status:   cart.StatusCooking,
+			to:       cart.StatusCancelled,
+			captured: true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
+			},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: cart.ErrInvalidTransition,
+		},
+		{
+			name:      "Scheduled order is held until cooking time",
+			status:    cart.StatusScheduled,
+			to:        cart.StatusCooking,
+			deliverAt: at(3 * time.Hour),
+			captured:  true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
+			},
+			wantIntent:    payment.IntentCaptured,
+			expectedError: cart.ErrInvalidTransition,
+		},
+		{
+			name:      "Scheduled order starts cooking on time",
+			status:    cart.StatusScheduled,
+			to:        cart.StatusCooking,
+			deliverAt: at(deliveryWindow(estimate.DeliveryTime) - time.Second),
+			captured:  true,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(order, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusScheduled, gomock.Any()).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(order, nil)
+			},
+			wantIntent: payment.IntentCaptured,
+		},
+		{
+			name:   "Order of another restaurant",
+			status: cart.StatusPaid,
+			to:     cart.StatusAccepted,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+				repo.EXPECT().GetRestaurantOrder(gomock.Any(), orderID, restaurantID).Return(models.Order{}, cart.ErrOrderNotFound)
+			},
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrOrderNotFound,
+		},
+		{
+			name:   "Not a staff member",
+			status: cart.StatusPaid,
+			to:     cart.StatusAccepted,
+			repoMocker: func(repo *mocks.MockRestaurantRepo, order models.Order) {
+				repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(uuid.Nil, cart.ErrNotRestaurantStaff)
 			},
-			expectedError: errors.New("database error"),
+			wantIntent:    payment.IntentPending,
+			expectedError: cart.ErrNotRestaurantStaff,
 		},
 	}
 
@@ -395,21 +1821,598 @@ func TestUpdateOrderStatus(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			restaurantRepo := mocks.NewMockRestaurantRepo(ctrl)
-			uc := &CartUsecase{
-				restaurantRepo: restaurantRepo,
+			ctx := context.Background()
+			payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+			intent, err := payments.CreateIntent(ctx, orderID, 500)
+			assert.NoError(t, err)
+			if tt.captured {
+				_, err = payments.Capture(ctx, intent.ID)
+				assert.NoError(t, err)
 			}
 
-			tt.repoMocker(restaurantRepo)
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			repo.EXPECT().GetOrderEstimate(gomock.Any(), orderID).Return(estimate, nil).AnyTimes()
+			tt.repoMocker(repo, models.Order{ID: orderID, UserID: userID.String(), Status: tt.status, PaymentID: intent.ID,
+				DeliverAt: tt.deliverAt})
 
-			err := uc.UpdateOrderStatus(context.Background(), tt.orderID)
+			uc := &CartUsecase{restaurantRepo: repo, payments: payments, now: time.Now, location: time.UTC}
+			_, err = uc.SetRestaurantOrderStatus(ctx, staffID, orderID, tt.to, "")
 
 			if tt.expectedError != nil {
-				assert.Error(t, err)
-				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			intent, err = payments.GetStatus(ctx, intent.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIntent, intent.Status)
+		})
+	}
+}
+
+func TestGetRestaurantOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	staffID := uuid.NewV4()
+	restaurantID := uuid.NewV4()
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetStaffRestaurant(gomock.Any(), staffID).Return(restaurantID, nil)
+	repo.EXPECT().GetRestaurantOrders(gomock.Any(), restaurantID, cart.RestaurantActiveStatuses).Return(nil, nil)
+
+	uc := &CartUsecase{restaurantRepo: repo}
+	orders, err := uc.GetRestaurantOrders(context.Background(), staffID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Order{}, orders)
+}
+
+func TestPickCourier(t *testing.T) {
+	restaurantLat, restaurantLon := 55.75, 37.62
+	nearLat, nearLon := 55.76, 37.62
+	farLat, farLon := 55.90, 37.62
+	order := models.DispatchOrder{OrderID: uuid.NewV4(), Latitude: &restaurantLat, Longitude: &restaurantLon}
+
+	tests := []struct {
+		name     string
+		order    models.DispatchOrder
+		couriers []models.Courier
+		want     int
+	}{
+		{
+			name:  "Nearest courier",
+			order: order,
+			couriers: []models.Courier{
+				{Latitude: &farLat, Longitude: &farLon, MaxOrders: 2},
+				{Latitude: &nearLat, Longitude: &nearLon, ActiveOrders: 1, MaxOrders: 2},
+			},
+			want: 1,
+		},
+		{
+			name:  "Nearest courier is full",
+			order: order,
+			couriers: []models.Courier{
+				{Latitude: &farLat, Longitude: &farLon, MaxOrders: 2},
+				{Latitude: &nearLat, Longitude: &nearLon, ActiveOrders: 2, MaxOrders: 2},
+			},
+			want: 0,
+		},
+		{
+			name:  "Unknown positions fall back to the least loaded",
+			order: models.DispatchOrder{OrderID: uuid.NewV4()},
+			couriers: []models.Courier{
+				{ActiveOrders: 1, MaxOrders: 3},
+				{Latitude: &nearLat, Longitude: &nearLon, MaxOrders: 3},
+				{ActiveOrders: 2, MaxOrders: 3},
+			},
+			want: 1,
+		},
+		{
+			name:  "Located courier beats unknown position",
+			order: order,
+			couriers: []models.Courier{
+				{MaxOrders: 2},
+				{Latitude: &farLat, Longitude: &farLon, ActiveOrders: 1, MaxOrders: 2},
+			},
+			want: 1,
+		},
+		{
+			name:     "Nobody is free",
+			order:    order,
+			couriers: []models.Courier{{ActiveOrders: 1, MaxOrders: 1}},
+			want:     -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pickCourier(tt.order, tt.couriers))
+		})
+	}
+}
+
+func TestDispatchOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	first, second, third := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	busy, idle := uuid.NewV4(), uuid.NewV4()
+
+	couriers := mocks.NewMockCourierRepo(ctrl)
+	couriers.EXPECT().ReleaseExpiredOffers(gomock.Any(), now.Add(-courierOfferTimeout)).Return(int64(1), nil)
+	couriers.EXPECT().GetUnassignedOrders(gomock.Any(), dispatchBatchSize).Return([]models.DispatchOrder{
+		{OrderID: first}, {OrderID: second}, {OrderID: third},
+	}, nil)
+	couriers.EXPECT().GetAvailableCouriers(gomock.Any(), true).Return([]models.Courier{
+		{ID: busy, ActiveOrders: 1, MaxOrders: 2},
+		{ID: idle, MaxOrders: 1},
+	}, nil)
+	gomock.InOrder(
+		couriers.EXPECT().AssignCourier(gomock.Any(), first, idle).Return(nil),
+		couriers.EXPECT().AssignCourier(gomock.Any(), second, busy).Return(nil),
+	)
+
+	uc := &CartUsecase{couriers: couriers, simulateFleet: true, now: func() time.Time { return now }}
+	uc.dispatchOrders(context.Background())
+}
+
+func TestCourierOrderAction(t *testing.T) {
+	userID := uuid.NewV4()
+	customerID := uuid.NewV4()
+	courierID := uuid.NewV4()
+	orderID := uuid.NewV4()
+	assignment := func(status, orderStatus string) models.CourierAssignment {
+		return models.CourierAssignment{OrderID: orderID, CourierID: courierID, Status: status,
+			OrderStatus: orderStatus, UserID: customerID.String()}
+	}
+
+	tests := []struct {
+		name          string
+		action        string
+		mockSetup     func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo)
+		expectedError error
+	}{
+		{
+			name:   "Accept offer",
+			action: cart.CourierActionAccept,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentOffered, cart.StatusReadyForPickup), nil)
+				couriers.EXPECT().UpdateAssignment(gomock.Any(), orderID, courierID, cart.AssignmentOffered, cart.AssignmentAccepted).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, customerID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:   "Pick up moves the order to delivery",
+			action: cart.CourierActionPickup,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentAccepted, cart.StatusReadyForPickup), nil)
+				repo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, cart.StatusReadyForPickup, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+						assert.Equal(t, cart.StatusInDelivery, event.Status)
+						assert.Equal(t, cart.ActorCourier, event.Actor)
+						return nil
+					})
+				couriers.EXPECT().UpdateAssignment(gomock.Any(), orderID, courierID, cart.AssignmentAccepted, cart.AssignmentPickedUp).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, customerID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:   "Repeated pick up catches up the assignment",
+			action: cart.CourierActionPickup,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentAccepted, cart.StatusInDelivery), nil)
+				couriers.EXPECT().UpdateAssignment(gomock.Any(), orderID, courierID, cart.AssignmentAccepted, cart.AssignmentPickedUp).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, customerID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:   "Deliver",
+			action: cart.CourierActionDeliver,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentPickedUp, cart.StatusInDelivery), nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusInDelivery, gomock.Any()).Return(nil)
+				couriers.EXPECT().UpdateAssignment(gomock.Any(), orderID, courierID, cart.AssignmentPickedUp, cart.AssignmentDelivered).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, customerID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:   "Decline returns the order to the queue",
+			action: cart.CourierActionDecline,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentOffered, cart.StatusReadyForPickup), nil)
+				couriers.EXPECT().DeleteAssignment(gomock.Any(), orderID, courierID).Return(nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, customerID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:   "Deliver before pick up",
+			action: cart.CourierActionDeliver,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentAccepted, cart.StatusReadyForPickup), nil)
+			},
+			expectedError: cart.ErrInvalidAssignment,
+		},
+		{
+			name:   "Pick up before the restaurant is ready",
+			action: cart.CourierActionPickup,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(assignment(cart.AssignmentAccepted, cart.StatusCooking), nil)
+			},
+			expectedError: cart.ErrInvalidTransition,
+		},
+		{
+			name:   "Order of another courier",
+			action: cart.CourierActionAccept,
+			mockSetup: func(couriers *mocks.MockCourierRepo, repo *mocks.MockRestaurantRepo) {
+				couriers.EXPECT().GetAssignment(gomock.Any(), orderID, courierID).
+					Return(models.CourierAssignment{}, cart.ErrAssignmentNotFound)
+			},
+			expectedError: cart.ErrAssignmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			couriers := mocks.NewMockCourierRepo(ctrl)
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			couriers.EXPECT().GetCourierByUser(gomock.Any(), userID).Return(models.Courier{ID: courierID}, nil)
+			repo.EXPECT().GetOrderEstimate(gomock.Any(), orderID).Return(models.DeliveryEstimate{}, nil).AnyTimes()
+			tt.mockSetup(couriers, repo)
+
+			uc := &CartUsecase{restaurantRepo: repo, couriers: couriers}
+			_, err := uc.CourierOrderAction(context.Background(), userID, orderID, tt.action)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
 			}
 		})
 	}
+
+	t.Run("Not a courier", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		couriers := mocks.NewMockCourierRepo(ctrl)
+		couriers.EXPECT().GetCourierByUser(gomock.Any(), userID).Return(models.Courier{}, cart.ErrNotCourier)
+
+		uc := &CartUsecase{couriers: couriers}
+		_, err := uc.CourierOrderAction(context.Background(), userID, orderID, cart.CourierActionAccept)
+		assert.ErrorIs(t, err, cart.ErrNotCourier)
+	})
+}
+
+func TestSimulatedAction(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		status  string
+		elapsed time.Duration
+		want    string
+	}{
+		{"Accepts offers at once", cart.AssignmentOffered, 0, cart.CourierActionAccept},
+		{"Rides to the restaurant", cart.AssignmentAccepted, expectedPickupTime / 2, ""},
+		{"Picks up", cart.AssignmentAccepted, expectedPickupTime, cart.CourierActionPickup},
+		{"Rides to the customer", cart.AssignmentPickedUp, expectedDeliveryTime / 2, ""},
+		{"Delivers", cart.AssignmentPickedUp, expectedDeliveryTime, cart.CourierActionDeliver},
+		{"Done", cart.AssignmentDelivered, time.Hour, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignment := models.CourierAssignment{Status: tt.status, UpdatedAt: now.Add(-tt.elapsed)}
+			assert.Equal(t, tt.want, simulatedAction(assignment, now))
+		})
+	}
+}
+
+func TestSimulatedKitchenStatus(t *testing.T) {
+	now := time.Now()
+	delivery := models.DeliveryTime{From: 30, To: 45}
+	later := now.Add(3 * time.Hour)
+	soon := now.Add(deliveryWindow(delivery))
+
+	tests := []struct {
+		name      string
+		status    string
+		deliverAt *time.Time
+		elapsed   time.Duration
+		want      string
+	}{
+		{"Accepts paid orders at once", cart.StatusPaid, nil, 0, cart.StatusAccepted},
+		{"Starts cooking an order for now", cart.StatusAccepted, nil, 0, cart.StatusCooking},
+		{"Holds a scheduled order", cart.StatusScheduled, &later, time.Hour, ""},
+		{"Cooks a scheduled order on time", cart.StatusScheduled, &soon, 0, cart.StatusCooking},
+		{"Still cooking", cart.StatusCooking, nil, expectedCookingTime / 2, ""},
+		{"Hands over to the courier", cart.StatusCooking, nil, expectedCookingTime, cart.StatusReadyForPickup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.KitchenOrder{Status: tt.status, DeliverAt: tt.deliverAt, DeliveryTime: delivery,
+				UpdatedAt: now.Add(-tt.elapsed)}
+			assert.Equal(t, tt.want, simulatedKitchenStatus(order, now))
+		})
+	}
+}
+
+func TestDriveSimulatedKitchens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.NewV4()
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetSimulatedKitchenOrders(gomock.Any(), simulatedKitchenStatuses, simulatedKitchenBatchSize).
+		Return([]models.KitchenOrder{{OrderID: orderID, Status: cart.StatusCooking, UpdatedAt: time.Now().Add(-time.Hour)}}, nil)
+	repo.EXPECT().GetOrderEstimate(gomock.Any(), orderID).Return(models.DeliveryEstimate{}, nil).AnyTimes()
+	repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusCooking, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+			assert.Equal(t, cart.StatusReadyForPickup, event.Status)
+			assert.Equal(t, cart.ActorRestaurant, event.Actor)
+			return nil
+		})
+
+	uc := &CartUsecase{restaurantRepo: repo, watchers: newOrderWatchers(), now: time.Now}
+	uc.driveSimulatedKitchens(context.Background())
+}
+
+func TestEstimateETA(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { ts := now.Add(d); return &ts }
+	window := models.DeliveryTime{From: 50, To: 60}
+
+	tests := []struct {
+		name     string
+		status   string
+		estimate models.DeliveryEstimate
+		want     *time.Time
+	}{
+		{
+			name:     "New order waits for the kitchen queue",
+			status:   cart.StatusCreated,
+			estimate: models.DeliveryEstimate{DeliveryTime: window, QueueLength: 2},
+			want:     at(55*time.Minute + 2*etaQueueDelayPerOrder),
+		},
+		{
+			name:     "Cooking order ignores the queue",
+			status:   cart.StatusCooking,
+			estimate: models.DeliveryEstimate{DeliveryTime: window, QueueLength: 2},
+			want:     at(55 * time.Minute),
+		},
+		{
+			name:     "Ready order only waits for the courier and the road",
+			status:   cart.StatusReadyForPickup,
+			estimate: models.DeliveryEstimate{DeliveryTime: window},
+			want:     at(27*time.Minute + 30*time.Second),
+		},
+		{
+			name:     "In delivery",
+			status:   cart.StatusInDelivery,
+			estimate: models.DeliveryEstimate{DeliveryTime: window},
+			want:     at(16*time.Minute + 30*time.Second),
+		},
+		{
+			name:     "Scheduled order is not delivered before the chosen time",
+			status:   cart.StatusAccepted,
+			estimate: models.DeliveryEstimate{DeliveryTime: window, DeliverAt: at(3 * time.Hour)},
+			want:     at(3 * time.Hour),
+		},
+		{
+			name:   "Delivered",
+			status: cart.StatusDelivered,
+			want:   at(0),
+		},
+		{
+			name:     "Cancelled order has no ETA",
+			status:   cart.StatusCancelled,
+			estimate: models.DeliveryEstimate{DeliveryTime: window},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, estimateETA(tt.status, tt.estimate, now))
+		})
+	}
+}
+
+func TestScheduledOrderArrivesOnTime(t *testing.T) {
+	deliverAt := time.Date(2025, 5, 10, 19, 0, 0, 0, time.UTC)
+
+	for _, delivery := range []models.DeliveryTime{{From: 0, To: 0}, {From: 20, To: 40}, {From: 50, To: 60}} {
+		estimate := models.DeliveryEstimate{DeliveryTime: delivery, DeliverAt: &deliverAt}
+		start := cookingStartsAt(deliverAt, delivery)
+
+		assert.False(t, canStartCooking(&deliverAt, delivery, start.Add(-time.Second)))
+		assert.True(t, canStartCooking(&deliverAt, delivery, start))
+		assert.Equal(t, &deliverAt, estimateETA(cart.StatusCooking, estimate, start))
+		assert.Equal(t, &deliverAt, estimateETA(cart.StatusCooking, models.DeliveryEstimate{DeliveryTime: delivery}, start))
+	}
+}
+
+func TestTransitOrderStatusUpdatesETA(t *testing.T) {
+	orderID := uuid.NewV4()
+	now := time.Now()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetOrderEstimate(gomock.Any(), orderID).
+		Return(models.DeliveryEstimate{DeliveryTime: models.DeliveryTime{From: 20, To: 40}}, nil)
+	repo.EXPECT().UpdateOrderStatus(gomock.Any(), orderID, cart.StatusAccepted, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, event models.OrderStatusEvent) error {
+			if assert.NotNil(t, event.ETA) {
+				assert.Equal(t, event.CreatedAt.Add(30*time.Minute), *event.ETA)
+			}
+			return nil
+		})
+
+	uc := &CartUsecase{restaurantRepo: repo, now: func() time.Time { return now }}
+	err := uc.transitOrderStatus(context.Background(), orderID, cart.StatusAccepted,
+		models.OrderStatusEvent{Status: cart.StatusCooking, Actor: cart.ActorRestaurant, CreatedAt: now})
+	assert.NoError(t, err)
+}
+
+func TestUpdateTip(t *testing.T) {
+	orderID := uuid.NewV4()
+	userID := uuid.NewV4()
+	now := time.Date(2025, 5, 10, 20, 0, 0, 0, time.UTC)
+
+	delivered := func(at time.Time, tip float64) models.Order {
+		return models.Order{
+			ID:             orderID,
+			Status:         cart.StatusDelivered,
+			PriceBreakdown: models.PriceBreakdown{Subtotal: 1000, Tip: tip, Total: 1000 + tip},
+			Timeline: []models.OrderStatusEvent{
+				{Status: cart.StatusCreated, CreatedAt: at.Add(-time.Hour)},
+				{Status: cart.StatusDelivered, CreatedAt: at},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		order         models.Order
+		req           models.TipReq
+		repoMocker    func(*mocks.MockRestaurantRepo)
+		wantRefunded  float64
+		expectedError error
+	}{
+		{
+			name:  "Raise tip",
+			order: delivered(now.Add(-time.Hour), 50),
+			req:   models.TipReq{Amount: 150},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().UpdateOrderTip(gomock.Any(), orderID, 50.0, 150.0).Return(nil)
+				repo.EXPECT().SaveTipPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tip models.TipPayment) error {
+						assert.Equal(t, orderID, tip.OrderID)
+						assert.Equal(t, 100.0, tip.Amount)
+						return nil
+					})
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{ID: orderID}, nil)
+			},
+		},
+		{
+			name:  "Lower tip by percent",
+			order: delivered(now.Add(-time.Hour), 150),
+			req:   models.TipReq{Percent: 5},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().UpdateOrderTip(gomock.Any(), orderID, 150.0, 50.0).Return(nil)
+				repo.EXPECT().GetTipPayments(gomock.Any(), orderID).Return(nil, nil)
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(models.Order{ID: orderID}, nil)
+			},
+			wantRefunded: 100,
+		},
+		{
+			name:       "Same tip",
+			order:      delivered(now.Add(-time.Hour), 50),
+			req:        models.TipReq{Amount: 50},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {},
+		},
+		{
+			name:          "Order not delivered yet",
+			order:         models.Order{ID: orderID, Status: cart.StatusInDelivery},
+			req:           models.TipReq{Amount: 100},
+			repoMocker:    func(repo *mocks.MockRestaurantRepo) {},
+			expectedError: cart.ErrTipNotEditable,
+		},
+		{
+			name:          "Edit window closed",
+			order:         delivered(now.Add(-tipEditWindow-time.Minute), 50),
+			req:           models.TipReq{Amount: 100},
+			repoMocker:    func(repo *mocks.MockRestaurantRepo) {},
+			expectedError: cart.ErrTipWindowClosed,
+		},
+		{
+			name:  "Tip changed concurrently",
+			order: delivered(now.Add(-time.Hour), 50),
+			req:   models.TipReq{Amount: 100},
+			repoMocker: func(repo *mocks.MockRestaurantRepo) {
+				repo.EXPECT().UpdateOrderTip(gomock.Any(), orderID, 50.0, 100.0).Return(cart.ErrStatusConflict)
+			},
+			expectedError: cart.ErrStatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+			intent, err := payments.CreateIntent(ctx, orderID, tt.order.PriceBreakdown.Total)
+			assert.NoError(t, err)
+			_, err = payments.Capture(ctx, intent.ID)
+			assert.NoError(t, err)
+			tt.order.PaymentID = intent.ID
+
+			repo := mocks.NewMockRestaurantRepo(ctrl)
+			repo.EXPECT().GetOrderById(gomock.Any(), orderID, userID).Return(tt.order, nil)
+			tt.repoMocker(repo)
+
+			uc := &CartUsecase{restaurantRepo: repo, payments: payments, now: func() time.Time { return now }}
+			_, err = uc.UpdateTip(ctx, orderID, userID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			intent, err = payments.GetStatus(ctx, intent.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRefunded, intent.RefundedAmount)
+		})
+	}
+}
+
+func TestRefundTip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	orderID := uuid.NewV4()
+	payments := fakePayment.NewProvider(fakePayment.Config{}, fakePayment.NewMemoryStore())
+	capture := func(amount float64) string {
+		intent, err := payments.CreateIntent(ctx, orderID, amount)
+		assert.NoError(t, err)
+		_, err = payments.Capture(ctx, intent.ID)
+		assert.NoError(t, err)
+		return intent.ID
+	}
+	orderPayment := capture(1100)
+	tipPayment := capture(50)
+
+	repo := mocks.NewMockRestaurantRepo(ctrl)
+	repo.EXPECT().GetTipPayments(gomock.Any(), orderID).
+		Return([]models.TipPayment{{PaymentID: tipPayment, OrderID: orderID, Amount: 50}}, nil)
+
+	uc := &CartUsecase{restaurantRepo: repo, payments: payments}
+	err := uc.refundTip(ctx, models.Order{ID: orderID, PaymentID: orderPayment}, 80)
+	assert.NoError(t, err)
+
+	tip, err := payments.GetStatus(ctx, tipPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentRefunded, tip.Status)
+	order, err := payments.GetStatus(ctx, orderPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, order.RefundedAmount)
 }
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/cart"
+	"github.com/satori/uuid"
+)
+
+const watchBufferSize = 8
+
+// orderWatchers рассылает смены статусов подписчикам внутри процесса cart-сервиса.
+type orderWatchers struct {
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[chan models.OrderStatusEvent]struct{}
+}
+
+func newOrderWatchers() *orderWatchers {
+	return &orderWatchers{subs: make(map[uuid.UUID]map[chan models.OrderStatusEvent]struct{})}
+}
+
+func (w *orderWatchers) subscribe(orderID uuid.UUID) (<-chan models.OrderStatusEvent, func()) {
+	ch := make(chan models.OrderStatusEvent, watchBufferSize)
+
+	w.mu.Lock()
+	if w.subs[orderID] == nil {
+		w.subs[orderID] = make(map[chan models.OrderStatusEvent]struct{})
+	}
+	w.subs[orderID][ch] = struct{}{}
+	w.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			w.mu.Lock()
+			delete(w.subs[orderID], ch)
+			if len(w.subs[orderID]) == 0 {
+				delete(w.subs, orderID)
+			}
+			w.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (w *orderWatchers) publish(orderID uuid.UUID, event models.OrderStatusEvent) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.subs[orderID] {
+		// Медленный подписчик не должен тормозить смену статуса.
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// isFinalStatus сообщает, что статус заказа больше не изменится. За отменой оплаченного
+// заказа следует возврат, а неоплаченный заказ на отмене и заканчивается.
+func isFinalStatus(status string, paid bool) bool {
+	switch status {
+	case cart.StatusDelivered, cart.StatusRefunded:
+		return true
+	case cart.StatusCancelled:
+		return !paid
+	}
+	return false
+}
+
+// isPaidStatus сообщает, что заказ в этом статусе уже оплачен: дальше "created" заказ
+// продвигается только после списания платежа.
+func isPaidStatus(status string) bool {
+	return status != cart.StatusCreated && status != cart.StatusCancelled
+}
+
+func (u *CartUsecase) WatchOrder(ctx context.Context, orderID, userID uuid.UUID) (<-chan models.OrderStatusEvent, error) {
+	// Подписываемся до чтения заказа, чтобы не потерять смену статуса между запросами.
+	updates, unsubscribe := u.watchers.subscribe(orderID)
+
+	order, err := u.restaurantRepo.GetOrderById(ctx, orderID, userID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	current := models.OrderStatusEvent{Status: order.Status, CreatedAt: order.CreatedAt}
+	if n := len(order.Timeline); n > 0 {
+		current = order.Timeline[n-1]
+	}
+	paid := isPaidStatus(order.Status)
+	for _, event := range order.Timeline {
+		paid = paid || isPaidStatus(event.Status)
+	}
+
+	out := make(chan models.OrderStatusEvent, watchBufferSize)
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		last := current.Status
+		out <- current
+		if isFinalStatus(last, paid) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-updates:
+				if event.Status == last {
+					continue
+				}
+				last = event.Status
+				paid = paid || isPaidStatus(last)
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+				if isFinalStatus(last, paid) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
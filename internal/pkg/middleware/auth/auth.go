@@ -0,0 +1,143 @@
+package authmw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/delivery/grpc/gen"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/grpcauth"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/send_error"
+	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Principal — пользователь, от имени которого выполняется запрос.
+type Principal struct {
+	ID        uuid.UUID
+	Login     string
+	SessionID uuid.UUID
+	Roles     []string
+}
+
+type principalKey struct{}
+
+type expiredLoginKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext возвращает пользователя запроса; у анонимного запроса его нет.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// principalFromClaims собирает Principal из claims access-токена. Токен без id, login
+// или jti считается недействительным.
+func principalFromClaims(claims jwt.MapClaims) (Principal, bool) {
+	caller, ok := grpcauth.CallerFromClaims(claims)
+	if !ok {
+		return Principal{}, false
+	}
+	return Principal{ID: caller.ID, Login: caller.Login, SessionID: caller.SessionID, Roles: jwtUtils.GetRolesFromClaims(claims)}, true
+}
+
+// LoginExpired сообщает, что анонимный запрос пришёл от вошедшего пользователя: его
+// access-токен истёк или отозван, либо с запросом пришёл refresh-токен. Такому клиенту
+// нужно обменять refresh-токен, а не начинать гостевую сессию.
+func LoginExpired(r *http.Request) bool {
+	if expired, _ := r.Context().Value(expiredLoginKey{}).(bool); expired {
+		return true
+	}
+	cookie, err := r.Cookie(jwtUtils.RefreshCookieName)
+	return err == nil && cookie.Value != ""
+}
+
+// HasRole сообщает, есть ли у пользователя хотя бы одна из ролей.
+func (p Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionChecker — часть клиента сервиса auth, которой нужен middleware.
+type SessionChecker interface {
+	CheckSession(ctx context.Context, in *gen.SessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+// CreateAuthMiddleware один раз на запрос проверяет токен из куки AdminJWT: подпись, срок
+// действия и то, что его сессия (jti) не отозвана. Для действительного токена в контекст
+// кладётся Principal, а сам токен — для пересылки в gRPC-сервисы. Иначе токен стирается
+// у клиента и вырезается из запроса, и обработчик видит анонимный запрос: закрытые ручки
+// ответят 401, а вход, регистрация и обмен refresh-токена сработают как обычно.
+func CreateAuthMiddleware(checker SessionChecker, keys jwtUtils.KeySet) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+			cookie, err := r.Cookie("AdminJWT")
+			if err != nil || cookie.Value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims := jwt.MapClaims{}
+			if !jwtUtils.ParseJWT(cookie.Value, claims, keys) {
+				next.ServeHTTP(w, dropToken(w, r))
+				return
+			}
+			principal, ok := principalFromClaims(claims)
+			if !ok {
+				next.ServeHTTP(w, dropToken(w, r))
+				return
+			}
+
+			_, err = checker.CheckSession(r.Context(), &gen.SessionRequest{SessionId: principal.SessionID.String()})
+			if status.Code(err) == codes.Unauthenticated {
+				logger.Info("сессия завершена", slog.String("session", principal.SessionID.String()))
+				next.ServeHTTP(w, dropToken(w, r))
+				return
+			}
+			if err != nil {
+				log.LogHandlerError(logger, fmt.Errorf("ошибка проверки сессии: %w", err), http.StatusInternalServerError)
+				utils.SendError(w, "ошибка проверки сессии", http.StatusInternalServerError)
+				return
+			}
+
+			ctx := ContextWithPrincipal(r.Context(), principal)
+			ctx = grpcauth.ContextWithToken(ctx, cookie.Value)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// dropToken удаляет access-токен у клиента и возвращает копию запроса без AdminJWT.
+// Refresh-токен не трогаем: истёкший access-токен меняется на новый через /auth/refresh,
+// а у отозванной сессии refresh-токен и так не сработает.
+func dropToken(w http.ResponseWriter, r *http.Request) *http.Request {
+	jwtUtils.ClearAccessCookie(w)
+
+	r = r.Clone(context.WithValue(r.Context(), expiredLoginKey{}, true))
+	cookies := r.Cookies()
+	r.Header.Del("Cookie")
+	for _, c := range cookies {
+		if c.Name != "AdminJWT" {
+			r.AddCookie(c)
+		}
+	}
+	return r
+}
@@ -0,0 +1,128 @@
+package authmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/auth/mocks"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	userID := uuid.NewV4()
+	token := jwtUtils.GenerateJWTForTest(t, "testuser", userID, models.RoleCustomer, models.RoleCourier)
+
+	tests := []struct {
+		name          string
+		token         string
+		setup         func(client *mocks.MockAuthServiceClient)
+		expectedCode  int
+		expectPassed  bool
+		expectToken   bool
+		expectCleared bool
+	}{
+		{
+			name:         "Anonymous request",
+			setup:        func(client *mocks.MockAuthServiceClient) {},
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+		},
+		{
+			name:  "Active session",
+			token: token,
+			setup: func(client *mocks.MockAuthServiceClient) {
+				client.EXPECT().CheckSession(gomock.Any(), gomock.Any()).Return(&emptypb.Empty{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+			expectToken:  true,
+		},
+		{
+			name:  "Revoked session",
+			token: token,
+			setup: func(client *mocks.MockAuthServiceClient) {
+				client.EXPECT().CheckSession(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.Unauthenticated, "сессия завершена"))
+			},
+			expectedCode:  http.StatusOK,
+			expectPassed:  true,
+			expectCleared: true,
+		},
+		{
+			name:          "Token without session",
+			token:         "garbage",
+			setup:         func(client *mocks.MockAuthServiceClient) {},
+			expectedCode:  http.StatusOK,
+			expectPassed:  true,
+			expectCleared: true,
+		},
+		{
+			name:  "Auth service unavailable",
+			token: token,
+			setup: func(client *mocks.MockAuthServiceClient) {
+				client.EXPECT().CheckSession(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.Unavailable, "нет соединения"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mocks.NewMockAuthServiceClient(ctrl)
+			tt.setup(client)
+
+			var passed, sawToken, loginExpired bool
+			var principal Principal
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				passed = true
+				_, err := r.Cookie("AdminJWT")
+				sawToken = err == nil
+				principal, _ = PrincipalFromContext(r.Context())
+				loginExpired = LoginExpired(r)
+				_, err = r.Cookie("CSRF-Token")
+				assert.NoError(t, err, "остальные куки должны сохраниться")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/cart", nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "AdminJWT", Value: tt.token})
+			}
+			req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: "csrf"})
+			rr := httptest.NewRecorder()
+
+			CreateAuthMiddleware(client, jwtUtils.TestKeySet())(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectPassed, passed)
+			assert.Equal(t, tt.expectToken, sawToken)
+			if tt.expectToken {
+				assert.Equal(t, userID, principal.ID)
+				assert.Equal(t, "testuser", principal.Login)
+				assert.Equal(t, []string{models.RoleCustomer, models.RoleCourier}, principal.Roles)
+			} else {
+				assert.Equal(t, Principal{}, principal)
+			}
+
+			cleared := false
+			for _, c := range rr.Result().Cookies() {
+				if c.Name == "AdminJWT" && c.Value == "" {
+					cleared = true
+				}
+			}
+			assert.Equal(t, tt.expectCleared, cleared)
+			assert.Equal(t, tt.expectCleared, loginExpired, "клиент со сброшенным токеном должен его обновить")
+		})
+	}
+}
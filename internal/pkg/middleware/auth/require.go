@@ -0,0 +1,89 @@
+package authmw
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/send_error"
+)
+
+var (
+	ErrUnauthorized = errors.New("пользователь не авторизован")
+	ErrInvalidCSRF  = errors.New("некорректный CSRF-токен")
+	ErrForbidden    = errors.New("недостаточно прав")
+)
+
+// Requirement — условие доступа к ручке. Если оно не выполнено, Requirement сам отвечает
+// клиенту и возвращает false.
+type Requirement func(w http.ResponseWriter, r *http.Request) bool
+
+// Protect оборачивает обработчик проверками, которые ручка объявляет при регистрации
+// маршрута. Проверки выполняются по порядку до первой неудачной.
+func Protect(handler http.HandlerFunc, requirements ...Requirement) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, requirement := range requirements {
+			if !requirement(w, r) {
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// SendUnauthorized — единый ответ 401 для запросов без действительного access-токена.
+func SendUnauthorized(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	log.LogHandlerError(logger, ErrUnauthorized, http.StatusUnauthorized)
+	utils.SendError(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+}
+
+// RequireAuth пропускает только запросы, для которых CreateAuthMiddleware нашёл пользователя.
+func RequireAuth(w http.ResponseWriter, r *http.Request) bool {
+	if _, ok := PrincipalFromContext(r.Context()); !ok {
+		SendUnauthorized(w, r)
+		return false
+	}
+	return true
+}
+
+// RequireRole пропускает пользователей, у которых есть хотя бы одна из ролей. Роли берутся
+// из access-токена, поэтому выданная роль заработает после обновления токена.
+func RequireRole(roles ...string) Requirement {
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			SendUnauthorized(w, r)
+			return false
+		}
+		if !principal.HasRole(roles...) {
+			logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+			log.LogHandlerError(logger, ErrForbidden, http.StatusForbidden)
+			utils.SendError(w, ErrForbidden.Error(), http.StatusForbidden)
+			return false
+		}
+		return true
+	}
+}
+
+// RequireCSRF проверяет double submit cookie: заголовок X-CSRF-Token должен совпадать
+// с кукой CSRF-Token. Без куки ответ 401 — её выдают вместе с сессией, — иначе 403.
+func RequireCSRF(w http.ResponseWriter, r *http.Request) bool {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	cookie, err := r.Cookie("CSRF-Token")
+	if err != nil {
+		log.LogHandlerError(logger, ErrInvalidCSRF, http.StatusUnauthorized)
+		utils.SendError(w, "CSRF-токен отсутствует", http.StatusUnauthorized)
+		return false
+	}
+
+	header := r.Header.Get("X-CSRF-Token")
+	if cookie.Value == "" || header == "" || cookie.Value != header {
+		log.LogHandlerError(logger, ErrInvalidCSRF, http.StatusForbidden)
+		utils.SendError(w, ErrInvalidCSRF.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
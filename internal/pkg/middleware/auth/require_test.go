@@ -0,0 +1,134 @@
+package authmw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtect(t *testing.T) {
+	principal := Principal{ID: uuid.NewV4(), Login: "testuser", Roles: []string{models.RoleCustomer}}
+	courier := Principal{ID: uuid.NewV4(), Login: "courier", Roles: []string{models.RoleCustomer, models.RoleCourier}}
+
+	tests := []struct {
+		name         string
+		principal    *Principal
+		csrfCookie   *string
+		csrfHeader   string
+		requirements []Requirement
+		expectedCode int
+		expectPassed bool
+	}{
+		{
+			name:         "Public route",
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+		},
+		{
+			name:         "Anonymous on protected route",
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfCookie:   strPtr("token"),
+			csrfHeader:   "token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Authorized with valid CSRF",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfCookie:   strPtr("token"),
+			csrfHeader:   "token",
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+		},
+		{
+			name:         "Missing CSRF cookie",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfHeader:   "token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Missing CSRF header",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfCookie:   strPtr("token"),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Mismatched CSRF tokens",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfCookie:   strPtr("cookie-token"),
+			csrfHeader:   "header-token",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Empty CSRF tokens",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireCSRF},
+			csrfCookie:   strPtr(""),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Role granted",
+			principal:    &courier,
+			requirements: []Requirement{RequireAuth, RequireRole(models.RoleCourier, models.RoleAdmin)},
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+		},
+		{
+			name:         "Role missing",
+			principal:    &principal,
+			requirements: []Requirement{RequireAuth, RequireRole(models.RoleCourier, models.RoleAdmin)},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Anonymous on role route",
+			requirements: []Requirement{RequireRole(models.RoleAdmin)},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Guest route checks only CSRF",
+			requirements: []Requirement{RequireCSRF},
+			csrfCookie:   strPtr("token"),
+			csrfHeader:   "token",
+			expectedCode: http.StatusOK,
+			expectPassed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/cart/clear", nil)
+			if tt.principal != nil {
+				req = req.WithContext(ContextWithPrincipal(context.Background(), *tt.principal))
+			}
+			if tt.csrfCookie != nil {
+				req.AddCookie(&http.Cookie{Name: "CSRF-Token", Value: *tt.csrfCookie})
+			}
+			req.Header.Set("X-CSRF-Token", tt.csrfHeader)
+			rr := httptest.NewRecorder()
+
+			passed := false
+			handler := Protect(func(w http.ResponseWriter, r *http.Request) {
+				passed = true
+			}, tt.requirements...)
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectPassed, passed)
+			if !tt.expectPassed {
+				assert.Contains(t, rr.Body.String(), `"error"`)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -12,7 +12,7 @@ func CorsMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Methods", "POST,GET")
 		w.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,X-Csrf-Token")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Expose-Headers", "Authorization,X-Csrf-Token")
+		w.Header().Set("Access-Control-Expose-Headers", "Authorization,X-Csrf-Token,X-Guest-Cart-Conflict,X-Next-Cursor")
 		w.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 		w.Header().Set("Content-Security-Policy", CSP)
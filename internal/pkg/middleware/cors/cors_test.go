@@ -22,7 +22,7 @@ func TestCorsMiddleware(t *testing.T) {
 				"Access-Control-Allow-Methods":    "POST,GET",
 				"Access-Control-Allow-Headers":    "Authorization,Content-Type,X-Csrf-Token",
 				"Access-Control-Allow-Credentials": "true",
-				"Access-Control-Expose-Headers":   "Authorization,X-Csrf-Token",
+				"Access-Control-Expose-Headers":   "Authorization,X-Csrf-Token,X-Guest-Cart-Conflict,X-Next-Cursor",
 				"Access-Control-Allow-Origin":     "http://localhost:3000",
 				"Access-Control-Max-Age":          "86400",
 				"Content-Security-Policy":         CSP,
@@ -36,7 +36,7 @@ func TestCorsMiddleware(t *testing.T) {
 				"Access-Control-Allow-Methods":    "POST,GET",
 				"Access-Control-Allow-Headers":    "Authorization,Content-Type,X-Csrf-Token",
 				"Access-Control-Allow-Credentials": "true",
-				"Access-Control-Expose-Headers":   "Authorization,X-Csrf-Token",
+				"Access-Control-Expose-Headers":   "Authorization,X-Csrf-Token,X-Guest-Cart-Conflict,X-Next-Cursor",
 				"Access-Control-Allow-Origin":     "http://localhost:3000",
 				"Access-Control-Max-Age":          "86400",
 				"Content-Security-Policy":         CSP,
@@ -0,0 +1,196 @@
+package grpcauth
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang-jwt/jwt"
+	"github.com/satori/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
+)
+
+type tokenKey struct{}
+
+type claimsKey struct{}
+
+// ContextWithToken запоминает проверенный access-токен запроса, чтобы клиентские
+// интерцепторы переслали его в вызываемый сервис.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	if !ok || token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, bearerPrefix+token)
+}
+
+// UnaryClientInterceptor передаёт access-токен из контекста в метаданных authorization.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// ContextWithClaims кладёт в контекст claims проверенного токена вызова.
+func ContextWithClaims(ctx context.Context, claims jwt.MapClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext возвращает claims токена, с которым пришёл вызов.
+func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(jwt.MapClaims)
+	return claims, ok
+}
+
+// Caller — пользователь, от имени которого пришёл вызов.
+type Caller struct {
+	ID        uuid.UUID
+	Login     string
+	SessionID uuid.UUID
+}
+
+// CallerFromContext возвращает пользователя вызова; у анонимного вызова его нет.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return Caller{}, false
+	}
+	return CallerFromClaims(claims)
+}
+
+// CallerFromClaims собирает Caller из claims access-токена. Токен без id, login
+// или jti пользователя не даёт.
+func CallerFromClaims(claims jwt.MapClaims) (Caller, bool) {
+	login, _ := claims["login"].(string)
+	idStr, _ := claims["id"].(string)
+	sessionStr, _ := claims["jti"].(string)
+
+	id, err := uuid.FromString(idStr)
+	if err != nil || login == "" {
+		return Caller{}, false
+	}
+	sessionID, err := uuid.FromString(sessionStr)
+	if err != nil {
+		return Caller{}, false
+	}
+	return Caller{ID: id, Login: login, SessionID: sessionID}, true
+}
+
+// Anonymous — методы, которые можно вызывать без токена: вход и гостевые корзины.
+// Всем остальным нужен действительный access-токен.
+type Anonymous map[string]bool
+
+// Signed — методы, которые вызывает не пользователь, а внешняя система вроде платёжного
+// провайдера: вместо токена сам запрос несёт подпись, которую проверяет функция метода.
+// Подпись проверяется всегда, с токеном пользователя или без него.
+type Signed map[string]func(req interface{}) bool
+
+// authenticate проверяет подпись токена из метаданных. Без токена пропускаются только
+// вызовы методов из anonymous.
+func authenticate(ctx context.Context, keys jwtUtils.KeySet, method string, anonymous Anonymous) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		if anonymous[method] {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "требуется авторизация")
+	}
+
+	token, found := strings.CutPrefix(values[0], bearerPrefix)
+	if !found {
+		return nil, status.Error(codes.Unauthenticated, "некорректный заголовок authorization")
+	}
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, keys.Keyfunc)
+	if err != nil || !parsed.Valid {
+		return nil, status.Error(codes.Unauthenticated, "недействительный токен")
+	}
+	return ContextWithClaims(ctx, claims), nil
+}
+
+// UnaryServerInterceptor проверяет пересланный access-токен ключами из JWKS сервиса auth,
+// а у методов из signed — подпись запроса.
+func UnaryServerInterceptor(keys jwtUtils.KeySet, anonymous Anonymous, signed Signed) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if verify, ok := signed[info.FullMethod]; ok {
+			if !verify(req) {
+				return nil, status.Error(codes.Unauthenticated, "недействительная подпись запроса")
+			}
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, keys, info.FullMethod, anonymous)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Policy сопоставляет полному имени метода роли, которым он доступен; достаточно любой из них.
+// Методы, которых нет в Policy, доступны любому пользователю с действительным токеном,
+// а методы из Anonymous — и без него.
+type Policy map[string][]string
+
+// UnaryRoleInterceptor проверяет роли из пересланного токена. В цепочке он должен стоять
+// после UnaryServerInterceptor, который кладёт claims в контекст.
+func UnaryRoleInterceptor(policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		allowed, ok := policy[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		claims, ok := ClaimsFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "требуется авторизация")
+		}
+		for _, role := range jwtUtils.GetRolesFromClaims(claims) {
+			if slices.Contains(allowed, role) {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.PermissionDenied, "недостаточно прав")
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func StreamServerInterceptor(keys jwtUtils.KeySet, anonymous Anonymous) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), keys, info.FullMethod, anonymous)
+		if err != nil {
+			return err
+		}
+		return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,202 @@
+package grpcauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
+	"github.com/golang-jwt/jwt"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const (
+		publicMethod = "/cart.CartService/GetCart"
+		signedMethod = "/cart.CartService/ConfirmPayment"
+	)
+	token := jwtUtils.GenerateJWTForTest(t, "testuser", uuid.NewV4())
+	anonymous := Anonymous{publicMethod: true}
+	signed := Signed{signedMethod: func(req interface{}) bool { return req == "signed" }}
+
+	tests := []struct {
+		name          string
+		method        string
+		req           interface{}
+		authorization []string
+		expectedCode  codes.Code
+		expectLogin   string
+	}{
+		{
+			name:         "Anonymous call to allowlisted method",
+			method:       publicMethod,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Signed method with valid signature",
+			method:       signedMethod,
+			req:          "signed",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Signed method with forged signature",
+			method:       signedMethod,
+			req:          "forged",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:          "Signed method ignores user token",
+			method:        signedMethod,
+			req:           "forged",
+			authorization: []string{"Bearer " + token},
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:         "Anonymous call to user method",
+			method:       "/cart.CartService/GetOrders",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:          "Valid token",
+			authorization: []string{"Bearer " + token},
+			expectedCode:  codes.OK,
+			expectLogin:   "testuser",
+		},
+		{
+			name:          "Invalid token",
+			authorization: []string{"Bearer garbage"},
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "Not a bearer token",
+			authorization: []string{token},
+			expectedCode:  codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{authorizationKey: tt.authorization})
+			}
+
+			var login string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if claims, ok := ClaimsFromContext(ctx); ok {
+					login, _ = claims["login"].(string)
+				}
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			_, err := UnaryServerInterceptor(jwtUtils.TestKeySet(), anonymous, signed)(ctx, tt.req, info, handler)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectLogin, login)
+		})
+	}
+}
+
+func TestCallerFromContext(t *testing.T) {
+	id := uuid.NewV4()
+	sessionID := uuid.NewV4()
+
+	_, ok := CallerFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithClaims(context.Background(), jwt.MapClaims{"login": "testuser", "id": id.String()})
+	_, ok = CallerFromContext(ctx)
+	assert.False(t, ok, "токен без jti не даёт пользователя")
+
+	ctx = ContextWithClaims(context.Background(), jwt.MapClaims{
+		"login": "testuser",
+		"id":    id.String(),
+		"jti":   sessionID.String(),
+	})
+	caller, ok := CallerFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, Caller{ID: id, Login: "testuser", SessionID: sessionID}, caller)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	require.NoError(t, UnaryClientInterceptor()(context.Background(), "/m", nil, nil, nil, invoker))
+	assert.Empty(t, sent.Get(authorizationKey))
+
+	ctx := ContextWithToken(context.Background(), "token")
+	require.NoError(t, UnaryClientInterceptor()(ctx, "/m", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"Bearer token"}, sent.Get(authorizationKey))
+}
+
+func TestUnaryRoleInterceptor(t *testing.T) {
+	const method = "/auth.AuthService/GrantRole"
+	policy := Policy{method: {models.RoleAdmin}}
+
+	tests := []struct {
+		name         string
+		method       string
+		roles        []string
+		anonymous    bool
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Method without policy",
+			method:       "/auth.AuthService/Check",
+			anonymous:    true,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Anonymous call",
+			method:       method,
+			anonymous:    true,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Role missing",
+			method:       method,
+			roles:        []string{models.RoleCustomer, models.RoleCourier},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Role granted",
+			method:       method,
+			roles:        []string{models.RoleCustomer, models.RoleAdmin},
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if !tt.anonymous {
+				token := jwtUtils.GenerateJWTForTest(t, "testuser", uuid.NewV4(), tt.roles...)
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{authorizationKey: {"Bearer " + token}})
+			}
+
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+			chain := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return UnaryRoleInterceptor(policy)(ctx, req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			}
+
+			anonymous := Anonymous{"/auth.AuthService/Check": true, method: true}
+			_, err := UnaryServerInterceptor(jwtUtils.TestKeySet(), anonymous, nil)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, chain)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedCode == codes.OK, called)
+		})
+	}
+}
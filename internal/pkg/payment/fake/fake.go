@@ -0,0 +1,197 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/mailru/easyjson"
+	"github.com/satori/uuid"
+)
+
+const defaultConfirmationURL = "http://localhost:5458/pay"
+
+// Config настраивает фейкового провайдера. С AutoPay провайдер сам «оплачивает» каждый
+// платёж через AutoPayDelay и присылает подписанное уведомление на WebhookURL. Автооплата
+// включается только явно: на стенде с настоящими заказами она отметила бы их оплаченными.
+type Config struct {
+	ConfirmationURL string
+	WebhookURL      string
+	WebhookSecret   string
+	AutoPay         bool
+	AutoPayDelay    time.Duration
+}
+
+func ConfigFromEnv() Config {
+	cfg := Config{
+		ConfirmationURL: os.Getenv("PAYMENT_CONFIRMATION_URL"),
+		WebhookURL:      os.Getenv("PAYMENT_WEBHOOK_URL"),
+		WebhookSecret:   os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		AutoPay:         os.Getenv("PAYMENT_AUTOPAY") == "true",
+	}
+	if delay, err := time.ParseDuration(os.Getenv("PAYMENT_AUTOPAY_DELAY")); err == nil {
+		cfg.AutoPayDelay = delay
+	}
+	return cfg
+}
+
+// Provider — платёжный провайдер без настоящих денег для тестов и локального стенда.
+// Платежи лежат в store; mu делает проверку и смену состояния платежа атомарной.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	mu     sync.Mutex
+	store  IntentStore
+}
+
+func NewProvider(cfg Config, store IntentStore) *Provider {
+	if cfg.ConfirmationURL == "" {
+		cfg.ConfirmationURL = defaultConfirmationURL
+	}
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 5 * time.Second},
+		store:  store,
+	}
+}
+
+func (p *Provider) CreateIntent(ctx context.Context, orderID uuid.UUID, amount float64) (models.PaymentIntent, error) {
+	id := "fake_" + uuid.NewV4().String()
+	intent := models.PaymentIntent{
+		ID:              id,
+		OrderID:         orderID,
+		Amount:          amount,
+		Status:          payment.IntentPending,
+		ConfirmationURL: strings.TrimRight(p.cfg.ConfirmationURL, "/") + "/" + id,
+	}
+
+	if err := p.store.SaveIntent(ctx, intent); err != nil {
+		return models.PaymentIntent{}, err
+	}
+
+	if p.cfg.AutoPay && p.cfg.WebhookURL != "" {
+		go p.autoPay(log.GetLoggerFromContext(ctx), intent)
+	}
+	return intent, nil
+}
+
+// Capture списывает деньги; повторный вызов для уже списанного платежа ничего не меняет.
+func (p *Provider) Capture(ctx context.Context, intentID string) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, err := p.store.GetIntent(ctx, intentID)
+	if err != nil {
+		return models.PaymentIntent{}, err
+	}
+	switch intent.Status {
+	case payment.IntentCaptured:
+		return intent, nil
+	case payment.IntentPending:
+		intent.Status = payment.IntentCaptured
+		if err := p.store.SaveIntent(ctx, intent); err != nil {
+			return models.PaymentIntent{}, err
+		}
+		return intent, nil
+	}
+	return models.PaymentIntent{}, fmt.Errorf("%w: %s", payment.ErrInvalidIntentState, intent.Status)
+}
+
+// Refund возвращает amount из списанной суммы; после полного возврата платёж становится refunded.
+func (p *Provider) Refund(ctx context.Context, intentID string, amount float64) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, err := p.store.GetIntent(ctx, intentID)
+	if err != nil {
+		return models.PaymentIntent{}, err
+	}
+	if intent.Status != payment.IntentCaptured {
+		return models.PaymentIntent{}, fmt.Errorf("%w: %s", payment.ErrInvalidIntentState, intent.Status)
+	}
+	if amount <= 0 || intent.RefundedAmount+amount > intent.Amount+0.005 {
+		return models.PaymentIntent{}, payment.ErrRefundTooLarge
+	}
+
+	intent.RefundedAmount = math.Round((intent.RefundedAmount+amount)*100) / 100
+	if intent.RefundedAmount >= intent.Amount {
+		intent.Status = payment.IntentRefunded
+	}
+	if err := p.store.SaveIntent(ctx, intent); err != nil {
+		return models.PaymentIntent{}, err
+	}
+	return intent, nil
+}
+
+// Cancel отменяет ожидающий оплаты платёж; повторная отмена ничего не меняет.
+func (p *Provider) Cancel(ctx context.Context, intentID string) (models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, err := p.store.GetIntent(ctx, intentID)
+	if err != nil {
+		return models.PaymentIntent{}, err
+	}
+	switch intent.Status {
+	case payment.IntentCancelled:
+		return intent, nil
+	case payment.IntentPending:
+		intent.Status = payment.IntentCancelled
+		if err := p.store.SaveIntent(ctx, intent); err != nil {
+			return models.PaymentIntent{}, err
+		}
+		return intent, nil
+	}
+	return models.PaymentIntent{}, fmt.Errorf("%w: %s", payment.ErrInvalidIntentState, intent.Status)
+}
+
+func (p *Provider) GetStatus(ctx context.Context, intentID string) (models.PaymentIntent, error) {
+	return p.store.GetIntent(ctx, intentID)
+}
+
+// autoPay имитирует оплату пользователем: отправляет подписанное уведомление на вебхук.
+// Платежи, которые к этому моменту уже списаны (например, доплата чаевых), пропускаются.
+func (p *Provider) autoPay(logger *slog.Logger, intent models.PaymentIntent) {
+	time.Sleep(p.cfg.AutoPayDelay)
+
+	current, err := p.store.GetIntent(context.Background(), intent.ID)
+	if err != nil || current.Status != payment.IntentPending {
+		return
+	}
+
+	body, err := easyjson.Marshal(models.PaymentNotification{
+		OrderID:   intent.OrderID.String(),
+		PaymentID: intent.ID,
+		Amount:    intent.Amount,
+	})
+	if err != nil {
+		logger.Error("не удалось сформировать уведомление об оплате", slog.String("error", err.Error()))
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		logger.Error("не удалось создать запрос на вебхук", slog.String("error", err.Error()))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(payment.SignatureHeader, payment.Sign(body, p.cfg.WebhookSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.Error("не удалось отправить уведомление об оплате", slog.String("error", err.Error()))
+		return
+	}
+	resp.Body.Close()
+	logger.Info("отправлено уведомление об оплате", slog.String("paymentID", intent.ID), slog.Int("status", resp.StatusCode))
+}
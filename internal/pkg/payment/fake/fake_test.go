@@ -0,0 +1,152 @@
+package fake
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/mailru/easyjson"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider(Config{ConfirmationURL: "http://pay.local/"}, NewMemoryStore())
+
+	intent, err := p.CreateIntent(ctx, uuid.NewV4(), 300)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentPending, intent.Status)
+	assert.Equal(t, "http://pay.local/"+intent.ID, intent.ConfirmationURL)
+
+	_, err = p.Refund(ctx, intent.ID, 100)
+	assert.ErrorIs(t, err, payment.ErrInvalidIntentState)
+
+	intent, err = p.Capture(ctx, intent.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentCaptured, intent.Status)
+
+	intent, err = p.Capture(ctx, intent.ID)
+	assert.NoError(t, err, "повторное списание должно быть идемпотентным")
+	assert.Equal(t, payment.IntentCaptured, intent.Status)
+
+	intent, err = p.Refund(ctx, intent.ID, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentCaptured, intent.Status)
+	assert.Equal(t, 100.0, intent.RefundedAmount)
+
+	_, err = p.Refund(ctx, intent.ID, 250)
+	assert.ErrorIs(t, err, payment.ErrRefundTooLarge)
+
+	intent, err = p.Refund(ctx, intent.ID, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentRefunded, intent.Status)
+
+	_, err = p.Capture(ctx, intent.ID)
+	assert.ErrorIs(t, err, payment.ErrInvalidIntentState)
+
+	_, err = p.Cancel(ctx, intent.ID)
+	assert.ErrorIs(t, err, payment.ErrInvalidIntentState, "оплаченный платёж не отменяется")
+
+	unpaid, err := p.CreateIntent(ctx, uuid.NewV4(), 300)
+	assert.NoError(t, err)
+	unpaid, err = p.Cancel(ctx, unpaid.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.IntentCancelled, unpaid.Status)
+
+	_, err = p.Capture(ctx, unpaid.ID)
+	assert.ErrorIs(t, err, payment.ErrInvalidIntentState, "отменённый платёж нельзя оплатить")
+
+	_, err = p.GetStatus(ctx, "missing")
+	assert.ErrorIs(t, err, payment.ErrIntentNotFound)
+}
+
+func TestProviderAutoPay(t *testing.T) {
+	secret := "webhook-secret"
+	received := make(chan models.PaymentNotification, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.True(t, payment.VerifySignature(body, r.Header.Get(payment.SignatureHeader), secret))
+
+		var notification models.PaymentNotification
+		assert.NoError(t, easyjson.Unmarshal(body, &notification))
+		received <- notification
+	}))
+	defer server.Close()
+
+	p := NewProvider(Config{WebhookURL: server.URL, WebhookSecret: secret, AutoPay: true}, NewMemoryStore())
+	orderID := uuid.NewV4()
+	intent, err := p.CreateIntent(context.Background(), orderID, 150)
+	assert.NoError(t, err)
+
+	notification := <-received
+	assert.Equal(t, orderID.String(), notification.OrderID)
+	assert.Equal(t, intent.ID, notification.PaymentID)
+	assert.Equal(t, 150.0, notification.Amount)
+}
+
+func TestProviderWithoutAutoPay(t *testing.T) {
+	requests := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+	}))
+	defer server.Close()
+
+	p := NewProvider(Config{WebhookURL: server.URL}, NewMemoryStore())
+	_, err := p.CreateIntent(context.Background(), uuid.NewV4(), 150)
+	assert.NoError(t, err)
+
+	select {
+	case <-requests:
+		t.Fatal("без PAYMENT_AUTOPAY провайдер не должен оплачивать заказы сам")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPgStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	store := &PgStore{db: mockPool}
+	intent := models.PaymentIntent{
+		ID:              "fake_1",
+		OrderID:         uuid.NewV4(),
+		Amount:          300,
+		RefundedAmount:  100,
+		Status:          payment.IntentCaptured,
+		ConfirmationURL: "http://pay.local/fake_1",
+	}
+
+	mockPool.EXPECT().Exec(gomock.Any(), saveIntent, intent.ID, intent.OrderID, intent.Amount,
+		intent.RefundedAmount, intent.Status, intent.ConfirmationURL).Return(pgconn.CommandTag("INSERT 0 1"), nil)
+	assert.NoError(t, store.SaveIntent(context.Background(), intent))
+
+	rows := pgxpoolmock.NewRows([]string{"id", "order_id", "amount", "refunded_amount", "status", "confirmation_url"}).
+		AddRow(intent.ID, intent.OrderID, intent.Amount, intent.RefundedAmount, intent.Status, intent.ConfirmationURL).
+		ToPgxRows()
+	rows.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), getIntent, intent.ID).Return(rows)
+	got, err := store.GetIntent(context.Background(), intent.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, intent, got)
+
+	mockPool.EXPECT().QueryRow(gomock.Any(), getIntent, "fake_2").Return(errRow{pgx.ErrNoRows})
+	_, err = store.GetIntent(context.Background(), "fake_2")
+	assert.ErrorIs(t, err, payment.ErrIntentNotFound)
+}
+
+// errRow — строка результата, Scan которой сразу возвращает ошибку.
+type errRow struct{ err error }
+
+func (r errRow) Scan(...interface{}) error { return r.err }
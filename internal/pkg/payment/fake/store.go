@@ -0,0 +1,96 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/payment"
+	dbUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/db"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// IntentStore хранит платежи фейкового провайдера.
+type IntentStore interface {
+	GetIntent(ctx context.Context, id string) (models.PaymentIntent, error)
+	SaveIntent(ctx context.Context, intent models.PaymentIntent) error
+}
+
+// MemoryStore держит платежи в памяти процесса; после перезапуска они теряются.
+type MemoryStore struct {
+	mu      sync.Mutex
+	intents map[string]models.PaymentIntent
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{intents: make(map[string]models.PaymentIntent)}
+}
+
+func (s *MemoryStore) GetIntent(ctx context.Context, id string) (models.PaymentIntent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	intent, ok := s.intents[id]
+	if !ok {
+		return models.PaymentIntent{}, payment.ErrIntentNotFound
+	}
+	return intent, nil
+}
+
+func (s *MemoryStore) SaveIntent(ctx context.Context, intent models.PaymentIntent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.intents[intent.ID] = intent
+	return nil
+}
+
+const (
+	getIntent = `SELECT id, order_id, amount, refunded_amount, status, confirmation_url
+		FROM payment_intents WHERE id = $1;`
+	saveIntent = `INSERT INTO payment_intents (id, order_id, amount, refunded_amount, status, confirmation_url)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET refunded_amount = EXCLUDED.refunded_amount,
+			status = EXCLUDED.status, updated_at = now();`
+)
+
+// PgStore хранит платежи в таблице payment_intents, чтобы стенд переживал перезапуск cart.
+type PgStore struct {
+	db pgxtype.Querier
+}
+
+func NewPgStore() (*PgStore, error) {
+	db, err := dbUtils.InitDB()
+	return &PgStore{db: db}, err
+}
+
+func (s *PgStore) GetIntent(ctx context.Context, id string) (models.PaymentIntent, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var intent models.PaymentIntent
+	err := s.db.QueryRow(ctx, getIntent, id).Scan(&intent.ID, &intent.OrderID, &intent.Amount,
+		&intent.RefundedAmount, &intent.Status, &intent.ConfirmationURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.PaymentIntent{}, payment.ErrIntentNotFound
+	}
+	if err != nil {
+		logger.Error("Ошибка при получении платежа", slog.String("error", err.Error()))
+		return models.PaymentIntent{}, err
+	}
+	return intent, nil
+}
+
+func (s *PgStore) SaveIntent(ctx context.Context, intent models.PaymentIntent) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	_, err := s.db.Exec(ctx, saveIntent, intent.ID, intent.OrderID, intent.Amount,
+		intent.RefundedAmount, intent.Status, intent.ConfirmationURL)
+	if err != nil {
+		logger.Error("Ошибка при сохранении платежа", slog.String("error", err.Error()))
+	}
+	return err
+}
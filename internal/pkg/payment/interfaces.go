@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/satori/uuid"
+)
+
+const (
+	IntentPending   = "pending"
+	IntentCaptured  = "captured"
+	IntentRefunded  = "refunded"
+	IntentCancelled = "cancelled"
+)
+
+var (
+	ErrIntentNotFound     = errors.New("платёж не найден")
+	ErrInvalidIntentState = errors.New("недопустимое состояние платежа")
+	ErrRefundTooLarge     = errors.New("сумма возврата превышает сумму платежа")
+)
+
+// PaymentProvider — платёжный провайдер, через которого проходят оплаты заказов.
+type PaymentProvider interface {
+	CreateIntent(ctx context.Context, orderID uuid.UUID, amount float64) (models.PaymentIntent, error)
+	Capture(ctx context.Context, intentID string) (models.PaymentIntent, error)
+	Refund(ctx context.Context, intentID string, amount float64) (models.PaymentIntent, error)
+	// Cancel отменяет ещё не оплаченный платёж, например если заказ для него не сохранился.
+	Cancel(ctx context.Context, intentID string) (models.PaymentIntent, error)
+	GetStatus(ctx context.Context, intentID string) (models.PaymentIntent, error)
+}
@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignatureHeader — заголовок, в котором провайдер передаёт подпись тела уведомления.
+const SignatureHeader = "X-Payment-Signature"
+
+// Sign возвращает hex-кодированный HMAC-SHA256 тела уведомления.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature сравнивает подпись за постоянное время. Пустой секрет не принимает ничего.
+func VerifySignature(body []byte, signature, secret string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
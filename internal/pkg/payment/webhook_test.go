@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"order_id":"1","payment_id":"pay_1","amount":100}`)
+	secret := "webhook-secret"
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		secret    string
+		want      bool
+	}{
+		{name: "Valid", body: body, signature: Sign(body, secret), secret: secret, want: true},
+		{name: "Tampered body", body: []byte(`{"order_id":"1","payment_id":"pay_1","amount":1}`), signature: Sign(body, secret), secret: secret, want: false},
+		{name: "Wrong secret", body: body, signature: Sign(body, "other"), secret: secret, want: false},
+		{name: "Not hex", body: body, signature: "zz", secret: secret, want: false},
+		{name: "Empty secret", body: body, signature: Sign(body, ""), secret: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifySignature(tt.body, tt.signature, tt.secret))
+		})
+	}
+}
@@ -6,15 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 	"strconv"
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	authmw "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/middleware/auth"
 	interfaces "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/restaurants"
-	jwtUtils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/jwt"
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/log"
 	utils "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/send_error"
-	"github.com/golang-jwt/jwt"
 	"github.com/gorilla/mux"
 	"github.com/mailru/easyjson"
 	"github.com/satori/uuid"
@@ -209,36 +207,13 @@ func (h *RestaurantHandler) CreateReview(w http.ResponseWriter, r *http.Request)
 		utils.SendError(w, "рейтинг должен быть от 1 до 5", http.StatusBadRequest)
 		return 
 	}
-	cookieJWT, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-	JWTStr := cookieJWT.Value
-
-	claims := jwt.MapClaims{}
-
-	idS, ok := jwtUtils.GetIdFromJWT(JWTStr, claims, os.Getenv("JWT_SECRET"))
-	if !ok || idS == "" {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-	id, err := uuid.FromString(idS)
-	if err != nil {
-		logger.Error(err.Error())
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	login, ok := jwtUtils.GetLoginFromJWT(JWTStr, claims, os.Getenv("JWT_SECRET"))
-	if !ok || login == "" {
-		w.WriteHeader(http.StatusUnauthorized)
+	principal, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
+	id := principal.ID
+	login := principal.Login
 
 	exists, err := h.restaurantUsecase.ReviewExists(r.Context(), id, restaurantID)
     if err != nil {
@@ -280,30 +255,12 @@ func (h *RestaurantHandler) CheckReviews(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	cookieJWT, err := r.Cookie("AdminJWT")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-	JWTStr := cookieJWT.Value
-
-	claims := jwt.MapClaims{}
-
-	idS, ok := jwtUtils.GetIdFromJWT(JWTStr, claims, os.Getenv("JWT_SECRET"))
-	if !ok || idS == "" {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-	id, err := uuid.FromString(idS)
-	if err != nil {
-		logger.Error(err.Error())
-		w.WriteHeader(http.StatusUnauthorized)
+	principal, ok := authmw.PrincipalFromContext(r.Context())
+	if !ok {
+		authmw.SendUnauthorized(w, r)
 		return
 	}
+	id := principal.ID
 
 	exists, err := h.restaurantUsecase.ReviewExistsReturn(r.Context(), id, restaurantID)
     if err != nil {
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	getAllRestaurant        = "SELECT id, name, description, rating, banner_url FROM restaurants ORDER BY id ASC LIMIT $1 OFFSET $2;"
+	getAllRestaurant        = "SELECT id, name, description, rating, banner_url, working_mode_from, working_mode_to FROM restaurants ORDER BY id ASC LIMIT $1 OFFSET $2;"
 	getRestaurantByid       = "SELECT id, name, description, rating FROM restaurants WHERE id = $1;"
 	getProductsByRestaurant = "SELECT id, name, banner_url, address, description, rating, rating_count, working_mode_from, working_mode_to, delivery_time_from, delivery_time_to FROM restaurants WHERE id = $1 ORDER BY id ASC;"
 	getRestaurantTag        = "SELECT rt.name FROM restaurant_tags rt JOIN restaurant_tags_relations rtr ON rtr.tag_id = rt.id WHERE rtr.restaurant_id = $1 ORDER BY rt.name ASC;"
@@ -118,7 +118,8 @@ func (r *RestaurantRepository) GetAll(ctx context.Context, count int, offset int
 	var restaurants []models.Restaurant
 	for rows.Next() {
 		var restaurant models.Restaurant
-		if err := rows.Scan(&restaurant.Id, &restaurant.Name, &restaurant.Description, &restaurant.Rating, &restaurant.ImageURL); err != nil {
+		if err := rows.Scan(&restaurant.Id, &restaurant.Name, &restaurant.Description, &restaurant.Rating, &restaurant.ImageURL,
+			&restaurant.WorkingMode.From, &restaurant.WorkingMode.To); err != nil {
 			logger.Error(err.Error())
 			return nil, err
 		}
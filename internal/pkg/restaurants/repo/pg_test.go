@@ -129,7 +129,7 @@ func TestGetAll(t *testing.T) {
 	}
 
 	// Добавляем image_url в список колонок
-	columns := []string{"id", "name", "description", "rating", "image_url"}
+	columns := []string{"id", "name", "description", "rating", "image_url", "working_mode_from", "working_mode_to"}
 
 	restaurantID := uuid.NewV4()
 	expectedRestaurant := models.Restaurant{
@@ -138,6 +138,7 @@ func TestGetAll(t *testing.T) {
 		Description: "Лучшее место на земле",
 		Rating:      4.8,
 		ImageURL:    "default.jpg", // Добавляем значение для image_url
+		WorkingMode: models.WorkingMode{From: 11, To: 0},
 	}
 
 	tests := []struct {
@@ -158,6 +159,8 @@ func TestGetAll(t *testing.T) {
 						expectedRestaurant.Description,
 						expectedRestaurant.Rating,
 						expectedRestaurant.ImageURL, // Добавляем image_url
+						expectedRestaurant.WorkingMode.From,
+						expectedRestaurant.WorkingMode.To,
 					).ToPgxRows()
 
 				mock.EXPECT().
@@ -7,15 +7,18 @@ import (
 
 	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
 	interfaces "github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/restaurants"
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/pkg/utils/workhours"
 	"github.com/satori/uuid"
 )
 
 type RestaurantUsecase struct {
-	repo interfaces.RestaurantRepo
+	repo     interfaces.RestaurantRepo
+	location *time.Location
+	now      func() time.Time
 }
 
 func NewRestaurantsUsecase(r interfaces.RestaurantRepo) *RestaurantUsecase {
-	return &RestaurantUsecase{repo: r}
+	return &RestaurantUsecase{repo: r, location: workhours.LocationFromEnv(), now: time.Now}
 }
 
 func (u *RestaurantUsecase) GetProductsByRestaurant(ctx context.Context, restaurantID uuid.UUID, count int, offset int) (*models.RestaurantFull, error) {
@@ -30,12 +33,22 @@ func (u *RestaurantUsecase) GetProductsByRestaurant(ctx context.Context, restaur
 	}
 
 	restaurant.Reviews = reviews
+	restaurant.IsOpen, restaurant.OpensAt = workhours.Status(restaurant.WorkingMode, u.now(), u.location)
 
 	return restaurant, nil
 }
 
 func (u *RestaurantUsecase) GetAll(ctx context.Context, count int, offset int) ([]models.Restaurant, error) {
-	return u.repo.GetAll(ctx, count, offset)
+	restaurants, err := u.repo.GetAll(ctx, count, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	for i := range restaurants {
+		restaurants[i].IsOpen, restaurants[i].OpensAt = workhours.Status(restaurants[i].WorkingMode, now, u.location)
+	}
+	return restaurants, nil
 }
 
 func (u *RestaurantUsecase) GetReviews(ctx context.Context, restaurantID uuid.UUID, count, offset int) ([]models.Review, error) {
@@ -133,6 +133,28 @@ func TestRestaurantUsecase_GetAll(t *testing.T) {
 	}
 }
 
+func TestRestaurantUsecase_GetAllWorkingHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRestaurantRepo(ctrl)
+	usecase := NewRestaurantsUsecase(mockRepo)
+	usecase.location = time.FixedZone("MSK", 3*60*60)
+	usecase.now = func() time.Time { return time.Date(2025, 5, 10, 1, 30, 0, 0, usecase.location) }
+
+	mockRepo.EXPECT().GetAll(gomock.Any(), 5, 0).Return([]models.Restaurant{
+		{Id: uuid.NewV4(), Name: "Бургер Кинг", WorkingMode: models.WorkingMode{From: 6, To: 3}},
+		{Id: uuid.NewV4(), Name: "Джонджоли", WorkingMode: models.WorkingMode{From: 11, To: 0}},
+	}, nil)
+
+	result, err := usecase.GetAll(context.Background(), 5, 0)
+	assert.NoError(t, err)
+	assert.True(t, result[0].IsOpen)
+	assert.Nil(t, result[0].OpensAt)
+	assert.False(t, result[1].IsOpen)
+	assert.Equal(t, time.Date(2025, 5, 10, 11, 0, 0, 0, usecase.location), *result[1].OpensAt)
+}
+
 func TestGetReviews(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
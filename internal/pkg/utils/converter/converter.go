@@ -89,9 +89,8 @@ func ProtoToCartItems(protoItems []*gen.CartItem) ([]models.CartItem, error) {
 	return items, nil
 }
 
-func OrderInReqToProto(req models.OrderInReq, cart models.Cart, login string) *gen.CreateOrderRequest {
+func OrderInReqToProto(req models.OrderInReq, cart models.Cart) *gen.CreateOrderRequest {
 	return &gen.CreateOrderRequest{
-		Status:            req.Status,
 		Address:           req.Address,
 		ApartmentOrOffice: req.ApartmentOrOffice,
 		Intercom:          req.Intercom,
@@ -101,7 +100,10 @@ func OrderInReqToProto(req models.OrderInReq, cart models.Cart, login string) *g
 		LeaveAtDoor:       req.LeaveAtDoor,
 		FinalPrice:        req.FinalPrice,
 		Cart:              CartToProto(cart),
-		Login:             login,
+		PromoCode:         req.PromoCode,
+		DeliverAt:         OptionalTimeToProto(req.DeliverAt),
+		TipAmount:         req.TipAmount,
+		TipPercent:        req.TipPercent,
 	}
 }
 
@@ -131,9 +133,18 @@ func OrderToProto(order models.Order, userId string) (*gen.OrderResponse, error)
 		LeaveAtDoor:       order.LeaveAtDoor,
 		CreatedAt:         createdAtProto,
 		FinalPrice:        order.FinalPrice,
+		PriceBreakdown:    PriceBreakdownToProto(order.PriceBreakdown),
+		Timeline:          OrderStatusEventsToProto(order.Timeline),
+		PaymentId:         order.PaymentID,
+		PaymentUrl:        order.PaymentURL,
+		PromoCode:         order.PromoCode,
+		DeliverAt:         OptionalTimeToProto(order.DeliverAt),
+		Eta:               OptionalTimeToProto(order.ETA),
 	}, nil
 }
 
+// ProtoToOrder готовит заказ к выдаче клиенту, поэтому экранирует его поля: сервис cart
+// хранит и отдаёт их как есть.
 func ProtoToOrder(grpcOrder *gen.OrderResponse) (models.Order, error) {
 	if grpcOrder == nil {
 		return models.Order{}, fmt.Errorf("nil order response")
@@ -157,7 +168,22 @@ func ProtoToOrder(grpcOrder *gen.OrderResponse) (models.Order, error) {
 		return models.Order{}, fmt.Errorf("failed to convert cart: %v", err)
 	}
 
-	return models.Order{
+	timeline, err := ProtoToOrderStatusEvents(grpcOrder.Timeline)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	deliverAt, err := ProtoToOptionalTime(grpcOrder.DeliverAt)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	eta, err := ProtoToOptionalTime(grpcOrder.Eta)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	order := models.Order{
 		ID:                orderID,
 		UserID:            grpcOrder.UserId,
 		Status:            grpcOrder.Status,
@@ -171,5 +197,255 @@ func ProtoToOrder(grpcOrder *gen.OrderResponse) (models.Order, error) {
 		LeaveAtDoor:       grpcOrder.LeaveAtDoor,
 		CreatedAt:         createdAt,
 		FinalPrice:        grpcOrder.FinalPrice,
+		PriceBreakdown:    ProtoToPriceBreakdown(grpcOrder.PriceBreakdown),
+		Timeline:          timeline,
+		PaymentID:         grpcOrder.PaymentId,
+		PaymentURL:        grpcOrder.PaymentUrl,
+		PromoCode:         grpcOrder.PromoCode,
+		DeliverAt:         deliverAt,
+		ETA:               eta,
+	}
+	order.Sanitize()
+	return order, nil
+}
+
+func PromoPreviewToProto(preview models.PromoPreview) *gen.PromoPreviewResponse {
+	return &gen.PromoPreviewResponse{
+		PromoCode:      preview.PromoCode,
+		Cart:           CartToProto(preview.Cart),
+		PriceBreakdown: PriceBreakdownToProto(preview.PriceBreakdown),
+	}
+}
+
+func ProtoToPromoPreview(protoPreview *gen.PromoPreviewResponse) (models.PromoPreview, error) {
+	if protoPreview == nil {
+		return models.PromoPreview{}, errors.New("nil promo preview")
+	}
+
+	cart, err := ProtoToCart(protoPreview.Cart)
+	if err != nil {
+		return models.PromoPreview{}, fmt.Errorf("failed to convert cart: %v", err)
+	}
+
+	return models.PromoPreview{
+		PromoCode:      protoPreview.PromoCode,
+		Cart:           cart,
+		PriceBreakdown: ProtoToPriceBreakdown(protoPreview.PriceBreakdown),
+	}, nil
+}
+
+func ReorderResultToProto(result models.ReorderResult) *gen.ReorderResponse {
+	return &gen.ReorderResponse{
+		Cart:     CartToProto(result.Cart),
+		Dropped:  ReorderItemsToProto(result.Dropped),
+		Repriced: ReorderItemsToProto(result.Repriced),
+	}
+}
+
+func ProtoToReorderResult(protoResult *gen.ReorderResponse) (models.ReorderResult, error) {
+	if protoResult == nil {
+		return models.ReorderResult{}, fmt.Errorf("nil reorder response")
+	}
+
+	cart, err := ProtoToCart(protoResult.Cart)
+	if err != nil {
+		return models.ReorderResult{}, fmt.Errorf("failed to convert cart: %v", err)
+	}
+	dropped, err := ProtoToReorderItems(protoResult.Dropped)
+	if err != nil {
+		return models.ReorderResult{}, err
+	}
+	repriced, err := ProtoToReorderItems(protoResult.Repriced)
+	if err != nil {
+		return models.ReorderResult{}, err
+	}
+
+	return models.ReorderResult{Cart: cart, Dropped: dropped, Repriced: repriced}, nil
+}
+
+func ReorderItemsToProto(items []models.ReorderItem) []*gen.ReorderItem {
+	protoItems := make([]*gen.ReorderItem, 0, len(items))
+	for _, item := range items {
+		protoItems = append(protoItems, &gen.ReorderItem{
+			Id:       item.Id.String(),
+			Name:     item.Name,
+			Amount:   int32(item.Amount),
+			OldPrice: item.OldPrice,
+			NewPrice: item.NewPrice,
+		})
+	}
+	return protoItems
+}
+
+func ProtoToReorderItems(protoItems []*gen.ReorderItem) ([]models.ReorderItem, error) {
+	items := make([]models.ReorderItem, 0, len(protoItems))
+	for _, protoItem := range protoItems {
+		id, err := uuid.FromString(protoItem.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %v", err)
+		}
+		items = append(items, models.ReorderItem{
+			Id:       id,
+			Name:     protoItem.Name,
+			Amount:   int(protoItem.Amount),
+			OldPrice: protoItem.OldPrice,
+			NewPrice: protoItem.NewPrice,
+		})
+	}
+	return items, nil
+}
+
+func ProtoToOrderFilter(in *gen.GetOrdersRequest) (models.OrderFilter, error) {
+	filter := models.OrderFilter{
+		Statuses:    in.Statuses,
+		MinTotal:    in.MinTotal,
+		MaxTotal:    in.MaxTotal,
+		OldestFirst: in.OldestFirst,
+	}
+	if in.RestaurantId != "" {
+		restaurantID, err := uuid.FromString(in.RestaurantId)
+		if err != nil {
+			return models.OrderFilter{}, fmt.Errorf("invalid restaurant ID: %v", err)
+		}
+		filter.RestaurantID = restaurantID
+	}
+
+	var err error
+	if filter.From, err = ProtoToOptionalTime(in.From); err != nil {
+		return models.OrderFilter{}, err
+	}
+	if filter.To, err = ProtoToOptionalTime(in.To); err != nil {
+		return models.OrderFilter{}, err
+	}
+	return filter, nil
+}
+
+func PriceBreakdownToProto(breakdown models.PriceBreakdown) *gen.PriceBreakdown {
+	return &gen.PriceBreakdown{
+		Subtotal:    breakdown.Subtotal,
+		DeliveryFee: breakdown.DeliveryFee,
+		ServiceFee:  breakdown.ServiceFee,
+		Discount:    breakdown.Discount,
+		Tip:         breakdown.Tip,
+		Total:       breakdown.Total,
+	}
+}
+
+func ProtoToPriceBreakdown(protoBreakdown *gen.PriceBreakdown) models.PriceBreakdown {
+	if protoBreakdown == nil {
+		return models.PriceBreakdown{}
+	}
+
+	return models.PriceBreakdown{
+		Subtotal:    protoBreakdown.Subtotal,
+		DeliveryFee: protoBreakdown.DeliveryFee,
+		ServiceFee:  protoBreakdown.ServiceFee,
+		Discount:    protoBreakdown.Discount,
+		Tip:         protoBreakdown.Tip,
+		Total:       protoBreakdown.Total,
+	}
+}
+
+func OrderStatusEventsToProto(events []models.OrderStatusEvent) []*gen.OrderStatusEvent {
+	protoEvents := make([]*gen.OrderStatusEvent, 0, len(events))
+	for _, event := range events {
+		protoEvents = append(protoEvents, OrderStatusEventToProto(event))
+	}
+	return protoEvents
+}
+
+func OrderStatusEventToProto(event models.OrderStatusEvent) *gen.OrderStatusEvent {
+	return &gen.OrderStatusEvent{
+		Status:    event.Status,
+		Actor:     event.Actor,
+		Reason:    event.Reason,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+		Eta:       OptionalTimeToProto(event.ETA),
+	}
+}
+
+// ProtoToOrderStatusEvent готовит событие к выдаче клиенту, поэтому экранирует причину.
+func ProtoToOrderStatusEvent(protoEvent *gen.OrderStatusEvent) (models.OrderStatusEvent, error) {
+	if err := protoEvent.GetCreatedAt().CheckValid(); err != nil {
+		return models.OrderStatusEvent{}, fmt.Errorf("invalid timeline timestamp: %v", err)
+	}
+	eta, err := ProtoToOptionalTime(protoEvent.GetEta())
+	if err != nil {
+		return models.OrderStatusEvent{}, err
+	}
+	event := models.OrderStatusEvent{
+		Status:    protoEvent.GetStatus(),
+		Actor:     protoEvent.GetActor(),
+		Reason:    protoEvent.GetReason(),
+		CreatedAt: protoEvent.GetCreatedAt().AsTime(),
+		ETA:       eta,
+	}
+	event.Sanitize()
+	return event, nil
+}
+
+func ProtoToOrderStatusEvents(protoEvents []*gen.OrderStatusEvent) ([]models.OrderStatusEvent, error) {
+	events := make([]models.OrderStatusEvent, 0, len(protoEvents))
+	for _, protoEvent := range protoEvents {
+		if protoEvent == nil {
+			continue
+		}
+		event, err := ProtoToOrderStatusEvent(protoEvent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func OptionalTimeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func ProtoToOptionalTime(protoTime *timestamppb.Timestamp) (*time.Time, error) {
+	if protoTime == nil {
+		return nil, nil
+	}
+	if err := protoTime.CheckValid(); err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %v", err)
+	}
+	t := protoTime.AsTime()
+	return &t, nil
+}
+
+func CourierToProto(courier models.Courier) *gen.CourierResponse {
+	return &gen.CourierResponse{
+		Id:           courier.ID.String(),
+		Name:         courier.Name,
+		Available:    courier.Available,
+		Latitude:     courier.Latitude,
+		Longitude:    courier.Longitude,
+		ActiveOrders: int32(courier.ActiveOrders),
+		MaxOrders:    int32(courier.MaxOrders),
+	}
+}
+
+func ProtoToCourier(protoCourier *gen.CourierResponse) (models.Courier, error) {
+	if protoCourier == nil {
+		return models.Courier{}, fmt.Errorf("nil courier response")
+	}
+
+	id, err := uuid.FromString(protoCourier.Id)
+	if err != nil {
+		return models.Courier{}, fmt.Errorf("invalid courier ID: %v", err)
+	}
+
+	return models.Courier{
+		ID:           id,
+		Name:         protoCourier.Name,
+		Available:    protoCourier.Available,
+		Latitude:     protoCourier.Latitude,
+		Longitude:    protoCourier.Longitude,
+		ActiveOrders: int(protoCourier.ActiveOrders),
+		MaxOrders:    int(protoCourier.MaxOrders),
 	}, nil
 }
@@ -69,6 +69,7 @@ func TestOrderConversion(t *testing.T) {
 	orderID := uuid.NewV4()
 	userID := "testuser"
 	now := time.Now().Truncate(time.Second)
+	deliverAt := now.Add(3 * time.Hour)
 	itemID := uuid.NewV4()
 
 	tests := []struct {
@@ -108,6 +109,22 @@ func TestOrderConversion(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "Scheduled order",
+			order: models.Order{
+				ID:            orderID,
+				UserID:        userID,
+				Status:        "scheduled",
+				OrderProducts: models.Cart{Id: uuid.NewV4()},
+				CreatedAt:     now,
+				DeliverAt:     &deliverAt,
+				ETA:           &deliverAt,
+				Timeline: []models.OrderStatusEvent{
+					{Status: "scheduled", Actor: "system", ETA: &deliverAt, CreatedAt: now},
+				},
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -123,6 +140,17 @@ func TestOrderConversion(t *testing.T) {
 				assert.Equal(t, tt.order.ID, orderResult.ID)
 				assert.Equal(t, tt.order.Address, orderResult.Address)
 				assert.Equal(t, tt.order.CreatedAt.Unix(), orderResult.CreatedAt.Unix())
+				if tt.order.DeliverAt == nil {
+					assert.Nil(t, orderResult.DeliverAt)
+				} else {
+					assert.True(t, tt.order.DeliverAt.Equal(*orderResult.DeliverAt))
+				}
+				if tt.order.ETA == nil {
+					assert.Nil(t, orderResult.ETA)
+				} else {
+					assert.True(t, tt.order.ETA.Equal(*orderResult.ETA))
+					assert.True(t, tt.order.ETA.Equal(*orderResult.Timeline[0].ETA))
+				}
 			}
 		})
 	}
@@ -207,3 +235,65 @@ func TestProtoToOrder_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestCourierConversion(t *testing.T) {
+	lat, lon := 55.75, 37.62
+	courier := models.Courier{
+		ID:           uuid.NewV4(),
+		Name:         "Курьер",
+		Available:    true,
+		Latitude:     &lat,
+		Longitude:    &lon,
+		ActiveOrders: 1,
+		MaxOrders:    2,
+	}
+
+	result, err := ProtoToCourier(CourierToProto(courier))
+	assert.NoError(t, err)
+	assert.Equal(t, courier, result)
+
+	_, err = ProtoToCourier(&gen.CourierResponse{Id: "invalid-uuid"})
+	assert.Error(t, err)
+}
+
+func TestProtoToOrderStatusEventEscapesReason(t *testing.T) {
+	event := models.OrderStatusEvent{
+		Status:    "cancelled",
+		Actor:     "user",
+		Reason:    "<b>передумал</b>",
+		CreatedAt: time.Now().UTC(),
+	}
+
+	proto := OrderStatusEventToProto(event)
+	assert.Equal(t, "<b>передумал</b>", proto.Reason)
+
+	result, err := ProtoToOrderStatusEvent(proto)
+	assert.NoError(t, err)
+	assert.Equal(t, "&lt;b&gt;передумал&lt;/b&gt;", result.Reason)
+}
+
+func TestProtoToOrderEscapesOnce(t *testing.T) {
+	order := models.Order{
+		ID:             uuid.NewV4(),
+		Status:         "created",
+		Address:        "ул. <Ленина> & Co",
+		CourierComment: `"звонить"`,
+		OrderProducts: models.Cart{
+			Id:        uuid.NewV4(),
+			Name:      "Том & Ям",
+			CartItems: []models.CartItem{{Id: uuid.NewV4(), Name: "<b>Рамен</b>", Price: 500, Amount: 1}},
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	proto, err := OrderToProto(order, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "<b>Рамен</b>", proto.OrderProducts.Products[0].Name)
+
+	result, err := ProtoToOrder(proto)
+	assert.NoError(t, err)
+	assert.Equal(t, "ул. &lt;Ленина&gt; &amp; Co", result.Address)
+	assert.Equal(t, "&#34;звонить&#34;", result.CourierComment)
+	assert.Equal(t, "Том &amp; Ям", result.OrderProducts.Name)
+	assert.Equal(t, "&lt;b&gt;Рамен&lt;/b&gt;", result.OrderProducts.CartItems[0].Name)
+}
@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// jwksCacheTTL — как долго закэшированный JWKS считается свежим.
+	jwksCacheTTL = 10 * time.Minute
+	// jwksMinRefresh — не чаще этого JWKS перекачивается вне очереди, чтобы поток токенов
+	// с мусорным kid не превратился в поток запросов к auth.
+	jwksMinRefresh = 30 * time.Second
+	// jwksMaxStale — дольше этого последний полученный набор не используется, даже если
+	// auth недоступен: иначе отозванный при ротации ключ продолжал бы приниматься вечно.
+	jwksMaxStale = time.Hour
+	jwksTimeout  = 5 * time.Second
+)
+
+var (
+	ErrUnknownKeyID = errors.New("неизвестный kid токена")
+	ErrJWKSFetch    = errors.New("не удалось получить JWKS")
+)
+
+// JWK — открытый ключ Ed25519 в формате RFC 8037.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS — набор открытых ключей, которые публикует сервис auth.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewJWK(kid string, key ed25519.PublicKey) JWK {
+	return JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(key),
+		Kid: kid,
+		Use: "sig",
+		Alg: jwt.SigningMethodEdDSA.Alg(),
+	}
+}
+
+func (k JWK) PublicKey() (ed25519.PublicKey, error) {
+	if k.Kty != "OKP" || k.Crv != "Ed25519" {
+		return nil, fmt.Errorf("неподдерживаемый ключ %s/%s", k.Kty, k.Crv)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("некорректный ключ %s", k.Kid)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// KeySet отдаёт ключ для проверки подписи токена.
+type KeySet interface {
+	Keyfunc(token *jwt.Token) (interface{}, error)
+}
+
+// StaticKeySet — неизменяемый набор ключей по kid.
+type StaticKeySet map[string]ed25519.PublicKey
+
+func (s StaticKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, err := tokenKeyID(token)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := s[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// tokenKeyID принимает только токены, подписанные EdDSA, и возвращает их kid.
+func tokenKeyID(token *jwt.Token) (string, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+		return "", fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return "", ErrUnknownKeyID
+	}
+	return kid, nil
+}
+
+// RemoteKeySet проверяет токены ключами из JWKS сервиса auth. Набор кэшируется на
+// jwksCacheTTL; токен с незнакомым kid (auth только что добавил ключ) вызывает внеочередное
+// обновление. Если auth недоступен, продолжаем проверять по последнему полученному набору,
+// но не дольше jwksMaxStale с момента его получения.
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        StaticKeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:    url,
+		client: &http.Client{Timeout: jwksTimeout},
+	}
+}
+
+func (s *RemoteKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, err := tokenKeyID(token)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	fresh := time.Since(s.fetchedAt) < jwksCacheTTL
+	s.mu.RUnlock()
+	if ok && fresh {
+		return key, nil
+	}
+
+	keys, err := s.refresh(ok)
+	if err != nil && keys == nil {
+		return nil, err
+	}
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKeyID
+}
+
+// refresh перекачивает JWKS и возвращает актуальный набор. stale означает, что ключ
+// в кэше есть, но набор устарел. Попытки, в том числе неудачные, делаются не чаще
+// jwksMinRefresh, чтобы упавший auth не задерживал каждый запрос на jwksTimeout.
+func (s *RemoteKeySet) refresh(stale bool) (StaticKeySet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.attemptedAt) < jwksMinRefresh || (stale && time.Since(s.fetchedAt) < jwksCacheTTL) {
+		return s.lastKeys(ErrJWKSFetch)
+	}
+	s.attemptedAt = time.Now()
+
+	keys, err := s.fetch()
+	if err != nil {
+		return s.lastKeys(err)
+	}
+	s.keys, s.fetchedAt = keys, time.Now()
+	return keys, nil
+}
+
+// lastKeys возвращает последний полученный набор, пока он не старше jwksMaxStale, иначе err.
+// Вызывается под s.mu.
+func (s *RemoteKeySet) lastKeys(err error) (StaticKeySet, error) {
+	if s.keys == nil || time.Since(s.fetchedAt) >= jwksMaxStale {
+		return nil, err
+	}
+	return s.keys, nil
+}
+
+func (s *RemoteKeySet) fetch() (StaticKeySet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSFetch, err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSFetch, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: статус %d", ErrJWKSFetch, resp.StatusCode)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSFetch, err)
+	}
+
+	keys := make(StaticKeySet, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKRoundTrip(t *testing.T) {
+	public := testSigningKey.Public().(ed25519.PublicKey)
+
+	key, err := NewJWK("k1", public).PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, public, key)
+
+	_, err = JWK{Kty: "RSA", Kid: "k1"}.PublicKey()
+	assert.Error(t, err)
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	var fetches atomic.Int32
+	var published atomic.Value
+	published.Store(JWKS{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(published.Load())
+	}))
+	defer server.Close()
+
+	claims := jwt.MapClaims{"login": "testuser", "exp": time.Now().Add(time.Hour).Unix()}
+	token := createTestJWT(t, claims, TestKeyID)
+	keys := NewRemoteKeySet(server.URL)
+
+	_, ok := GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.False(t, ok, "ключа ещё нет в JWKS")
+	assert.EqualValues(t, 1, fetches.Load())
+
+	published.Store(JWKS{Keys: []JWK{NewJWK(TestKeyID, testSigningKey.Public().(ed25519.PublicKey))}})
+	_, ok = GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.False(t, ok, "незнакомый kid не перекачивает JWKS чаще jwksMinRefresh")
+	assert.EqualValues(t, 1, fetches.Load())
+
+	keys.attemptedAt = time.Now().Add(-jwksMinRefresh)
+	login, ok := GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.True(t, ok)
+	assert.Equal(t, "testuser", login)
+	assert.EqualValues(t, 2, fetches.Load())
+
+	_, ok = GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, fetches.Load(), "известный kid берётся из кэша")
+
+	server.Close()
+	keys.fetchedAt = time.Now().Add(-jwksCacheTTL)
+	keys.attemptedAt = keys.fetchedAt
+	_, ok = GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.True(t, ok, "при недоступном auth работаем по последнему набору")
+
+	keys.fetchedAt = time.Now().Add(-jwksMaxStale)
+	keys.attemptedAt = keys.fetchedAt
+	_, ok = GetLoginFromJWT(token, jwt.MapClaims{}, keys)
+	assert.False(t, ok, "слишком старому набору не доверяем")
+
+	_, err := keys.Keyfunc(&jwt.Token{Method: jwt.SigningMethodEdDSA, Header: map[string]interface{}{"kid": TestKeyID}})
+	assert.ErrorIs(t, err, ErrJWKSFetch, "повторная попытка ещё не разрешена, но старый набор уже не годится")
+}
@@ -1,8 +1,12 @@
 package utils
 
 import (
-	"fmt"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,17 +15,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func GetLoginFromJWT(JWTStr string, claims jwt.MapClaims, secret string) (string, bool) {
-	token, err := jwt.ParseWithClaims(JWTStr, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		if secret == "" {
-			return nil, fmt.Errorf("JWT_SECRET не установлен")
-		}
-		return []byte(secret), nil
-	})
-	if err != nil || !token.Valid {
+// ParseJWT проверяет подпись и срок действия токена и заполняет claims.
+func ParseJWT(JWTStr string, claims jwt.MapClaims, keys KeySet) bool {
+	if keys == nil {
+		return false
+	}
+	token, err := jwt.ParseWithClaims(JWTStr, claims, keys.Keyfunc)
+	return err == nil && token.Valid
+}
+
+func GetLoginFromJWT(JWTStr string, claims jwt.MapClaims, keys KeySet) (string, bool) {
+	if !ParseJWT(JWTStr, claims, keys) {
 		return "", false
 	}
 
@@ -29,51 +33,171 @@ func GetLoginFromJWT(JWTStr string, claims jwt.MapClaims, secret string) (string
 	return login, ok
 }
 
-func CheckDoubleSubmitCookie(w http.ResponseWriter, r *http.Request) bool {
-	cookieCSRF, err := r.Cookie("CSRF-Token")
-	if err != nil {
-		if err == http.ErrNoCookie {
-			w.WriteHeader(http.StatusUnauthorized)
-			return false
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return false
+func GetIdFromJWT(JWTStr string, claims jwt.MapClaims, keys KeySet) (string, bool) {
+	if !ParseJWT(JWTStr, claims, keys) {
+		return "", false
 	}
 
-	headerCSRF := r.Header.Get("X-CSRF-Token")
-	if cookieCSRF.Value == "" || headerCSRF == "" || cookieCSRF.Value != headerCSRF {
-		w.WriteHeader(http.StatusForbidden)
-		return false
+	id, ok := claims["id"].(string)
+	return id, ok
+}
+
+// GetSessionIDFromJWT возвращает jti токена — идентификатор сессии, под которую он выдан.
+func GetSessionIDFromJWT(JWTStr string, claims jwt.MapClaims, keys KeySet) (string, bool) {
+	if !ParseJWT(JWTStr, claims, keys) {
+		return "", false
 	}
 
-	return true
+	sessionID, ok := claims["jti"].(string)
+	return sessionID, ok
 }
 
-func GetIdFromJWT(JWTStr string, claims jwt.MapClaims, secret string) (string, bool) {
-	token, err := jwt.ParseWithClaims(JWTStr, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		if secret == "" {
-			return nil, fmt.Errorf("JWT_SECRET не установлен")
+// GetRolesFromClaims возвращает роли из уже проверенных claims токена.
+func GetRolesFromClaims(claims jwt.MapClaims) []string {
+	values, _ := claims["roles"].([]interface{})
+	roles := make([]string, 0, len(values))
+	for _, value := range values {
+		if role, ok := value.(string); ok {
+			roles = append(roles, role)
 		}
-		return []byte(secret), nil
+	}
+	return roles
+}
+
+const (
+	// AccessTokenTTL — срок жизни access-токена. Кука AdminJWT живёт дольше, как сессия:
+	// истёкший токен продолжает приходить с запросами, и сервер отличает вошедшего
+	// пользователя, которому пора обменять refresh-токен, от гостя.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL — срок жизни сессии, а с ней и всех её refresh-токенов.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+
+	// RefreshCookieName — кука с refresh-токеном. Браузер отправляет её только в /api/auth.
+	RefreshCookieName = "AdminRefresh"
+	refreshCookiePath = "/api/auth"
+)
+
+// SetAuthCookies выставляет куки после входа или обмена refresh-токена.
+func SetAuthCookies(w http.ResponseWriter, token, refreshToken, csrfToken string) {
+	now := time.Now()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "AdminJWT",
+		Value:    token,
+		Expires:  now.Add(RefreshTokenTTL),
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
 	})
-	if err != nil || !token.Valid {
-		return "", false
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    refreshToken,
+		Expires:  now.Add(RefreshTokenTTL),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     refreshCookiePath,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "CSRF-Token",
+		Value:    csrfToken,
+		Expires:  now.Add(AccessTokenTTL),
+		HttpOnly: false,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+}
+
+// ClearAccessCookie удаляет у клиента только access-токен: refresh-токен остаётся, и по нему
+// можно получить новый.
+func ClearAccessCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "AdminJWT",
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+	})
+}
+
+// ClearAuthCookies удаляет у клиента все куки авторизации.
+func ClearAuthCookies(w http.ResponseWriter) {
+	ClearAccessCookie(w)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     refreshCookiePath,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "CSRF-Token",
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: false,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+}
+
+// GuestCookieName — кука с подписанным идентификатором гостя, которому принадлежит анонимная корзина.
+const GuestCookieName = "GuestCart"
+
+// SignGuestID возвращает значение гостевой куки в виде "<id>.<hmac>".
+func SignGuestID(guestID uuid.UUID, secret string) string {
+	return guestID.String() + "." + guestSignature(guestID.String(), secret)
+}
+
+func GetGuestIDFromCookie(value, secret string) (uuid.UUID, bool) {
+	if secret == "" {
+		return uuid.Nil, false
+	}
+	id, signature, found := strings.Cut(value, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(guestSignature(id, secret))) {
+		return uuid.Nil, false
 	}
+	guestID, err := uuid.FromString(id)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return guestID, true
+}
 
-	id, ok := claims["id"].(string)
-	return id, ok
+func guestSignature(id, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("guest:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestKeyID — kid ключа, которым подписываются токены в тестах.
+const TestKeyID = "test"
+
+var testSigningKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+// TestKeySet возвращает набор ключей, которым проверяются токены из GenerateJWTForTest.
+func TestKeySet() StaticKeySet {
+	return StaticKeySet{TestKeyID: testSigningKey.Public().(ed25519.PublicKey)}
 }
 
-func GenerateJWTForTest(t *testing.T, login, secret string, id uuid.UUID) string {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func GenerateJWTForTest(t *testing.T, login string, id uuid.UUID, roles ...string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
 		"login": login,
 		"exp":   time.Now().Add(time.Hour).Unix(),
 		"id":    id,
+		"jti":   uuid.NewV4().String(),
+		"roles": roles,
 	})
-	tokenStr, err := token.SignedString([]byte(secret))
+	token.Header["kid"] = TestKeyID
+	tokenStr, err := token.SignedString(testSigningKey)
 	require.NoError(t, err)
 	return tokenStr
 }
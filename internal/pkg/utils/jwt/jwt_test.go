@@ -1,8 +1,7 @@
 package utils
 
 import (
-	"net/http"
-	"net/http/httptest"
+	"crypto/ed25519"
 	"testing"
 	"time"
 
@@ -13,9 +12,10 @@ import (
 
 const secret = "test_secret"
 
-func createTestJWT(t *testing.T, claims jwt.MapClaims, secret string) string {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString([]byte(secret))
+func createTestJWT(t *testing.T, claims jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = kid
+	tokenStr, err := token.SignedString(testSigningKey)
 	assert.NoError(t, err)
 	return tokenStr
 }
@@ -26,9 +26,9 @@ func TestGetLoginFromJWT(t *testing.T) {
 		"exp":   time.Now().Add(time.Hour).Unix(),
 	}
 
-	tokenStr := createTestJWT(t, claims, secret)
+	tokenStr := createTestJWT(t, claims, TestKeyID)
 
-	login, ok := GetLoginFromJWT(tokenStr, jwt.MapClaims{}, secret)
+	login, ok := GetLoginFromJWT(tokenStr, jwt.MapClaims{}, TestKeySet())
 	assert.True(t, ok)
 	assert.Equal(t, "testuser", login)
 }
@@ -39,89 +39,69 @@ func TestGetIdFromJWT(t *testing.T) {
 		"exp": time.Now().Add(time.Hour).Unix(),
 	}
 
-	tokenStr := createTestJWT(t, claims, secret)
+	tokenStr := createTestJWT(t, claims, TestKeyID)
 
-	id, ok := GetIdFromJWT(tokenStr, jwt.MapClaims{}, secret)
+	id, ok := GetIdFromJWT(tokenStr, jwt.MapClaims{}, TestKeySet())
 	assert.True(t, ok)
 	assert.Equal(t, "12345", id)
 }
 
-func TestGenerateJWTForTest(t *testing.T) {
-	login := "testuser"
-	secret := "secret"
-	id := uuid.NewV4()
+func TestParseJWTRejects(t *testing.T) {
+	claims := jwt.MapClaims{"login": "testuser", "exp": time.Now().Add(time.Hour).Unix()}
 
-	tokenStr := GenerateJWTForTest(t, login, secret, id)
-	assert.NotNil(t, tokenStr)
-}
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	forged.Header["kid"] = TestKeyID
+	forgedToken, err := forged.SignedString(otherKey)
+	assert.NoError(t, err)
 
-func TestCheckDoubleSubmitCookie(t *testing.T) {
 	tests := []struct {
-		name            string
-		cookieValue     string
-		headerValue     string
-		expectStatus    int
-		expectReturnVal bool
-		skipCookie      bool
+		name  string
+		token string
 	}{
-		{
-			name:            "Valid CSRF Token",
-			cookieValue:     "valid-token",
-			headerValue:     "valid-token",
-			expectStatus:    0,
-			expectReturnVal: true,
-		},
-		{
-			name:            "Missing Cookie",
-			headerValue:     "some-token",
-			expectStatus:    http.StatusUnauthorized,
-			expectReturnVal: false,
-			skipCookie:      true,
-		},
-		{
-			name:            "Missing Header",
-			cookieValue:     "token",
-			headerValue:     "",
-			expectStatus:    http.StatusForbidden,
-			expectReturnVal: false,
-		},
-		{
-			name:            "Mismatched Tokens",
-			cookieValue:     "cookie-token",
-			headerValue:     "header-token",
-			expectStatus:    http.StatusForbidden,
-			expectReturnVal: false,
-		},
-		{
-			name:            "Empty Tokens",
-			cookieValue:     "",
-			headerValue:     "",
-			expectStatus:    http.StatusForbidden,
-			expectReturnVal: false,
-		},
+		{name: "HMAC token", token: hmacToken},
+		{name: "Unknown kid", token: createTestJWT(t, claims, "other")},
+		{name: "Missing kid", token: createTestJWT(t, claims, "")},
+		{name: "Foreign signature", token: forgedToken},
+		{name: "Expired", token: createTestJWT(t, jwt.MapClaims{"login": "testuser", "exp": time.Now().Add(-time.Minute).Unix()}, TestKeyID)},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
-			if !tt.skipCookie {
-				req.AddCookie(&http.Cookie{
-					Name:  "CSRF-Token",
-					Value: tt.cookieValue,
-				})
-			}
-			req.Header.Set("X-CSRF-Token", tt.headerValue)
-
-			rr := httptest.NewRecorder()
-
-			result := CheckDoubleSubmitCookie(rr, req)
-
-			if rr.Code != 200 && rr.Code != tt.expectStatus {
-				t.Errorf("unexpected status code: got %d, want %d", rr.Code, tt.expectStatus)
-			}
-			if result != tt.expectReturnVal {
-				t.Errorf("unexpected result: got %v, want %v", result, tt.expectReturnVal)
-			}
+			_, ok := GetLoginFromJWT(tt.token, jwt.MapClaims{}, TestKeySet())
+			assert.False(t, ok)
 		})
 	}
 }
+
+func TestGenerateJWTForTest(t *testing.T) {
+	login := "testuser"
+	id := uuid.NewV4()
+
+	tokenStr := GenerateJWTForTest(t, login, id)
+	got, ok := GetLoginFromJWT(tokenStr, jwt.MapClaims{}, TestKeySet())
+	assert.True(t, ok)
+	assert.Equal(t, login, got)
+}
+
+func TestGuestCookie(t *testing.T) {
+	guestID := uuid.NewV4()
+	value := SignGuestID(guestID, secret)
+
+	id, ok := GetGuestIDFromCookie(value, secret)
+	assert.True(t, ok)
+	assert.Equal(t, guestID, id)
+
+	_, ok = GetGuestIDFromCookie(value, "other_secret")
+	assert.False(t, ok)
+
+	_, ok = GetGuestIDFromCookie(uuid.NewV4().String()+value[36:], secret)
+	assert.False(t, ok)
+
+	_, ok = GetGuestIDFromCookie(guestID.String(), secret)
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -14,8 +15,13 @@ const (
 	maxCommentLength    = 300
 	minFieldLength      = 1
 	maxFloorValue       = 100
+	maxPromoCodeLength  = 32
+	maxTipAmount        = 10000
+	maxTipPercent       = 50
 )
 
+const promoCodeSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+
 const allowedSymbols = "абвгдеёжзийклмнопрстуфхцчшщъыьэюя" +
 	"АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯ" +
 	"abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_#*,./"
@@ -57,9 +63,6 @@ func isValidComment(s string) bool {
 }
 
 func ValidateOrderInput(req *models.OrderInReq) error {
-	if !isValidShortField(req.Status) {
-		return errors.New("некорректный статус (макс 20 символов)")
-	}
 	if !isValidAddress(req.Address) {
 		return errors.New("некорректный адрес (макс 200 символов)")
 	}
@@ -83,5 +86,66 @@ func ValidateOrderInput(req *models.OrderInReq) error {
 	if req.FinalPrice < 0 {
 		return errors.New("цена не может быть отрицательной")
 	}
+	if req.PromoCode != "" {
+		if err := ValidatePromoCode(req.PromoCode); err != nil {
+			return err
+		}
+	}
+	if err := ValidateTip(req.TipAmount, req.TipPercent); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateTip проверяет чаевые: указывается либо сумма, либо процент, оба в разумных пределах.
+func ValidateTip(amount, percent float64) error {
+	if amount < 0 || percent < 0 {
+		return errors.New("чаевые не могут быть отрицательными")
+	}
+	if amount > 0 && percent > 0 {
+		return errors.New("укажите либо сумму, либо процент чаевых")
+	}
+	if amount > maxTipAmount {
+		return fmt.Errorf("чаевые не могут быть больше %d", maxTipAmount)
+	}
+	if percent > maxTipPercent {
+		return fmt.Errorf("процент чаевых не может быть больше %d", maxTipPercent)
+	}
+	return nil
+}
+
+func ValidatePromoCode(code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) < minFieldLength || len(code) > maxPromoCodeLength {
+		return errors.New("некорректный промокод (макс 32 символа)")
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(promoCodeSymbols, r) {
+			return errors.New("промокод может содержать только латинские буквы, цифры, '-' и '_'")
+		}
+	}
+	return nil
+}
+
+func ValidateCancelReason(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("укажите причину отмены")
+	}
+	if !isValidComment(reason) {
+		return errors.New("некорректная причина отмены (макс 300 символов)")
+	}
+	return nil
+}
+
+func ValidateCourierPosition(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return errors.New("укажите обе координаты или ни одной")
+	}
+	if latitude == nil {
+		return nil
+	}
+	if *latitude < -90 || *latitude > 90 || *longitude < -180 || *longitude > 180 {
+		return errors.New("координаты вне допустимого диапазона")
+	}
 	return nil
 }
@@ -17,7 +17,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Valid input",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           "г. Москва, ул. Пушкина, д. 10",
 				ApartmentOrOffice: "12Б",
 				Intercom:          "123",
@@ -28,23 +27,9 @@ func TestValidateOrderInput(t *testing.T) {
 			},
 			wantErr: "",
 		},
-		{
-			name: "Invalid status (too long)",
-			input: models.OrderInReq{
-				Status:            "оченьдлинноенекорректноезначениестатуса",
-				Address:           "г. Москва",
-				ApartmentOrOffice: "12",
-				Intercom:          "123",
-				Entrance:          "1",
-				Floor:             "3",
-				FinalPrice:        100,
-			},
-			wantErr: "некорректный статус (макс 20 символов)",
-		},
 		{
 			name: "Invalid address (empty)",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           "",
 				ApartmentOrOffice: "12",
 				Intercom:          "123",
@@ -57,7 +42,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Invalid apartment (contains !)",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           "г. Москва",
 				ApartmentOrOffice: "12!",
 				Intercom:          "123",
@@ -70,7 +54,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Invalid comment (too long)",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           "г. Москва",
 				ApartmentOrOffice: "12",
 				Intercom:          "123",
@@ -84,7 +67,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Negative price",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           "г. Москва",
 				ApartmentOrOffice: "12",
 				Intercom:          "123",
@@ -94,23 +76,9 @@ func TestValidateOrderInput(t *testing.T) {
 			},
 			wantErr: "цена не может быть отрицательной",
 		},
-		{
-			name: "Valid status with max length",
-			input: models.OrderInReq{
-				Status:            "12345678901234567890", 
-				Address:           "г. Москва",
-				ApartmentOrOffice: "12",
-				Intercom:          "123",
-				Entrance:          "1",
-				Floor:             "3",
-				FinalPrice:        100,
-			},
-			wantErr: "",
-		},
 		{
 			name: "Valid address with max length",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           strings.Repeat("a", maxAddressLength), 
 				ApartmentOrOffice: "12",
 				Intercom:          "123",
@@ -123,7 +91,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Invalid address with too long value",
 			input: models.OrderInReq{
-				Status:            "Ожидается",
 				Address:           strings.Repeat("a", maxAddressLength+1), // превышает максимальную длину
 				ApartmentOrOffice: "12",
 				Intercom:          "123",
@@ -136,7 +103,6 @@ func TestValidateOrderInput(t *testing.T) {
 		{
 			name: "Empty fields",
 			input: models.OrderInReq{
-				Status:            "",
 				Address:           "",
 				ApartmentOrOffice: "",
 				Intercom:          "",
@@ -144,7 +110,21 @@ func TestValidateOrderInput(t *testing.T) {
 				Floor:             "",
 				FinalPrice:        100,
 			},
-			wantErr: "некорректный статус (макс 20 символов)",
+			wantErr: "некорректный адрес (макс 200 символов)",
+		},
+		{
+			name: "Tip as amount and percent",
+			input: models.OrderInReq{
+				Address:           "г. Москва",
+				ApartmentOrOffice: "12",
+				Intercom:          "123",
+				Entrance:          "1",
+				Floor:             "3",
+				FinalPrice:        100,
+				TipAmount:         50,
+				TipPercent:        10,
+			},
+			wantErr: "укажите либо сумму, либо процент чаевых",
 		},
 	}
 
@@ -159,3 +139,37 @@ func TestValidateOrderInput(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCancelReason(t *testing.T) {
+	assert.NoError(t, ValidateCancelReason("Передумал, закажу позже"))
+	assert.Error(t, ValidateCancelReason("   "))
+	assert.Error(t, ValidateCancelReason(strings.Repeat("а", 301)))
+	assert.Error(t, ValidateCancelReason("<script>"))
+}
+
+func TestValidatePromoCode(t *testing.T) {
+	assert.NoError(t, ValidatePromoCode("WELCOME"))
+	assert.NoError(t, ValidatePromoCode(" minus-300 "))
+	assert.Error(t, ValidatePromoCode(""))
+	assert.Error(t, ValidatePromoCode(strings.Repeat("A", 33)))
+	assert.Error(t, ValidatePromoCode("СКИДКА"))
+	assert.Error(t, ValidatePromoCode("<b>"))
+}
+
+func TestValidateTip(t *testing.T) {
+	assert.NoError(t, ValidateTip(0, 0))
+	assert.NoError(t, ValidateTip(150, 0))
+	assert.NoError(t, ValidateTip(0, 15))
+	assert.Error(t, ValidateTip(-1, 0))
+	assert.Error(t, ValidateTip(10001, 0))
+	assert.Error(t, ValidateTip(0, 51))
+}
+
+func TestValidateCourierPosition(t *testing.T) {
+	lat, lon, far := 55.75, 37.62, 200.0
+	assert.NoError(t, ValidateCourierPosition(nil, nil))
+	assert.NoError(t, ValidateCourierPosition(&lat, &lon))
+	assert.Error(t, ValidateCourierPosition(&lat, nil))
+	assert.Error(t, ValidateCourierPosition(&far, &lon))
+	assert.Error(t, ValidateCourierPosition(&lat, &far))
+}
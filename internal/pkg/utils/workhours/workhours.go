@@ -0,0 +1,85 @@
+package workhours
+
+import (
+	"os"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+)
+
+const defaultTimezone = "Europe/Moscow"
+
+// LocationFromEnv возвращает часовой пояс, в котором заданы часы работы ресторанов (RESTAURANT_TIMEZONE).
+func LocationFromEnv() *time.Location {
+	name := os.Getenv("RESTAURANT_TIMEZONE")
+	if name == "" {
+		name = defaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone("MSK", 3*60*60)
+	}
+	return loc
+}
+
+// IsOpen сообщает, работает ли ресторан в момент now. Интервал [From, To) может
+// переходить через полночь (например, 11→0 или 6→3); From == To означает круглосуточную работу.
+func IsOpen(mode models.WorkingMode, now time.Time, loc *time.Location) bool {
+	from, to := normalizeHour(mode.From), normalizeHour(mode.To)
+	if from == to {
+		return true
+	}
+	hour := now.In(loc).Hour()
+	if from < to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to
+}
+
+// OpensAt возвращает ближайшее время открытия ресторана после now.
+func OpensAt(mode models.WorkingMode, now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	opening := time.Date(local.Year(), local.Month(), local.Day(), normalizeHour(mode.From), 0, 0, 0, loc)
+	if !opening.After(local) {
+		opening = opening.AddDate(0, 0, 1)
+	}
+	return opening
+}
+
+// ClosesAt возвращает ближайшее время закрытия ресторана после now. У круглосуточного
+// ресторана закрытия нет, и ok == false.
+func ClosesAt(mode models.WorkingMode, now time.Time, loc *time.Location) (closing time.Time, ok bool) {
+	from, to := normalizeHour(mode.From), normalizeHour(mode.To)
+	if from == to {
+		return time.Time{}, false
+	}
+	local := now.In(loc)
+	closing = time.Date(local.Year(), local.Month(), local.Day(), to, 0, 0, 0, loc)
+	if !closing.After(local) {
+		closing = closing.AddDate(0, 0, 1)
+	}
+	return closing, true
+}
+
+// IsOpenFor сообщает, работает ли ресторан весь промежуток [start, start+d): заказ,
+// который начали выполнять в start, нужно доставить до закрытия.
+func IsOpenFor(mode models.WorkingMode, start time.Time, d time.Duration, loc *time.Location) bool {
+	if !IsOpen(mode, start, loc) {
+		return false
+	}
+	closing, ok := ClosesAt(mode, start, loc)
+	return !ok || !start.Add(d).After(closing)
+}
+
+// Status возвращает признак работы ресторана и, если он закрыт, время открытия.
+func Status(mode models.WorkingMode, now time.Time, loc *time.Location) (bool, *time.Time) {
+	if IsOpen(mode, now, loc) {
+		return true, nil
+	}
+	opening := OpensAt(mode, now, loc)
+	return false, &opening
+}
+
+func normalizeHour(hour int) int {
+	return ((hour % 24) + 24) % 24
+}
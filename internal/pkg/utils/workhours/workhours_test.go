@@ -0,0 +1,89 @@
+package workhours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_adminadmin/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOpen(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 5, 10, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		mode     models.WorkingMode
+		now      time.Time
+		expected bool
+	}{
+		{"DaytimeOpen", models.WorkingMode{From: 11, To: 20}, at(11, 0), true},
+		{"DaytimeBeforeOpening", models.WorkingMode{From: 11, To: 20}, at(10, 59), false},
+		{"DaytimeAtClosing", models.WorkingMode{From: 11, To: 20}, at(20, 0), false},
+		{"UntilMidnightLateEvening", models.WorkingMode{From: 11, To: 0}, at(23, 30), true},
+		{"UntilMidnightAfterMidnight", models.WorkingMode{From: 11, To: 0}, at(0, 15), false},
+		{"OvernightAfterMidnight", models.WorkingMode{From: 6, To: 3}, at(2, 59), true},
+		{"OvernightClosed", models.WorkingMode{From: 6, To: 3}, at(4, 0), false},
+		{"OvernightMorning", models.WorkingMode{From: 6, To: 3}, at(6, 0), true},
+		{"AroundTheClock", models.WorkingMode{From: 0, To: 24}, at(4, 0), true},
+		{"OtherTimezone", models.WorkingMode{From: 11, To: 20}, time.Date(2025, 5, 10, 8, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsOpen(tt.mode, tt.now, loc))
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+
+	open, opensAt := Status(models.WorkingMode{From: 11, To: 0}, time.Date(2025, 5, 10, 12, 0, 0, 0, loc), loc)
+	assert.True(t, open)
+	assert.Nil(t, opensAt)
+
+	open, opensAt = Status(models.WorkingMode{From: 11, To: 0}, time.Date(2025, 5, 10, 9, 0, 0, 0, loc), loc)
+	assert.False(t, open)
+	assert.Equal(t, time.Date(2025, 5, 10, 11, 0, 0, 0, loc), *opensAt)
+
+	open, opensAt = Status(models.WorkingMode{From: 6, To: 3}, time.Date(2025, 5, 10, 4, 0, 0, 0, loc), loc)
+	assert.False(t, open)
+	assert.Equal(t, time.Date(2025, 5, 10, 6, 0, 0, 0, loc), *opensAt)
+
+	open, opensAt = Status(models.WorkingMode{From: 11, To: 20}, time.Date(2025, 5, 10, 21, 0, 0, 0, loc), loc)
+	assert.False(t, open)
+	assert.Equal(t, time.Date(2025, 5, 11, 11, 0, 0, 0, loc), *opensAt)
+}
+
+func TestIsOpenFor(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 5, 10, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		mode     models.WorkingMode
+		start    time.Time
+		expected bool
+	}{
+		{"DeliveredBeforeClosing", models.WorkingMode{From: 11, To: 20}, at(18, 30), true},
+		{"DeliveredExactlyAtClosing", models.WorkingMode{From: 11, To: 20}, at(19, 0), true},
+		{"ClosesDuringDelivery", models.WorkingMode{From: 11, To: 20}, at(19, 30), false},
+		{"ClosedAtStart", models.WorkingMode{From: 11, To: 20}, at(10, 30), false},
+		{"UntilMidnight", models.WorkingMode{From: 11, To: 0}, at(23, 30), false},
+		{"OvernightCrossesMidnight", models.WorkingMode{From: 6, To: 3}, at(23, 30), true},
+		{"OvernightClosesDuringDelivery", models.WorkingMode{From: 6, To: 3}, at(2, 30), false},
+		{"AroundTheClock", models.WorkingMode{From: 0, To: 24}, at(23, 30), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsOpenFor(tt.mode, tt.start, time.Hour, loc))
+		})
+	}
+}